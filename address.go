@@ -0,0 +1,49 @@
+package envloped
+
+import (
+	"fmt"
+	"net/mail"
+)
+
+// Address is an email address with an optional display name.
+// Use its String method for the address fields of SendEmailRequest:
+//
+//	From: envloped.Address{Name: "My App", Email: "hello@yourdomain.com"}.String(),
+//	To:   envloped.Addresses(user1, user2),
+type Address struct {
+	// Name is the display name, e.g. "Jane Doe". It may be empty.
+	Name string
+
+	// Email is the bare address, e.g. "jane@example.com".
+	Email string
+}
+
+// String formats the address as "Name <email>", or just the email if Name is
+// empty. Names are quoted and encoded as needed, so they may contain commas,
+// quotes, or non-ASCII characters.
+func (a Address) String() string {
+	if a.Name == "" {
+		return a.Email
+	}
+	return (&mail.Address{Name: a.Name, Address: a.Email}).String()
+}
+
+// ParseAddress parses a single address such as "Jane Doe <jane@example.com>"
+// or "jane@example.com".
+func ParseAddress(s string) (Address, error) {
+	addr, err := mail.ParseAddress(s)
+	if err != nil {
+		return Address{}, fmt.Errorf("envloped: invalid address %q: %w", s, err)
+	}
+	return Address{Name: addr.Name, Email: addr.Address}, nil
+}
+
+// Addresses formats each address with String, for use in the To, Cc, and Bcc
+// fields of SendEmailRequest.
+func Addresses(addrs ...Address) []string {
+	out := make([]string, len(addrs))
+	for i, a := range addrs {
+		out[i] = a.String()
+	}
+	return out
+}
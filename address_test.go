@@ -0,0 +1,111 @@
+package envloped
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAddress_String(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		addr Address
+		want string
+	}{
+		{"bare", Address{Email: "jane@example.com"}, "jane@example.com"},
+		{"simple name", Address{Name: "Jane Doe", Email: "jane@example.com"}, `"Jane Doe" <jane@example.com>`},
+		{"comma in name", Address{Name: "Doe, Jane", Email: "jane@example.com"}, `"Doe, Jane" <jane@example.com>`},
+		{"non-ascii name", Address{Name: "Zoë", Email: "zoe@example.com"}, "=?utf-8?q?Zo=C3=AB?= <zoe@example.com>"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := tt.addr.String()
+			if got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+
+			parsed, err := ParseAddress(got)
+			if err != nil {
+				t.Fatalf("ParseAddress(%q): %v", got, err)
+			}
+			if parsed != tt.addr {
+				t.Errorf("round trip = %+v, want %+v", parsed, tt.addr)
+			}
+		})
+	}
+}
+
+func TestParseAddress_Invalid(t *testing.T) {
+	t.Parallel()
+
+	for _, s := range []string{"", "not-an-address", "Jane <jane@>"} {
+		if _, err := ParseAddress(s); err == nil {
+			t.Errorf("ParseAddress(%q): expected error", s)
+		}
+	}
+}
+
+func TestSendEmail_CcBcc(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req SendEmailRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+		if len(req.Cc) != 1 || req.Cc[0] != `"Ann" <ann@example.com>` {
+			t.Errorf("unexpected cc %v", req.Cc)
+		}
+		if len(req.Bcc) != 1 || req.Bcc[0] != "audit@example.com" {
+			t.Errorf("unexpected bcc %v", req.Bcc)
+		}
+		w.Write([]byte(`{"success":true,"messageId":"msg_1"}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	_, err := client.Emails.Send(&SendEmailRequest{
+		From:    Address{Name: "My App", Email: "hello@example.com"}.String(),
+		To:      Addresses(Address{Email: "user@example.com"}),
+		Cc:      Addresses(Address{Name: "Ann", Email: "ann@example.com"}),
+		Bcc:     []string{"audit@example.com"},
+		Subject: "s",
+		Text:    "x",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestSendEmail_CcBccValidation(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("key")
+	base := SendEmailRequest{From: "a@b.com", To: []string{"b@c.com"}, Subject: "s", Text: "x"}
+
+	cc := base
+	cc.Cc = []string{"nope"}
+	if _, err := client.Emails.Send(&cc); err == nil || !contains(err.Error(), "invalid cc address") {
+		t.Errorf("expected invalid cc error, got %v", err)
+	}
+
+	bcc := base
+	bcc.Bcc = []string{"nope"}
+	if _, err := client.Emails.Send(&bcc); err == nil || !contains(err.Error(), "invalid bcc address") {
+		t.Errorf("expected invalid bcc error, got %v", err)
+	}
+
+	limited := base
+	limited.Cc = []string{"c@d.com"}
+	_, err := NewClient("key").WithLimits(&Limits{MaxRecipients: 1}).Emails.Send(&limited)
+	if err == nil || !contains(err.Error(), "too many recipients (2)") {
+		t.Errorf("expected cc to count towards recipient limit, got %v", err)
+	}
+}
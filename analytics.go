@@ -0,0 +1,196 @@
+package envloped
+
+import (
+	"sort"
+	"time"
+)
+
+// GroupBy selects how an Aggregator groups events.
+type GroupBy int
+
+const (
+	// GroupByNone aggregates all events together.
+	GroupByNone GroupBy = iota
+
+	// GroupByTag aggregates events per tag. An event for an email with
+	// several tags counts towards each of them.
+	GroupByTag
+
+	// GroupByTemplate aggregates events per template ID.
+	GroupByTemplate
+)
+
+// DeliveryStats holds event counts and the rates derived from them.
+// Opens and clicks are counted once per message and recipient.
+type DeliveryStats struct {
+	Sent       int `json:"sent"`
+	Delivered  int `json:"delivered"`
+	Bounced    int `json:"bounced"`
+	Complained int `json:"complained"`
+	Opened     int `json:"opened"`
+	Clicked    int `json:"clicked"`
+
+	// DeliveryRate is Delivered / Sent.
+	DeliveryRate float64 `json:"deliveryRate"`
+
+	// BounceRate is Bounced / Sent.
+	BounceRate float64 `json:"bounceRate"`
+
+	// ComplaintRate is Complained / Delivered.
+	ComplaintRate float64 `json:"complaintRate"`
+
+	// OpenRate is Opened / Delivered.
+	OpenRate float64 `json:"openRate"`
+
+	// ClickRate is Clicked / Delivered.
+	ClickRate float64 `json:"clickRate"`
+}
+
+// StatsBucket is the aggregate for one group over one time window.
+type StatsBucket struct {
+	// Key is the tag or template ID of the group, or empty for GroupByNone
+	// and for events without a tag or template.
+	Key string `json:"key"`
+
+	// Start is the inclusive start of the window.
+	Start time.Time `json:"start"`
+
+	// End is the exclusive end of the window.
+	End time.Time `json:"end"`
+
+	DeliveryStats
+}
+
+// Aggregator computes delivery, open, and click rates from events, grouped
+// by tag or template and bucketed into fixed time windows. Feed it events
+// from Events.Stream, Events.Poll, or webhooks, then call Buckets.
+// An Aggregator is not safe for concurrent use.
+type Aggregator struct {
+	window  time.Duration
+	groupBy GroupBy
+
+	buckets map[bucketKey]*StatsBucket
+	unique  map[uniqueKey]struct{}
+}
+
+type bucketKey struct {
+	key   string
+	start int64
+}
+
+type uniqueKey struct {
+	bucket    bucketKey
+	typ       EventType
+	messageID string
+	recipient string
+}
+
+// NewAggregator returns an Aggregator with the given window size and grouping.
+// A window of zero puts all events into a single bucket per group.
+func NewAggregator(window time.Duration, groupBy GroupBy) *Aggregator {
+	return &Aggregator{
+		window:  window,
+		groupBy: groupBy,
+		buckets: make(map[bucketKey]*StatsBucket),
+		unique:  make(map[uniqueKey]struct{}),
+	}
+}
+
+// Add records an event. Event types that do not affect delivery stats are ignored.
+func (a *Aggregator) Add(e Event) {
+	var keys []string
+	switch a.groupBy {
+	case GroupByTag:
+		keys = e.Tags
+		if len(keys) == 0 {
+			keys = []string{""}
+		}
+	case GroupByTemplate:
+		keys = []string{e.TemplateID}
+	default:
+		keys = []string{""}
+	}
+
+	var start, end time.Time
+	if a.window > 0 {
+		start = e.CreatedAt.UTC().Truncate(a.window)
+		end = start.Add(a.window)
+	}
+
+	for _, key := range keys {
+		bk := bucketKey{key: key, start: start.UnixNano()}
+		if a.window <= 0 {
+			bk.start = 0
+		}
+
+		b := a.buckets[bk]
+		if b == nil {
+			b = &StatsBucket{Key: key, Start: start, End: end}
+			a.buckets[bk] = b
+		}
+
+		switch e.Type {
+		case EventTypeSent:
+			b.Sent++
+		case EventTypeDelivered:
+			b.Delivered++
+		case EventTypeBounced:
+			b.Bounced++
+		case EventTypeComplained:
+			b.Complained++
+		case EventTypeOpened, EventTypeClicked:
+			uk := uniqueKey{bucket: bk, typ: e.Type, messageID: e.MessageID, recipient: e.Recipient}
+			if _, ok := a.unique[uk]; ok {
+				continue
+			}
+			a.unique[uk] = struct{}{}
+			if e.Type == EventTypeOpened {
+				b.Opened++
+			} else {
+				b.Clicked++
+			}
+		}
+	}
+}
+
+// Buckets returns the aggregated stats with rates filled in, ordered by
+// window start and then by key.
+func (a *Aggregator) Buckets() []StatsBucket {
+	out := make([]StatsBucket, 0, len(a.buckets))
+	for _, b := range a.buckets {
+		s := *b
+		s.DeliveryRate = ratio(s.Delivered, s.Sent)
+		s.BounceRate = ratio(s.Bounced, s.Sent)
+		s.ComplaintRate = ratio(s.Complained, s.Delivered)
+		s.OpenRate = ratio(s.Opened, s.Delivered)
+		s.ClickRate = ratio(s.Clicked, s.Delivered)
+		out = append(out, s)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].Start.Equal(out[j].Start) {
+			return out[i].Start.Before(out[j].Start)
+		}
+		return out[i].Key < out[j].Key
+	})
+
+	return out
+}
+
+// AggregateEvents is a convenience wrapper that feeds events into a new
+// Aggregator and returns its buckets.
+func AggregateEvents(events []Event, window time.Duration, groupBy GroupBy) []StatsBucket {
+	a := NewAggregator(window, groupBy)
+	for _, e := range events {
+		a.Add(e)
+	}
+	return a.Buckets()
+}
+
+// ratio returns n / d, or 0 if d is zero.
+func ratio(n, d int) float64 {
+	if d == 0 {
+		return 0
+	}
+	return float64(n) / float64(d)
+}
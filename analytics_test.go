@@ -0,0 +1,73 @@
+package envloped
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAggregateEvents_ByTagAndWindow(t *testing.T) {
+	t.Parallel()
+
+	day1 := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	day2 := day1.Add(24 * time.Hour)
+
+	events := []Event{
+		{Type: EventTypeSent, MessageID: "m1", Tags: []string{"welcome"}, CreatedAt: day1},
+		{Type: EventTypeSent, MessageID: "m2", Tags: []string{"welcome", "onboarding"}, CreatedAt: day1},
+		{Type: EventTypeDelivered, MessageID: "m1", Tags: []string{"welcome"}, CreatedAt: day1},
+		{Type: EventTypeBounced, MessageID: "m2", Tags: []string{"welcome", "onboarding"}, CreatedAt: day1},
+		{Type: EventTypeOpened, MessageID: "m1", Recipient: "a@example.com", Tags: []string{"welcome"}, CreatedAt: day1},
+		// Repeat opens of the same message are counted once.
+		{Type: EventTypeOpened, MessageID: "m1", Recipient: "a@example.com", Tags: []string{"welcome"}, CreatedAt: day1},
+		{Type: EventTypeClicked, MessageID: "m1", Recipient: "a@example.com", Tags: []string{"welcome"}, CreatedAt: day1},
+		{Type: EventTypeSent, MessageID: "m3", Tags: []string{"welcome"}, CreatedAt: day2},
+	}
+
+	buckets := AggregateEvents(events, 24*time.Hour, GroupByTag)
+	if len(buckets) != 3 {
+		t.Fatalf("expected 3 buckets, got %d: %+v", len(buckets), buckets)
+	}
+
+	onboarding, welcome, welcomeDay2 := buckets[0], buckets[1], buckets[2]
+
+	if onboarding.Key != "onboarding" || onboarding.Sent != 1 || onboarding.Bounced != 1 || onboarding.BounceRate != 1 {
+		t.Errorf("unexpected onboarding bucket: %+v", onboarding)
+	}
+
+	if welcome.Key != "welcome" {
+		t.Fatalf("expected welcome bucket, got %q", welcome.Key)
+	}
+	if !welcome.Start.Equal(time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)) || !welcome.End.Equal(welcome.Start.Add(24*time.Hour)) {
+		t.Errorf("unexpected window: %v - %v", welcome.Start, welcome.End)
+	}
+	if welcome.Sent != 2 || welcome.Delivered != 1 || welcome.Opened != 1 || welcome.Clicked != 1 {
+		t.Errorf("unexpected welcome counts: %+v", welcome.DeliveryStats)
+	}
+	if welcome.DeliveryRate != 0.5 || welcome.OpenRate != 1 || welcome.ClickRate != 1 {
+		t.Errorf("unexpected welcome rates: %+v", welcome.DeliveryStats)
+	}
+
+	if welcomeDay2.Key != "welcome" || welcomeDay2.Sent != 1 || welcomeDay2.DeliveryRate != 0 {
+		t.Errorf("unexpected day 2 bucket: %+v", welcomeDay2)
+	}
+}
+
+func TestAggregator_ByTemplateSingleWindow(t *testing.T) {
+	t.Parallel()
+
+	a := NewAggregator(0, GroupByTemplate)
+	a.Add(Event{Type: EventTypeSent, TemplateID: "tpl_a", CreatedAt: time.Now()})
+	a.Add(Event{Type: EventTypeDelivered, TemplateID: "tpl_a", CreatedAt: time.Now().Add(-72 * time.Hour)})
+	a.Add(Event{Type: EventTypeSent, TemplateID: "tpl_b", CreatedAt: time.Now()})
+
+	buckets := a.Buckets()
+	if len(buckets) != 2 {
+		t.Fatalf("expected 2 buckets, got %d", len(buckets))
+	}
+	if buckets[0].Key != "tpl_a" || buckets[0].DeliveryRate != 1 {
+		t.Errorf("unexpected tpl_a bucket: %+v", buckets[0])
+	}
+	if !buckets[0].Start.IsZero() {
+		t.Errorf("expected zero window start, got %v", buckets[0].Start)
+	}
+}
@@ -0,0 +1,13 @@
+package envloped
+
+// apiVersionHeader is the request header that pins the API version.
+const apiVersionHeader = "Envloped-Version"
+
+// WithAPIVersion pins every request to the dated API version, such as
+// "2025-01-01", so the API's behavior does not change when newer versions
+// are released. The version is included in API errors.
+// Returns the client for method chaining.
+func (c *Client) WithAPIVersion(version string) *Client {
+	c.apiVersion = version
+	return c
+}
@@ -0,0 +1,50 @@
+package envloped
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWithAPIVersion(t *testing.T) {
+	t.Parallel()
+
+	var got string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("Envloped-Version")
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Request-Id", "req_1")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":"Not found"}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server).WithAPIVersion("2025-01-01")
+	_, err := client.Ping()
+
+	if got != "2025-01-01" {
+		t.Errorf("expected Envloped-Version header, got %q", got)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.APIVersion != "2025-01-01" {
+		t.Fatalf("expected APIError with API version, got %v", err)
+	}
+	want := "envloped: Not found (status 404, request id req_1, api version 2025-01-01)"
+	if err.Error() != want {
+		t.Errorf("expected %q, got %q", want, err.Error())
+	}
+}
+
+func TestWithAPIVersion_Unset(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.Header["Envloped-Version"]; ok {
+			t.Error("expected no Envloped-Version header")
+		}
+	}))
+	defer server.Close()
+
+	newTestClient(t, server).Ping()
+}
@@ -0,0 +1,290 @@
+package envloped
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Errors returned by AsyncSender.Enqueue.
+var (
+	// ErrQueueFull is returned when the send queue has no room left.
+	ErrQueueFull = errors.New("send queue full")
+
+	// ErrSenderClosed is returned after the sender has been closed.
+	ErrSenderClosed = errors.New("sender closed")
+)
+
+const (
+	// defaultAsyncQueueSize is the default capacity of the send queue.
+	defaultAsyncQueueSize = 1000
+
+	// defaultAsyncConcurrency is the default number of emails of a batch
+	// sent at the same time.
+	defaultAsyncConcurrency = 4
+
+	// defaultAsyncBatchSize is the default number of emails per batch.
+	defaultAsyncBatchSize = 100
+
+	// defaultAsyncFlushInterval is the default time a batch waits to fill up.
+	defaultAsyncFlushInterval = time.Second
+)
+
+// AsyncSenderOptions configures an AsyncSender.
+type AsyncSenderOptions struct {
+	// QueueSize is the number of emails that can wait to be sent before
+	// Enqueue fails with ErrQueueFull. Defaults to 1000.
+	QueueSize int
+
+	// Concurrency is the number of emails of a batch sent at the same time.
+	// Defaults to 4.
+	Concurrency int
+
+	// BatchSize is the number of queued emails sent together. A batch is
+	// sent once it is full, once FlushInterval has passed since its first
+	// email was queued, or on Flush and Close. Defaults to 100.
+	BatchSize int
+
+	// FlushInterval is the longest time a queued email waits for its batch
+	// to fill up. Defaults to one second.
+	FlushInterval time.Duration
+
+	// OnSuccess is called after an email was sent. It may be nil.
+	OnSuccess func(params *SendEmailRequest, resp *SendEmailResponse)
+
+	// OnFailure is called after an email could not be sent. It may be nil.
+	OnFailure func(params *SendEmailRequest, err error)
+}
+
+// AsyncSender sends emails in the background, so request handlers can hand
+// off an email without waiting for the API. Emails are queued by Enqueue,
+// grouped into batches, and each batch is sent with a BulkSender, which
+// retries sends rejected by the rate limit. The callbacks are called from a
+// background goroutine, one at a time, after their batch was sent.
+//
+// Call Close before the program exits, to send the emails still queued:
+//
+//	sender := envloped.NewAsyncSender(client, &envloped.AsyncSenderOptions{
+//	    OnFailure: func(params *envloped.SendEmailRequest, err error) {
+//	        log.Printf("sending to %v failed: %v", params.To, err)
+//	    },
+//	})
+//	defer sender.Close()
+type AsyncSender struct {
+	bulk      *BulkSender
+	opts      AsyncSenderOptions
+	queue     chan *SendEmailRequest
+	flushNow  chan struct{}
+	ctx       context.Context
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+	closeOnce sync.Once
+
+	mu      sync.Mutex
+	closed  bool
+	pending int
+	idle    chan struct{} // closed while pending is zero
+}
+
+// NewAsyncSender starts an AsyncSender that sends with client. opts may be nil.
+func NewAsyncSender(client *Client, opts *AsyncSenderOptions) *AsyncSender {
+	var o AsyncSenderOptions
+	if opts != nil {
+		o = *opts
+	}
+	if o.QueueSize <= 0 {
+		o.QueueSize = defaultAsyncQueueSize
+	}
+	if o.Concurrency <= 0 {
+		o.Concurrency = defaultAsyncConcurrency
+	}
+	if o.BatchSize <= 0 {
+		o.BatchSize = defaultAsyncBatchSize
+	}
+	if o.FlushInterval <= 0 {
+		o.FlushInterval = defaultAsyncFlushInterval
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	a := &AsyncSender{
+		bulk:     NewBulkSender(client, o.Concurrency),
+		opts:     o,
+		queue:    make(chan *SendEmailRequest, o.QueueSize),
+		flushNow: make(chan struct{}, 1),
+		ctx:      ctx,
+		cancel:   cancel,
+		idle:     make(chan struct{}),
+	}
+	close(a.idle)
+
+	a.wg.Add(1)
+	go a.run()
+	return a
+}
+
+// Enqueue queues params to be sent in the background and returns without
+// waiting. params is validated first, so invalid emails fail immediately.
+// It returns ErrQueueFull if the queue has no room, and ErrSenderClosed
+// after Close. params must not be modified after it is queued.
+func (a *AsyncSender) Enqueue(params *SendEmailRequest) error {
+	if err := validateSendEmailRequest(params); err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.closed {
+		return ErrSenderClosed
+	}
+	select {
+	case a.queue <- params:
+	default:
+		return ErrQueueFull
+	}
+	if a.pending == 0 {
+		a.idle = make(chan struct{})
+	}
+	a.pending++
+	return nil
+}
+
+// Flush sends the emails queued so far without waiting for their batch to
+// fill up, and waits until each has been sent or has failed, or until ctx
+// is done.
+func (a *AsyncSender) Flush(ctx context.Context) error {
+	select {
+	case a.flushNow <- struct{}{}:
+	default:
+	}
+
+	a.mu.Lock()
+	idle := a.idle
+	a.mu.Unlock()
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close stops accepting emails, waits for the queued emails to be sent, and
+// stops the workers. Use Shutdown to bound the wait.
+func (a *AsyncSender) Close() error {
+	return a.Shutdown(context.Background())
+}
+
+// Shutdown is like Close, but if ctx is done before the queue is drained,
+// it abandons the remaining emails, reporting them to OnFailure, and
+// returns the context's error.
+func (a *AsyncSender) Shutdown(ctx context.Context) error {
+	a.closeOnce.Do(func() {
+		a.mu.Lock()
+		a.closed = true
+		close(a.queue)
+		a.mu.Unlock()
+	})
+
+	done := make(chan struct{})
+	go func() {
+		a.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		a.cancel()
+		return nil
+	case <-ctx.Done():
+		a.cancel()
+		<-done
+		return ctx.Err()
+	}
+}
+
+// run collects queued emails into batches and sends them until the queue is
+// closed and drained.
+func (a *AsyncSender) run() {
+	defer a.wg.Done()
+
+	var batch []*SendEmailRequest
+	var timer *time.Timer
+	var timeout <-chan time.Time
+	flush := func() {
+		if timer != nil {
+			timer.Stop()
+			timer, timeout = nil, nil
+		}
+		if len(batch) > 0 {
+			a.sendBatch(batch)
+			batch = nil
+		}
+	}
+	add := func(params *SendEmailRequest) {
+		batch = append(batch, params)
+		if len(batch) == 1 {
+			timer = time.NewTimer(a.opts.FlushInterval)
+			timeout = timer.C
+		}
+		if len(batch) >= a.opts.BatchSize {
+			flush()
+		}
+	}
+
+	for {
+		select {
+		case params, ok := <-a.queue:
+			if !ok {
+				flush()
+				return
+			}
+			add(params)
+		case <-timeout:
+			flush()
+		case <-a.flushNow:
+			// Take the emails already queued, so Flush does not wait for
+			// FlushInterval on their account.
+			for n := len(a.queue); n > 0; n-- {
+				params, ok := <-a.queue
+				if !ok {
+					break
+				}
+				add(params)
+			}
+			flush()
+		}
+	}
+}
+
+// sendBatch sends batch and reports the results to the callbacks. Once
+// Shutdown gave up, the emails fail with the context's error.
+func (a *AsyncSender) sendBatch(batch []*SendEmailRequest) {
+	var results []BulkResult
+	if err := a.ctx.Err(); err != nil {
+		results = make([]BulkResult, len(batch))
+		for i, params := range batch {
+			results[i] = BulkResult{Index: i, Request: params, Err: err}
+		}
+	} else {
+		results = a.bulk.Send(a.ctx, batch)
+	}
+
+	for _, r := range results {
+		if r.Err != nil {
+			if a.opts.OnFailure != nil {
+				a.opts.OnFailure(r.Request, r.Err)
+			}
+		} else if a.opts.OnSuccess != nil {
+			a.opts.OnSuccess(r.Request, r.Response)
+		}
+	}
+
+	a.mu.Lock()
+	a.pending -= len(batch)
+	if a.pending == 0 {
+		close(a.idle)
+	}
+	a.mu.Unlock()
+}
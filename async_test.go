@@ -0,0 +1,204 @@
+package envloped
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestAsyncSender(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var params SendEmailRequest
+		json.NewDecoder(r.Body).Decode(&params)
+		w.Header().Set("Content-Type", "application/json")
+		if params.To[0] == "user2@example.com" {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			w.Write([]byte(`{"error":"Domain not verified"}`))
+			return
+		}
+		w.Write([]byte(`{"success":true,"messageId":"msg_1"}`))
+	}))
+	defer server.Close()
+
+	var mu sync.Mutex
+	var succeeded, failed int
+	sender := NewAsyncSender(newTestClient(t, server), &AsyncSenderOptions{
+		Concurrency: 2,
+		OnSuccess: func(params *SendEmailRequest, resp *SendEmailResponse) {
+			mu.Lock()
+			defer mu.Unlock()
+			succeeded++
+		},
+		OnFailure: func(params *SendEmailRequest, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			failed++
+			if !errors.Is(err, ErrUnprocessable) {
+				t.Errorf("unexpected error %v", err)
+			}
+		},
+	})
+
+	for _, p := range bulkParams(5) {
+		if err := sender.Enqueue(p); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := sender.Enqueue(&SendEmailRequest{}); err == nil {
+		t.Error("expected invalid email to be rejected")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := sender.Flush(ctx); err != nil {
+		t.Fatalf("unexpected flush error: %v", err)
+	}
+	mu.Lock()
+	if succeeded != 4 || failed != 1 {
+		t.Errorf("expected 4 successes and 1 failure, got %d and %d", succeeded, failed)
+	}
+	mu.Unlock()
+
+	if err := sender.Close(); err != nil {
+		t.Fatalf("unexpected close error: %v", err)
+	}
+	if err := sender.Enqueue(bulkParams(1)[0]); !errors.Is(err, ErrSenderClosed) {
+		t.Errorf("expected ErrSenderClosed, got %v", err)
+	}
+	if err := sender.Close(); err != nil {
+		t.Errorf("expected repeated close to succeed, got %v", err)
+	}
+}
+
+func TestAsyncSender_QueueFullAndShutdown(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"success":true,"messageId":"msg_1"}`))
+	}))
+	defer server.Close()
+	defer close(release)
+
+	var mu sync.Mutex
+	var failures []error
+	sender := NewAsyncSender(newTestClient(t, server), &AsyncSenderOptions{
+		QueueSize:   1,
+		Concurrency: 1,
+		BatchSize:   1,
+		OnFailure: func(params *SendEmailRequest, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			failures = append(failures, err)
+		},
+	})
+
+	params := bulkParams(3)
+	if err := sender.Enqueue(params[0]); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Wait for the first email to be sent, freeing the queue slot.
+	deadline := time.Now().Add(time.Second)
+	for len(sender.queue) > 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if err := sender.Enqueue(params[1]); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := sender.Enqueue(params[2]); !errors.Is(err, ErrQueueFull) {
+		t.Errorf("expected ErrQueueFull, got %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := sender.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(failures) != 2 {
+		t.Errorf("expected both abandoned emails to be reported, got %v", failures)
+	}
+}
+
+func TestAsyncSender_Batching(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"success":true,"messageId":"msg_1"}`))
+	}))
+	defer server.Close()
+
+	sent := make(chan *SendEmailRequest, 10)
+	sender := NewAsyncSender(newTestClient(t, server), &AsyncSenderOptions{
+		BatchSize:     3,
+		FlushInterval: time.Hour,
+		OnSuccess: func(params *SendEmailRequest, resp *SendEmailResponse) {
+			sent <- params
+		},
+	})
+	defer sender.Close()
+
+	waitSent := func(n int) {
+		t.Helper()
+		for i := 0; i < n; i++ {
+			select {
+			case <-sent:
+			case <-time.After(5 * time.Second):
+				t.Fatalf("expected %d sent emails, got %d", n, i)
+			}
+		}
+	}
+
+	// A full batch is sent without waiting for the flush interval.
+	params := bulkParams(4)
+	for _, p := range params[:3] {
+		if err := sender.Enqueue(p); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	waitSent(3)
+
+	// A partial batch waits for the flush interval, or for Flush.
+	if err := sender.Enqueue(params[3]); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	select {
+	case <-sent:
+		t.Fatal("expected the partial batch to wait")
+	case <-time.After(50 * time.Millisecond):
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := sender.Flush(ctx); err != nil {
+		t.Fatalf("unexpected flush error: %v", err)
+	}
+	waitSent(1)
+
+	// Without Flush, the partial batch is sent after the flush interval.
+	timed := NewAsyncSender(newTestClient(t, server), &AsyncSenderOptions{
+		FlushInterval: 10 * time.Millisecond,
+		OnSuccess: func(params *SendEmailRequest, resp *SendEmailResponse) {
+			sent <- params
+		},
+	})
+	defer timed.Close()
+	if err := timed.Enqueue(bulkParams(1)[0]); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	waitSent(1)
+}
@@ -0,0 +1,261 @@
+package envloped
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+)
+
+// defaultMaxAttachmentSize is the per-attachment size limit enforced
+// client-side unless plan limits with a different value are loaded.
+const defaultMaxAttachmentSize = 10 << 20 // 10 MB
+
+// defaultMaxMessageSize is the limit on the combined size of the bodies and
+// attachments of an email enforced client-side unless plan limits with a
+// different value are loaded. Larger requests are rejected by the API with
+// HTTP 413.
+const defaultMaxMessageSize = 40 << 20 // 40 MB
+
+// Attachment is a file attached to an email.
+type Attachment struct {
+	// Filename is the name of the file as shown to the recipient.
+	Filename string
+
+	// Content is the raw file content. It is base64-encoded for transport automatically.
+	Content []byte
+
+	// Base64Content is the file content, already base64-encoded (standard encoding).
+	// It is used only when Content is empty.
+	Base64Content string
+
+	// ContentType is the MIME type of the file (e.g., "application/pdf").
+	// If empty, the API infers it from the filename.
+	ContentType string
+
+	// Reader streams the file content. It is used only when Content and
+	// Base64Content are empty. Before the email is sent, the content is
+	// uploaded as multipart/form-data without being buffered in memory, so
+	// large files do not need to be held and base64-encoded in the request.
+	Reader io.Reader
+
+	// ID references a file previously uploaded with Client.UploadAttachment,
+	// so the same file can be attached to many emails without re-uploading.
+	// It is used only when Content, Base64Content, and Reader are empty.
+	ID string
+}
+
+// attachmentJSON is the wire representation of an Attachment.
+type attachmentJSON struct {
+	Filename    string `json:"filename"`
+	Content     string `json:"content,omitempty"`
+	ContentType string `json:"contentType,omitempty"`
+	ID          string `json:"id,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (a Attachment) MarshalJSON() ([]byte, error) {
+	content := a.Base64Content
+	if len(a.Content) > 0 {
+		content = base64.StdEncoding.EncodeToString(a.Content)
+	}
+	return json.Marshal(attachmentJSON{
+		Filename:    a.Filename,
+		Content:     content,
+		ContentType: a.ContentType,
+		ID:          a.ID,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler. The decoded file content is
+// stored in Content.
+func (a *Attachment) UnmarshalJSON(data []byte) error {
+	var aj attachmentJSON
+	if err := json.Unmarshal(data, &aj); err != nil {
+		return err
+	}
+	content, err := base64.StdEncoding.DecodeString(aj.Content)
+	if err != nil {
+		return fmt.Errorf("envloped: invalid attachment content: %w", err)
+	}
+	*a = Attachment{Filename: aj.Filename, Content: content, ContentType: aj.ContentType, ID: aj.ID}
+	return nil
+}
+
+// streamed reports whether the attachment content is read from Reader or
+// was uploaded separately, rather than sent inline.
+func (a *Attachment) streamed() bool {
+	return len(a.Content) == 0 && a.Base64Content == "" && (a.Reader != nil || a.ID != "")
+}
+
+// size returns the decoded size of the attachment content in bytes.
+func (a *Attachment) size() (int64, error) {
+	if len(a.Content) > 0 {
+		return int64(len(a.Content)), nil
+	}
+	n, err := base64.StdEncoding.DecodeString(a.Base64Content)
+	if err != nil {
+		return 0, err
+	}
+	return int64(len(n)), nil
+}
+
+// validateAttachments checks that every attachment is well-formed and no
+// larger than maxSize bytes.
+func validateAttachments(attachments []Attachment, maxSize int64) error {
+	for i := range attachments {
+		a := &attachments[i]
+		if a.Filename == "" {
+			return fieldError(fmt.Sprintf("attachments[%d].filename", i), "required", "attachment %d: filename is required", i)
+		}
+		if a.streamed() {
+			// The size of streamed content is only known to the API.
+			continue
+		}
+		size, err := a.size()
+		if err != nil {
+			return fieldError(fmt.Sprintf("attachments[%d].content", i), "invalid", "attachment %q: invalid base64 content: %v", a.Filename, err)
+		}
+		if size == 0 {
+			return fieldError(fmt.Sprintf("attachments[%d].content", i), "required", "attachment %q: content is required", a.Filename)
+		}
+		if size > maxSize {
+			return fieldError(fmt.Sprintf("attachments[%d].content", i), "too_large", "attachment %q is %d bytes, exceeds the %d byte limit", a.Filename, size, maxSize)
+		}
+	}
+	return nil
+}
+
+// validateMessageSize checks that the combined size of the Html, Text, and
+// AmpHtml bodies and the attachments of params is at most maxSize bytes.
+// Attachments are counted at their base64-encoded size, as sent in the
+// request. Streamed and uploaded attachments are not counted, since their
+// size is only known to the API. The error names the largest part as its
+// field.
+func validateMessageSize(params *SendEmailRequest, maxSize int64) error {
+	parts := []struct {
+		field string
+		size  int64
+	}{
+		{"html", int64(len(params.Html))},
+		{"text", int64(len(params.Text))},
+		{"ampHtml", int64(len(params.AmpHtml))},
+		{"attachments", 0},
+	}
+	for i := range params.Attachments {
+		if a := &params.Attachments[i]; !a.streamed() {
+			size, _ := a.size() // validated by validateAttachments
+			parts[3].size += int64(base64.StdEncoding.EncodedLen(int(size)))
+		}
+	}
+
+	var total int64
+	largest := parts[0]
+	for _, p := range parts {
+		total += p.size
+		if p.size > largest.size {
+			largest = p
+		}
+	}
+	if total > maxSize {
+		return fieldError(largest.field, "too_large", "email is %d bytes, exceeds the %d byte limit for bodies and attachments combined", total, maxSize)
+	}
+	return nil
+}
+
+// uploadResponse is the response of the attachment upload endpoint.
+type uploadResponse struct {
+	ID string `json:"id"`
+}
+
+// UploadAttachment streams a file to the API and returns its attachment ID.
+// Reference the ID in Attachment.ID to attach the file to any number of emails.
+func (c *Client) UploadAttachment(filename, contentType string, r io.Reader) (string, error) {
+	return c.UploadAttachmentWithContext(context.Background(), filename, contentType, r)
+}
+
+// UploadAttachmentWithContext streams a file to the API using the given context.
+// The content is sent as multipart/form-data without being buffered in memory.
+// If contentType is empty, the API infers it from the filename.
+func (c *Client) UploadAttachmentWithContext(ctx context.Context, filename, contentType string, r io.Reader) (string, error) {
+	if filename == "" {
+		return "", fmt.Errorf("envloped: attachment filename is required")
+	}
+	if r == nil {
+		return "", fmt.Errorf("envloped: attachment reader must not be nil")
+	}
+
+	pr, pw := io.Pipe()
+	defer pr.Close()
+
+	mw := multipart.NewWriter(pw)
+	go func() {
+		pw.CloseWithError(writeAttachmentForm(mw, filename, contentType, r))
+	}()
+
+	req, err := c.newStreamRequest(ctx, http.MethodPost, "/v1/attachments", "/v1/attachments", pr, mw.FormDataContentType())
+	if err != nil {
+		return "", fmt.Errorf("envloped: failed to create attachment upload request: %w", err)
+	}
+
+	var resp uploadResponse
+	if err := c.do(req, &resp); err != nil {
+		return "", err
+	}
+
+	return resp.ID, nil
+}
+
+// writeAttachmentForm writes the file part to mw and closes it.
+func writeAttachmentForm(mw *multipart.Writer, filename, contentType string, r io.Reader) error {
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	h := make(textproto.MIMEHeader)
+	h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename=%q`, filename))
+	h.Set("Content-Type", contentType)
+	part, err := mw.CreatePart(h)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(part, r); err != nil {
+		return err
+	}
+
+	return mw.Close()
+}
+
+// uploadAttachments uploads every attachment of params that streams from a
+// Reader. If any does, it returns a shallow copy of params whose attachments
+// reference the uploads by ID; params itself is not modified.
+func (c *Client) uploadAttachments(ctx context.Context, params *SendEmailRequest) (*SendEmailRequest, error) {
+	var out []Attachment
+	for i := range params.Attachments {
+		a := &params.Attachments[i]
+		if !a.streamed() || a.Reader == nil {
+			continue
+		}
+		if out == nil {
+			out = append([]Attachment(nil), params.Attachments...)
+		}
+
+		id, err := c.UploadAttachmentWithContext(ctx, a.Filename, a.ContentType, a.Reader)
+		if err != nil {
+			return nil, fmt.Errorf("envloped: attachment %q: upload failed: %w", a.Filename, err)
+		}
+		out[i].Reader = nil
+		out[i].ID = id
+	}
+	if out == nil {
+		return params, nil
+	}
+
+	cp := *params
+	cp.Attachments = out
+	return &cp, nil
+}
@@ -0,0 +1,146 @@
+package envloped
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+// AttachmentLoadOptions configures AttachmentFromFile and AttachmentFromURL.
+type AttachmentLoadOptions struct {
+	// HTTPClient downloads the attachments of AttachmentFromURL. Defaults to
+	// http.DefaultClient.
+	HTTPClient *http.Client
+
+	// MaxSize is the size of the largest attachment accepted, in bytes.
+	// Defaults to 10 MB, the default attachment limit. On plans with a
+	// higher limit, set it to Limits.MaxAttachmentSize.
+	MaxSize int64
+}
+
+// maxSize returns the attachment size limit of o, which may be nil.
+func (o *AttachmentLoadOptions) maxSize() int64 {
+	if o == nil || o.MaxSize <= 0 {
+		return defaultMaxAttachmentSize
+	}
+	return o.MaxSize
+}
+
+// AttachmentFromFile reads the file at name into an Attachment named after
+// its base name. The content type is derived from the file extension, or
+// detected from the content if the extension is unknown. Files larger than
+// opts.MaxSize are rejected before they are read. opts may be nil.
+func AttachmentFromFile(name string, opts *AttachmentLoadOptions) (Attachment, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return Attachment{}, fmt.Errorf("envloped: failed to open attachment: %w", err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return Attachment{}, fmt.Errorf("envloped: failed to open attachment: %w", err)
+	}
+	if info.IsDir() {
+		return Attachment{}, fmt.Errorf("envloped: attachment %s is a directory", name)
+	}
+	filename := filepath.Base(name)
+	maxSize := opts.maxSize()
+	if info.Size() > maxSize {
+		return Attachment{}, fmt.Errorf("envloped: attachment %q is %d bytes, exceeds the %d byte limit", filename, info.Size(), maxSize)
+	}
+
+	content, err := readAttachment(filename, f, maxSize)
+	if err != nil {
+		return Attachment{}, err
+	}
+	return Attachment{
+		Filename:    filename,
+		Content:     content,
+		ContentType: detectContentType(filename, "", content),
+	}, nil
+}
+
+// AttachmentFromURL downloads rawURL into an Attachment named after the last
+// element of its path, using opts.HTTPClient. The content type is taken from
+// the response, the file extension, or the content, in that order. Responses
+// other than 200 OK and bodies larger than opts.MaxSize are rejected. opts
+// may be nil.
+func AttachmentFromURL(ctx context.Context, rawURL string, opts *AttachmentLoadOptions) (Attachment, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return Attachment{}, fmt.Errorf("envloped: invalid attachment url: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return Attachment{}, fmt.Errorf("envloped: attachment url %q must use http or https", rawURL)
+	}
+	filename := path.Base(u.Path)
+	if filename == "/" || filename == "." {
+		filename = "attachment"
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return Attachment{}, fmt.Errorf("envloped: failed to create attachment request: %w", err)
+	}
+	httpClient := http.DefaultClient
+	if opts != nil && opts.HTTPClient != nil {
+		httpClient = opts.HTTPClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return Attachment{}, fmt.Errorf("envloped: failed to download attachment: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return Attachment{}, fmt.Errorf("envloped: failed to download attachment %s: %s", u.Redacted(), resp.Status)
+	}
+	maxSize := opts.maxSize()
+	if resp.ContentLength > maxSize {
+		return Attachment{}, fmt.Errorf("envloped: attachment %q is %d bytes, exceeds the %d byte limit", filename, resp.ContentLength, maxSize)
+	}
+
+	content, err := readAttachment(filename, resp.Body, maxSize)
+	if err != nil {
+		return Attachment{}, err
+	}
+	return Attachment{
+		Filename:    filename,
+		Content:     content,
+		ContentType: detectContentType(filename, resp.Header.Get("Content-Type"), content),
+	}, nil
+}
+
+// readAttachment reads r, failing if it is empty or exceeds maxSize bytes.
+func readAttachment(filename string, r io.Reader, maxSize int64) ([]byte, error) {
+	content, err := io.ReadAll(io.LimitReader(r, maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to read attachment %q: %w", filename, err)
+	}
+	if int64(len(content)) > maxSize {
+		return nil, fmt.Errorf("envloped: attachment %q exceeds the %d byte limit", filename, maxSize)
+	}
+	if len(content) == 0 {
+		return nil, fmt.Errorf("envloped: attachment %q is empty", filename)
+	}
+	return content, nil
+}
+
+// detectContentType returns the media type of an attachment from the given
+// Content-Type header, its filename extension, or its content.
+func detectContentType(filename, header string, content []byte) string {
+	if mediaType, _, err := mime.ParseMediaType(header); err == nil && mediaType != "application/octet-stream" {
+		return header
+	}
+	if ct := mime.TypeByExtension(filepath.Ext(filename)); ct != "" {
+		return ct
+	}
+	return http.DetectContentType(content)
+}
@@ -0,0 +1,151 @@
+package envloped
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestAttachmentFromFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	pdf := filepath.Join(dir, "invoice.pdf")
+	if err := os.WriteFile(pdf, []byte("%PDF-1.4"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	noExt := filepath.Join(dir, "logo")
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	if err := os.WriteFile(noExt, png, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	a, err := AttachmentFromFile(pdf, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if a.Filename != "invoice.pdf" || a.ContentType != "application/pdf" || string(a.Content) != "%PDF-1.4" {
+		t.Errorf("unexpected attachment %+v", a)
+	}
+
+	if a, err = AttachmentFromFile(noExt, nil); err != nil || a.ContentType != "image/png" {
+		t.Errorf("expected sniffed image/png, got %q, %v", a.ContentType, err)
+	}
+}
+
+func TestAttachmentFromFile_Errors(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	empty := filepath.Join(dir, "empty.txt")
+	if err := os.WriteFile(empty, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	large := filepath.Join(dir, "large.bin")
+	if err := os.WriteFile(large, make([]byte, defaultMaxAttachmentSize+1), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		wantErr string
+	}{
+		{"missing", filepath.Join(dir, "missing.pdf"), "failed to open attachment"},
+		{"directory", dir, "is a directory"},
+		{"empty", empty, "is empty"},
+		{"too large", large, "exceeds the 10485760 byte limit"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if _, err := AttachmentFromFile(tt.path, nil); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestAttachmentFromURL(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/files/report.csv":
+			w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+			w.Write([]byte("a,b\n1,2\n"))
+		case "/files/terms.pdf":
+			w.Header().Set("Content-Type", "application/octet-stream")
+			w.Write([]byte("%PDF-1.4"))
+		case "/files/huge.bin":
+			w.Write(bytes.Repeat([]byte("x"), defaultMaxAttachmentSize+1))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	a, err := AttachmentFromURL(ctx, server.URL+"/files/report.csv", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if a.Filename != "report.csv" || a.ContentType != "text/csv; charset=utf-8" || string(a.Content) != "a,b\n1,2\n" {
+		t.Errorf("unexpected attachment %+v", a)
+	}
+
+	if a, err = AttachmentFromURL(ctx, server.URL+"/files/terms.pdf", nil); err != nil || a.ContentType != "application/pdf" {
+		t.Errorf("expected content type from extension, got %q, %v", a.ContentType, err)
+	}
+
+	for path, wantErr := range map[string]string{
+		"/files/missing.pdf": "404 Not Found",
+		"/files/huge.bin":    "exceeds the 10485760 byte limit",
+	} {
+		if _, err := AttachmentFromURL(ctx, server.URL+path, nil); err == nil || !strings.Contains(err.Error(), wantErr) {
+			t.Errorf("%s: expected error containing %q, got %v", path, wantErr, err)
+		}
+	}
+	if _, err := AttachmentFromURL(ctx, "file:///etc/passwd", nil); err == nil {
+		t.Error("expected error for non-http url")
+	}
+}
+
+func TestAttachmentLoadOptions(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Write(bytes.Repeat([]byte("x"), 100))
+	}))
+	defer server.Close()
+
+	file := filepath.Join(t.TempDir(), "data.bin")
+	if err := os.WriteFile(file, bytes.Repeat([]byte("x"), 100), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	small := &AttachmentLoadOptions{HTTPClient: server.Client(), MaxSize: 50}
+	if _, err := AttachmentFromFile(file, small); err == nil || !strings.Contains(err.Error(), "exceeds the 50 byte limit") {
+		t.Errorf("expected the file to exceed MaxSize, got %v", err)
+	}
+	if _, err := AttachmentFromURL(context.Background(), server.URL+"/data.bin", small); err == nil || !strings.Contains(err.Error(), "exceeds the 50 byte limit") {
+		t.Errorf("expected the download to exceed MaxSize, got %v", err)
+	}
+
+	large := &AttachmentLoadOptions{HTTPClient: server.Client(), MaxSize: 100}
+	if a, err := AttachmentFromURL(context.Background(), server.URL+"/data.bin", large); err != nil || len(a.Content) != 100 {
+		t.Errorf("unexpected attachment %+v, %v", a, err)
+	}
+	if got := atomic.LoadInt32(&requests); got != 2 {
+		t.Errorf("expected 2 requests through the HTTP client, got %d", got)
+	}
+}
@@ -0,0 +1,291 @@
+package envloped
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSendEmail_MultipleAttachments(t *testing.T) {
+	t.Parallel()
+
+	pdf := []byte("%PDF-1.4 fake pdf content")
+	csvData := "id,total\n1,9.99\n"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var raw struct {
+			Attachments []map[string]string `json:"attachments"`
+		}
+		var req SendEmailRequest
+		body := new(bytes.Buffer)
+		body.ReadFrom(r.Body)
+		if err := json.Unmarshal(body.Bytes(), &raw); err != nil {
+			t.Fatalf("failed to decode raw body: %v", err)
+		}
+		if err := json.Unmarshal(body.Bytes(), &req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+
+		if len(raw.Attachments) != 2 {
+			t.Fatalf("expected 2 attachments, got %d", len(raw.Attachments))
+		}
+		if got := raw.Attachments[0]["content"]; got != base64.StdEncoding.EncodeToString(pdf) {
+			t.Errorf("expected base64 encoded content, got %q", got)
+		}
+		if got := raw.Attachments[0]["contentType"]; got != "application/pdf" {
+			t.Errorf("expected contentType application/pdf, got %q", got)
+		}
+
+		if !bytes.Equal(req.Attachments[0].Content, pdf) {
+			t.Errorf("unexpected decoded pdf content: %q", req.Attachments[0].Content)
+		}
+		if req.Attachments[1].Filename != "orders.csv" || string(req.Attachments[1].Content) != csvData {
+			t.Errorf("unexpected second attachment: %+v", req.Attachments[1])
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(SendEmailResponse{Success: true, MessageId: "msg_att"})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	_, err := client.Emails.Send(&SendEmailRequest{
+		From:    "sender@example.com",
+		To:      []string{"recipient@example.com"},
+		Subject: "Your invoice",
+		Html:    "<p>Attached.</p>",
+		Attachments: []Attachment{
+			{Filename: "invoice.pdf", Content: pdf, ContentType: "application/pdf"},
+			{Filename: "orders.csv", Base64Content: base64.StdEncoding.EncodeToString([]byte(csvData)), ContentType: "text/csv"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestSendEmail_AttachmentValidation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		attachment Attachment
+		wantErr    string
+	}{
+		{
+			name:       "missing filename",
+			attachment: Attachment{Content: []byte("x")},
+			wantErr:    "filename is required",
+		},
+		{
+			name:       "empty content",
+			attachment: Attachment{Filename: "a.txt"},
+			wantErr:    "content is required",
+		},
+		{
+			name:       "invalid base64",
+			attachment: Attachment{Filename: "a.txt", Base64Content: "not base64!"},
+			wantErr:    "invalid base64 content",
+		},
+		{
+			name:       "too large",
+			attachment: Attachment{Filename: "big.bin", Content: make([]byte, defaultMaxAttachmentSize+1)},
+			wantErr:    "exceeds the",
+		},
+	}
+
+	client := NewClient("key")
+
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := client.Emails.Send(&SendEmailRequest{
+				From:        "a@b.com",
+				To:          []string{"b@c.com"},
+				Subject:     "s",
+				Text:        "x",
+				Attachments: []Attachment{{Filename: "ok.txt", Content: []byte("ok")}, tt.attachment},
+			})
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+			if got := err.Error(); !contains(got, tt.wantErr) {
+				t.Errorf("expected error to contain %q, got %q", tt.wantErr, got)
+			}
+		})
+	}
+}
+
+func TestSendEmail_AttachmentLimitFromPlan(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("key").WithLimits(&Limits{MaxAttachmentSize: 4})
+	_, err := client.Emails.Send(&SendEmailRequest{
+		From:        "a@b.com",
+		To:          []string{"b@c.com"},
+		Subject:     "s",
+		Text:        "x",
+		Attachments: []Attachment{{Filename: "a.txt", Content: []byte("12345")}},
+	})
+	if err == nil || !contains(err.Error(), "exceeds the 4 byte limit") {
+		t.Errorf("expected plan attachment limit error, got %v", err)
+	}
+}
+
+func TestSendEmail_MessageSize(t *testing.T) {
+	t.Parallel()
+
+	fiveMB := make([]byte, 5<<20)
+	var attachments []Attachment
+	for i := 0; i < 8; i++ {
+		attachments = append(attachments, Attachment{Filename: fmt.Sprintf("part%d.bin", i), Content: fiveMB})
+	}
+
+	client := NewClient("key")
+	_, err := client.Emails.Send(&SendEmailRequest{
+		From:        "a@b.com",
+		To:          []string{"b@c.com"},
+		Subject:     "s",
+		Text:        "x",
+		Attachments: attachments,
+	})
+	var ve *ValidationError
+	if !errors.As(err, &ve) {
+		t.Fatalf("expected ValidationError, got %v", err)
+	}
+	if ve.Fields[0].Field != "attachments" || ve.Fields[0].Code != "too_large" {
+		t.Errorf("unexpected field error %+v", ve.Fields[0])
+	}
+
+	client = NewClient("key").WithLimits(&Limits{MaxMessageSize: 8})
+	_, err = client.Emails.Send(&SendEmailRequest{
+		From:    "a@b.com",
+		To:      []string{"b@c.com"},
+		Subject: "s",
+		Html:    "<p>Hello</p>",
+		Text:    "x",
+	})
+	if err == nil || !contains(err.Error(), "email is 13 bytes, exceeds the 8 byte limit") {
+		t.Errorf("expected plan message size error, got %v", err)
+	}
+	if errors.As(err, &ve) && ve.Fields[0].Field != "html" {
+		t.Errorf("expected html to be reported as the largest part, got %q", ve.Fields[0].Field)
+	}
+
+	// 6 bytes of content are 8 bytes once base64-encoded.
+	client = NewClient("key").WithLimits(&Limits{MaxMessageSize: 10})
+	_, err = client.Emails.Send(&SendEmailRequest{
+		From:        "a@b.com",
+		To:          []string{"b@c.com"},
+		Subject:     "s",
+		Text:        "xxx",
+		Attachments: []Attachment{{Filename: "a.txt", Content: []byte("123456")}},
+	})
+	if err == nil || !contains(err.Error(), "email is 11 bytes, exceeds the 10 byte limit") {
+		t.Errorf("expected encoded attachment size to be counted, got %v", err)
+	}
+
+	// The text derived by AutoText is sent, so it counts too.
+	client = NewClient("key").WithLimits(&Limits{MaxMessageSize: 16})
+	_, err = client.Emails.Send(&SendEmailRequest{
+		From:     "a@b.com",
+		To:       []string{"b@c.com"},
+		Subject:  "s",
+		Html:     "<p>Hello</p>",
+		AutoText: true,
+	})
+	if err == nil || !contains(err.Error(), "email is 17 bytes, exceeds the 16 byte limit") {
+		t.Errorf("expected derived text to be counted, got %v", err)
+	}
+}
+
+func TestSendEmail_StreamedAttachment(t *testing.T) {
+	t.Parallel()
+
+	const pdf = "%PDF-1.4 streamed content"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/attachments":
+			if !strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+				t.Errorf("expected multipart upload, got %q", r.Header.Get("Content-Type"))
+			}
+			file, header, err := r.FormFile("file")
+			if err != nil {
+				t.Fatalf("expected file part: %v", err)
+			}
+			defer file.Close()
+			data, _ := io.ReadAll(file)
+			if string(data) != pdf || header.Filename != "report.pdf" {
+				t.Errorf("unexpected upload %q: %q", header.Filename, data)
+			}
+			if got := header.Header.Get("Content-Type"); got != "application/pdf" {
+				t.Errorf("expected part content type application/pdf, got %q", got)
+			}
+			w.Write([]byte(`{"id":"att_1"}`))
+		case "/v1/emails":
+			var body struct {
+				Attachments []map[string]string `json:"attachments"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			if len(body.Attachments) != 2 {
+				t.Fatalf("expected 2 attachments, got %v", body.Attachments)
+			}
+			if body.Attachments[0]["content"] == "" {
+				t.Errorf("expected inline attachment content, got %v", body.Attachments[0])
+			}
+			streamed := body.Attachments[1]
+			if streamed["id"] != "att_1" || streamed["filename"] != "report.pdf" {
+				t.Errorf("expected streamed attachment to reference the upload, got %v", streamed)
+			}
+			if _, ok := streamed["content"]; ok {
+				t.Errorf("expected no inline content for streamed attachment, got %v", streamed)
+			}
+			w.Write([]byte(`{"success":true,"messageId":"msg_1"}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+
+	params := &SendEmailRequest{
+		From:    "a@b.com",
+		To:      []string{"b@c.com"},
+		Subject: "s",
+		Text:    "x",
+		Attachments: []Attachment{
+			{Filename: "notes.txt", Content: []byte("inline")},
+			{Filename: "report.pdf", ContentType: "application/pdf", Reader: strings.NewReader(pdf)},
+		},
+	}
+	if _, err := client.Emails.Send(params); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if params.Attachments[1].ID != "" || params.Attachments[1].Reader == nil {
+		t.Error("expected caller's params to be left unmodified")
+	}
+}
+
+func TestUploadAttachment_Validation(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("key")
+	if _, err := client.UploadAttachment("", "", strings.NewReader("x")); err == nil {
+		t.Error("expected error for missing filename")
+	}
+	if _, err := client.UploadAttachment("a.txt", "", nil); err == nil {
+		t.Error("expected error for nil reader")
+	}
+}
@@ -0,0 +1,214 @@
+package envloped
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Audience is a named list of contacts that broadcasts are sent to.
+type Audience struct {
+	// ID is the unique identifier of the audience.
+	ID string `json:"id"`
+
+	// Name is a human-readable name for the audience.
+	Name string `json:"name"`
+
+	// ContactCount is the number of contacts in the audience.
+	ContactCount int `json:"contactCount"`
+
+	// CreatedAt is when the audience was created.
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// AudienceRequest is the request body for creating an audience.
+type AudienceRequest struct {
+	// Name is a human-readable name for the audience. Required.
+	Name string `json:"name"`
+}
+
+// audienceMembersRequest is the request body for adding or removing audience members.
+type audienceMembersRequest struct {
+	ContactIDs []string `json:"contactIds"`
+}
+
+// AudiencesSvc defines the interface for the audiences service.
+// This interface can be mocked in consumer tests.
+type AudiencesSvc interface {
+	// Create creates an audience.
+	Create(params *AudienceRequest) (*Audience, error)
+
+	// CreateWithContext creates an audience using the provided context.
+	CreateWithContext(ctx context.Context, params *AudienceRequest) (*Audience, error)
+
+	// Get retrieves an audience by ID.
+	Get(audienceID string) (*Audience, error)
+
+	// GetWithContext retrieves an audience using the provided context.
+	GetWithContext(ctx context.Context, audienceID string) (*Audience, error)
+
+	// List returns all audiences.
+	List() ([]Audience, error)
+
+	// ListWithContext returns all audiences using the provided context.
+	ListWithContext(ctx context.Context) ([]Audience, error)
+
+	// Delete removes an audience. Its contacts are kept.
+	Delete(audienceID string) error
+
+	// DeleteWithContext removes an audience using the provided context.
+	DeleteWithContext(ctx context.Context, audienceID string) error
+
+	// AddContacts adds existing contacts to an audience.
+	AddContacts(audienceID string, contactIDs []string) error
+
+	// AddContactsWithContext adds contacts to an audience using the provided context.
+	AddContactsWithContext(ctx context.Context, audienceID string, contactIDs []string) error
+
+	// RemoveContacts removes contacts from an audience without deleting them.
+	RemoveContacts(audienceID string, contactIDs []string) error
+
+	// RemoveContactsWithContext removes contacts from an audience using the provided context.
+	RemoveContactsWithContext(ctx context.Context, audienceID string, contactIDs []string) error
+}
+
+// audiencesSvcImpl implements AudiencesSvc.
+type audiencesSvcImpl struct {
+	client *Client
+}
+
+// audiencePath returns the API path of an audience, with optional suffix.
+func audiencePath(audienceID, suffix string) string {
+	return "/v1/audiences/" + url.PathEscape(audienceID) + suffix
+}
+
+// Create creates an audience.
+func (s *audiencesSvcImpl) Create(params *AudienceRequest) (*Audience, error) {
+	return s.CreateWithContext(context.Background(), params)
+}
+
+// CreateWithContext creates an audience using the provided context.
+func (s *audiencesSvcImpl) CreateWithContext(ctx context.Context, params *AudienceRequest) (*Audience, error) {
+	if params == nil {
+		return nil, fmt.Errorf("envloped: audience params must not be nil")
+	}
+	if params.Name == "" {
+		return nil, fmt.Errorf("envloped: audience name is required")
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodPost, "/v1/audiences", "/v1/audiences", params)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to create audience request: %w", err)
+	}
+
+	var resp Audience
+	if err := s.client.do(req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// Get retrieves an audience by ID.
+func (s *audiencesSvcImpl) Get(audienceID string) (*Audience, error) {
+	return s.GetWithContext(context.Background(), audienceID)
+}
+
+// GetWithContext retrieves an audience using the provided context.
+func (s *audiencesSvcImpl) GetWithContext(ctx context.Context, audienceID string) (*Audience, error) {
+	if audienceID == "" {
+		return nil, fmt.Errorf("envloped: audience id is required")
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodGet, "/v1/audiences/{id}", audiencePath(audienceID, ""), nil)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to create get audience request: %w", err)
+	}
+
+	var resp Audience
+	if err := s.client.do(req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// List returns all audiences.
+func (s *audiencesSvcImpl) List() ([]Audience, error) {
+	return s.ListWithContext(context.Background())
+}
+
+// ListWithContext returns all audiences using the provided context.
+func (s *audiencesSvcImpl) ListWithContext(ctx context.Context) ([]Audience, error) {
+	req, err := s.client.newRequest(ctx, http.MethodGet, "/v1/audiences", "/v1/audiences", nil)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to create list audiences request: %w", err)
+	}
+
+	var resp listResponse[Audience]
+	if err := s.client.do(req, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Data, nil
+}
+
+// Delete removes an audience. Its contacts are kept.
+func (s *audiencesSvcImpl) Delete(audienceID string) error {
+	return s.DeleteWithContext(context.Background(), audienceID)
+}
+
+// DeleteWithContext removes an audience using the provided context.
+func (s *audiencesSvcImpl) DeleteWithContext(ctx context.Context, audienceID string) error {
+	if audienceID == "" {
+		return fmt.Errorf("envloped: audience id is required")
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodDelete, "/v1/audiences/{id}", audiencePath(audienceID, ""), nil)
+	if err != nil {
+		return fmt.Errorf("envloped: failed to create delete audience request: %w", err)
+	}
+
+	return s.client.do(req, nil)
+}
+
+// AddContacts adds existing contacts to an audience.
+func (s *audiencesSvcImpl) AddContacts(audienceID string, contactIDs []string) error {
+	return s.AddContactsWithContext(context.Background(), audienceID, contactIDs)
+}
+
+// AddContactsWithContext adds contacts to an audience using the provided context.
+// Contacts already in the audience are ignored.
+func (s *audiencesSvcImpl) AddContactsWithContext(ctx context.Context, audienceID string, contactIDs []string) error {
+	return s.members(ctx, http.MethodPost, audienceID, contactIDs)
+}
+
+// RemoveContacts removes contacts from an audience without deleting them.
+func (s *audiencesSvcImpl) RemoveContacts(audienceID string, contactIDs []string) error {
+	return s.RemoveContactsWithContext(context.Background(), audienceID, contactIDs)
+}
+
+// RemoveContactsWithContext removes contacts from an audience using the provided context.
+func (s *audiencesSvcImpl) RemoveContactsWithContext(ctx context.Context, audienceID string, contactIDs []string) error {
+	return s.members(ctx, http.MethodDelete, audienceID, contactIDs)
+}
+
+// members adds (POST) or removes (DELETE) audience members.
+func (s *audiencesSvcImpl) members(ctx context.Context, method, audienceID string, contactIDs []string) error {
+	if audienceID == "" {
+		return fmt.Errorf("envloped: audience id is required")
+	}
+	if len(contactIDs) == 0 {
+		return fmt.Errorf("envloped: at least one contact id is required")
+	}
+
+	body := &audienceMembersRequest{ContactIDs: contactIDs}
+	req, err := s.client.newRequest(ctx, method, "/v1/audiences/{id}/contacts", audiencePath(audienceID, "/contacts"), body)
+	if err != nil {
+		return fmt.Errorf("envloped: failed to create audience contacts request: %w", err)
+	}
+
+	return s.client.do(req, nil)
+}
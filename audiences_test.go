@@ -0,0 +1,101 @@
+package envloped
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAudiences_CRUD(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/audiences":
+			var req AudienceRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			json.NewEncoder(w).Encode(Audience{ID: "aud_1", Name: req.Name})
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/audiences/aud_1":
+			json.NewEncoder(w).Encode(Audience{ID: "aud_1", Name: "Newsletter", ContactCount: 2})
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/audiences":
+			json.NewEncoder(w).Encode(map[string]interface{}{"data": []Audience{{ID: "aud_1"}}})
+		case r.Method == http.MethodDelete && r.URL.Path == "/v1/audiences/aud_1":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+
+	aud, err := client.Audiences.Create(&AudienceRequest{Name: "Newsletter"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if aud.ID != "aud_1" || aud.Name != "Newsletter" {
+		t.Errorf("unexpected audience: %+v", aud)
+	}
+
+	aud, err = client.Audiences.Get("aud_1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if aud.ContactCount != 2 {
+		t.Errorf("expected 2 contacts, got %d", aud.ContactCount)
+	}
+
+	list, err := client.Audiences.List()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(list) != 1 {
+		t.Errorf("expected 1 audience, got %d", len(list))
+	}
+
+	if err := client.Audiences.Delete("aud_1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestAudiences_Members(t *testing.T) {
+	t.Parallel()
+
+	var methods []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/audiences/aud_1/contacts" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		var body struct {
+			ContactIDs []string `json:"contactIds"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if len(body.ContactIDs) != 2 {
+			t.Errorf("expected 2 contact ids, got %v", body.ContactIDs)
+		}
+		methods = append(methods, r.Method)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+
+	if err := client.Audiences.AddContacts("aud_1", []string{"con_1", "con_2"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := client.Audiences.RemoveContacts("aud_1", []string{"con_1", "con_2"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(methods) != 2 || methods[0] != http.MethodPost || methods[1] != http.MethodDelete {
+		t.Errorf("unexpected methods %v", methods)
+	}
+
+	if err := client.Audiences.AddContacts("aud_1", nil); err == nil {
+		t.Error("expected error for empty contact ids")
+	}
+	if err := client.Audiences.AddContacts("", []string{"con_1"}); err == nil {
+		t.Error("expected error for missing audience id")
+	}
+}
@@ -0,0 +1,188 @@
+package envloped
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+// HTMLToText derives a readable plain-text version of an HTML email body.
+// Paragraphs and headings are separated by blank lines, list items are
+// prefixed with "- ", link targets are kept in parentheses after the link
+// text, and scripts, styles, and comments are dropped.
+//
+// It is used by SendEmailRequest.AutoText, and is exported so the result can
+// be previewed or adjusted before sending.
+func HTMLToText(s string) string {
+	var w textWriter
+	for len(s) > 0 {
+		i := strings.IndexByte(s, '<')
+		if i < 0 {
+			w.text(s)
+			break
+		}
+		w.text(s[:i])
+		s = s[i:]
+
+		if !startsTag(s) {
+			// A "<" not followed by a tag name, e.g. in "1 < 2", is text.
+			w.text("<")
+			s = s[1:]
+			continue
+		}
+		if strings.HasPrefix(s, "<!--") {
+			end := strings.Index(s, "-->")
+			if end < 0 {
+				break
+			}
+			s = s[end+len("-->"):]
+			continue
+		}
+		end := strings.IndexByte(s, '>')
+		if end < 0 {
+			w.text(s)
+			break
+		}
+		name, closing, attrs := parseTag(s[1:end])
+		s = s[end+1:]
+
+		switch name {
+		case "script", "style", "head", "title":
+			if !closing {
+				// Skip the element's content up to its closing tag.
+				if j := indexFold(s, "</"+name); j >= 0 {
+					s = s[j:]
+				} else {
+					s = ""
+				}
+			}
+		case "br":
+			w.newline(1)
+		case "p", "h1", "h2", "h3", "h4", "h5", "h6", "table", "ul", "ol", "blockquote", "hr":
+			w.newline(2)
+		case "div", "tr", "section", "article", "header", "footer":
+			w.newline(1)
+		case "li":
+			w.newline(1)
+			if !closing {
+				w.word("- ", false)
+			}
+		case "td", "th":
+			if closing {
+				w.space = true
+			}
+		case "a":
+			w.link(closing, html.UnescapeString(attrValue(attrs, "href")))
+		}
+	}
+	return w.String()
+}
+
+// indexFold returns the index of the first ASCII case-insensitive match of
+// substr, which must be lowercase, in s, or -1. Unlike searching
+// strings.ToLower(s), the index is valid in s even if s contains non-ASCII text.
+func indexFold(s, substr string) int {
+outer:
+	for i := 0; i+len(substr) <= len(s); i++ {
+		for j := 0; j < len(substr); j++ {
+			c := s[i+j]
+			if 'A' <= c && c <= 'Z' {
+				c += 'a' - 'A'
+			}
+			if c != substr[j] {
+				continue outer
+			}
+		}
+		return i
+	}
+	return -1
+}
+
+// textWriter accumulates plain text, collapsing whitespace the way browsers
+// do and inserting line breaks only between pieces of text.
+type textWriter struct {
+	b        strings.Builder
+	space    bool // a space is pending before the next word
+	newlines int  // line breaks pending before the next word
+	href     string
+	linkFrom int
+}
+
+// text writes HTML text content, decoding entities and collapsing whitespace.
+func (w *textWriter) text(s string) {
+	s = html.UnescapeString(s)
+	for len(s) > 0 {
+		i := strings.IndexFunc(s, unicode.IsSpace)
+		if i != 0 {
+			if i < 0 {
+				i = len(s)
+			}
+			w.word(s[:i], w.space)
+			w.space = false
+			s = s[i:]
+			continue
+		}
+		w.space = true
+		s = strings.TrimLeftFunc(s, unicode.IsSpace)
+	}
+}
+
+// word writes s after any pending line breaks, preceded by a space if space
+// is set and the output does not already end with whitespace.
+func (w *textWriter) word(s string, space bool) {
+	if w.b.Len() > 0 {
+		if w.newlines > 0 {
+			w.b.WriteString(strings.Repeat("\n", w.newlines))
+		} else if space && !w.endsWithSpace() {
+			w.b.WriteByte(' ')
+		}
+	}
+	w.newlines = 0
+	w.space = false
+	w.b.WriteString(s)
+}
+
+// newline requests n line breaks before the next word.
+func (w *textWriter) newline(n int) {
+	w.newlines = max(w.newlines, n)
+	w.space = false
+}
+
+// link handles an <a> tag. After the link text, the target is written in
+// parentheses unless it adds nothing, such as an in-page anchor or a target
+// equal to the text.
+func (w *textWriter) link(closing bool, href string) {
+	if !closing {
+		w.href = href
+		w.linkFrom = w.b.Len()
+		return
+	}
+	href, w.href = w.href, ""
+	if href == "" || strings.HasPrefix(href, "#") {
+		return
+	}
+	text := strings.TrimSpace(w.b.String()[w.linkFrom:])
+	if text == href || "mailto:"+text == href {
+		return
+	}
+	if text == "" {
+		w.word(href, true)
+		return
+	}
+	w.word("("+href+")", true)
+}
+
+// endsWithSpace reports whether the output ends with whitespace.
+func (w *textWriter) endsWithSpace() bool {
+	s := w.b.String()
+	return s != "" && (s[len(s)-1] == ' ' || s[len(s)-1] == '\n')
+}
+
+// String returns the text written so far.
+func (w *textWriter) String() string {
+	lines := strings.Split(w.b.String(), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRightFunc(line, unicode.IsSpace)
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,89 @@
+package envloped
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHTMLToText(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{"plain", "Hello", "Hello"},
+		{"paragraphs", "<h1>Welcome</h1><p>First  line\n of text.</p><p>Second.</p>", "Welcome\n\nFirst line of text.\n\nSecond."},
+		{"line breaks", "Line one<br>Line two<br/>Line three", "Line one\nLine two\nLine three"},
+		{"entities", "<p>Fish &amp; chips&nbsp;&mdash; &lt;3</p>", "Fish & chips — <3"},
+		{"list", "<ul><li>One</li><li>Two</li></ul><p>After</p>", "- One\n- Two\n\nAfter"},
+		{"link", `Click <a href="https://example.com/a?b=1&amp;c=2">here</a>.`, "Click here (https://example.com/a?b=1&c=2)."},
+		{"link same as text", `<a href='https://example.com'>https://example.com</a>`, "https://example.com"},
+		{"mailto link", `<a href="mailto:hi@example.com">hi@example.com</a>`, "hi@example.com"},
+		{"anchor link", `<a href="#top">Top</a>`, "Top"},
+		{"image link", `Logo: <a href="https://example.com"><img src="logo.png"></a>`, "Logo: https://example.com"},
+		{"dropped content", "<html><head><title>T</title><style>p{color:red}</style></head><body><!-- hidden --><script>alert(1)</script><p>Body</p></body></html>", "Body"},
+		{"table", "<table><tr><td>A</td><td>B</td></tr><tr><td>C</td><td>D</td></tr></table>", "A B\nC D"},
+		{"unclosed tag", "a < b", "a < b"},
+		{"less than", "1 < 2 and 3 > 2", "1 < 2 and 3 > 2"},
+		{"data-href attribute", `<a data-href="x" href="https://a.com">here</a>`, "here (https://a.com)"},
+		{"non-ASCII before script", "<p>İİİİ</p><SCRIPT>x</SCRIPT><p>Ok</p>", "İİİİ\n\nOk"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := HTMLToText(tt.html); got != tt.want {
+				t.Errorf("HTMLToText(%q) = %q, want %q", tt.html, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSendEmail_AutoText(t *testing.T) {
+	t.Parallel()
+
+	var body map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"success":true,"messageId":"msg_1"}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+
+	params := &SendEmailRequest{
+		From:     "sender@example.com",
+		To:       []string{"recipient@example.com"},
+		Subject:  "Hi",
+		Html:     "<p>Hello <b>there</b></p>",
+		AutoText: true,
+	}
+	if _, err := client.Emails.Send(params); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if body["text"] != "Hello there" {
+		t.Errorf("expected derived text %q, got %v", "Hello there", body["text"])
+	}
+	if _, ok := body["autoText"]; ok {
+		t.Error("expected autoText not to be sent to the API")
+	}
+	if params.Text != "" {
+		t.Errorf("expected caller's params to be unmodified, got text %q", params.Text)
+	}
+
+	params.Text = "Custom"
+	if _, err := client.Emails.Send(params); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if body["text"] != "Custom" {
+		t.Errorf("expected explicit text to win, got %v", body["text"])
+	}
+}
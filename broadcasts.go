@@ -0,0 +1,294 @@
+package envloped
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// BroadcastStatus is the state of a broadcast.
+type BroadcastStatus string
+
+// Broadcast statuses.
+const (
+	BroadcastStatusDraft     BroadcastStatus = "draft"
+	BroadcastStatusScheduled BroadcastStatus = "scheduled"
+	BroadcastStatusSending   BroadcastStatus = "sending"
+	BroadcastStatusSent      BroadcastStatus = "sent"
+	BroadcastStatusCanceled  BroadcastStatus = "canceled"
+)
+
+// Broadcast is a campaign email sent to every subscribed contact of an audience.
+type Broadcast struct {
+	// ID is the unique identifier of the broadcast.
+	ID string `json:"id"`
+
+	// Name is a human-readable name for the broadcast.
+	Name string `json:"name"`
+
+	// AudienceID is the audience the broadcast is sent to.
+	AudienceID string `json:"audienceId"`
+
+	// From is the sender address.
+	From string `json:"from"`
+
+	// Subject is the email subject line.
+	Subject string `json:"subject,omitempty"`
+
+	// Html is the HTML body.
+	Html string `json:"html,omitempty"`
+
+	// Text is the plain text body.
+	Text string `json:"text,omitempty"`
+
+	// TemplateID is the template the broadcast is rendered from, if any.
+	TemplateID string `json:"templateId,omitempty"`
+
+	// Status is the current state of the broadcast.
+	Status BroadcastStatus `json:"status"`
+
+	// ScheduledAt is when the broadcast is or was scheduled to be sent.
+	ScheduledAt *time.Time `json:"scheduledAt,omitempty"`
+
+	// SentAt is when sending finished, or nil if it has not.
+	SentAt *time.Time `json:"sentAt,omitempty"`
+
+	// CreatedAt is when the broadcast was created.
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// BroadcastRequest is the request body for creating a broadcast.
+// Contact attributes are available as template variables.
+type BroadcastRequest struct {
+	// Name is a human-readable name for the broadcast.
+	Name string `json:"name,omitempty"`
+
+	// AudienceID is the audience to send to. Required.
+	AudienceID string `json:"audienceId"`
+
+	// From is the sender address. Required.
+	From string `json:"from"`
+
+	// ReplyTo is the list of addresses replies should be sent to, if different from From.
+	ReplyTo []string `json:"replyTo,omitempty"`
+
+	// Subject is the email subject line. Required unless TemplateID is set.
+	Subject string `json:"subject,omitempty"`
+
+	// Html is the HTML body. At least one of Html, Text, or TemplateID is required.
+	Html string `json:"html,omitempty"`
+
+	// Text is the plain text body.
+	Text string `json:"text,omitempty"`
+
+	// TemplateID renders the broadcast from a stored template.
+	TemplateID string `json:"templateId,omitempty"`
+}
+
+// BroadcastStats holds the delivery metrics of a broadcast.
+type BroadcastStats struct {
+	// BroadcastID is the broadcast the statistics belong to.
+	BroadcastID string `json:"broadcastId"`
+
+	// Recipients is the number of contacts the broadcast was sent to.
+	Recipients int `json:"recipients"`
+
+	// Unsubscribed is the number of recipients who unsubscribed from it.
+	Unsubscribed int `json:"unsubscribed"`
+
+	DeliveryStats
+}
+
+// scheduleBroadcastRequest is the request body for scheduling a broadcast.
+type scheduleBroadcastRequest struct {
+	ScheduledAt *time.Time `json:"scheduledAt,omitempty"`
+}
+
+// BroadcastsSvc defines the interface for the broadcasts service.
+// This interface can be mocked in consumer tests.
+type BroadcastsSvc interface {
+	// Create creates a draft broadcast.
+	Create(params *BroadcastRequest) (*Broadcast, error)
+
+	// CreateWithContext creates a draft broadcast using the provided context.
+	CreateWithContext(ctx context.Context, params *BroadcastRequest) (*Broadcast, error)
+
+	// Get retrieves a broadcast by ID.
+	Get(broadcastID string) (*Broadcast, error)
+
+	// GetWithContext retrieves a broadcast using the provided context.
+	GetWithContext(ctx context.Context, broadcastID string) (*Broadcast, error)
+
+	// List returns all broadcasts.
+	List() ([]Broadcast, error)
+
+	// ListWithContext returns all broadcasts using the provided context.
+	ListWithContext(ctx context.Context) ([]Broadcast, error)
+
+	// Schedule schedules a draft broadcast to be sent at the given time.
+	Schedule(broadcastID string, at time.Time) (*Broadcast, error)
+
+	// ScheduleWithContext schedules a broadcast using the provided context.
+	ScheduleWithContext(ctx context.Context, broadcastID string, at time.Time) (*Broadcast, error)
+
+	// Cancel cancels a broadcast that has not started sending.
+	Cancel(broadcastID string) (*Broadcast, error)
+
+	// CancelWithContext cancels a broadcast using the provided context.
+	CancelWithContext(ctx context.Context, broadcastID string) (*Broadcast, error)
+
+	// Stats returns the delivery metrics of a broadcast.
+	Stats(broadcastID string) (*BroadcastStats, error)
+
+	// StatsWithContext returns broadcast metrics using the provided context.
+	StatsWithContext(ctx context.Context, broadcastID string) (*BroadcastStats, error)
+}
+
+// broadcastsSvcImpl implements BroadcastsSvc.
+type broadcastsSvcImpl struct {
+	client *Client
+}
+
+// broadcastPath returns the API path of a broadcast, with optional suffix.
+func broadcastPath(broadcastID, suffix string) string {
+	return "/v1/broadcasts/" + url.PathEscape(broadcastID) + suffix
+}
+
+// Create creates a draft broadcast.
+func (s *broadcastsSvcImpl) Create(params *BroadcastRequest) (*Broadcast, error) {
+	return s.CreateWithContext(context.Background(), params)
+}
+
+// CreateWithContext creates a draft broadcast using the provided context.
+// Nothing is sent until the broadcast is scheduled.
+func (s *broadcastsSvcImpl) CreateWithContext(ctx context.Context, params *BroadcastRequest) (*Broadcast, error) {
+	if params == nil {
+		return nil, fmt.Errorf("envloped: broadcast params must not be nil")
+	}
+	if params.AudienceID == "" {
+		return nil, fmt.Errorf("envloped: broadcast audience id is required")
+	}
+	if params.From == "" {
+		return nil, fmt.Errorf("envloped: from address is required")
+	}
+	if params.TemplateID == "" {
+		if params.Subject == "" {
+			return nil, fmt.Errorf("envloped: subject is required")
+		}
+		if params.Html == "" && params.Text == "" {
+			return nil, fmt.Errorf("envloped: html or text body is required")
+		}
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodPost, "/v1/broadcasts", "/v1/broadcasts", params)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to create broadcast request: %w", err)
+	}
+
+	var resp Broadcast
+	if err := s.client.do(req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// Get retrieves a broadcast by ID.
+func (s *broadcastsSvcImpl) Get(broadcastID string) (*Broadcast, error) {
+	return s.GetWithContext(context.Background(), broadcastID)
+}
+
+// GetWithContext retrieves a broadcast using the provided context.
+func (s *broadcastsSvcImpl) GetWithContext(ctx context.Context, broadcastID string) (*Broadcast, error) {
+	var resp Broadcast
+	if err := s.broadcastRequest(ctx, http.MethodGet, broadcastID, "", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// List returns all broadcasts.
+func (s *broadcastsSvcImpl) List() ([]Broadcast, error) {
+	return s.ListWithContext(context.Background())
+}
+
+// ListWithContext returns all broadcasts using the provided context.
+func (s *broadcastsSvcImpl) ListWithContext(ctx context.Context) ([]Broadcast, error) {
+	req, err := s.client.newRequest(ctx, http.MethodGet, "/v1/broadcasts", "/v1/broadcasts", nil)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to create list broadcasts request: %w", err)
+	}
+
+	var resp listResponse[Broadcast]
+	if err := s.client.do(req, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Data, nil
+}
+
+// Schedule schedules a draft broadcast to be sent at the given time.
+func (s *broadcastsSvcImpl) Schedule(broadcastID string, at time.Time) (*Broadcast, error) {
+	return s.ScheduleWithContext(context.Background(), broadcastID, at)
+}
+
+// ScheduleWithContext schedules a broadcast using the provided context.
+// A zero at sends the broadcast immediately.
+func (s *broadcastsSvcImpl) ScheduleWithContext(ctx context.Context, broadcastID string, at time.Time) (*Broadcast, error) {
+	body := &scheduleBroadcastRequest{}
+	if !at.IsZero() {
+		body.ScheduledAt = &at
+	}
+
+	var resp Broadcast
+	if err := s.broadcastRequest(ctx, http.MethodPost, broadcastID, "/schedule", body, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Cancel cancels a broadcast that has not started sending.
+func (s *broadcastsSvcImpl) Cancel(broadcastID string) (*Broadcast, error) {
+	return s.CancelWithContext(context.Background(), broadcastID)
+}
+
+// CancelWithContext cancels a broadcast using the provided context.
+// The API rejects the cancellation once sending has started.
+func (s *broadcastsSvcImpl) CancelWithContext(ctx context.Context, broadcastID string) (*Broadcast, error) {
+	var resp Broadcast
+	if err := s.broadcastRequest(ctx, http.MethodPost, broadcastID, "/cancel", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Stats returns the delivery metrics of a broadcast.
+func (s *broadcastsSvcImpl) Stats(broadcastID string) (*BroadcastStats, error) {
+	return s.StatsWithContext(context.Background(), broadcastID)
+}
+
+// StatsWithContext returns broadcast metrics using the provided context.
+func (s *broadcastsSvcImpl) StatsWithContext(ctx context.Context, broadcastID string) (*BroadcastStats, error) {
+	var resp BroadcastStats
+	if err := s.broadcastRequest(ctx, http.MethodGet, broadcastID, "/stats", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// broadcastRequest performs a request against a single broadcast and decodes
+// the response into target.
+func (s *broadcastsSvcImpl) broadcastRequest(ctx context.Context, method, broadcastID, suffix string, body, target interface{}) error {
+	if broadcastID == "" {
+		return fmt.Errorf("envloped: broadcast id is required")
+	}
+
+	req, err := s.client.newRequest(ctx, method, "/v1/broadcasts/{id}"+suffix, broadcastPath(broadcastID, suffix), body)
+	if err != nil {
+		return fmt.Errorf("envloped: failed to create broadcast request: %w", err)
+	}
+
+	return s.client.do(req, target)
+}
@@ -0,0 +1,136 @@
+package envloped
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestBroadcasts_Lifecycle(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/broadcasts":
+			var req BroadcastRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			if req.AudienceID != "aud_1" {
+				t.Errorf("unexpected audience id %q", req.AudienceID)
+			}
+			json.NewEncoder(w).Encode(Broadcast{ID: "bc_1", AudienceID: req.AudienceID, Status: BroadcastStatusDraft})
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/broadcasts/bc_1/schedule":
+			var body struct {
+				ScheduledAt *time.Time `json:"scheduledAt"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			if body.ScheduledAt == nil || !body.ScheduledAt.Equal(at) {
+				t.Errorf("unexpected scheduledAt %v", body.ScheduledAt)
+			}
+			json.NewEncoder(w).Encode(Broadcast{ID: "bc_1", Status: BroadcastStatusScheduled, ScheduledAt: body.ScheduledAt})
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/broadcasts/bc_1/stats":
+			w.Write([]byte(`{"broadcastId":"bc_1","recipients":100,"unsubscribed":2,"sent":100,"delivered":98,"deliveryRate":0.98}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/broadcasts/bc_1/cancel":
+			json.NewEncoder(w).Encode(Broadcast{ID: "bc_1", Status: BroadcastStatusCanceled})
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/broadcasts":
+			json.NewEncoder(w).Encode(map[string]interface{}{"data": []Broadcast{{ID: "bc_1"}}})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+
+	bc, err := client.Broadcasts.Create(&BroadcastRequest{
+		AudienceID: "aud_1",
+		From:       "news@example.com",
+		Subject:    "June news",
+		Html:       "<p>Hi {{firstName}}</p>",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if bc.Status != BroadcastStatusDraft {
+		t.Errorf("expected draft, got %q", bc.Status)
+	}
+
+	bc, err = client.Broadcasts.Schedule("bc_1", at)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if bc.Status != BroadcastStatusScheduled {
+		t.Errorf("expected scheduled, got %q", bc.Status)
+	}
+
+	stats, err := client.Broadcasts.Stats("bc_1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stats.Recipients != 100 || stats.Delivered != 98 || stats.DeliveryRate != 0.98 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+
+	bc, err = client.Broadcasts.Cancel("bc_1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if bc.Status != BroadcastStatusCanceled {
+		t.Errorf("expected canceled, got %q", bc.Status)
+	}
+
+	list, err := client.Broadcasts.List()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(list) != 1 {
+		t.Errorf("expected 1 broadcast, got %d", len(list))
+	}
+}
+
+func TestBroadcasts_ScheduleNow(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if _, ok := body["scheduledAt"]; ok {
+			t.Errorf("expected scheduledAt to be omitted, got %v", body)
+		}
+		json.NewEncoder(w).Encode(Broadcast{ID: "bc_1", Status: BroadcastStatusSending})
+	}))
+	defer server.Close()
+
+	if _, err := newTestClient(t, server).Broadcasts.Schedule("bc_1", time.Time{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestBroadcasts_Validation(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("key")
+	tests := []struct {
+		name   string
+		params *BroadcastRequest
+	}{
+		{name: "nil params", params: nil},
+		{name: "missing audience", params: &BroadcastRequest{From: "a@b.com", Subject: "s", Html: "h"}},
+		{name: "missing from", params: &BroadcastRequest{AudienceID: "aud_1", Subject: "s", Html: "h"}},
+		{name: "missing subject", params: &BroadcastRequest{AudienceID: "aud_1", From: "a@b.com", Html: "h"}},
+		{name: "missing body", params: &BroadcastRequest{AudienceID: "aud_1", From: "a@b.com", Subject: "s"}},
+	}
+	for _, tt := range tests {
+		if _, err := client.Broadcasts.Create(tt.params); err == nil {
+			t.Errorf("%s: expected error, got nil", tt.name)
+		}
+	}
+
+	if _, err := client.Broadcasts.Cancel(""); err == nil {
+		t.Error("expected error for missing broadcast id")
+	}
+}
@@ -0,0 +1,221 @@
+package envloped
+
+import (
+	"maps"
+	"math"
+)
+
+// EmailBuilder assembles a SendEmailRequest with chained calls:
+//
+//	params, err := envloped.NewEmail().
+//	    From("My App <hello@yourdomain.com>").
+//	    To("user@example.com").
+//	    Subject("Your receipt").
+//	    HTML("<p>Thanks for your order!</p>").
+//	    Attach("receipt.pdf", pdf).
+//	    Build()
+//
+// Methods that take lists append to the values set so far. A builder can be
+// reused: each Build returns an independent request.
+type EmailBuilder struct {
+	req SendEmailRequest
+}
+
+// NewEmail returns an empty EmailBuilder.
+func NewEmail() *EmailBuilder {
+	return &EmailBuilder{}
+}
+
+// From sets the sender address.
+func (b *EmailBuilder) From(addr string) *EmailBuilder {
+	b.req.From = addr
+	return b
+}
+
+// To adds recipient addresses.
+func (b *EmailBuilder) To(addrs ...string) *EmailBuilder {
+	b.req.To = append(b.req.To, addrs...)
+	return b
+}
+
+// Cc adds carbon copy recipient addresses.
+func (b *EmailBuilder) Cc(addrs ...string) *EmailBuilder {
+	b.req.Cc = append(b.req.Cc, addrs...)
+	return b
+}
+
+// Bcc adds blind carbon copy recipient addresses.
+func (b *EmailBuilder) Bcc(addrs ...string) *EmailBuilder {
+	b.req.Bcc = append(b.req.Bcc, addrs...)
+	return b
+}
+
+// ReplyTo adds reply-to addresses.
+func (b *EmailBuilder) ReplyTo(addrs ...string) *EmailBuilder {
+	b.req.ReplyTo = append(b.req.ReplyTo, addrs...)
+	return b
+}
+
+// Subject sets the subject line.
+func (b *EmailBuilder) Subject(subject string) *EmailBuilder {
+	b.req.Subject = subject
+	return b
+}
+
+// HTML sets the HTML body.
+func (b *EmailBuilder) HTML(html string) *EmailBuilder {
+	b.req.Html = html
+	return b
+}
+
+// AMP sets the AMP for Email body. It requires an HTML body.
+func (b *EmailBuilder) AMP(ampHTML string) *EmailBuilder {
+	b.req.AmpHtml = ampHTML
+	return b
+}
+
+// Importance sets how mail clients flag the email.
+func (b *EmailBuilder) Importance(importance Importance) *EmailBuilder {
+	b.req.Importance = importance
+	return b
+}
+
+// MessageID sets a custom Message-ID header.
+func (b *EmailBuilder) MessageID(id string) *EmailBuilder {
+	b.req.MessageID = id
+	return b
+}
+
+// AsReplyTo threads the email under the email with the given Message-ID.
+// See SendEmailRequest.AsReplyTo.
+func (b *EmailBuilder) AsReplyTo(messageID string) *EmailBuilder {
+	b.req.AsReplyTo(messageID)
+	return b
+}
+
+// Text sets the plain text body.
+func (b *EmailBuilder) Text(text string) *EmailBuilder {
+	b.req.Text = text
+	return b
+}
+
+// Preheader sets the inbox preview text of the email.
+func (b *EmailBuilder) Preheader(text string) *EmailBuilder {
+	b.req.Preheader = text
+	return b
+}
+
+// AutoText derives the plain text body from the HTML body when sending,
+// unless Text is set.
+func (b *EmailBuilder) AutoText() *EmailBuilder {
+	b.req.AutoText = true
+	return b
+}
+
+// Template renders the email from a stored template with the given data.
+func (b *EmailBuilder) Template(id string, data map[string]any) *EmailBuilder {
+	b.req.TemplateID = id
+	b.req.TemplateData = data
+	return b
+}
+
+// Personalize adds a personalized copy of the email.
+func (b *EmailBuilder) Personalize(p Personalization) *EmailBuilder {
+	b.req.Personalizations = append(b.req.Personalizations, p)
+	return b
+}
+
+// Attach adds a file attachment with the given name and raw content.
+func (b *EmailBuilder) Attach(filename string, content []byte) *EmailBuilder {
+	return b.AddAttachment(Attachment{Filename: filename, Content: content})
+}
+
+// AddAttachment adds an attachment, for content types, streamed content, or
+// previously uploaded files.
+func (b *EmailBuilder) AddAttachment(a Attachment) *EmailBuilder {
+	b.req.Attachments = append(b.req.Attachments, a)
+	return b
+}
+
+// Header sets a custom message header.
+func (b *EmailBuilder) Header(name, value string) *EmailBuilder {
+	if b.req.Headers == nil {
+		b.req.Headers = make(map[string]string)
+	}
+	b.req.Headers[name] = value
+	return b
+}
+
+// ReturnPath sets the envelope sender (bounce) address.
+func (b *EmailBuilder) ReturnPath(addr string) *EmailBuilder {
+	b.req.ReturnPath = addr
+	return b
+}
+
+// IPPool sets the dedicated IP pool to send through.
+func (b *EmailBuilder) IPPool(name string) *EmailBuilder {
+	b.req.IPPool = name
+	return b
+}
+
+// IdempotencyKey sets the idempotency key of the send.
+func (b *EmailBuilder) IdempotencyKey(key string) *EmailBuilder {
+	b.req.IdempotencyKey = key
+	return b
+}
+
+// SuppressAutoReplies marks the email as automatically generated, so
+// out-of-office responders do not reply.
+func (b *EmailBuilder) SuppressAutoReplies() *EmailBuilder {
+	b.req.SuppressAutoReplies = true
+	return b
+}
+
+// TrackOpens enables or disables open tracking for the email.
+func (b *EmailBuilder) TrackOpens(enabled bool) *EmailBuilder {
+	b.req.TrackOpens = Bool(enabled)
+	return b
+}
+
+// TrackClicks enables or disables click tracking for the email.
+func (b *EmailBuilder) TrackClicks(enabled bool) *EmailBuilder {
+	b.req.TrackClicks = Bool(enabled)
+	return b
+}
+
+// Unsubscribe sets the one-click unsubscribe URL of the email.
+func (b *EmailBuilder) Unsubscribe(url string) *EmailBuilder {
+	b.req.UnsubscribeURL = url
+	return b
+}
+
+// Build validates the email and returns the request. It applies the same
+// checks as Emails.Send except those that depend on client configuration,
+// such as plan limits and strict address validation.
+func (b *EmailBuilder) Build() (*SendEmailRequest, error) {
+	req := b.req
+	req.To = cloneSlice(req.To)
+	req.Cc = cloneSlice(req.Cc)
+	req.Bcc = cloneSlice(req.Bcc)
+	req.ReplyTo = cloneSlice(req.ReplyTo)
+	req.Personalizations = cloneSlice(req.Personalizations)
+	req.Attachments = cloneSlice(req.Attachments)
+	req.Headers = maps.Clone(req.Headers)
+
+	if err := validateSendEmailRequest(&req); err != nil {
+		return nil, err
+	}
+	// The attachment size limit depends on the plan and is checked on send.
+	if err := validateAttachments(req.Attachments, math.MaxInt64); err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+// cloneSlice returns a copy of s, or nil if s is empty.
+func cloneSlice[T any](s []T) []T {
+	if len(s) == 0 {
+		return nil
+	}
+	return append([]T(nil), s...)
+}
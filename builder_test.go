@@ -0,0 +1,142 @@
+package envloped
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestEmailBuilder_Build(t *testing.T) {
+	t.Parallel()
+
+	params, err := NewEmail().
+		From("My App <hello@example.com>").
+		To("a@example.com").
+		To("b@example.com", "c@example.com").
+		Cc("cc@example.com").
+		Bcc("bcc@example.com").
+		ReplyTo("support@example.com").
+		Subject("Receipt").
+		HTML("<p>Thanks</p>").
+		AMP("<html \u26a14email><body>Thanks</body></html>").
+		Text("Thanks").
+		Attach("receipt.pdf", []byte("%PDF")).
+		Header("X-Entity-Ref-ID", "order-42").
+		IdempotencyKey("order-42-receipt").
+		SuppressAutoReplies().
+		Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if params.From != "My App <hello@example.com>" || params.Subject != "Receipt" || params.AmpHtml == "" {
+		t.Errorf("unexpected params %+v", params)
+	}
+	if len(params.To) != 3 || params.To[2] != "c@example.com" {
+		t.Errorf("expected to addresses to accumulate, got %v", params.To)
+	}
+	if len(params.Attachments) != 1 || params.Attachments[0].Filename != "receipt.pdf" {
+		t.Errorf("unexpected attachments %+v", params.Attachments)
+	}
+	if params.Headers["X-Entity-Ref-ID"] != "order-42" || !params.SuppressAutoReplies {
+		t.Errorf("unexpected headers %v", params.Headers)
+	}
+	if params.IdempotencyKey != "order-42-receipt" {
+		t.Errorf("unexpected idempotency key %q", params.IdempotencyKey)
+	}
+}
+
+func TestEmailBuilder_Validation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		builder *EmailBuilder
+		wantErr string
+	}{
+		{"missing from", NewEmail().To("a@example.com").Subject("s").Text("x"), "from address is required"},
+		{"missing to", NewEmail().From("a@example.com").Subject("s").Text("x"), "at least one to address is required"},
+		{"missing body", NewEmail().From("a@example.com").To("b@example.com").Subject("s"), "html or text body is required"},
+		{"reserved header", NewEmail().From("a@example.com").To("b@example.com").Subject("s").Text("x").Header("From", "x"), "is reserved"},
+		{"empty attachment", NewEmail().From("a@example.com").To("b@example.com").Subject("s").Text("x").Attach("a.txt", nil), "content is required"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := tt.builder.Build()
+			if !errors.Is(err, ErrValidation) {
+				t.Fatalf("expected ErrValidation, got %v", err)
+			}
+			if !contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error to contain %q, got %q", tt.wantErr, err.Error())
+			}
+		})
+	}
+}
+
+func TestEmailBuilder_Template(t *testing.T) {
+	t.Parallel()
+
+	params, err := NewEmail().
+		From("a@example.com").
+		Personalize(Personalization{To: []string{"b@example.com"}, Data: map[string]any{"name": "Bo"}}).
+		Template("tmpl_1", map[string]any{"product": "Widget"}).
+		Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if params.TemplateID != "tmpl_1" || params.TemplateData["product"] != "Widget" || len(params.Personalizations) != 1 {
+		t.Errorf("unexpected params %+v", params)
+	}
+}
+
+func TestEmailBuilder_BuildIsIndependent(t *testing.T) {
+	t.Parallel()
+
+	b := NewEmail().From("a@example.com").To("b@example.com").Subject("s").Text("x").Header("X-A", "1")
+	first, err := b.Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	b.To("c@example.com").Header("X-A", "2")
+	second, err := b.Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(first.To) != 1 || first.Headers["X-A"] != "1" {
+		t.Errorf("expected first request to be unaffected, got %+v", first)
+	}
+	if len(second.To) != 2 || second.Headers["X-A"] != "2" {
+		t.Errorf("unexpected second request %+v", second)
+	}
+}
+
+func TestEmailBuilder_Send(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["subject"] != "Hello" {
+			t.Errorf("expected subject Hello, got %v", body["subject"])
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"success":true,"messageId":"msg_1"}`))
+	}))
+	defer server.Close()
+
+	params, err := NewEmail().From("a@example.com").To("b@example.com").Subject("Hello").HTML("<p>Hi</p>").Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := newTestClient(t, server).Emails.Send(params); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,226 @@
+package envloped
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// bulkMaxRetries is how many times a rate limited bulk send is retried.
+	bulkMaxRetries = 3
+
+	// bulkInitialBackoff is the wait before retrying a rate limited bulk send
+	// when the API did not report when the limit resets.
+	bulkInitialBackoff = time.Second
+
+	// bulkMaxBackoff caps the wait before retrying a rate limited bulk send.
+	bulkMaxBackoff = 30 * time.Second
+)
+
+// BulkResult is the outcome of one email sent by a BulkSender.
+type BulkResult struct {
+	// Index is the position of the email in the input slice, or the order
+	// in which it was received from the input channel.
+	Index int
+
+	// Request is the email that was sent.
+	Request *SendEmailRequest
+
+	// Response is the API response, if the send succeeded.
+	Response *SendEmailResponse
+
+	// Err is the error of the send, if it failed.
+	Err error
+}
+
+// BulkProgress reports how far a bulk send has come.
+type BulkProgress struct {
+	// Done is the number of emails processed so far, including failures.
+	Done int
+
+	// Failed is the number of emails that could not be sent.
+	Failed int
+
+	// Total is the number of emails to send, or zero if unknown because
+	// they are read from a channel.
+	Total int
+}
+
+// BulkSender sends many emails concurrently through a bounded pool of
+// workers. Sends go through Client.Emails, so client-side pacing set with
+// Client.WithRateLimit applies, and sends rejected by the API's rate limit
+// are retried after the limit resets.
+//
+//	results := envloped.NewBulkSender(client, 8).Send(ctx, params)
+//	for _, r := range results {
+//	    if r.Err != nil {
+//	        log.Printf("email %d failed: %v", r.Index, r.Err)
+//	    }
+//	}
+type BulkSender struct {
+	client      *Client
+	concurrency int
+	onProgress  func(BulkProgress)
+}
+
+// NewBulkSender returns a BulkSender that sends with client using up to
+// concurrency workers. A concurrency below 1 is treated as 1.
+func NewBulkSender(client *Client, concurrency int) *BulkSender {
+	return &BulkSender{
+		client:      client,
+		concurrency: max(concurrency, 1),
+	}
+}
+
+// WithProgress registers fn to be called after each email is processed.
+// Calls are serialized, so fn does not need to be safe for concurrent use.
+// Returns the sender for method chaining.
+func (b *BulkSender) WithProgress(fn func(BulkProgress)) *BulkSender {
+	b.onProgress = fn
+	return b
+}
+
+// Send sends every email in params and returns their results in the same
+// order. If ctx is done before all emails are sent, the results of the
+// remaining emails carry the context's error.
+func (b *BulkSender) Send(ctx context.Context, params []*SendEmailRequest) []BulkResult {
+	in := make(chan *SendEmailRequest)
+	go func() {
+		defer close(in)
+		for _, p := range params {
+			select {
+			case in <- p:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	results := make([]BulkResult, len(params))
+	done := make([]bool, len(params))
+	for r := range b.run(ctx, in, len(params)) {
+		results[r.Index] = r
+		done[r.Index] = true
+	}
+	for i := range results {
+		if !done[i] {
+			results[i] = BulkResult{Index: i, Request: params[i], Err: ctx.Err()}
+		}
+	}
+	return results
+}
+
+// SendAll sends the emails received from in until it is closed or ctx is
+// done, and returns a channel of their results in completion order. The
+// channel is closed once all received emails have been processed. The
+// caller must drain it.
+func (b *BulkSender) SendAll(ctx context.Context, in <-chan *SendEmailRequest) <-chan BulkResult {
+	return b.run(ctx, in, 0)
+}
+
+// run fans the emails received from in out to the workers. total is only
+// used for progress reports.
+func (b *BulkSender) run(ctx context.Context, in <-chan *SendEmailRequest, total int) <-chan BulkResult {
+	type job struct {
+		index  int
+		params *SendEmailRequest
+	}
+
+	jobs := make(chan job)
+	go func() {
+		defer close(jobs)
+		for i := 0; ; i++ {
+			var params *SendEmailRequest
+			select {
+			case <-ctx.Done():
+				return
+			case p, ok := <-in:
+				if !ok {
+					return
+				}
+				params = p
+			}
+			select {
+			case jobs <- job{index: i, params: params}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	out := make(chan BulkResult)
+	var mu sync.Mutex
+	progress := BulkProgress{Total: total}
+
+	var wg sync.WaitGroup
+	for w := 0; w < b.concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				resp, err := b.send(ctx, j.params)
+				r := BulkResult{Index: j.index, Request: j.params, Response: resp, Err: err}
+
+				mu.Lock()
+				progress.Done++
+				if err != nil {
+					progress.Failed++
+				}
+				if b.onProgress != nil {
+					b.onProgress(progress)
+				}
+				mu.Unlock()
+
+				out <- r
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+
+	return out
+}
+
+// send sends one email, retrying while it is rejected by the rate limit.
+func (b *BulkSender) send(ctx context.Context, params *SendEmailRequest) (*SendEmailResponse, error) {
+	backoff := bulkInitialBackoff
+	for attempt := 0; ; attempt++ {
+		resp, err := b.client.Emails.SendWithContext(withRetryCount(ctx, attempt), params)
+		if err == nil || attempt == bulkMaxRetries ||
+			!(errors.Is(err, ErrRateLimited) || errors.Is(err, ErrQuotaExhausted)) {
+			return resp, err
+		}
+
+		wait := backoff
+		if reset := rateLimitReset(b.client, err); !reset.IsZero() {
+			wait = time.Until(reset)
+		}
+		wait = min(max(wait, 0), bulkMaxBackoff)
+		backoff *= 2
+
+		select {
+		case <-ctx.Done():
+			return nil, err
+		case <-time.After(wait):
+		}
+		b.client.observeRetry(http.MethodPost, "/v1/emails")
+	}
+}
+
+// rateLimitReset returns when the rate limit that caused err resets, or the
+// zero time if unknown.
+func rateLimitReset(c *Client, err error) time.Time {
+	var quotaErr *QuotaExhaustedError
+	if errors.As(err, &quotaErr) {
+		return quotaErr.Reset
+	}
+	if rl := c.rateLimit.Load(); rl != nil && rl.Remaining == 0 {
+		return rl.Reset
+	}
+	return time.Time{}
+}
@@ -0,0 +1,159 @@
+package envloped
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func bulkParams(n int) []*SendEmailRequest {
+	params := make([]*SendEmailRequest, n)
+	for i := range params {
+		params[i] = &SendEmailRequest{
+			From:    "sender@example.com",
+			To:      []string{fmt.Sprintf("user%d@example.com", i)},
+			Subject: "Hello",
+			Text:    "Hi",
+		}
+	}
+	return params
+}
+
+func TestBulkSender_Send(t *testing.T) {
+	t.Parallel()
+
+	var active, peak int32
+	var rateLimited sync.Once
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&active, 1)
+		defer atomic.AddInt32(&active, -1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+
+		var params SendEmailRequest
+		json.NewDecoder(r.Body).Decode(&params)
+		w.Header().Set("Content-Type", "application/json")
+
+		limited := false
+		if params.To[0] == "user3@example.com" {
+			rateLimited.Do(func() { limited = true })
+		}
+		switch {
+		case limited:
+			w.Header().Set("X-RateLimit-Limit", "10")
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"error":"Rate limit exceeded"}`))
+		case params.To[0] == "user5@example.com":
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			w.Write([]byte(`{"error":"Domain not verified"}`))
+		default:
+			fmt.Fprintf(w, `{"success":true,"messageId":"msg_%s"}`, params.To[0])
+		}
+	}))
+	defer server.Close()
+
+	var progress []BulkProgress
+	params := bulkParams(10)
+	results := NewBulkSender(newTestClient(t, server), 3).
+		WithProgress(func(p BulkProgress) { progress = append(progress, p) }).
+		Send(context.Background(), params)
+
+	if len(results) != 10 {
+		t.Fatalf("expected 10 results, got %d", len(results))
+	}
+	for i, r := range results {
+		if r.Index != i || r.Request != params[i] {
+			t.Errorf("result %d out of order: %+v", i, r)
+		}
+		if i == 5 {
+			if !errors.Is(r.Err, ErrUnprocessable) {
+				t.Errorf("expected result 5 to fail, got %v", r.Err)
+			}
+			continue
+		}
+		if r.Err != nil {
+			t.Errorf("unexpected error for result %d: %v", i, r.Err)
+		} else if want := fmt.Sprintf("msg_user%d@example.com", i); r.Response.MessageId != want {
+			t.Errorf("expected message id %q, got %q", want, r.Response.MessageId)
+		}
+	}
+
+	if p := atomic.LoadInt32(&peak); p > 3 {
+		t.Errorf("expected at most 3 concurrent sends, got %d", p)
+	}
+	if len(progress) != 10 {
+		t.Fatalf("expected 10 progress reports, got %d", len(progress))
+	}
+	if last := progress[9]; last != (BulkProgress{Done: 10, Failed: 1, Total: 10}) {
+		t.Errorf("unexpected final progress %+v", last)
+	}
+}
+
+func TestBulkSender_SendAll(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"success":true,"messageId":"msg_1"}`))
+	}))
+	defer server.Close()
+
+	in := make(chan *SendEmailRequest)
+	go func() {
+		defer close(in)
+		for _, p := range bulkParams(5) {
+			in <- p
+		}
+	}()
+
+	seen := make(map[int]bool)
+	for r := range NewBulkSender(newTestClient(t, server), 2).SendAll(context.Background(), in) {
+		if r.Err != nil {
+			t.Errorf("unexpected error: %v", r.Err)
+		}
+		seen[r.Index] = true
+	}
+	if len(seen) != 5 {
+		t.Errorf("expected 5 distinct results, got %v", seen)
+	}
+}
+
+func TestBulkSender_Canceled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 2 {
+			cancel()
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"success":true,"messageId":"msg_1"}`))
+	}))
+	defer server.Close()
+
+	results := NewBulkSender(newTestClient(t, server), 1).Send(ctx, bulkParams(10))
+	if len(results) != 10 {
+		t.Fatalf("expected 10 results, got %d", len(results))
+	}
+	if !errors.Is(results[9].Err, context.Canceled) {
+		t.Errorf("expected unsent emails to fail with context.Canceled, got %v", results[9].Err)
+	}
+	if n := atomic.LoadInt32(&calls); n > 3 {
+		t.Errorf("expected sending to stop after cancellation, got %d calls", n)
+	}
+}
@@ -0,0 +1,203 @@
+package envloped
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// CalendarMethod is the iTIP method of a calendar invite, which tells the
+// recipient's calendar what to do with the event.
+type CalendarMethod string
+
+// Calendar methods.
+const (
+	// CalendarMethodRequest invites the attendees, or updates an invite.
+	CalendarMethodRequest CalendarMethod = "REQUEST"
+
+	// CalendarMethodCancel cancels a previously sent invite.
+	CalendarMethodCancel CalendarMethod = "CANCEL"
+
+	// CalendarMethodPublish shares the event without asking for replies.
+	CalendarMethodPublish CalendarMethod = "PUBLISH"
+)
+
+// icsTimeFormat is the iCalendar UTC date-time format.
+const icsTimeFormat = "20060102T150405Z"
+
+// CalendarEvent is a meeting invite that can be attached to an email as an
+// iCalendar (ICS) file, so Outlook, Gmail, and other clients show it with
+// accept and decline buttons:
+//
+//	invite, err := (&envloped.CalendarEvent{
+//	    Summary:   "Kickoff",
+//	    Start:     start,
+//	    End:       start.Add(time.Hour),
+//	    Organizer: envloped.Address{Name: "Jane", Email: "jane@yourdomain.com"},
+//	    Attendees: []envloped.Address{{Email: "bo@example.com"}},
+//	}).Attachment()
+type CalendarEvent struct {
+	// UID identifies the event. Reuse it with a higher Sequence to update or
+	// cancel the event. If empty, a random UID is generated and stored here.
+	UID string
+
+	// Method is the iTIP method. Defaults to CalendarMethodRequest.
+	Method CalendarMethod
+
+	// Sequence is the revision of the event. Increment it for every update.
+	Sequence int
+
+	// Summary is the title of the event.
+	Summary string
+
+	// Description is the body of the event.
+	Description string
+
+	// Location is where the event takes place, e.g. a room or a meeting URL.
+	Location string
+
+	// Start is when the event starts. Required.
+	Start time.Time
+
+	// End is when the event ends. Required, and not before Start.
+	End time.Time
+
+	// Organizer is the person the attendees reply to. Required.
+	Organizer Address
+
+	// Attendees are the invited people.
+	Attendees []Address
+}
+
+// ICS returns the event as an iCalendar file.
+func (e *CalendarEvent) ICS() ([]byte, error) {
+	return e.ics(time.Now())
+}
+
+// Attachment returns the event as an ICS attachment whose content type
+// carries the method, as calendar clients require to recognize invites.
+func (e *CalendarEvent) Attachment() (Attachment, error) {
+	content, err := e.ICS()
+	if err != nil {
+		return Attachment{}, err
+	}
+	return Attachment{
+		Filename:    "invite.ics",
+		Content:     content,
+		ContentType: fmt.Sprintf("text/calendar; method=%s; charset=UTF-8", e.method()),
+	}, nil
+}
+
+// method returns the event's method, or the default.
+func (e *CalendarEvent) method() CalendarMethod {
+	if e.Method == "" {
+		return CalendarMethodRequest
+	}
+	return e.Method
+}
+
+// ics renders the event with now as its timestamp.
+func (e *CalendarEvent) ics(now time.Time) ([]byte, error) {
+	if e.Start.IsZero() || e.End.IsZero() {
+		return nil, fmt.Errorf("envloped: calendar event start and end are required")
+	}
+	if e.End.Before(e.Start) {
+		return nil, fmt.Errorf("envloped: calendar event end must not be before start")
+	}
+	if e.Organizer.Email == "" {
+		return nil, fmt.Errorf("envloped: calendar event organizer is required")
+	}
+	if e.UID == "" {
+		b := make([]byte, 16)
+		if _, err := rand.Read(b); err != nil {
+			return nil, fmt.Errorf("envloped: failed to generate calendar event uid: %w", err)
+		}
+		e.UID = hex.EncodeToString(b) + "@envloped"
+	}
+
+	status := "CONFIRMED"
+	if e.method() == CalendarMethodCancel {
+		status = "CANCELLED"
+	}
+
+	var b strings.Builder
+	line := func(s string) {
+		b.WriteString(foldICSLine(s))
+		b.WriteString("\r\n")
+	}
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//Envloped//envloped-go//EN")
+	line("CALSCALE:GREGORIAN")
+	line("METHOD:" + string(e.method()))
+	line("BEGIN:VEVENT")
+	line("UID:" + escapeICSText(e.UID))
+	line("DTSTAMP:" + now.UTC().Format(icsTimeFormat))
+	line("DTSTART:" + e.Start.UTC().Format(icsTimeFormat))
+	line("DTEND:" + e.End.UTC().Format(icsTimeFormat))
+	line(fmt.Sprintf("SEQUENCE:%d", e.Sequence))
+	line("STATUS:" + status)
+	if e.Summary != "" {
+		line("SUMMARY:" + escapeICSText(e.Summary))
+	}
+	if e.Description != "" {
+		line("DESCRIPTION:" + escapeICSText(e.Description))
+	}
+	if e.Location != "" {
+		line("LOCATION:" + escapeICSText(e.Location))
+	}
+	line("ORGANIZER" + icsName(e.Organizer) + ":mailto:" + e.Organizer.Email)
+	for _, a := range e.Attendees {
+		line("ATTENDEE" + icsName(a) + ";ROLE=REQ-PARTICIPANT;PARTSTAT=NEEDS-ACTION;RSVP=TRUE:mailto:" + a.Email)
+	}
+	line("END:VEVENT")
+	line("END:VCALENDAR")
+	return []byte(b.String()), nil
+}
+
+// icsName returns the CN parameter for the address's display name, if any.
+func icsName(a Address) string {
+	if a.Name == "" {
+		return ""
+	}
+	return `;CN="` + strings.ReplaceAll(a.Name, `"`, "'") + `"`
+}
+
+// escapeICSText escapes a TEXT value as RFC 5545 requires.
+func escapeICSText(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+// foldICSLine splits a content line into lines of at most 75 octets, as
+// RFC 5545 requires, without splitting UTF-8 characters. Continuation lines
+// start with a space.
+func foldICSLine(s string) string {
+	const maxLen = 75
+	if len(s) <= maxLen {
+		return s
+	}
+
+	var b strings.Builder
+	limit := maxLen
+	for len(s) > limit {
+		i := limit
+		for i > 0 && !utf8.RuneStart(s[i]) {
+			i--
+		}
+		b.WriteString(s[:i])
+		b.WriteString("\r\n ")
+		s = s[i:]
+		limit = maxLen - 1 // the leading space counts toward the limit
+	}
+	b.WriteString(s)
+	return b.String()
+}
@@ -0,0 +1,129 @@
+package envloped
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCalendarEvent_ICS(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2026, 3, 4, 10, 0, 0, 0, time.FixedZone("CET", 3600))
+	e := &CalendarEvent{
+		UID:         "evt-1@example.com",
+		Summary:     "Kickoff; planning, Q2",
+		Description: "Agenda:\nintro",
+		Location:    "Room 1",
+		Start:       start,
+		End:         start.Add(time.Hour),
+		Organizer:   Address{Name: "Jane Doe", Email: "jane@example.com"},
+		Attendees:   []Address{{Email: "bo@example.com"}},
+	}
+
+	got, err := e.ics(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//Envloped//envloped-go//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:REQUEST",
+		"BEGIN:VEVENT",
+		"UID:evt-1@example.com",
+		"DTSTAMP:20260301T000000Z",
+		"DTSTART:20260304T090000Z",
+		"DTEND:20260304T100000Z",
+		"SEQUENCE:0",
+		"STATUS:CONFIRMED",
+		`SUMMARY:Kickoff\; planning\, Q2`,
+		`DESCRIPTION:Agenda:\nintro`,
+		"LOCATION:Room 1",
+		`ORGANIZER;CN="Jane Doe":mailto:jane@example.com`,
+		"ATTENDEE;ROLE=REQ-PARTICIPANT;PARTSTAT=NEEDS-ACTION;RSVP=TRUE:mailto:bo@exa",
+		" mple.com",
+		"END:VEVENT",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n")
+	if string(got) != want {
+		t.Errorf("ICS =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestCalendarEvent_Attachment(t *testing.T) {
+	t.Parallel()
+
+	start := time.Now().Add(24 * time.Hour)
+	e := &CalendarEvent{
+		Method:    CalendarMethodCancel,
+		Sequence:  1,
+		Start:     start,
+		End:       start.Add(time.Hour),
+		Organizer: Address{Email: "jane@example.com"},
+	}
+
+	a, err := e.Attachment()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if a.Filename != "invite.ics" || a.ContentType != "text/calendar; method=CANCEL; charset=UTF-8" {
+		t.Errorf("unexpected attachment %+v", a)
+	}
+	if e.UID == "" || !contains(string(a.Content), "UID:"+e.UID) {
+		t.Errorf("expected generated UID %q in content", e.UID)
+	}
+	if !contains(string(a.Content), "STATUS:CANCELLED") || !contains(string(a.Content), "SEQUENCE:1") {
+		t.Errorf("unexpected content %s", a.Content)
+	}
+}
+
+func TestCalendarEvent_Validation(t *testing.T) {
+	t.Parallel()
+
+	start := time.Now()
+	organizer := Address{Email: "jane@example.com"}
+
+	tests := []struct {
+		name    string
+		event   CalendarEvent
+		wantErr string
+	}{
+		{"missing times", CalendarEvent{Organizer: organizer}, "start and end are required"},
+		{"end before start", CalendarEvent{Start: start, End: start.Add(-time.Minute), Organizer: organizer}, "end must not be before start"},
+		{"missing organizer", CalendarEvent{Start: start, End: start}, "organizer is required"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := tt.event.ICS()
+			if err == nil || !contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestFoldICSLine(t *testing.T) {
+	t.Parallel()
+
+	line := "DESCRIPTION:" + strings.Repeat("é", 100)
+	folded := foldICSLine(line)
+	for i, l := range strings.Split(folded, "\r\n") {
+		if len(l) > 75 {
+			t.Errorf("line %d is %d octets", i, len(l))
+		}
+		if i > 0 && !strings.HasPrefix(l, " ") {
+			t.Errorf("continuation line %d does not start with a space", i)
+		}
+	}
+	if strings.ReplaceAll(folded, "\r\n ", "") != line {
+		t.Error("unfolding did not restore the line")
+	}
+}
@@ -0,0 +1,100 @@
+package envloped
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// WithCircuitBreaker makes the client stop calling the API after threshold
+// consecutive failed requests, failing fast with a *CircuitOpenError
+// instead. Server errors (HTTP 5xx), timeouts, and network errors count as
+// failures. After cooldown, one request is let through as a trial: if it
+// succeeds the breaker closes, otherwise it stays open for another cooldown.
+// Pass threshold <= 0 to disable it. Returns the client for method chaining.
+func (c *Client) WithCircuitBreaker(threshold int, cooldown time.Duration) *Client {
+	if threshold <= 0 {
+		c.breaker = nil
+		return c
+	}
+	c.breaker = &circuitBreaker{threshold: threshold, cooldown: cooldown}
+	return c
+}
+
+// CircuitOpenError is returned without contacting the API while the circuit
+// breaker is open. See Client.WithCircuitBreaker.
+type CircuitOpenError struct {
+	// Until is when the breaker lets a trial request through.
+	Until time.Time
+}
+
+// Error implements the error interface.
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("envloped: circuit breaker open until %s", e.Until.Format(time.RFC3339))
+}
+
+// Is enables sentinel error matching via errors.Is().
+func (e *CircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+// circuitBreaker tracks consecutive request failures. It is closed while
+// openUntil is zero.
+type circuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	failures  int
+	openUntil time.Time
+	probing   bool // a trial request is in flight
+}
+
+// allow returns a *CircuitOpenError if a request must not be sent at now.
+// Otherwise the caller must report the outcome of the request with done,
+// passing on trial, which reports whether the request is the trial of a
+// breaker that was open.
+func (b *circuitBreaker) allow(now time.Time) (trial bool, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.openUntil.IsZero() {
+		return false, nil
+	}
+	if now.Before(b.openUntil) || b.probing {
+		return false, &CircuitOpenError{Until: b.openUntil}
+	}
+	b.probing = true
+	return true, nil
+}
+
+// done records the outcome of a request sent at now. While the breaker is
+// open, only the trial request decides whether it closes; requests that
+// were in flight when it opened are ignored. Outcomes that say nothing
+// about the health of the API, such as canceled requests, are ignored too.
+func (b *circuitBreaker) done(now time.Time, trial bool, resp *http.Response, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if trial {
+		b.probing = false
+	} else if !b.openUntil.IsZero() {
+		return
+	}
+
+	switch {
+	case err != nil && errors.Is(err, context.Canceled):
+		return
+	case err == nil && resp.StatusCode < 500:
+		b.failures = 0
+		b.openUntil = time.Time{}
+		return
+	}
+
+	b.failures++
+	if trial || b.failures >= b.threshold {
+		b.openUntil = now.Add(b.cooldown)
+	}
+}
@@ -0,0 +1,126 @@
+package envloped
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithCircuitBreaker(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	var healthy atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Content-Type", "application/json")
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusBadGateway)
+			w.Write([]byte(`{"error":"Bad gateway"}`))
+			return
+		}
+		w.Write([]byte(`{"message":"pong","companyId":"c"}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server).WithCircuitBreaker(2, 20*time.Millisecond)
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.Ping(); !errors.Is(err, ErrServer) {
+			t.Fatalf("expected server error, got %v", err)
+		}
+	}
+	_, err := client.Ping()
+	var openErr *CircuitOpenError
+	if !errors.As(err, &openErr) || !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected open circuit, got %v", err)
+	}
+	if !IsRetryable(err) {
+		t.Error("expected open circuit to be retryable")
+	}
+	if n := atomic.LoadInt32(&calls); n != 2 {
+		t.Errorf("expected no request while open, got %d calls", n)
+	}
+
+	// A failed trial request reopens the breaker.
+	time.Sleep(25 * time.Millisecond)
+	if _, err := client.Ping(); !errors.Is(err, ErrServer) {
+		t.Fatalf("expected trial request to reach the server, got %v", err)
+	}
+	if _, err := client.Ping(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected breaker to reopen, got %v", err)
+	}
+
+	// A successful trial request closes it.
+	healthy.Store(true)
+	time.Sleep(25 * time.Millisecond)
+	for i := 0; i < 3; i++ {
+		if _, err := client.Ping(); err != nil {
+			t.Fatalf("expected breaker to close, got %v", err)
+		}
+	}
+}
+
+func TestWithCircuitBreaker_ClientErrorsDoNotCount(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error":"Invalid API key"}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server).WithCircuitBreaker(1, time.Minute)
+	for i := 0; i < 3; i++ {
+		if _, err := client.Ping(); !errors.Is(err, ErrUnauthorized) {
+			t.Fatalf("expected unauthorized, got %v", err)
+		}
+	}
+}
+
+func TestWithCircuitBreaker_Disable(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("key").WithCircuitBreaker(3, time.Second).WithCircuitBreaker(0, 0)
+	if client.breaker != nil {
+		t.Error("expected breaker to be disabled")
+	}
+}
+
+func TestCircuitBreaker_StaleRequestDoesNotEndTrial(t *testing.T) {
+	t.Parallel()
+
+	b := &circuitBreaker{threshold: 1, cooldown: time.Minute}
+	now := time.Now()
+	ok := &http.Response{StatusCode: http.StatusOK}
+
+	// A request is in flight when another one opens the breaker.
+	stale, err := b.allow(now)
+	if err != nil || stale {
+		t.Fatalf("expected closed breaker to allow a regular request, got %v, %v", stale, err)
+	}
+	b.done(now, false, nil, errors.New("connection reset"))
+
+	later := now.Add(time.Minute)
+	trial, err := b.allow(later)
+	if err != nil || !trial {
+		t.Fatalf("expected a trial request after cooldown, got %v, %v", trial, err)
+	}
+
+	// The stale request finishes first: it must neither close the breaker
+	// nor let a second trial through.
+	b.done(later, stale, ok, nil)
+	if _, err := b.allow(later); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected breaker to stay open during the trial, got %v", err)
+	}
+
+	// The trial's failure reopens the breaker.
+	b.done(later, trial, nil, errors.New("timeout"))
+	if _, err := b.allow(later.Add(time.Second)); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected failed trial to reopen the breaker, got %v", err)
+	}
+}
@@ -0,0 +1,30 @@
+package envloped
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+)
+
+// WithGzip makes the client compress JSON request bodies of at least
+// minSize bytes with gzip, sending them with Content-Encoding: gzip. Large
+// HTML bodies and inline attachments compress well, which cuts upload time
+// for big sends. Pass minSize <= 0 to disable compression.
+// Returns the client for method chaining.
+func (c *Client) WithGzip(minSize int) *Client {
+	c.gzipMinSize = max(minSize, 0)
+	return c
+}
+
+// gzipBody returns body compressed with gzip.
+func gzipBody(body []byte) (*bytes.Buffer, error) {
+	buf := new(bytes.Buffer)
+	zw := gzip.NewWriter(buf)
+	if _, err := zw.Write(body); err != nil {
+		return nil, fmt.Errorf("failed to compress request body: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress request body: %w", err)
+	}
+	return buf, nil
+}
@@ -0,0 +1,56 @@
+package envloped
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWithGzip(t *testing.T) {
+	t.Parallel()
+
+	var encodings []string
+	var subjects []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encodings = append(encodings, r.Header.Get("Content-Encoding"))
+		body := io.Reader(r.Body)
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			zr, err := gzip.NewReader(r.Body)
+			if err != nil {
+				t.Fatalf("invalid gzip body: %v", err)
+			}
+			body = zr
+		}
+		var params SendEmailRequest
+		if err := json.NewDecoder(body).Decode(&params); err != nil {
+			t.Errorf("failed to decode body: %v", err)
+		}
+		subjects = append(subjects, params.Subject)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"success":true,"messageId":"msg_1"}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server).WithGzip(1024)
+	small := bulkParams(1)[0]
+	large := bulkParams(1)[0]
+	large.Subject = "Large"
+	large.Html = strings.Repeat("<p>Hello</p>", 200)
+
+	for _, params := range []*SendEmailRequest{small, large} {
+		if _, err := client.Emails.Send(params); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if encodings[0] != "" || encodings[1] != "gzip" {
+		t.Errorf("expected only the large body to be compressed, got %q", encodings)
+	}
+	if subjects[1] != "Large" {
+		t.Errorf("expected compressed body to decode, got %q", subjects)
+	}
+}
@@ -0,0 +1,500 @@
+package envloped
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"time"
+)
+
+// ImportDedupe controls how a contact import handles rows whose email address
+// already exists in the audience.
+type ImportDedupe string
+
+const (
+	// ImportDedupeSkip keeps the existing contact and ignores the CSV row.
+	ImportDedupeSkip ImportDedupe = "skip"
+
+	// ImportDedupeUpdate overwrites the existing contact's attributes with the CSV row.
+	ImportDedupeUpdate ImportDedupe = "update"
+)
+
+// ImportOptions configures a bulk contact import.
+type ImportOptions struct {
+	// AudienceID is the audience the contacts are imported into.
+	// If empty, contacts are imported into the account's default audience.
+	AudienceID string
+
+	// ColumnMapping maps CSV header names to contact fields
+	// (e.g., {"E-mail": "email", "First Name": "firstName"}).
+	// Unmapped columns are imported as custom attributes under their header name.
+	ColumnMapping map[string]string
+
+	// Dedupe controls how rows for existing contacts are handled.
+	// Defaults to ImportDedupeSkip on the API side when empty.
+	Dedupe ImportDedupe
+
+	// Filename is reported to the API as the uploaded file's name.
+	// Defaults to "contacts.csv".
+	Filename string
+}
+
+// ImportStatus is the processing state of a contact import job.
+type ImportStatus string
+
+// Import job statuses.
+const (
+	ImportStatusPending    ImportStatus = "pending"
+	ImportStatusProcessing ImportStatus = "processing"
+	ImportStatusCompleted  ImportStatus = "completed"
+	ImportStatusFailed     ImportStatus = "failed"
+)
+
+// Done reports whether the import has reached a terminal state.
+func (s ImportStatus) Done() bool {
+	return s == ImportStatusCompleted || s == ImportStatusFailed
+}
+
+// ContactImport describes a contact import job.
+type ContactImport struct {
+	// ID is the unique identifier of the import job.
+	ID string `json:"id"`
+
+	// Status is the current processing state.
+	Status ImportStatus `json:"status"`
+
+	// TotalRows is the number of data rows found in the CSV.
+	TotalRows int `json:"totalRows"`
+
+	// ProcessedRows is the number of rows processed so far.
+	ProcessedRows int `json:"processedRows"`
+
+	// Imported is the number of contacts created or updated.
+	Imported int `json:"imported"`
+
+	// Skipped is the number of rows skipped as duplicates.
+	Skipped int `json:"skipped"`
+
+	// Failed is the number of rows rejected (e.g., invalid email address).
+	Failed int `json:"failed"`
+
+	// Error describes why the import failed, when Status is ImportStatusFailed.
+	Error string `json:"error,omitempty"`
+
+	// CreatedAt is when the import job was created.
+	CreatedAt time.Time `json:"createdAt"`
+
+	// CompletedAt is when the import job finished, or nil if it is still running.
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+}
+
+// SubscriptionStatus is whether a contact receives marketing email.
+type SubscriptionStatus string
+
+// Contact subscription statuses.
+const (
+	SubscriptionStatusSubscribed   SubscriptionStatus = "subscribed"
+	SubscriptionStatusUnsubscribed SubscriptionStatus = "unsubscribed"
+)
+
+// Contact is a person in the account's address book.
+type Contact struct {
+	// ID is the unique identifier of the contact.
+	ID string `json:"id"`
+
+	// Email is the contact's email address. It is unique within the account.
+	Email string `json:"email"`
+
+	// FirstName is the contact's first name.
+	FirstName string `json:"firstName,omitempty"`
+
+	// LastName is the contact's last name.
+	LastName string `json:"lastName,omitempty"`
+
+	// Attributes holds custom contact attributes, available as template variables.
+	Attributes map[string]any `json:"attributes,omitempty"`
+
+	// Status is the contact's subscription status.
+	Status SubscriptionStatus `json:"status"`
+
+	// AudienceIDs are the audiences the contact belongs to.
+	AudienceIDs []string `json:"audienceIds,omitempty"`
+
+	// CreatedAt is when the contact was created.
+	CreatedAt time.Time `json:"createdAt"`
+
+	// UpdatedAt is when the contact was last changed.
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// ContactRequest is the request body for creating or updating a contact.
+// On update, zero-valued fields are left unchanged, and Attributes are merged
+// into the existing attributes.
+type ContactRequest struct {
+	// Email is the contact's email address. Required on create.
+	Email string `json:"email,omitempty"`
+
+	// FirstName is the contact's first name.
+	FirstName string `json:"firstName,omitempty"`
+
+	// LastName is the contact's last name.
+	LastName string `json:"lastName,omitempty"`
+
+	// Attributes holds custom contact attributes.
+	Attributes map[string]any `json:"attributes,omitempty"`
+
+	// Status sets the subscription status. New contacts are subscribed when empty.
+	Status SubscriptionStatus `json:"status,omitempty"`
+
+	// AudienceIDs adds the contact to these audiences on create.
+	AudienceIDs []string `json:"audienceIds,omitempty"`
+}
+
+// ListContactsOptions configures a page of results from Contacts.List.
+// Zero-valued fields are not applied.
+type ListContactsOptions struct {
+	ListOptions
+
+	// AudienceID only matches contacts in this audience.
+	AudienceID string
+
+	// Status only matches contacts with this subscription status.
+	Status SubscriptionStatus
+}
+
+// ContactPage is a single page of contacts.
+type ContactPage = Page[Contact]
+
+// ContactsSvc defines the interface for the contacts service.
+// This interface can be mocked in consumer tests.
+type ContactsSvc interface {
+	// Create adds a contact.
+	Create(params *ContactRequest) (*Contact, error)
+
+	// CreateWithContext adds a contact using the provided context.
+	CreateWithContext(ctx context.Context, params *ContactRequest) (*Contact, error)
+
+	// Get retrieves a contact by ID or email address.
+	Get(idOrEmail string) (*Contact, error)
+
+	// GetWithContext retrieves a contact using the provided context.
+	GetWithContext(ctx context.Context, idOrEmail string) (*Contact, error)
+
+	// List returns a page of contacts matching opts.
+	List(opts *ListContactsOptions) (*ContactPage, error)
+
+	// ListWithContext returns a page of contacts using the provided context.
+	ListWithContext(ctx context.Context, opts *ListContactsOptions) (*ContactPage, error)
+
+	// Update changes a contact's fields, attributes, or subscription status.
+	Update(idOrEmail string, params *ContactRequest) (*Contact, error)
+
+	// UpdateWithContext updates a contact using the provided context.
+	UpdateWithContext(ctx context.Context, idOrEmail string, params *ContactRequest) (*Contact, error)
+
+	// Delete removes a contact from the account and all audiences.
+	Delete(idOrEmail string) error
+
+	// DeleteWithContext removes a contact using the provided context.
+	DeleteWithContext(ctx context.Context, idOrEmail string) error
+
+	// Import streams a CSV of contacts to the API and returns the created import job.
+	Import(csv io.Reader, opts *ImportOptions) (*ContactImport, error)
+
+	// ImportWithContext streams a CSV of contacts using the provided context.
+	ImportWithContext(ctx context.Context, csv io.Reader, opts *ImportOptions) (*ContactImport, error)
+
+	// GetImport retrieves the current state of an import job.
+	GetImport(importID string) (*ContactImport, error)
+
+	// GetImportWithContext retrieves an import job using the provided context.
+	GetImportWithContext(ctx context.Context, importID string) (*ContactImport, error)
+
+	// WaitForImport polls an import job every pollInterval until it completes,
+	// fails, or ctx is done.
+	WaitForImport(ctx context.Context, importID string, pollInterval time.Duration) (*ContactImport, error)
+}
+
+// contactsSvcImpl implements ContactsSvc.
+type contactsSvcImpl struct {
+	client *Client
+}
+
+// contactPath returns the API path of a contact, addressed by ID or email.
+func contactPath(idOrEmail string) string {
+	return "/v1/contacts/" + url.PathEscape(idOrEmail)
+}
+
+// Create adds a contact.
+func (s *contactsSvcImpl) Create(params *ContactRequest) (*Contact, error) {
+	return s.CreateWithContext(context.Background(), params)
+}
+
+// CreateWithContext adds a contact using the provided context.
+func (s *contactsSvcImpl) CreateWithContext(ctx context.Context, params *ContactRequest) (*Contact, error) {
+	if params == nil {
+		return nil, fmt.Errorf("envloped: contact params must not be nil")
+	}
+	if params.Email == "" {
+		return nil, fmt.Errorf("envloped: contact email is required")
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodPost, "/v1/contacts", "/v1/contacts", params)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to create contact request: %w", err)
+	}
+
+	var resp Contact
+	if err := s.client.do(req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// Get retrieves a contact by ID or email address.
+func (s *contactsSvcImpl) Get(idOrEmail string) (*Contact, error) {
+	return s.GetWithContext(context.Background(), idOrEmail)
+}
+
+// GetWithContext retrieves a contact using the provided context.
+// Check the Status field of the result to see whether the contact is subscribed.
+func (s *contactsSvcImpl) GetWithContext(ctx context.Context, idOrEmail string) (*Contact, error) {
+	if idOrEmail == "" {
+		return nil, fmt.Errorf("envloped: contact id or email is required")
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodGet, "/v1/contacts/{id}", contactPath(idOrEmail), nil)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to create get contact request: %w", err)
+	}
+
+	var resp Contact
+	if err := s.client.do(req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// List returns a page of contacts matching opts.
+func (s *contactsSvcImpl) List(opts *ListContactsOptions) (*ContactPage, error) {
+	return s.ListWithContext(context.Background(), opts)
+}
+
+// ListWithContext returns a page of contacts using the provided context.
+// Pass the returned NextCursor as opts.Cursor to fetch the following page.
+func (s *contactsSvcImpl) ListWithContext(ctx context.Context, opts *ListContactsOptions) (*ContactPage, error) {
+	if opts == nil {
+		opts = &ListContactsOptions{}
+	}
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
+	q := opts.values()
+	if opts.AudienceID != "" {
+		q.Set("audienceId", opts.AudienceID)
+	}
+	if opts.Status != "" {
+		q.Set("status", string(opts.Status))
+	}
+
+	path := "/v1/contacts"
+	if len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodGet, "/v1/contacts", path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to create list contacts request: %w", err)
+	}
+
+	var page ContactPage
+	if err := s.client.do(req, &page); err != nil {
+		return nil, err
+	}
+
+	return &page, nil
+}
+
+// Update changes a contact's fields, attributes, or subscription status.
+func (s *contactsSvcImpl) Update(idOrEmail string, params *ContactRequest) (*Contact, error) {
+	return s.UpdateWithContext(context.Background(), idOrEmail, params)
+}
+
+// UpdateWithContext updates a contact using the provided context.
+func (s *contactsSvcImpl) UpdateWithContext(ctx context.Context, idOrEmail string, params *ContactRequest) (*Contact, error) {
+	if idOrEmail == "" {
+		return nil, fmt.Errorf("envloped: contact id or email is required")
+	}
+	if params == nil {
+		return nil, fmt.Errorf("envloped: contact params must not be nil")
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodPatch, "/v1/contacts/{id}", contactPath(idOrEmail), params)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to create update contact request: %w", err)
+	}
+
+	var resp Contact
+	if err := s.client.do(req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// Delete removes a contact from the account and all audiences.
+func (s *contactsSvcImpl) Delete(idOrEmail string) error {
+	return s.DeleteWithContext(context.Background(), idOrEmail)
+}
+
+// DeleteWithContext removes a contact using the provided context.
+func (s *contactsSvcImpl) DeleteWithContext(ctx context.Context, idOrEmail string) error {
+	if idOrEmail == "" {
+		return fmt.Errorf("envloped: contact id or email is required")
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodDelete, "/v1/contacts/{id}", contactPath(idOrEmail), nil)
+	if err != nil {
+		return fmt.Errorf("envloped: failed to create delete contact request: %w", err)
+	}
+
+	return s.client.do(req, nil)
+}
+
+// Import streams a CSV of contacts to the API and returns the created import job.
+func (s *contactsSvcImpl) Import(csv io.Reader, opts *ImportOptions) (*ContactImport, error) {
+	return s.ImportWithContext(context.Background(), csv, opts)
+}
+
+// ImportWithContext streams a CSV of contacts using the provided context.
+// The CSV is uploaded as multipart/form-data without being buffered in memory,
+// so arbitrarily large files can be imported.
+func (s *contactsSvcImpl) ImportWithContext(ctx context.Context, csv io.Reader, opts *ImportOptions) (*ContactImport, error) {
+	if csv == nil {
+		return nil, fmt.Errorf("envloped: import csv reader must not be nil")
+	}
+	if opts == nil {
+		opts = &ImportOptions{}
+	}
+
+	pr, pw := io.Pipe()
+	defer pr.Close()
+
+	mw := multipart.NewWriter(pw)
+	go func() {
+		pw.CloseWithError(writeImportForm(mw, csv, opts))
+	}()
+
+	req, err := s.client.newStreamRequest(ctx, http.MethodPost, "/v1/contacts/imports", "/v1/contacts/imports", pr, mw.FormDataContentType())
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to create contact import request: %w", err)
+	}
+
+	var resp ContactImport
+	if err := s.client.do(req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// writeImportForm writes the import options and CSV file to mw and closes it.
+func writeImportForm(mw *multipart.Writer, csv io.Reader, opts *ImportOptions) error {
+	if opts.AudienceID != "" {
+		if err := mw.WriteField("audienceId", opts.AudienceID); err != nil {
+			return err
+		}
+	}
+	if opts.Dedupe != "" {
+		if err := mw.WriteField("dedupe", string(opts.Dedupe)); err != nil {
+			return err
+		}
+	}
+	if len(opts.ColumnMapping) > 0 {
+		mapping, err := json.Marshal(opts.ColumnMapping)
+		if err != nil {
+			return err
+		}
+		if err := mw.WriteField("columnMapping", string(mapping)); err != nil {
+			return err
+		}
+	}
+
+	filename := opts.Filename
+	if filename == "" {
+		filename = "contacts.csv"
+	}
+
+	h := make(textproto.MIMEHeader)
+	h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename=%q`, filename))
+	h.Set("Content-Type", "text/csv")
+	part, err := mw.CreatePart(h)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(part, csv); err != nil {
+		return err
+	}
+
+	return mw.Close()
+}
+
+// GetImport retrieves the current state of an import job.
+func (s *contactsSvcImpl) GetImport(importID string) (*ContactImport, error) {
+	return s.GetImportWithContext(context.Background(), importID)
+}
+
+// GetImportWithContext retrieves an import job using the provided context.
+func (s *contactsSvcImpl) GetImportWithContext(ctx context.Context, importID string) (*ContactImport, error) {
+	if importID == "" {
+		return nil, fmt.Errorf("envloped: import id is required")
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodGet, "/v1/contacts/imports/{id}", "/v1/contacts/imports/"+url.PathEscape(importID), nil)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to create get import request: %w", err)
+	}
+
+	var resp ContactImport
+	if err := s.client.do(req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// WaitForImport polls an import job every pollInterval until it completes,
+// fails, or ctx is done. A failed import is returned without an error;
+// check the Status and Error fields of the result.
+func (s *contactsSvcImpl) WaitForImport(ctx context.Context, importID string, pollInterval time.Duration) (*ContactImport, error) {
+	if pollInterval <= 0 {
+		return nil, fmt.Errorf("envloped: poll interval must be positive")
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		job, err := s.GetImportWithContext(ctx, importID)
+		if err != nil {
+			return nil, err
+		}
+		if job.Status.Done() {
+			return job, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return job, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package envloped
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestContactsImport_Success(t *testing.T) {
+	t.Parallel()
+
+	const csvData = "E-mail,First Name\nada@example.com,Ada\ngrace@example.com,Grace\n"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("expected POST, got %s", r.Method)
+		}
+		if r.URL.Path != "/v1/contacts/imports" {
+			t.Errorf("expected path /v1/contacts/imports, got %s", r.URL.Path)
+		}
+
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatalf("failed to parse multipart form: %v", err)
+		}
+		if got := r.FormValue("audienceId"); got != "aud_123" {
+			t.Errorf("expected audienceId %q, got %q", "aud_123", got)
+		}
+		if got := r.FormValue("dedupe"); got != "update" {
+			t.Errorf("expected dedupe %q, got %q", "update", got)
+		}
+
+		var mapping map[string]string
+		if err := json.Unmarshal([]byte(r.FormValue("columnMapping")), &mapping); err != nil {
+			t.Fatalf("failed to decode column mapping: %v", err)
+		}
+		if mapping["E-mail"] != "email" {
+			t.Errorf("unexpected column mapping: %v", mapping)
+		}
+
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			t.Fatalf("expected file part: %v", err)
+		}
+		defer file.Close()
+		if header.Filename != "contacts.csv" {
+			t.Errorf("expected filename %q, got %q", "contacts.csv", header.Filename)
+		}
+		data, _ := io.ReadAll(file)
+		if string(data) != csvData {
+			t.Errorf("unexpected csv content: %q", data)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		json.NewEncoder(w).Encode(ContactImport{ID: "imp_123", Status: ImportStatusPending})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	job, err := client.Contacts.Import(strings.NewReader(csvData), &ImportOptions{
+		AudienceID:    "aud_123",
+		ColumnMapping: map[string]string{"E-mail": "email", "First Name": "firstName"},
+		Dedupe:        ImportDedupeUpdate,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if job.ID != "imp_123" {
+		t.Errorf("expected import id %q, got %q", "imp_123", job.ID)
+	}
+	if job.Status != ImportStatusPending {
+		t.Errorf("expected status %q, got %q", ImportStatusPending, job.Status)
+	}
+}
+
+func TestContactsImport_NilReader(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("key")
+	_, err := client.Contacts.Import(nil, nil)
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func TestContactsWaitForImport(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/contacts/imports/imp_123" {
+			t.Errorf("expected path /v1/contacts/imports/imp_123, got %s", r.URL.Path)
+		}
+
+		status := ImportStatusProcessing
+		if atomic.AddInt32(&calls, 1) >= 3 {
+			status = ImportStatusCompleted
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(ContactImport{ID: "imp_123", Status: status, Imported: 2})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	job, err := client.Contacts.WaitForImport(ctx, "imp_123", 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if job.Status != ImportStatusCompleted {
+		t.Errorf("expected status %q, got %q", ImportStatusCompleted, job.Status)
+	}
+	if n := atomic.LoadInt32(&calls); n != 3 {
+		t.Errorf("expected 3 polls, got %d", n)
+	}
+}
+
+func TestContacts_CRUD(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/contacts":
+			var req ContactRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			if req.Email != "ada@example.com" || req.Attributes["plan"] != "pro" {
+				t.Errorf("unexpected create body: %+v", req)
+			}
+			json.NewEncoder(w).Encode(Contact{ID: "con_1", Email: req.Email, Status: SubscriptionStatusSubscribed})
+		case r.Method == http.MethodGet && r.URL.EscapedPath() == "/v1/contacts/ada@example.com":
+			json.NewEncoder(w).Encode(Contact{ID: "con_1", Email: "ada@example.com", Status: SubscriptionStatusSubscribed})
+		case r.Method == http.MethodPatch && r.URL.Path == "/v1/contacts/con_1":
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			if body["status"] != "unsubscribed" || len(body) != 1 {
+				t.Errorf("unexpected update body: %v", body)
+			}
+			json.NewEncoder(w).Encode(Contact{ID: "con_1", Status: SubscriptionStatusUnsubscribed})
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/contacts":
+			q := r.URL.Query()
+			if q.Get("audienceId") != "aud_1" || q.Get("status") != "subscribed" || q.Get("limit") != "50" || q.Get("cursor") != "c1" {
+				t.Errorf("unexpected list query: %v", q)
+			}
+			json.NewEncoder(w).Encode(ContactPage{Items: []Contact{{ID: "con_1"}}, NextCursor: "c2"})
+		case r.Method == http.MethodDelete && r.URL.Path == "/v1/contacts/con_1":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+
+	contact, err := client.Contacts.Create(&ContactRequest{
+		Email:      "ada@example.com",
+		Attributes: map[string]any{"plan": "pro"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if contact.ID != "con_1" {
+		t.Errorf("expected id con_1, got %q", contact.ID)
+	}
+
+	contact, err = client.Contacts.Get("ada@example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if contact.Status != SubscriptionStatusSubscribed {
+		t.Errorf("expected subscribed, got %q", contact.Status)
+	}
+
+	contact, err = client.Contacts.Update("con_1", &ContactRequest{Status: SubscriptionStatusUnsubscribed})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if contact.Status != SubscriptionStatusUnsubscribed {
+		t.Errorf("expected unsubscribed, got %q", contact.Status)
+	}
+
+	page, err := client.Contacts.List(&ListContactsOptions{AudienceID: "aud_1", Status: SubscriptionStatusSubscribed, ListOptions: ListOptions{Limit: 50, Cursor: "c1"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(page.Items) != 1 || page.NextCursor != "c2" {
+		t.Errorf("unexpected page: %+v", page)
+	}
+
+	if err := client.Contacts.Delete("con_1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestContacts_Validation(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("key")
+
+	if _, err := client.Contacts.Create(&ContactRequest{}); err == nil || !strings.Contains(err.Error(), "contact email is required") {
+		t.Errorf("expected missing email error, got %v", err)
+	}
+	if _, err := client.Contacts.Get(""); err == nil {
+		t.Error("expected missing id error")
+	}
+	if _, err := client.Contacts.List(&ListContactsOptions{ListOptions: ListOptions{Limit: -1}}); err == nil {
+		t.Error("expected negative limit error")
+	}
+}
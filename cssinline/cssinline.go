@@ -0,0 +1,599 @@
+// Package cssinline moves the rules of an HTML email's <style> elements into
+// the style attributes of the elements they match, because many email
+// clients strip <style> elements.
+//
+// Usage:
+//
+//	params := &envloped.SendEmailRequest{
+//	    // ...
+//	    Html: cssinline.Inline(html),
+//	}
+//
+// Rules whose selectors are a single element with optional classes and IDs,
+// such as "p", ".button", "a.button#cta", or "*", are inlined. Rules with
+// other selectors (descendants, pseudo-classes, attributes) and at-rules
+// such as @media are kept in a <style> element, where clients that support
+// them still apply them.
+package cssinline
+
+import (
+	"html"
+	"sort"
+	"strings"
+)
+
+// styleMarker marks where the first <style> element stood while the document
+// is rewritten.
+const styleMarker = "\x00cssinline-style\x00"
+
+// skipElements are never given a style attribute.
+var skipElements = map[string]bool{
+	"html": true, "head": true, "title": true, "meta": true, "link": true,
+	"base": true, "style": true, "script": true,
+}
+
+// Inline returns doc with the CSS rules of its <style> elements applied to
+// the style attributes of matching elements. Declarations already in a style
+// attribute take precedence over inlined ones, unless the rule's declaration
+// is marked !important. <style> elements with a media attribute are left
+// untouched.
+func Inline(doc string) string {
+	css, doc := extractStyles(doc)
+	rules, leftover := parseCSS(css)
+
+	var b strings.Builder
+	for {
+		i := strings.IndexByte(doc, '<')
+		if i < 0 {
+			b.WriteString(doc)
+			break
+		}
+		b.WriteString(doc[:i])
+		doc = doc[i:]
+
+		if strings.HasPrefix(doc, "<!--") {
+			end := strings.Index(doc, "-->")
+			if end < 0 {
+				b.WriteString(doc)
+				break
+			}
+			b.WriteString(doc[:end+len("-->")])
+			doc = doc[end+len("-->"):]
+			continue
+		}
+		if len(doc) < 2 || !isLetter(doc[1]) {
+			// A closing tag, doctype, or stray "<": copy it as-is.
+			end := tagEnd(doc)
+			b.WriteString(doc[:end])
+			doc = doc[end:]
+			continue
+		}
+
+		end := tagEnd(doc)
+		tag := strings.TrimSuffix(doc[1:end], ">")
+		doc = doc[end:]
+		name := tagName(tag)
+		b.WriteString("<" + rewriteTag(name, tag, rules) + ">")
+
+		if name == "script" {
+			// Script content is not markup.
+			j := indexFold(doc, "</script")
+			if j < 0 {
+				j = len(doc)
+			}
+			b.WriteString(doc[:j])
+			doc = doc[j:]
+		}
+	}
+
+	style := ""
+	if leftover != "" {
+		style = "<style type=\"text/css\">\n" + leftover + "</style>"
+	}
+	return strings.Replace(b.String(), styleMarker, style, 1)
+}
+
+// extractStyles removes the <style> elements without a media attribute from
+// doc, leaving styleMarker in place of the first one, and returns their
+// combined content.
+func extractStyles(doc string) (css string, rest string) {
+	var b, out strings.Builder
+	marked := false
+	for {
+		i := indexFold(doc, "<style")
+		if i < 0 || i+len("<style") >= len(doc) {
+			out.WriteString(doc)
+			break
+		}
+		if c := doc[i+len("<style")]; c != '>' && !isSpace(c) {
+			out.WriteString(doc[:i+len("<style")])
+			doc = doc[i+len("<style"):]
+			continue
+		}
+
+		open := i + tagEnd(doc[i:])
+		closing := indexFold(doc[open:], "</style")
+		if closing < 0 {
+			out.WriteString(doc)
+			break
+		}
+		closing += open
+		end := closing + tagEnd(doc[closing:])
+
+		if hasAttr(doc[i+1:open-1], "media") {
+			out.WriteString(doc[:end])
+			doc = doc[end:]
+			continue
+		}
+
+		out.WriteString(doc[:i])
+		if !marked {
+			out.WriteString(styleMarker)
+			marked = true
+		}
+		b.WriteString(doc[open:closing])
+		b.WriteByte('\n')
+		doc = doc[end:]
+	}
+	return b.String(), out.String()
+}
+
+// rewriteTag returns tag, the inside of an opening tag named name, with the
+// declarations of the matching rules merged into its style attribute.
+func rewriteTag(name, tag string, rules []rule) string {
+	if skipElements[name] {
+		return tag
+	}
+
+	attrs := parseAttrs(tag, len(name))
+	var id string
+	classes := make(map[string]bool)
+	var style *attr
+	for i := range attrs {
+		switch attrs[i].name {
+		case "id":
+			id = attrs[i].value
+		case "class":
+			for _, c := range strings.Fields(attrs[i].value) {
+				classes[c] = true
+			}
+		case "style":
+			style = &attrs[i]
+		}
+	}
+
+	var merged declarations
+	for _, r := range rules {
+		if r.selector.matches(name, id, classes) {
+			merged.merge(r.decls)
+		}
+	}
+	if len(merged.list) == 0 {
+		return tag
+	}
+
+	if style != nil {
+		merged.merge(parseDecls(style.value))
+		tag = tag[:style.start] + tag[style.end:]
+	}
+	tag = strings.TrimRight(tag, " \t\r\n")
+	selfClosing := strings.HasSuffix(tag, "/")
+	if selfClosing {
+		tag = strings.TrimRight(tag[:len(tag)-1], " \t\r\n")
+	}
+
+	tag += ` style="` + html.EscapeString(merged.String()) + `"`
+	if selfClosing {
+		tag += " /"
+	}
+	return tag
+}
+
+// selector is a compound selector of an optional element name plus classes
+// and IDs.
+type selector struct {
+	element string // "" or "*" matches any element
+	ids     []string
+	classes []string
+}
+
+// parseSelector parses s, reporting false if it is not a supported selector.
+func parseSelector(s string) (selector, bool) {
+	var sel selector
+	if s == "" {
+		return sel, false
+	}
+
+	i := 0
+	if s[0] == '*' {
+		sel.element = "*"
+		i = 1
+	} else {
+		for i < len(s) && isNameChar(s[i]) {
+			i++
+		}
+		sel.element = strings.ToLower(s[:i])
+	}
+	for i < len(s) {
+		c := s[i]
+		if c != '.' && c != '#' {
+			return sel, false
+		}
+		j := i + 1
+		for j < len(s) && isNameChar(s[j]) {
+			j++
+		}
+		if j == i+1 {
+			return sel, false
+		}
+		if c == '.' {
+			sel.classes = append(sel.classes, s[i+1:j])
+		} else {
+			sel.ids = append(sel.ids, s[i+1:j])
+		}
+		i = j
+	}
+	return sel, true
+}
+
+// specificity ranks the selector by the CSS specificity rules.
+func (s selector) specificity() int {
+	n := 100*len(s.ids) + 10*len(s.classes)
+	if s.element != "" && s.element != "*" {
+		n++
+	}
+	return n
+}
+
+// matches reports whether the selector matches an element.
+func (s selector) matches(element, id string, classes map[string]bool) bool {
+	if s.element != "" && s.element != "*" && s.element != element {
+		return false
+	}
+	for _, want := range s.ids {
+		if want != id {
+			return false
+		}
+	}
+	for _, want := range s.classes {
+		if !classes[want] {
+			return false
+		}
+	}
+	return true
+}
+
+// rule is a style rule with a single selector.
+type rule struct {
+	selector selector
+	decls    []declaration
+}
+
+// parseCSS returns the inlinable rules of css, ordered by ascending
+// specificity and then source order, and the CSS that cannot be inlined.
+func parseCSS(css string) (rules []rule, leftover string) {
+	css = stripComments(css)
+	var keep strings.Builder
+	for {
+		css = strings.TrimSpace(css)
+		if css == "" {
+			break
+		}
+
+		brace := strings.IndexByte(css, '{')
+		if css[0] == '@' {
+			semi := strings.IndexByte(css, ';')
+			if semi >= 0 && (brace < 0 || semi < brace) {
+				keep.WriteString(css[:semi+1] + "\n")
+				css = css[semi+1:]
+				continue
+			}
+			if brace < 0 {
+				keep.WriteString(css + "\n")
+				break
+			}
+			end := matchBrace(css, brace)
+			keep.WriteString(css[:end] + "\n")
+			css = css[end:]
+			continue
+		}
+		if brace < 0 {
+			break
+		}
+
+		end := strings.IndexByte(css[brace:], '}')
+		if end < 0 {
+			end = len(css)
+		} else {
+			end += brace
+		}
+		prelude, body := css[:brace], css[brace+1:end]
+		css = css[min(end+1, len(css)):]
+
+		decls := parseDecls(body)
+		var unsupported []string
+		for _, s := range strings.Split(prelude, ",") {
+			s = strings.TrimSpace(s)
+			if sel, ok := parseSelector(s); ok {
+				rules = append(rules, rule{selector: sel, decls: decls})
+			} else if s != "" {
+				unsupported = append(unsupported, s)
+			}
+		}
+		if len(unsupported) > 0 {
+			keep.WriteString(strings.Join(unsupported, ", ") + " { " + strings.TrimSpace(body) + " }\n")
+		}
+	}
+
+	sort.SliceStable(rules, func(i, j int) bool {
+		return rules[i].selector.specificity() < rules[j].selector.specificity()
+	})
+	return rules, keep.String()
+}
+
+// stripComments removes CSS comments.
+func stripComments(css string) string {
+	var b strings.Builder
+	for {
+		i := strings.Index(css, "/*")
+		if i < 0 {
+			b.WriteString(css)
+			return b.String()
+		}
+		b.WriteString(css[:i])
+		end := strings.Index(css[i+2:], "*/")
+		if end < 0 {
+			return b.String()
+		}
+		css = css[i+2+end+2:]
+	}
+}
+
+// matchBrace returns the index just past the brace that closes the one at
+// open, or len(s) if it is unclosed.
+func matchBrace(s string, open int) int {
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+	return len(s)
+}
+
+// declaration is a single CSS property declaration.
+type declaration struct {
+	property  string
+	value     string
+	important bool
+}
+
+// parseDecls parses a declaration block such as "color: red; margin: 0".
+func parseDecls(block string) []declaration {
+	var decls []declaration
+	for _, part := range splitDecls(block) {
+		prop, value, ok := strings.Cut(part, ":")
+		if !ok {
+			continue
+		}
+		prop = strings.ToLower(strings.TrimSpace(prop))
+		value = strings.TrimSpace(value)
+		important := false
+		if v, ok := cutSuffixFold(value, "!important"); ok {
+			value = strings.TrimSpace(v)
+			important = true
+		}
+		if prop == "" || value == "" {
+			continue
+		}
+		decls = append(decls, declaration{property: prop, value: value, important: important})
+	}
+	return decls
+}
+
+// splitDecls splits a declaration block at semicolons outside quotes and
+// parentheses, so values like url(data:image/png;base64,...) stay whole.
+func splitDecls(block string) []string {
+	var parts []string
+	var quote byte
+	depth, start := 0, 0
+	for i := 0; i < len(block); i++ {
+		c := block[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')' && depth > 0:
+			depth--
+		case c == ';' && depth == 0:
+			parts = append(parts, block[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, block[start:])
+}
+
+// declarations is an ordered set of declarations keyed by property.
+type declarations struct {
+	list  []declaration
+	index map[string]int
+}
+
+// merge applies decls on top of d. A later declaration replaces an earlier
+// one of the same property, unless only the earlier one is !important.
+func (d *declarations) merge(decls []declaration) {
+	if d.index == nil {
+		d.index = make(map[string]int)
+	}
+	for _, decl := range decls {
+		i, ok := d.index[decl.property]
+		if !ok {
+			d.index[decl.property] = len(d.list)
+			d.list = append(d.list, decl)
+			continue
+		}
+		if d.list[i].important && !decl.important {
+			continue
+		}
+		d.list[i] = decl
+	}
+}
+
+// String formats the declarations for a style attribute.
+func (d *declarations) String() string {
+	parts := make([]string, len(d.list))
+	for i, decl := range d.list {
+		parts[i] = decl.property + ": " + decl.value
+		if decl.important {
+			parts[i] += " !important"
+		}
+	}
+	return strings.Join(parts, "; ")
+}
+
+// attr is an attribute of a tag, with its byte range in the tag.
+type attr struct {
+	name       string
+	value      string
+	start, end int
+}
+
+// parseAttrs parses the attributes of tag, the inside of an opening tag,
+// starting at byte offset from.
+func parseAttrs(tag string, from int) []attr {
+	var attrs []attr
+	i := from
+	for i < len(tag) {
+		for i < len(tag) && (isSpace(tag[i]) || tag[i] == '/') {
+			i++
+		}
+		start := i
+		for i < len(tag) && !isSpace(tag[i]) && tag[i] != '=' && tag[i] != '/' {
+			i++
+		}
+		if i == start {
+			break
+		}
+		a := attr{name: strings.ToLower(tag[start:i]), start: start}
+
+		j := i
+		for j < len(tag) && isSpace(tag[j]) {
+			j++
+		}
+		if j < len(tag) && tag[j] == '=' {
+			j++
+			for j < len(tag) && isSpace(tag[j]) {
+				j++
+			}
+			if j < len(tag) && (tag[j] == '"' || tag[j] == '\'') {
+				k := strings.IndexByte(tag[j+1:], tag[j])
+				if k < 0 {
+					a.value, j = tag[j+1:], len(tag)
+				} else {
+					a.value, j = tag[j+1:j+1+k], j+2+k
+				}
+			} else {
+				k := j
+				for k < len(tag) && !isSpace(tag[k]) {
+					k++
+				}
+				a.value, j = tag[j:k], k
+			}
+			i = j
+		}
+		a.value = html.UnescapeString(a.value)
+		a.end = i
+		attrs = append(attrs, a)
+	}
+	return attrs
+}
+
+// hasAttr reports whether tag, the inside of an opening tag, has the named
+// attribute.
+func hasAttr(tag, name string) bool {
+	for _, a := range parseAttrs(tag, len(tagName(tag))) {
+		if a.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// tagName returns the lowercase element name of tag, the inside of a tag.
+func tagName(tag string) string {
+	i := 0
+	for i < len(tag) && !isSpace(tag[i]) && tag[i] != '/' {
+		i++
+	}
+	return strings.ToLower(tag[:i])
+}
+
+// tagEnd returns the index just past the '>' that ends the tag at the start
+// of s, skipping quoted attribute values, or len(s) if it is unterminated.
+func tagEnd(s string) int {
+	var quote byte
+	for i := 1; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '>':
+			return i + 1
+		}
+	}
+	return len(s)
+}
+
+// indexFold returns the index of the first ASCII case-insensitive match of
+// substr, which must be lowercase, in s, or -1.
+func indexFold(s, substr string) int {
+outer:
+	for i := 0; i+len(substr) <= len(s); i++ {
+		for j := 0; j < len(substr); j++ {
+			c := s[i+j]
+			if 'A' <= c && c <= 'Z' {
+				c += 'a' - 'A'
+			}
+			if c != substr[j] {
+				continue outer
+			}
+		}
+		return i
+	}
+	return -1
+}
+
+// cutSuffixFold is strings.CutSuffix with ASCII case-insensitive matching.
+func cutSuffixFold(s, suffix string) (string, bool) {
+	if len(s) >= len(suffix) && strings.EqualFold(s[len(s)-len(suffix):], suffix) {
+		return s[:len(s)-len(suffix)], true
+	}
+	return s, false
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
+
+func isLetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isNameChar(c byte) bool {
+	return isLetter(c) || c >= '0' && c <= '9' || c == '-' || c == '_'
+}
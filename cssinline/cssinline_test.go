@@ -0,0 +1,85 @@
+package cssinline
+
+import "testing"
+
+func TestInline(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		doc  string
+		want string
+	}{
+		{
+			name: "element selector",
+			doc:  `<style>p { color: red; margin: 0 }</style><p>Hi</p>`,
+			want: `<p style="color: red; margin: 0">Hi</p>`,
+		},
+		{
+			name: "class and id selectors",
+			doc:  `<style>.btn { color: blue } a.btn#cta { font-weight: bold }</style><a class="btn" id="cta" href="#">Go</a><a class="btn">No</a>`,
+			want: `<a class="btn" id="cta" href="#" style="color: blue; font-weight: bold">Go</a><a class="btn" style="color: blue">No</a>`,
+		},
+		{
+			name: "specificity beats source order",
+			doc:  `<style>.x { color: blue } p { color: red }</style><p class="x">Hi</p>`,
+			want: `<p class="x" style="color: blue">Hi</p>`,
+		},
+		{
+			name: "existing style attribute wins",
+			doc:  `<style>p { color: red; padding: 4px }</style><p style="color: green">Hi</p>`,
+			want: `<p style="color: green; padding: 4px">Hi</p>`,
+		},
+		{
+			name: "important rule beats style attribute",
+			doc:  `<style>p { color: red !important }</style><p style="color: green">Hi</p>`,
+			want: `<p style="color: red !important">Hi</p>`,
+		},
+		{
+			name: "selector groups",
+			doc:  `<style>h1, h2 { margin: 0 }</style><h1>A</h1><h2>B</h2>`,
+			want: `<h1 style="margin: 0">A</h1><h2 style="margin: 0">B</h2>`,
+		},
+		{
+			name: "unsupported rules are kept",
+			doc:  "<head><style>/* c */ a:hover { color: red } @media (max-width: 600px) { p { width: 100% } } p { margin: 0 }</style></head><p>Hi</p>",
+			want: "<head><style type=\"text/css\">\na:hover { color: red }\n@media (max-width: 600px) { p { width: 100% } }\n</style></head><p style=\"margin: 0\">Hi</p>",
+		},
+		{
+			name: "media style element untouched",
+			doc:  `<style media="print">p { color: red }</style><p>Hi</p>`,
+			want: `<style media="print">p { color: red }</style><p>Hi</p>`,
+		},
+		{
+			name: "self-closing and void elements",
+			doc:  `<STYLE>img { border: 0 }</STYLE><img src="a.png"/><img src="b.png">`,
+			want: `<img src="a.png" style="border: 0" /><img src="b.png" style="border: 0">`,
+		},
+		{
+			name: "quoted values",
+			doc:  `<style>p { font-family: "Helvetica Neue", Arial; background: url(data:image/png;base64,AA==) }</style><p title="a > b">Hi</p>`,
+			want: `<p title="a > b" style="font-family: &#34;Helvetica Neue&#34;, Arial; background: url(data:image/png;base64,AA==)">Hi</p>`,
+		},
+		{
+			name: "comments, closing tags and head elements unchanged",
+			doc:  `<style>* { margin: 0 }</style><html><head><title>T</title></head><!-- <p> --><body></body></html>`,
+			want: `<html><head><title>T</title></head><!-- <p> --><body style="margin: 0"></body></html>`,
+		},
+		{
+			name: "no style element",
+			doc:  `<p>Hi &amp; bye</p>`,
+			want: `<p>Hi &amp; bye</p>`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := Inline(tt.doc); got != tt.want {
+				t.Errorf("Inline() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,437 @@
+package envloped
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// StatsPeriod is the trailing time window that statistics are computed over.
+type StatsPeriod string
+
+// Statistics periods.
+const (
+	StatsPeriodDay   StatsPeriod = "1d"
+	StatsPeriodWeek  StatsPeriod = "7d"
+	StatsPeriodMonth StatsPeriod = "30d"
+)
+
+// DomainStats holds reputation-relevant sending metrics for a domain.
+type DomainStats struct {
+	// DomainID is the domain the statistics belong to.
+	DomainID string `json:"domainId"`
+
+	// Period is the window the statistics cover.
+	Period StatsPeriod `json:"period"`
+
+	// Start is the beginning of the window.
+	Start time.Time `json:"start"`
+
+	// End is the end of the window.
+	End time.Time `json:"end"`
+
+	// Sent is the number of emails sent from the domain.
+	Sent int `json:"sent"`
+
+	// Delivered is the number of emails accepted by recipient servers.
+	Delivered int `json:"delivered"`
+
+	// Bounced is the number of hard and soft bounces.
+	Bounced int `json:"bounced"`
+
+	// Complained is the number of spam complaints.
+	Complained int `json:"complained"`
+
+	// BounceRate is Bounced / Sent.
+	BounceRate float64 `json:"bounceRate"`
+
+	// ComplaintRate is Complained / Delivered.
+	ComplaintRate float64 `json:"complaintRate"`
+}
+
+// DNSRecordPurpose identifies what a DNS record is used for.
+type DNSRecordPurpose string
+
+// DNS record purposes.
+const (
+	DNSRecordDKIM       DNSRecordPurpose = "dkim"
+	DNSRecordSPF        DNSRecordPurpose = "spf"
+	DNSRecordReturnPath DNSRecordPurpose = "return_path"
+	DNSRecordDMARC      DNSRecordPurpose = "dmarc"
+)
+
+// DNSRecord is a DNS record that must be published for a domain feature to work.
+type DNSRecord struct {
+	// Purpose identifies what the record is used for.
+	Purpose DNSRecordPurpose `json:"purpose,omitempty"`
+
+	// Type is the record type (e.g., "TXT", "CNAME", "MX").
+	Type string `json:"type"`
+
+	// Name is the fully qualified record name.
+	Name string `json:"name"`
+
+	// Value is the expected record value.
+	Value string `json:"value"`
+
+	// Verified reports whether the record was found with the expected value.
+	Verified bool `json:"verified"`
+}
+
+// DomainStatus is the verification state of a sending domain.
+type DomainStatus string
+
+// Domain verification statuses.
+const (
+	DomainStatusPending  DomainStatus = "pending"
+	DomainStatusVerified DomainStatus = "verified"
+	DomainStatusFailed   DomainStatus = "failed"
+)
+
+// Domain is a sending domain registered with Envloped.
+type Domain struct {
+	// ID is the unique identifier of the domain.
+	ID string `json:"id"`
+
+	// Name is the domain name (e.g., "mail.yourdomain.com").
+	Name string `json:"name"`
+
+	// Status is the verification state of the domain.
+	Status DomainStatus `json:"status"`
+
+	// Records are the DNS records (DKIM, SPF, return path) that must be
+	// published before the domain can be verified.
+	Records []DNSRecord `json:"records"`
+
+	// CreatedAt is when the domain was added.
+	CreatedAt time.Time `json:"createdAt"`
+
+	// VerifiedAt is when the domain was verified, or nil if it is not verified.
+	VerifiedAt *time.Time `json:"verifiedAt,omitempty"`
+}
+
+// CreateDomainRequest is the request body for adding a sending domain.
+type CreateDomainRequest struct {
+	// Name is the domain name to send from.
+	Name string `json:"name"`
+}
+
+// ReturnPath is the custom bounce (envelope sender) domain configured for a
+// sending domain.
+type ReturnPath struct {
+	// Domain is the fully qualified return-path domain (e.g., "bounces.yourdomain.com").
+	Domain string `json:"domain"`
+
+	// Record is the DNS record that routes bounces for Domain to Envloped.
+	Record DNSRecord `json:"record"`
+
+	// Verified reports whether the return-path domain is verified and in use.
+	Verified bool `json:"verified"`
+}
+
+// DomainsSvc defines the interface for the domains service.
+// This interface can be mocked in consumer tests.
+type DomainsSvc interface {
+	// Create adds a sending domain and returns the DNS records to publish.
+	Create(params *CreateDomainRequest) (*Domain, error)
+
+	// CreateWithContext adds a sending domain using the provided context.
+	CreateWithContext(ctx context.Context, params *CreateDomainRequest) (*Domain, error)
+
+	// Get retrieves a domain by ID.
+	Get(domainID string) (*Domain, error)
+
+	// GetWithContext retrieves a domain using the provided context.
+	GetWithContext(ctx context.Context, domainID string) (*Domain, error)
+
+	// List returns all sending domains.
+	List() ([]Domain, error)
+
+	// ListWithContext returns all sending domains using the provided context.
+	ListWithContext(ctx context.Context) ([]Domain, error)
+
+	// Delete removes a sending domain.
+	Delete(domainID string) error
+
+	// DeleteWithContext removes a sending domain using the provided context.
+	DeleteWithContext(ctx context.Context, domainID string) error
+
+	// Verify asks the API to check the domain's DNS records now and returns
+	// the updated domain.
+	Verify(domainID string) (*Domain, error)
+
+	// VerifyWithContext verifies a domain using the provided context.
+	VerifyWithContext(ctx context.Context, domainID string) (*Domain, error)
+
+	// WaitForVerification asks the API to verify the domain every
+	// pollInterval until it is verified or ctx is done.
+	WaitForVerification(ctx context.Context, domainID string, pollInterval time.Duration) (*Domain, error)
+
+	// Stats returns sending metrics for a domain over the given period.
+	Stats(domainID string, period StatsPeriod) (*DomainStats, error)
+
+	// StatsWithContext returns domain sending metrics using the provided context.
+	StatsWithContext(ctx context.Context, domainID string, period StatsPeriod) (*DomainStats, error)
+
+	// GetReturnPath returns the custom return-path configuration of a domain.
+	GetReturnPath(domainID string) (*ReturnPath, error)
+
+	// GetReturnPathWithContext returns the return-path configuration using the provided context.
+	GetReturnPathWithContext(ctx context.Context, domainID string) (*ReturnPath, error)
+
+	// SetReturnPath configures subdomain (e.g., "bounces") of the domain as its
+	// custom return path. Publish the returned DNS record and wait for
+	// Verified before relying on it.
+	SetReturnPath(domainID, subdomain string) (*ReturnPath, error)
+
+	// SetReturnPathWithContext configures the return path using the provided context.
+	SetReturnPathWithContext(ctx context.Context, domainID, subdomain string) (*ReturnPath, error)
+
+	// CheckDNS looks up the domain's required DNS records locally and
+	// reports which are missing or mismatched, so sender setup can be
+	// verified before deploys without waiting for the API's verification.
+	CheckDNS(domainID string) (*DNSCheckResult, error)
+
+	// CheckDNSWithContext checks the domain's DNS records using the provided context.
+	CheckDNSWithContext(ctx context.Context, domainID string) (*DNSCheckResult, error)
+}
+
+// domainsSvcImpl implements DomainsSvc.
+type domainsSvcImpl struct {
+	client *Client
+}
+
+// domainPath returns the API path of a domain, with optional suffix.
+func domainPath(domainID, suffix string) string {
+	return "/v1/domains/" + url.PathEscape(domainID) + suffix
+}
+
+// Create adds a sending domain and returns the DNS records to publish.
+func (s *domainsSvcImpl) Create(params *CreateDomainRequest) (*Domain, error) {
+	return s.CreateWithContext(context.Background(), params)
+}
+
+// CreateWithContext adds a sending domain using the provided context.
+func (s *domainsSvcImpl) CreateWithContext(ctx context.Context, params *CreateDomainRequest) (*Domain, error) {
+	if params == nil {
+		return nil, fmt.Errorf("envloped: create domain params must not be nil")
+	}
+	if params.Name == "" {
+		return nil, fmt.Errorf("envloped: domain name is required")
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodPost, "/v1/domains", "/v1/domains", params)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to create domain request: %w", err)
+	}
+
+	var resp Domain
+	if err := s.client.do(req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// Get retrieves a domain by ID.
+func (s *domainsSvcImpl) Get(domainID string) (*Domain, error) {
+	return s.GetWithContext(context.Background(), domainID)
+}
+
+// GetWithContext retrieves a domain using the provided context.
+func (s *domainsSvcImpl) GetWithContext(ctx context.Context, domainID string) (*Domain, error) {
+	return s.domainRequest(ctx, http.MethodGet, domainID, "")
+}
+
+// List returns all sending domains.
+func (s *domainsSvcImpl) List() ([]Domain, error) {
+	return s.ListWithContext(context.Background())
+}
+
+// ListWithContext returns all sending domains using the provided context.
+func (s *domainsSvcImpl) ListWithContext(ctx context.Context) ([]Domain, error) {
+	req, err := s.client.newRequest(ctx, http.MethodGet, "/v1/domains", "/v1/domains", nil)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to create list domains request: %w", err)
+	}
+
+	var resp listResponse[Domain]
+	if err := s.client.do(req, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Data, nil
+}
+
+// Delete removes a sending domain.
+func (s *domainsSvcImpl) Delete(domainID string) error {
+	return s.DeleteWithContext(context.Background(), domainID)
+}
+
+// DeleteWithContext removes a sending domain using the provided context.
+func (s *domainsSvcImpl) DeleteWithContext(ctx context.Context, domainID string) error {
+	if domainID == "" {
+		return fmt.Errorf("envloped: domain id is required")
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodDelete, "/v1/domains/{id}", domainPath(domainID, ""), nil)
+	if err != nil {
+		return fmt.Errorf("envloped: failed to create delete domain request: %w", err)
+	}
+
+	return s.client.do(req, nil)
+}
+
+// Verify asks the API to check the domain's DNS records now.
+func (s *domainsSvcImpl) Verify(domainID string) (*Domain, error) {
+	return s.VerifyWithContext(context.Background(), domainID)
+}
+
+// VerifyWithContext verifies a domain using the provided context. DNS
+// propagation can take time, so a domain may remain pending after this call;
+// inspect the returned records to see which are still missing.
+func (s *domainsSvcImpl) VerifyWithContext(ctx context.Context, domainID string) (*Domain, error) {
+	return s.domainRequest(ctx, http.MethodPost, domainID, "/verify")
+}
+
+// WaitForVerification asks the API to verify the domain every pollInterval
+// until it is verified or ctx is done, in which case the last state of the
+// domain is returned with the context's error. A failed verification does
+// not stop the wait, since DNS changes can take time to propagate; bound it
+// with a context deadline.
+func (s *domainsSvcImpl) WaitForVerification(ctx context.Context, domainID string, pollInterval time.Duration) (*Domain, error) {
+	if pollInterval <= 0 {
+		return nil, fmt.Errorf("envloped: poll interval must be positive")
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	var last *Domain
+	for {
+		domain, err := s.VerifyWithContext(ctx, domainID)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil && last != nil {
+				return last, ctxErr
+			}
+			return nil, err
+		}
+		if domain.Status == DomainStatusVerified {
+			return domain, nil
+		}
+		last = domain
+
+		select {
+		case <-ctx.Done():
+			return domain, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// domainRequest performs a body-less request against a single domain.
+func (s *domainsSvcImpl) domainRequest(ctx context.Context, method, domainID, suffix string) (*Domain, error) {
+	if domainID == "" {
+		return nil, fmt.Errorf("envloped: domain id is required")
+	}
+
+	req, err := s.client.newRequest(ctx, method, "/v1/domains/{id}"+suffix, domainPath(domainID, suffix), nil)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to create domain request: %w", err)
+	}
+
+	var resp Domain
+	if err := s.client.do(req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// Stats returns sending metrics for a domain over the given period.
+func (s *domainsSvcImpl) Stats(domainID string, period StatsPeriod) (*DomainStats, error) {
+	return s.StatsWithContext(context.Background(), domainID, period)
+}
+
+// StatsWithContext returns domain sending metrics using the provided context.
+// An empty period defaults to StatsPeriodWeek on the API side.
+func (s *domainsSvcImpl) StatsWithContext(ctx context.Context, domainID string, period StatsPeriod) (*DomainStats, error) {
+	if domainID == "" {
+		return nil, fmt.Errorf("envloped: domain id is required")
+	}
+
+	path := domainPath(domainID, "/stats")
+	if period != "" {
+		path += "?" + url.Values{"period": {string(period)}}.Encode()
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodGet, "/v1/domains/{id}/stats", path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to create domain stats request: %w", err)
+	}
+
+	var resp DomainStats
+	if err := s.client.do(req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// GetReturnPath returns the custom return-path configuration of a domain.
+func (s *domainsSvcImpl) GetReturnPath(domainID string) (*ReturnPath, error) {
+	return s.GetReturnPathWithContext(context.Background(), domainID)
+}
+
+// GetReturnPathWithContext returns the return-path configuration using the provided context.
+func (s *domainsSvcImpl) GetReturnPathWithContext(ctx context.Context, domainID string) (*ReturnPath, error) {
+	if domainID == "" {
+		return nil, fmt.Errorf("envloped: domain id is required")
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodGet, "/v1/domains/{id}/return-path", domainPath(domainID, "/return-path"), nil)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to create get return path request: %w", err)
+	}
+
+	var resp ReturnPath
+	if err := s.client.do(req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// SetReturnPath configures a subdomain of the domain as its custom return path.
+func (s *domainsSvcImpl) SetReturnPath(domainID, subdomain string) (*ReturnPath, error) {
+	return s.SetReturnPathWithContext(context.Background(), domainID, subdomain)
+}
+
+// SetReturnPathWithContext configures the return path using the provided context.
+func (s *domainsSvcImpl) SetReturnPathWithContext(ctx context.Context, domainID, subdomain string) (*ReturnPath, error) {
+	if domainID == "" {
+		return nil, fmt.Errorf("envloped: domain id is required")
+	}
+	if subdomain == "" || strings.ContainsAny(subdomain, "@/ ") {
+		return nil, fmt.Errorf("envloped: invalid return path subdomain %q", subdomain)
+	}
+
+	body := struct {
+		Subdomain string `json:"subdomain"`
+	}{subdomain}
+
+	req, err := s.client.newRequest(ctx, http.MethodPut, "/v1/domains/{id}/return-path", domainPath(domainID, "/return-path"), body)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to create set return path request: %w", err)
+	}
+
+	var resp ReturnPath
+	if err := s.client.do(req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
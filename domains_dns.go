@@ -0,0 +1,219 @@
+package envloped
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// DNSCheckStatus is the outcome of checking one DNS record.
+type DNSCheckStatus string
+
+// DNS check statuses.
+const (
+	// DNSCheckOK means the record is published with the expected value.
+	DNSCheckOK DNSCheckStatus = "ok"
+
+	// DNSCheckMissing means no record of the expected type exists.
+	DNSCheckMissing DNSCheckStatus = "missing"
+
+	// DNSCheckMismatch means records exist, but none has the expected value.
+	DNSCheckMismatch DNSCheckStatus = "mismatch"
+
+	// DNSCheckError means the lookup failed, e.g. because of a timeout.
+	DNSCheckError DNSCheckStatus = "error"
+)
+
+// DNSRecordCheck is the result of looking up one required DNS record.
+type DNSRecordCheck struct {
+	// Record is the record the domain requires.
+	Record DNSRecord
+
+	// Status is the outcome of the check.
+	Status DNSCheckStatus
+
+	// Found lists the values found for the record's name and type.
+	Found []string
+
+	// Err is the lookup error, if Status is DNSCheckError.
+	Err error
+}
+
+// DNSCheckResult is the result of Domains.CheckDNS.
+type DNSCheckResult struct {
+	// Domain is the domain whose records were checked.
+	Domain *Domain
+
+	// Records holds one check per required record, in the order of
+	// Domain.Records.
+	Records []DNSRecordCheck
+}
+
+// OK reports whether every required record is published as expected.
+func (r *DNSCheckResult) OK() bool {
+	return len(r.Problems()) == 0
+}
+
+// Problems returns the checks of records that are missing, mismatched, or
+// could not be looked up.
+func (r *DNSCheckResult) Problems() []DNSRecordCheck {
+	var out []DNSRecordCheck
+	for _, c := range r.Records {
+		if c.Status != DNSCheckOK {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// dnsResolver performs the DNS lookups of Domains.CheckDNS. It is
+// implemented by *net.Resolver.
+type dnsResolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+	LookupCNAME(ctx context.Context, host string) (string, error)
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+}
+
+// WithDNSResolver sets the resolver Domains.CheckDNS uses for its lookups,
+// e.g. one that queries a domain's authoritative name servers to avoid
+// stale cached answers. Defaults to net.DefaultResolver. Returns the client
+// for method chaining.
+func (c *Client) WithDNSResolver(resolver *net.Resolver) *Client {
+	if resolver == nil {
+		c.resolver = nil
+	} else {
+		c.resolver = resolver
+	}
+	return c
+}
+
+// CheckDNS looks up the DNS records required by a domain locally.
+func (s *domainsSvcImpl) CheckDNS(domainID string) (*DNSCheckResult, error) {
+	return s.CheckDNSWithContext(context.Background(), domainID)
+}
+
+// CheckDNSWithContext looks up the DNS records required by a domain using
+// the provided context. The domain's records are fetched from the API, and
+// each is looked up with the client's resolver, independently of the API's
+// own verification. Lookup failures are reported per record; the returned
+// error is only set if the domain could not be fetched.
+func (s *domainsSvcImpl) CheckDNSWithContext(ctx context.Context, domainID string) (*DNSCheckResult, error) {
+	domain, err := s.GetWithContext(ctx, domainID)
+	if err != nil {
+		return nil, err
+	}
+
+	var resolver dnsResolver = net.DefaultResolver
+	if s.client.resolver != nil {
+		resolver = s.client.resolver
+	}
+
+	result := &DNSCheckResult{Domain: domain}
+	for _, record := range domain.Records {
+		result.Records = append(result.Records, checkDNSRecord(ctx, resolver, record))
+	}
+	return result, nil
+}
+
+// checkDNSRecord looks up record and compares what is published with the
+// expected value.
+func checkDNSRecord(ctx context.Context, resolver dnsResolver, record DNSRecord) DNSRecordCheck {
+	check := DNSRecordCheck{Record: record}
+
+	var err error
+	switch strings.ToUpper(record.Type) {
+	case "TXT":
+		check.Found, err = resolver.LookupTXT(ctx, record.Name)
+	case "CNAME":
+		var target string
+		target, err = resolver.LookupCNAME(ctx, record.Name)
+		// Without a CNAME record, the name is its own canonical name.
+		if err == nil && !sameHost(target, record.Name) {
+			check.Found = []string{strings.TrimSuffix(target, ".")}
+		}
+	case "MX":
+		var mxs []*net.MX
+		mxs, err = resolver.LookupMX(ctx, record.Name)
+		for _, mx := range mxs {
+			check.Found = append(check.Found, strings.TrimSuffix(mx.Host, "."))
+		}
+	default:
+		check.Status = DNSCheckError
+		check.Err = fmt.Errorf("envloped: unsupported dns record type %q", record.Type)
+		return check
+	}
+
+	var dnsErr *net.DNSError
+	switch {
+	case errors.As(err, &dnsErr) && dnsErr.IsNotFound:
+		check.Status = DNSCheckMissing
+	case err != nil:
+		check.Status = DNSCheckError
+		check.Err = err
+	case len(check.Found) == 0:
+		check.Status = DNSCheckMissing
+	case dnsRecordMatches(record, check.Found):
+		check.Status = DNSCheckOK
+	default:
+		check.Status = DNSCheckMismatch
+	}
+	return check
+}
+
+// dnsRecordMatches reports whether any of the found values satisfies record.
+// SPF records match if they include every mechanism of the expected value,
+// since they often authorize other senders too, and DMARC records match any
+// policy, since the policy is the domain owner's choice.
+func dnsRecordMatches(record DNSRecord, found []string) bool {
+	for _, value := range found {
+		switch {
+		case record.Purpose == DNSRecordSPF && strings.EqualFold(record.Type, "TXT"):
+			if spfIncludes(value, record.Value) {
+				return true
+			}
+		case record.Purpose == DNSRecordDMARC:
+			if strings.HasPrefix(strings.ToUpper(strings.TrimSpace(value)), "V=DMARC1") {
+				return true
+			}
+		case strings.EqualFold(record.Type, "TXT"):
+			if strings.Join(strings.Fields(value), "") == strings.Join(strings.Fields(record.Value), "") {
+				return true
+			}
+		default:
+			// MX values may be given with their priority, e.g. "10 host".
+			fields := strings.Fields(record.Value)
+			if len(fields) > 0 && sameHost(value, fields[len(fields)-1]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// spfIncludes reports whether the SPF record published contains every
+// include and ip mechanism of the SPF record expected.
+func spfIncludes(published, expected string) bool {
+	fields := strings.Fields(strings.ToLower(published))
+	if len(fields) == 0 || fields[0] != "v=spf1" {
+		return false
+	}
+	have := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		have[strings.TrimLeft(f, "+")] = true
+	}
+	for _, f := range strings.Fields(strings.ToLower(expected)) {
+		f = strings.TrimLeft(f, "+")
+		if (strings.HasPrefix(f, "include:") || strings.HasPrefix(f, "ip4:") || strings.HasPrefix(f, "ip6:")) && !have[f] {
+			return false
+		}
+	}
+	return true
+}
+
+// sameHost reports whether a and b name the same host, ignoring case and a
+// trailing dot.
+func sameHost(a, b string) bool {
+	return strings.EqualFold(strings.TrimSuffix(a, "."), strings.TrimSuffix(b, "."))
+}
@@ -0,0 +1,170 @@
+package envloped
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// fakeResolver serves DNS lookups from maps. Names without an entry are not
+// found.
+type fakeResolver struct {
+	txt   map[string][]string
+	cname map[string]string
+	mx    map[string][]*net.MX
+	err   error
+}
+
+func notFound(name string) error {
+	return &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+}
+
+func (r *fakeResolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	if v, ok := r.txt[name]; ok {
+		return v, nil
+	}
+	return nil, notFound(name)
+}
+
+func (r *fakeResolver) LookupCNAME(ctx context.Context, host string) (string, error) {
+	if v, ok := r.cname[host]; ok {
+		return v, nil
+	}
+	return "", notFound(host)
+}
+
+func (r *fakeResolver) LookupMX(ctx context.Context, name string) ([]*net.MX, error) {
+	if v, ok := r.mx[name]; ok {
+		return v, nil
+	}
+	return nil, notFound(name)
+}
+
+func TestDomains_CheckDNS(t *testing.T) {
+	t.Parallel()
+
+	records := []DNSRecord{
+		{Purpose: DNSRecordDKIM, Type: "CNAME", Name: "ev1._domainkey.example.com", Value: "ev1.dkim.envloped.com"},
+		{Purpose: DNSRecordDKIM, Type: "CNAME", Name: "ev2._domainkey.example.com", Value: "ev2.dkim.envloped.com"},
+		{Purpose: DNSRecordSPF, Type: "TXT", Name: "example.com", Value: "v=spf1 include:spf.envloped.com ~all"},
+		{Purpose: DNSRecordDMARC, Type: "TXT", Name: "_dmarc.example.com", Value: "v=DMARC1; p=none;"},
+		{Purpose: DNSRecordReturnPath, Type: "MX", Name: "bounces.example.com", Value: "10 feedback.envloped.com"},
+		{Purpose: DNSRecordReturnPath, Type: "CNAME", Name: "www.example.com", Value: "track.envloped.com"},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/domains/dom_123" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(Domain{ID: "dom_123", Name: "example.com", Records: records})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	client.resolver = &fakeResolver{
+		cname: map[string]string{
+			"ev1._domainkey.example.com": "EV1.dkim.envloped.com.",
+			"ev2._domainkey.example.com": "ev2.dkim.other.com.",
+			// A name without a CNAME record resolves to itself.
+			"www.example.com": "www.example.com.",
+		},
+		txt: map[string][]string{
+			"example.com":        {"google-site-verification=abc", "v=spf1 include:_spf.google.com include:spf.envloped.com ~all"},
+			"_dmarc.example.com": {"v=DMARC1; p=reject; rua=mailto:dmarc@example.com"},
+		},
+		mx: map[string][]*net.MX{
+			"bounces.example.com": {{Host: "feedback.envloped.com.", Pref: 10}},
+		},
+	}
+
+	result, err := client.Domains.CheckDNS("dom_123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []DNSCheckStatus{DNSCheckOK, DNSCheckMismatch, DNSCheckOK, DNSCheckOK, DNSCheckOK, DNSCheckMissing}
+	if len(result.Records) != len(want) {
+		t.Fatalf("expected %d checks, got %+v", len(want), result.Records)
+	}
+	for i, status := range want {
+		if got := result.Records[i]; got.Status != status {
+			t.Errorf("record %s: expected %s, got %s (found %v)", got.Record.Name, status, got.Status, got.Found)
+		}
+	}
+	if result.OK() || len(result.Problems()) != 2 {
+		t.Errorf("expected 2 problems, got %+v", result.Problems())
+	}
+	if found := result.Records[1].Found; len(found) != 1 || found[0] != "ev2.dkim.other.com" {
+		t.Errorf("expected found value to be reported, got %v", found)
+	}
+}
+
+func TestCheckDNSRecord(t *testing.T) {
+	t.Parallel()
+
+	timeout := &net.DNSError{Err: "i/o timeout", Name: "example.com", IsTimeout: true}
+	tests := []struct {
+		name     string
+		record   DNSRecord
+		resolver *fakeResolver
+		want     DNSCheckStatus
+	}{
+		{
+			name:     "missing spf",
+			record:   DNSRecord{Purpose: DNSRecordSPF, Type: "TXT", Name: "example.com", Value: "v=spf1 include:spf.envloped.com ~all"},
+			resolver: &fakeResolver{txt: map[string][]string{"example.com": {"v=spf1 include:_spf.google.com ~all"}}},
+			want:     DNSCheckMismatch,
+		},
+		{
+			name:     "dkim txt with whitespace",
+			record:   DNSRecord{Purpose: DNSRecordDKIM, Type: "TXT", Name: "k._domainkey.example.com", Value: "v=DKIM1; k=rsa; p=MIGf"},
+			resolver: &fakeResolver{txt: map[string][]string{"k._domainkey.example.com": {"v=DKIM1;k=rsa;p=MIGf"}}},
+			want:     DNSCheckOK,
+		},
+		{
+			name:     "lookup error",
+			record:   DNSRecord{Type: "TXT", Name: "example.com", Value: "x"},
+			resolver: &fakeResolver{err: timeout},
+			want:     DNSCheckError,
+		},
+		{
+			name:     "unsupported type",
+			record:   DNSRecord{Type: "SRV", Name: "example.com"},
+			resolver: &fakeResolver{},
+			want:     DNSCheckError,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			check := checkDNSRecord(context.Background(), tt.resolver, tt.record)
+			if check.Status != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, check.Status)
+			}
+			if tt.want == DNSCheckError && check.Err == nil {
+				t.Error("expected error to be set")
+			}
+		})
+	}
+
+	check := checkDNSRecord(context.Background(), &fakeResolver{err: timeout}, DNSRecord{Type: "TXT", Name: "example.com"})
+	if !errors.Is(check.Err, timeout) {
+		t.Errorf("expected lookup error, got %v", check.Err)
+	}
+}
+
+func TestDomains_CheckDNS_MissingID(t *testing.T) {
+	t.Parallel()
+
+	if _, err := NewClient("key").Domains.CheckDNS(""); err == nil {
+		t.Error("expected error for missing domain id")
+	}
+}
@@ -0,0 +1,259 @@
+package envloped
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDomainsStats_Success(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("expected GET, got %s", r.Method)
+		}
+		if r.URL.Path != "/v1/domains/dom_123/stats" {
+			t.Errorf("expected path /v1/domains/dom_123/stats, got %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("period"); got != "30d" {
+			t.Errorf("expected period %q, got %q", "30d", got)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(DomainStats{
+			DomainID:      "dom_123",
+			Period:        StatsPeriodMonth,
+			Sent:          1000,
+			Bounced:       25,
+			BounceRate:    0.025,
+			ComplaintRate: 0.001,
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	stats, err := client.Domains.Stats("dom_123", StatsPeriodMonth)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stats.BounceRate != 0.025 {
+		t.Errorf("expected bounce rate 0.025, got %v", stats.BounceRate)
+	}
+	if stats.Sent != 1000 {
+		t.Errorf("expected 1000 sent, got %d", stats.Sent)
+	}
+}
+
+func TestDomainsStats_MissingID(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("key")
+	if _, err := client.Domains.Stats("", StatsPeriodDay); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func TestDomainsReturnPath(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/domains/dom_123/return-path" {
+			t.Errorf("expected path /v1/domains/dom_123/return-path, got %s", r.URL.Path)
+		}
+
+		resp := ReturnPath{
+			Domain: "bounces.example.com",
+			Record: DNSRecord{Type: "CNAME", Name: "bounces.example.com", Value: "feedback.envloped.com"},
+		}
+		switch r.Method {
+		case http.MethodPut:
+			var body map[string]string
+			json.NewDecoder(r.Body).Decode(&body)
+			if body["subdomain"] != "bounces" {
+				t.Errorf("expected subdomain %q, got %q", "bounces", body["subdomain"])
+			}
+		case http.MethodGet:
+			resp.Verified = true
+			resp.Record.Verified = true
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+
+	rp, err := client.Domains.SetReturnPath("dom_123", "bounces")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rp.Verified || rp.Record.Type != "CNAME" {
+		t.Errorf("unexpected return path: %+v", rp)
+	}
+
+	rp, err = client.Domains.GetReturnPath("dom_123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !rp.Verified {
+		t.Error("expected return path to be verified")
+	}
+
+	if _, err := client.Domains.SetReturnPath("dom_123", "bounces@example.com"); err == nil {
+		t.Error("expected error for invalid subdomain")
+	}
+}
+
+func TestDomains_CreateAndVerify(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/domains":
+			var req CreateDomainRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			if req.Name != "mail.example.com" {
+				t.Errorf("expected name %q, got %q", "mail.example.com", req.Name)
+			}
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(Domain{
+				ID:     "dom_123",
+				Name:   req.Name,
+				Status: DomainStatusPending,
+				Records: []DNSRecord{
+					{Purpose: DNSRecordDKIM, Type: "CNAME", Name: "ev1._domainkey.mail.example.com", Value: "ev1.dkim.envloped.com"},
+					{Purpose: DNSRecordSPF, Type: "TXT", Name: "mail.example.com", Value: "v=spf1 include:spf.envloped.com ~all"},
+				},
+			})
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/domains/dom_123/verify":
+			json.NewEncoder(w).Encode(Domain{ID: "dom_123", Status: DomainStatusVerified})
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/domains":
+			json.NewEncoder(w).Encode(map[string]interface{}{"data": []Domain{{ID: "dom_123"}}})
+		case r.Method == http.MethodDelete && r.URL.Path == "/v1/domains/dom_123":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+
+	domain, err := client.Domains.Create(&CreateDomainRequest{Name: "mail.example.com"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(domain.Records) != 2 || domain.Records[0].Purpose != DNSRecordDKIM {
+		t.Errorf("unexpected records: %+v", domain.Records)
+	}
+
+	domain, err = client.Domains.Verify("dom_123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if domain.Status != DomainStatusVerified {
+		t.Errorf("expected verified status, got %q", domain.Status)
+	}
+
+	domains, err := client.Domains.List()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(domains) != 1 {
+		t.Errorf("expected 1 domain, got %d", len(domains))
+	}
+
+	if err := client.Domains.Delete("dom_123"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestDomains_Validation(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("key")
+	if _, err := client.Domains.Create(&CreateDomainRequest{}); err == nil {
+		t.Error("expected error for missing name")
+	}
+	if _, err := client.Domains.Get(""); err == nil {
+		t.Error("expected error for empty domain id")
+	}
+	if err := client.Domains.Delete(""); err == nil {
+		t.Error("expected error for empty domain id")
+	}
+}
+
+func TestDomainsWaitForVerification(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/domains/dom_123/verify" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		status := DomainStatusPending
+		switch atomic.AddInt32(&calls, 1) {
+		case 1:
+		case 2:
+			status = DomainStatusFailed
+		default:
+			status = DomainStatusVerified
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(Domain{ID: "dom_123", Status: status})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	domain, err := client.Domains.WaitForVerification(ctx, "dom_123", 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if domain.Status != DomainStatusVerified {
+		t.Errorf("expected status %q, got %q", DomainStatusVerified, domain.Status)
+	}
+	if n := atomic.LoadInt32(&calls); n != 3 {
+		t.Errorf("expected 3 polls, got %d", n)
+	}
+}
+
+func TestDomainsWaitForVerification_Deadline(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(Domain{ID: "dom_123", Status: DomainStatusPending})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	domain, err := client.Domains.WaitForVerification(ctx, "dom_123", 10*time.Millisecond)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if domain == nil || domain.Status != DomainStatusPending {
+		t.Errorf("expected the last state of the domain, got %+v", domain)
+	}
+
+	if _, err := client.Domains.WaitForVerification(context.Background(), "dom_123", 0); err == nil {
+		t.Error("expected error for non-positive poll interval")
+	}
+}
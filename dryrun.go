@@ -0,0 +1,126 @@
+package envloped
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+)
+
+// dryRunIDPrefix prefixes the synthetic IDs returned by dry runs, so they
+// cannot be mistaken for IDs assigned by the API.
+const dryRunIDPrefix = "dryrun_"
+
+// WithDryRun sets whether every send is a dry run. A dry run validates and
+// serializes the email like a real send, logs what would be sent at info
+// level if a logger is set, and returns a synthetic response without
+// calling the API. Checks that call the API, such as WithSuppressionCheck,
+// are skipped, and attachments read from a Reader are consumed but not
+// uploaded. Use it for staging environments and load tests. It applies to
+// Send and SendRaw. Returns the client for method chaining.
+func (c *Client) WithDryRun(enabled bool) *Client {
+	c.dryRun = enabled
+	return c
+}
+
+// WithDryRun makes the call a dry run, as if the client was configured with
+// Client.WithDryRun(true).
+func WithDryRun() RequestOption {
+	return func(o *requestOptions) {
+		o.dryRun = true
+	}
+}
+
+// skipAttachmentUploads returns params with the content of every attachment
+// read from a Reader consumed and replaced by a synthetic ID, as a dry run
+// stand-in for uploadAttachments. params itself is not modified.
+func skipAttachmentUploads(params *SendEmailRequest) (*SendEmailRequest, error) {
+	var out []Attachment
+	for i := range params.Attachments {
+		a := &params.Attachments[i]
+		if !a.streamed() || a.Reader == nil {
+			continue
+		}
+		if out == nil {
+			out = append([]Attachment(nil), params.Attachments...)
+		}
+
+		if _, err := io.Copy(io.Discard, a.Reader); err != nil {
+			return nil, fmt.Errorf("envloped: attachment %q: read failed: %w", a.Filename, err)
+		}
+		id, err := dryRunID()
+		if err != nil {
+			return nil, err
+		}
+		out[i].Reader = nil
+		out[i].ID = id
+	}
+	if out == nil {
+		return params, nil
+	}
+
+	cp := *params
+	cp.Attachments = out
+	return &cp, nil
+}
+
+// dryRunSend completes a dry run of the send req: it consumes the request
+// body, logs the request, and returns a synthetic response with one message
+// ID per copy. body is the value the request body was encoded from, if any;
+// it is logged only if WithLogRecipients(true) is set, since it contains
+// the recipients.
+func (c *Client) dryRunSend(req *http.Request, body any, copies int) (*SendEmailResponse, error) {
+	var size int64
+	if req.Body != nil {
+		n, err := io.Copy(io.Discard, req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("envloped: failed to read request body: %w", err)
+		}
+		size = n
+	}
+
+	resp := &SendEmailResponse{Success: true, DryRun: true}
+	var err error
+	if resp.MessageId, err = dryRunID(); err != nil {
+		return nil, err
+	}
+	for i := 0; i < copies; i++ {
+		id, err := dryRunID()
+		if err != nil {
+			return nil, err
+		}
+		resp.MessageIds = append(resp.MessageIds, id)
+	}
+
+	if c.logger != nil {
+		attrs := []slog.Attr{
+			slog.String("method", req.Method),
+			slog.String("path", req.URL.RequestURI()),
+			slog.Int64("bytes", size),
+			slog.String("message_id", resp.MessageId),
+		}
+		if key := req.Header.Get("Idempotency-Key"); key != "" {
+			attrs = append(attrs, slog.String("idempotency_key", key))
+		}
+		if c.logRecipients && body != nil {
+			if data, err := json.Marshal(body); err == nil {
+				attrs = append(attrs, slog.String("body", string(data)))
+			}
+		}
+		c.logger.LogAttrs(req.Context(), slog.LevelInfo, "envloped: dry run", attrs...)
+	}
+	return resp, nil
+}
+
+// dryRunID returns a random synthetic ID.
+func dryRunID() (string, error) {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("envloped: failed to generate dry run id: %w", err)
+	}
+	return dryRunIDPrefix + hex.EncodeToString(b), nil
+}
@@ -0,0 +1,119 @@
+package envloped
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestWithDryRun(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"success":true,"messageId":"msg_1"}`))
+	}))
+	defer server.Close()
+
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, nil))
+	client := newTestClient(t, server).
+		WithDryRun(true).
+		WithSuppressionCheck(true).
+		WithLogger(logger)
+
+	params := &SendEmailRequest{
+		From:           "sender@example.com",
+		To:             []string{"recipient@example.com"},
+		Subject:        "Hello",
+		Text:           "Hi",
+		IdempotencyKey: "order-1",
+		Attachments:    []Attachment{{Filename: "report.csv", Reader: strings.NewReader("a,b")}},
+	}
+	resp, err := client.Emails.Send(params)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Success || !resp.DryRun || !strings.HasPrefix(resp.MessageId, dryRunIDPrefix) {
+		t.Errorf("unexpected response %+v", resp)
+	}
+	if params.Attachments[0].Reader == nil {
+		t.Error("expected params not to be modified")
+	}
+
+	if _, err := client.Emails.SendRaw(strings.NewReader("From: sender@example.com\r\n\r\nHi")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if n := atomic.LoadInt32(&calls); n != 0 {
+		t.Errorf("expected no API calls, got %d", n)
+	}
+	out := logs.String()
+	for _, want := range []string{"envloped: dry run", "path=/v1/emails", "idempotency_key=order-1", "path=/v1/emails/raw"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected log to contain %q, got %s", want, out)
+		}
+	}
+	if strings.Contains(out, "recipient@example.com") {
+		t.Errorf("expected recipients not to be logged, got %s", out)
+	}
+
+	if _, err := client.Emails.Send(&SendEmailRequest{From: "sender@example.com"}); err == nil {
+		t.Error("expected validation to run in dry-run mode")
+	}
+}
+
+func TestWithDryRun_RequestOption(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"success":true,"messageId":"msg_1"}`))
+	}))
+	defer server.Close()
+
+	var logs bytes.Buffer
+	client := newTestClient(t, server).
+		WithLogger(slog.New(slog.NewTextHandler(&logs, nil))).
+		WithLogRecipients(true)
+
+	params := &SendEmailRequest{
+		From:    "sender@example.com",
+		Subject: "Hello",
+		Text:    "Hi",
+		Personalizations: []Personalization{
+			{To: []string{"a@example.com"}},
+			{To: []string{"b@example.com"}},
+		},
+	}
+	resp, err := client.Emails.SendWithContext(context.Background(), params, WithDryRun())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.DryRun || len(resp.MessageIds) != 2 {
+		t.Errorf("expected a synthetic id per copy, got %+v", resp)
+	}
+	if !strings.Contains(logs.String(), "a@example.com") {
+		t.Errorf("expected body to be logged, got %s", logs.String())
+	}
+	if n := atomic.LoadInt32(&calls); n != 0 {
+		t.Errorf("expected no API calls, got %d", n)
+	}
+
+	resp, err = client.Emails.Send(params)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.DryRun || atomic.LoadInt32(&calls) != 1 {
+		t.Errorf("expected a real send without the option, got %+v", resp)
+	}
+}
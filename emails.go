@@ -2,8 +2,15 @@ package envloped
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"net/mail"
+	"net/textproto"
+	"net/url"
+	"strings"
+	"time"
 )
 
 // SendEmailRequest is the request body for sending an email.
@@ -11,20 +18,254 @@ import (
 // See https://docs.envloped.com/api-reference/emails/send-email
 type SendEmailRequest struct {
 	// From is the sender email address (e.g., "hello@yourdomain.com" or "My App <hello@yourdomain.com>").
-	// The domain must be verified in your Envloped dashboard.
+	// The domain must be verified in your Envloped dashboard. Use Address.String
+	// to format an address with a display name.
 	From string `json:"from"`
 
-	// To is the list of recipient email addresses.
-	To []string `json:"to"`
+	// To is the list of recipient email addresses. Leave it empty when using
+	// Personalizations.
+	To []string `json:"to,omitempty"`
 
-	// Subject is the email subject line.
-	Subject string `json:"subject"`
+	// Cc is the list of carbon copy recipient addresses.
+	Cc []string `json:"cc,omitempty"`
+
+	// Bcc is the list of blind carbon copy recipient addresses. They are not
+	// visible to other recipients.
+	Bcc []string `json:"bcc,omitempty"`
 
-	// Html is the HTML body of the email. At least one of Html or Text must be provided.
+	// ReplyTo is the list of addresses replies should be sent to, if different from From.
+	ReplyTo []string `json:"replyTo,omitempty"`
+
+	// Subject is the email subject line. Required unless TemplateID is set,
+	// in which case it overrides the template's subject.
+	Subject string `json:"subject,omitempty"`
+
+	// Html is the HTML body of the email. At least one of Html or Text must be
+	// provided unless TemplateID is set.
 	Html string `json:"html,omitempty"`
 
-	// Text is the plain text body of the email. At least one of Html or Text must be provided.
+	// Text is the plain text body of the email. At least one of Html or Text must
+	// be provided unless TemplateID is set.
 	Text string `json:"text,omitempty"`
+
+	// AmpHtml is the AMP for Email body, a dynamic version of the email that
+	// supporting clients such as Gmail show instead of Html. Clients without
+	// AMP support fall back to Html, so Html is required with it. The sending
+	// domain must be registered with the mailbox providers for AMP.
+	AmpHtml string `json:"ampHtml,omitempty"`
+
+	// TemplateID renders the email from a stored template instead of Html and Text.
+	TemplateID string `json:"templateId,omitempty"`
+
+	// TemplateData holds the variables substituted into the template.
+	TemplateData map[string]any `json:"templateData,omitempty"`
+
+	// Personalizations sends an individualized copy of the email to each entry's
+	// recipients in a single request. When set, To must be empty.
+	Personalizations []Personalization `json:"personalizations,omitempty"`
+
+	// Attachments are files attached to the email.
+	Attachments []Attachment `json:"attachments,omitempty"`
+
+	// ReturnPath is the envelope sender (bounce) address, e.g. "bounces@mail.yourdomain.com".
+	// Its domain must be verified as a return-path domain in your account; the API
+	// rejects the send otherwise. If empty, the domain's configured return path is used.
+	ReturnPath string `json:"returnPath,omitempty"`
+
+	// IPPool is the name of the dedicated IP pool to send through.
+	// If empty, the account's default pool is used.
+	IPPool string `json:"ipPool,omitempty"`
+
+	// Headers are custom message headers (e.g., "X-Entity-Ref-ID", "List-Unsubscribe").
+	// Headers the SDK or API manage, such as From, To, and Subject, are rejected,
+	// as are headers set by another field of the request, such as Message-ID
+	// with MessageID. Header names are compared case-insensitively.
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// Tags label the email for filtering in Emails.List and analytics, e.g.
+	// "welcome" or "password-reset". They are included in the email's events.
+	Tags []string `json:"tags,omitempty"`
+
+	// Metadata holds arbitrary key-value pairs, such as internal order or user
+	// IDs, to correlate the email with your records. It is included in the
+	// email's events and webhook payloads.
+	Metadata map[string]string `json:"metadata,omitempty"`
+
+	// TrackOpens enables or disables the open tracking pixel for this email.
+	// If nil, the client default (see Client.WithTrackOpens) or the account
+	// setting applies. Use Bool to set it inline.
+	TrackOpens *bool `json:"trackOpens,omitempty"`
+
+	// TrackClicks enables or disables link rewriting for click tracking for
+	// this email. If nil, the client default (see Client.WithTrackClicks) or
+	// the account setting applies.
+	TrackClicks *bool `json:"trackClicks,omitempty"`
+
+	// Sandbox processes the email like any other send, including events and
+	// webhooks, but does not deliver it. Use Client.WithSandbox to enable it
+	// for every send, e.g. in staging environments.
+	Sandbox bool `json:"sandbox,omitempty"`
+
+	// ScheduledAt delays delivery until the given time. Scheduled emails
+	// have EmailStatusScheduled until they are sent, and can be moved with
+	// Emails.UpdateScheduled or canceled with Emails.Cancel. It must be in
+	// the future. If nil, the email is sent immediately.
+	ScheduledAt *time.Time `json:"scheduledAt,omitempty"`
+
+	// IdempotencyKey is sent as the Idempotency-Key header. Repeating a send with
+	// the same key within 24 hours returns the original response instead of
+	// sending the email again, so a send can be retried safely after a timeout.
+	// Use a value that is unique per logical email, such as a UUID or "order-42-receipt".
+	IdempotencyKey string `json:"-"`
+
+	// SuppressAutoReplies marks the email as automatically generated by setting
+	// the Auto-Submitted and X-Auto-Response-Suppress headers, so out-of-office
+	// and vacation responders do not reply to the From address.
+	SuppressAutoReplies bool `json:"-"`
+
+	// Importance flags the email in mail clients, e.g. ImportanceHigh for
+	// alerts. It is sent in the X-Priority, Importance, and X-MSMail-Priority
+	// headers. If empty, no priority headers are sent, which clients treat
+	// as normal importance. Reserve ImportanceHigh for urgent email, since
+	// overusing it can hurt deliverability.
+	Importance Importance `json:"-"`
+
+	// MessageID replaces the Message-ID header the API generates, e.g. to
+	// match bounces and replies against IDs from your own records. It must
+	// be globally unique, in the form "unique-part@yourdomain.com"; angle
+	// brackets are optional. It cannot be used with Personalizations, since
+	// every copy needs its own ID; set a Message-ID header per copy instead.
+	// SendEmailResponse.MessageId remains the ID to use with Emails.Get.
+	MessageID string `json:"-"`
+
+	// InReplyTo is the Message-ID of the email this one replies to, sent in
+	// the In-Reply-To header. Together with References, it makes mail clients
+	// show the email in the same thread. Angle brackets are optional.
+	InReplyTo string `json:"-"`
+
+	// References lists the Message-IDs of the thread, oldest first, sent in
+	// the References header. Use AsReplyTo to set it with InReplyTo.
+	References []string `json:"-"`
+
+	// UnsubscribeURL is an https URL that unsubscribes the recipient with a
+	// single POST request (RFC 8058). It is sent in the List-Unsubscribe and
+	// List-Unsubscribe-Post headers, which Gmail and Yahoo require for bulk mail.
+	// For per-recipient URLs, set these headers in each Personalization instead.
+	UnsubscribeURL string `json:"-"`
+
+	// UnsubscribeEmail is an address that unsubscribes the sender of any email
+	// sent to it. It is sent in the List-Unsubscribe header as a mailto link.
+	UnsubscribeEmail string `json:"-"`
+
+	// Preheader is the preview text inbox lists show next to the subject. It
+	// is inserted as hidden text at the top of Html before sending, so it does
+	// not appear in the opened email. Requires Html.
+	Preheader string `json:"-"`
+
+	// AutoText derives the plain text body from Html with HTMLToText before
+	// sending, when Text is empty. A plain text alternative improves
+	// deliverability for emails authored only in HTML.
+	AutoText bool `json:"-"`
+}
+
+// MarshalJSON implements json.Marshaler. Options that map to message headers
+// are sent to the API in the "headers" object.
+func (r SendEmailRequest) MarshalJSON() ([]byte, error) {
+	type alias SendEmailRequest
+	return json.Marshal(struct {
+		alias
+		Headers map[string]string `json:"headers,omitempty"`
+	}{
+		alias:   alias(r),
+		Headers: r.wireHeaders(),
+	})
+}
+
+// withAutoText returns params with Text derived from Html if AutoText is
+// set and Text is empty. params itself is not modified.
+func (r *SendEmailRequest) withAutoText() *SendEmailRequest {
+	if !r.AutoText || r.Text != "" || r.Html == "" {
+		return r
+	}
+	cp := *r
+	cp.Text = HTMLToText(r.Html)
+	return &cp
+}
+
+// wireHeaders returns the custom headers merged with the headers derived from
+// the request options, or nil if there are none.
+func (r *SendEmailRequest) wireHeaders() map[string]string {
+	var headers map[string]string
+	set := func(name, value string) {
+		if headers == nil {
+			headers = make(map[string]string, len(r.Headers))
+		}
+		headers[name] = value
+	}
+
+	for name, value := range r.Headers {
+		set(name, value)
+	}
+
+	if r.SuppressAutoReplies {
+		set("Auto-Submitted", "auto-generated")
+		set("X-Auto-Response-Suppress", "All")
+	}
+
+	if values, ok := importanceHeaders[r.Importance]; ok {
+		set("X-Priority", values[0])
+		set("Importance", values[1])
+		set("X-MSMail-Priority", values[2])
+	}
+	if r.MessageID != "" {
+		set("Message-ID", angleAddr(r.MessageID))
+	}
+	if r.InReplyTo != "" {
+		set("In-Reply-To", angleAddr(r.InReplyTo))
+	}
+	if len(r.References) > 0 {
+		refs := make([]string, len(r.References))
+		for i, id := range r.References {
+			refs[i] = angleAddr(id)
+		}
+		set("References", strings.Join(refs, " "))
+	}
+
+	var unsubscribe []string
+	if r.UnsubscribeEmail != "" {
+		unsubscribe = append(unsubscribe, "<mailto:"+r.UnsubscribeEmail+">")
+	}
+	if r.UnsubscribeURL != "" {
+		unsubscribe = append(unsubscribe, "<"+r.UnsubscribeURL+">")
+		set("List-Unsubscribe-Post", "List-Unsubscribe=One-Click")
+	}
+	if len(unsubscribe) > 0 {
+		set("List-Unsubscribe", strings.Join(unsubscribe, ", "))
+	}
+
+	return headers
+}
+
+// AsReplyTo threads the email under the email with the given Message-ID by
+// setting InReplyTo and adding it to References, e.g. to group status updates
+// under the original notification in the recipient's inbox. For a sent email,
+// pass SendEmailResponse.MessageId; for a received one,
+// InboundMessage.MessageID. Its own References are not known, so replies to
+// replies should also append those of the parent.
+func (r *SendEmailRequest) AsReplyTo(messageID string) *SendEmailRequest {
+	r.InReplyTo = messageID
+	for _, id := range r.References {
+		if strings.Trim(id, "<>") == strings.Trim(messageID, "<>") {
+			return r
+		}
+	}
+	r.References = append(r.References, messageID)
+	return r
+}
+
+// angleAddr returns a Message-ID in angle brackets.
+func angleAddr(id string) string {
+	return "<" + strings.Trim(id, "<>") + ">"
 }
 
 // SendEmailResponse is the response from a successful email send.
@@ -34,16 +275,239 @@ type SendEmailResponse struct {
 
 	// MessageId is the unique identifier for the sent email (SES Message ID).
 	MessageId string `json:"messageId"`
+
+	// MessageIds lists the ID of each personalized copy, in the order of the
+	// request's Personalizations. It is empty for other sends.
+	MessageIds []string `json:"messageIds,omitempty"`
+
+	// Sandbox reports whether the email was accepted in sandbox mode, in
+	// which case it is not delivered.
+	Sandbox bool `json:"sandbox,omitempty"`
+
+	// DryRun reports whether the send was a dry run, in which case the API
+	// was not called and the message IDs are synthetic.
+	DryRun bool `json:"-"`
+
+	// Relayed reports whether the email was submitted to the SMTP relay by
+	// an SMTPFallback, in which case MessageId is its Message-ID header.
+	Relayed bool `json:"-"`
+}
+
+// EmailStatus is the delivery state of a sent email.
+type EmailStatus string
+
+// Email delivery statuses.
+const (
+	EmailStatusScheduled EmailStatus = "scheduled"
+	EmailStatusCanceled  EmailStatus = "canceled"
+	EmailStatusQueued    EmailStatus = "queued"
+	EmailStatusSent      EmailStatus = "sent"
+	EmailStatusDelivered EmailStatus = "delivered"
+	EmailStatusBounced   EmailStatus = "bounced"
+	EmailStatusComplaint EmailStatus = "complained"
+	EmailStatusFailed    EmailStatus = "failed"
+)
+
+// Done reports whether the email has reached a terminal state, after which
+// its status no longer changes except for a later complaint.
+func (s EmailStatus) Done() bool {
+	switch s {
+	case EmailStatusCanceled, EmailStatusDelivered, EmailStatusBounced, EmailStatusComplaint, EmailStatusFailed:
+		return true
+	}
+	return false
+}
+
+// Email is a sent email as recorded in the send log.
+type Email struct {
+	// ID is the unique identifier of the email (same as SendEmailResponse.MessageId).
+	ID string `json:"id"`
+
+	// From is the sender address.
+	From string `json:"from"`
+
+	// To is the list of recipient addresses.
+	To []string `json:"to"`
+
+	// Subject is the email subject line.
+	Subject string `json:"subject"`
+
+	// Tags are the tags the email was sent with.
+	Tags []string `json:"tags,omitempty"`
+
+	// Metadata holds the key-value pairs the email was sent with.
+	Metadata map[string]string `json:"metadata,omitempty"`
+
+	// Status is the current delivery status.
+	Status EmailStatus `json:"status"`
+
+	// CreatedAt is when the email was accepted by the API.
+	CreatedAt time.Time `json:"createdAt"`
+
+	// ScheduledAt is when a scheduled email is to be sent, or nil if it was
+	// sent immediately.
+	ScheduledAt *time.Time `json:"scheduledAt,omitempty"`
+
+	// SentAt is when the email was handed off for delivery, or nil if it has not been sent yet.
+	SentAt *time.Time `json:"sentAt,omitempty"`
+
+	// DeliveredAt is when the recipient server accepted the email, or nil if it has not been delivered.
+	DeliveredAt *time.Time `json:"deliveredAt,omitempty"`
+
+	// UpdatedAt is when the email's status last changed.
+	UpdatedAt time.Time `json:"updatedAt"`
+
+	// LastEvent is the most recent event recorded for the email, if any.
+	LastEvent *Event `json:"lastEvent,omitempty"`
+}
+
+// EmailFilter narrows down the emails returned from the send log.
+// Zero-valued fields are not applied.
+type EmailFilter struct {
+	// Status only matches emails with this delivery status.
+	Status EmailStatus
+
+	// Since only matches emails created at or after this time.
+	Since time.Time
+
+	// Until only matches emails created before this time.
+	Until time.Time
+
+	// Tag only matches emails carrying this tag.
+	Tag string
+
+	// Recipient only matches emails sent to this address.
+	Recipient string
+
+	// Metadata only matches emails whose metadata contains all of these
+	// key-value pairs.
+	Metadata map[string]string
+}
+
+// values encodes the filter as URL query parameters.
+func (f *EmailFilter) values() url.Values {
+	q := url.Values{}
+	if f == nil {
+		return q
+	}
+	if f.Status != "" {
+		q.Set("status", string(f.Status))
+	}
+	setTimeRange(q, f.Since, f.Until)
+	if f.Tag != "" {
+		q.Set("tag", f.Tag)
+	}
+	if f.Recipient != "" {
+		q.Set("recipient", f.Recipient)
+	}
+	for k, v := range f.Metadata {
+		q.Set("metadata["+k+"]", v)
+	}
+	return q
+}
+
+// ListEmailsOptions configures a page of results from Emails.List.
+// Zero-valued fields are not applied.
+type ListEmailsOptions struct {
+	ListOptions
+
+	// Status only matches emails with this delivery status.
+	Status EmailStatus
+
+	// Tag only matches emails carrying this tag.
+	Tag string
+
+	// Recipient only matches emails sent to this address.
+	Recipient string
+
+	// Metadata only matches emails whose metadata contains all of these
+	// key-value pairs.
+	Metadata map[string]string
 }
 
+// values encodes the options as URL query parameters.
+func (o *ListEmailsOptions) values() url.Values {
+	q := (&EmailFilter{
+		Status:    o.Status,
+		Since:     o.Since,
+		Until:     o.Until,
+		Tag:       o.Tag,
+		Recipient: o.Recipient,
+		Metadata:  o.Metadata,
+	}).values()
+	for k, v := range o.ListOptions.values() {
+		q[k] = v
+	}
+	return q
+}
+
+// EmailPage is a single page of the send log, newest first.
+type EmailPage = Page[Email]
+
 // EmailsSvc defines the interface for the email sending service.
 // This interface can be mocked in consumer tests.
 type EmailsSvc interface {
 	// Send sends an email with the given parameters.
-	Send(params *SendEmailRequest) (*SendEmailResponse, error)
+	Send(params *SendEmailRequest, opts ...RequestOption) (*SendEmailResponse, error)
 
 	// SendWithContext sends an email using the provided context for cancellation and deadlines.
-	SendWithContext(ctx context.Context, params *SendEmailRequest) (*SendEmailResponse, error)
+	SendWithContext(ctx context.Context, params *SendEmailRequest, opts ...RequestOption) (*SendEmailResponse, error)
+
+	// SendWithResponse sends an email and also returns the metadata of the
+	// API response, such as its headers and request ID.
+	SendWithResponse(ctx context.Context, params *SendEmailRequest, opts ...RequestOption) (*SendEmailResponse, *ResponseMeta, error)
+
+	// SendRaw sends a pre-built RFC 5322 message, read from message.
+	SendRaw(message io.Reader, opts ...RequestOption) (*SendEmailResponse, error)
+
+	// SendRawWithContext sends a pre-built RFC 5322 message using the provided context.
+	SendRawWithContext(ctx context.Context, message io.Reader, opts ...RequestOption) (*SendEmailResponse, error)
+
+	// Get retrieves a sent email by its message ID.
+	Get(messageID string) (*Email, error)
+
+	// GetWithContext retrieves a sent email using the provided context.
+	GetWithContext(ctx context.Context, messageID string) (*Email, error)
+
+	// WaitForStatus polls a sent email every pollInterval until it reaches
+	// target or a terminal status, or ctx is done.
+	WaitForStatus(ctx context.Context, messageID string, target EmailStatus, pollInterval time.Duration) (*Email, error)
+
+	// ListEvents returns the delivery timeline of a sent email, oldest first.
+	ListEvents(messageID string) ([]Event, error)
+
+	// ListEventsWithContext returns the delivery timeline using the provided context.
+	ListEventsWithContext(ctx context.Context, messageID string) ([]Event, error)
+
+	// List returns a page of sent emails matching opts.
+	List(opts *ListEmailsOptions) (*EmailPage, error)
+
+	// ListWithContext returns a page of sent emails using the provided context.
+	ListWithContext(ctx context.Context, opts *ListEmailsOptions) (*EmailPage, error)
+
+	// Export writes every email in the send log matching params to w as CSV or NDJSON.
+	Export(params *ExportEmailsRequest, w io.Writer) error
+
+	// ExportWithContext exports the send log using the provided context.
+	ExportWithContext(ctx context.Context, params *ExportEmailsRequest, w io.Writer) error
+
+	// Cancel cancels a scheduled email that has not been sent yet.
+	Cancel(messageID string) (*Email, error)
+
+	// CancelWithContext cancels a scheduled email using the provided context.
+	CancelWithContext(ctx context.Context, messageID string) (*Email, error)
+
+	// UpdateScheduled moves a scheduled email to a new send time.
+	UpdateScheduled(messageID string, sendAt time.Time) (*Email, error)
+
+	// UpdateScheduledWithContext moves a scheduled email using the provided context.
+	UpdateScheduledWithContext(ctx context.Context, messageID string, sendAt time.Time) (*Email, error)
+
+	// Resend sends a previously sent email again.
+	Resend(messageID string, params *ResendEmailRequest, opts ...RequestOption) (*SendEmailResponse, error)
+
+	// ResendWithContext sends a previously sent email again using the provided context.
+	ResendWithContext(ctx context.Context, messageID string, params *ResendEmailRequest, opts ...RequestOption) (*SendEmailResponse, error)
 }
 
 // emailsSvcImpl implements EmailsSvc.
@@ -53,20 +517,64 @@ type emailsSvcImpl struct {
 
 // Send sends an email with the given parameters.
 // It validates required fields before making the API call.
-func (s *emailsSvcImpl) Send(params *SendEmailRequest) (*SendEmailResponse, error) {
-	return s.SendWithContext(context.Background(), params)
+func (s *emailsSvcImpl) Send(params *SendEmailRequest, opts ...RequestOption) (*SendEmailResponse, error) {
+	return s.SendWithContext(context.Background(), params, opts...)
 }
 
 // SendWithContext sends an email using the provided context.
-func (s *emailsSvcImpl) SendWithContext(ctx context.Context, params *SendEmailRequest) (*SendEmailResponse, error) {
+func (s *emailsSvcImpl) SendWithContext(ctx context.Context, params *SendEmailRequest, opts ...RequestOption) (*SendEmailResponse, error) {
+	o := newRequestOptions(opts)
+	ctx, cancel := o.context(ctx)
+	defer cancel()
+	dryRun := s.client.dryRun || o.dryRun
+
 	if err := validateSendEmailRequest(params); err != nil {
 		return nil, err
 	}
+	if s.client.strictValidation {
+		if err := validateAddresses(params); err != nil {
+			return nil, err
+		}
+	}
+	limits := s.client.limits.Load()
+	if err := limits.validate(params); err != nil {
+		return nil, err
+	}
+	// Size the bodies as they are sent, including derived text and the
+	// preheader block.
+	params = params.withAutoText()
+	params = params.withPreheader()
+	if err := validateMessageSize(params, limits.maxMessageSize()); err != nil {
+		return nil, err
+	}
+	if s.client.checkSuppressions && !dryRun {
+		if err := s.client.checkRecipients(ctx, params); err != nil {
+			return nil, err
+		}
+	}
+	var err error
+	if dryRun {
+		params, err = skipAttachmentUploads(params)
+	} else {
+		params, err = s.client.uploadAttachments(ctx, params)
+	}
+	if err != nil {
+		return nil, err
+	}
+	params = s.client.withTrackingDefaults(params)
+	params = s.client.withSandbox(params)
 
-	req, err := s.client.newRequest(ctx, http.MethodPost, "/v1/emails", params)
+	req, err := s.client.newRequest(ctx, http.MethodPost, "/v1/emails", "/v1/emails", params)
 	if err != nil {
 		return nil, fmt.Errorf("envloped: failed to create send email request: %w", err)
 	}
+	if params.IdempotencyKey != "" {
+		req.Header.Set("Idempotency-Key", params.IdempotencyKey)
+	}
+	o.apply(req)
+	if dryRun {
+		return s.client.dryRunSend(req, params, len(params.Personalizations))
+	}
 
 	var resp SendEmailResponse
 	if err := s.client.do(req, &resp); err != nil {
@@ -76,6 +584,152 @@ func (s *emailsSvcImpl) SendWithContext(ctx context.Context, params *SendEmailRe
 	return &resp, nil
 }
 
+// SendWithResponse sends an email and also returns the metadata of the API
+// response. The metadata is returned for error responses too; it is nil only
+// if no response was received (e.g., validation or network failures).
+func (s *emailsSvcImpl) SendWithResponse(ctx context.Context, params *SendEmailRequest, opts ...RequestOption) (*SendEmailResponse, *ResponseMeta, error) {
+	var meta ResponseMeta
+	resp, err := s.SendWithContext(CaptureResponseMeta(ctx, &meta), params, opts...)
+	if meta.StatusCode == 0 {
+		return resp, nil, err
+	}
+	return resp, &meta, err
+}
+
+// Get retrieves a sent email by its message ID.
+func (s *emailsSvcImpl) Get(messageID string) (*Email, error) {
+	return s.GetWithContext(context.Background(), messageID)
+}
+
+// GetWithContext retrieves a sent email using the provided context.
+func (s *emailsSvcImpl) GetWithContext(ctx context.Context, messageID string) (*Email, error) {
+	if messageID == "" {
+		return nil, fmt.Errorf("envloped: message id is required")
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodGet, "/v1/emails/{id}", emailPath(messageID, ""), nil)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to create get email request: %w", err)
+	}
+
+	var resp Email
+	if err := s.client.do(req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// WaitForStatus polls a sent email every pollInterval until its status is
+// target or a terminal status, or ctx is done, in which case the last state
+// of the email is returned with the context's error. Reaching a different
+// terminal status is not an error, e.g. a bounce while waiting for
+// EmailStatusDelivered; check the Status of the result. Bound the wait with
+// a context deadline.
+func (s *emailsSvcImpl) WaitForStatus(ctx context.Context, messageID string, target EmailStatus, pollInterval time.Duration) (*Email, error) {
+	if target == "" {
+		return nil, fmt.Errorf("envloped: target status is required")
+	}
+	if pollInterval <= 0 {
+		return nil, fmt.Errorf("envloped: poll interval must be positive")
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	var last *Email
+	for {
+		email, err := s.GetWithContext(ctx, messageID)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil && last != nil {
+				return last, ctxErr
+			}
+			return nil, err
+		}
+		if email.Status == target || email.Status.Done() {
+			return email, nil
+		}
+		last = email
+
+		select {
+		case <-ctx.Done():
+			return email, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// ListEvents returns the delivery timeline of a sent email, oldest first.
+func (s *emailsSvcImpl) ListEvents(messageID string) ([]Event, error) {
+	return s.ListEventsWithContext(context.Background(), messageID)
+}
+
+// ListEventsWithContext returns the delivery timeline using the provided context.
+// Each event carries its decoded payload, e.g. Bounce for bounced events.
+func (s *emailsSvcImpl) ListEventsWithContext(ctx context.Context, messageID string) ([]Event, error) {
+	if messageID == "" {
+		return nil, fmt.Errorf("envloped: message id is required")
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodGet, "/v1/emails/{id}/events", emailPath(messageID, "/events"), nil)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to create list email events request: %w", err)
+	}
+
+	var resp listResponse[Event]
+	if err := s.client.do(req, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Data, nil
+}
+
+// List returns a page of sent emails matching opts.
+func (s *emailsSvcImpl) List(opts *ListEmailsOptions) (*EmailPage, error) {
+	return s.ListWithContext(context.Background(), opts)
+}
+
+// ListWithContext returns a page of sent emails using the provided context.
+// Pass the returned NextCursor as opts.Cursor to fetch the following page.
+func (s *emailsSvcImpl) ListWithContext(ctx context.Context, opts *ListEmailsOptions) (*EmailPage, error) {
+	if opts == nil {
+		opts = &ListEmailsOptions{}
+	}
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
+	return s.listPage(ctx, opts.values())
+}
+
+// listPage fetches a single page of the send log matching q.
+func (s *emailsSvcImpl) listPage(ctx context.Context, q url.Values) (*EmailPage, error) {
+	path := "/v1/emails"
+	if len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodGet, "/v1/emails", path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to create list emails request: %w", err)
+	}
+
+	var page EmailPage
+	if err := s.client.do(req, &page); err != nil {
+		return nil, err
+	}
+
+	return &page, nil
+}
+
+// emailPath returns the API path of a sent email, with optional suffix.
+func emailPath(messageID, suffix string) string {
+	return "/v1/emails/" + url.PathEscape(messageID) + suffix
+}
+
+// maxIdempotencyKeyLength is the longest Idempotency-Key the API accepts.
+const maxIdempotencyKeyLength = 255
+
 // validateSendEmailRequest checks that all required fields are present
 // before making the API call, so the user gets immediate client-side feedback.
 func validateSendEmailRequest(params *SendEmailRequest) error {
@@ -83,16 +737,212 @@ func validateSendEmailRequest(params *SendEmailRequest) error {
 		return fmt.Errorf("envloped: send email params must not be nil")
 	}
 	if params.From == "" {
-		return fmt.Errorf("envloped: from address is required")
+		return fieldError("from", "required", "from address is required")
+	}
+	subjectPerCopy := false
+	if len(params.Personalizations) > 0 {
+		if len(params.To) > 0 {
+			return fieldError("to", "conflict", "to must be empty when personalizations are set")
+		}
+		var err error
+		if subjectPerCopy, err = validatePersonalizations(params.Personalizations); err != nil {
+			return err
+		}
+	} else if len(params.To) == 0 {
+		return fieldError("to", "required", "at least one to address is required")
+	}
+	if params.TemplateID != "" {
+		if params.Html != "" || params.Text != "" {
+			return fieldError("templateId", "conflict", "html and text body must be empty when a template id is set")
+		}
+	} else {
+		if len(params.TemplateData) > 0 {
+			return fieldError("templateData", "conflict", "template data requires a template id")
+		}
+		if params.Subject == "" && !subjectPerCopy {
+			return fieldError("subject", "required", "subject is required")
+		}
+		if params.Html == "" && params.Text == "" {
+			return fieldError("html", "required", "html or text body is required")
+		}
+	}
+	for i, addr := range params.Cc {
+		if _, err := mail.ParseAddress(addr); err != nil {
+			return fieldError(fmt.Sprintf("cc[%d]", i), "invalid", "invalid cc address %q", addr)
+		}
+	}
+	for i, addr := range params.Bcc {
+		if _, err := mail.ParseAddress(addr); err != nil {
+			return fieldError(fmt.Sprintf("bcc[%d]", i), "invalid", "invalid bcc address %q", addr)
+		}
+	}
+	for i, addr := range params.ReplyTo {
+		if _, err := mail.ParseAddress(addr); err != nil {
+			return fieldError(fmt.Sprintf("replyTo[%d]", i), "invalid", "invalid reply-to address %q", addr)
+		}
+	}
+	if len(params.IdempotencyKey) > maxIdempotencyKeyLength {
+		return fieldError("idempotencyKey", "too_long", "idempotency key must be at most %d characters", maxIdempotencyKeyLength)
+	}
+	if strings.ContainsAny(params.IdempotencyKey, "\r\n") {
+		return fieldError("idempotencyKey", "invalid", "idempotency key must not contain line breaks")
+	}
+	if err := validateHeaders("headers", params.Headers); err != nil {
+		return err
+	}
+	if !params.Importance.valid() {
+		return fieldError("importance", "invalid", "unknown importance %q", params.Importance)
+	}
+	if params.Importance != "" {
+		if err := validateHeaderConflict(params.Headers, "the importance", "X-Priority", "Importance", "X-MSMail-Priority"); err != nil {
+			return err
+		}
+	}
+	if params.SuppressAutoReplies {
+		if err := validateHeaderConflict(params.Headers, "suppressing auto replies", "Auto-Submitted", "X-Auto-Response-Suppress"); err != nil {
+			return err
+		}
 	}
-	if len(params.To) == 0 {
-		return fmt.Errorf("envloped: at least one to address is required")
+	if params.MessageID != "" {
+		if err := validateHeaderConflict(params.Headers, "the message id", "Message-ID"); err != nil {
+			return err
+		}
+		if len(params.Personalizations) > 0 {
+			return fieldError("messageId", "conflict", "message id cannot be set with personalizations")
+		}
+		if !strings.Contains(params.MessageID, "@") {
+			return fieldError("messageId", "invalid", "message id %q must have the form unique-part@domain", params.MessageID)
+		}
+		if err := validateMessageID("messageId", params.MessageID); err != nil {
+			return err
+		}
 	}
-	if params.Subject == "" {
-		return fmt.Errorf("envloped: subject is required")
+	if err := validateMessageID("inReplyTo", params.InReplyTo); err != nil {
+		return err
 	}
-	if params.Html == "" && params.Text == "" {
-		return fmt.Errorf("envloped: html or text body is required")
+	if params.InReplyTo != "" {
+		if err := validateHeaderConflict(params.Headers, "in reply to", "In-Reply-To"); err != nil {
+			return err
+		}
+	}
+	if len(params.References) > 0 {
+		if err := validateHeaderConflict(params.Headers, "references", "References"); err != nil {
+			return err
+		}
+	}
+	for i, id := range params.References {
+		if id == "" {
+			return fieldError(fmt.Sprintf("references[%d]", i), "required", "reference %d must not be empty", i)
+		}
+		if err := validateMessageID(fmt.Sprintf("references[%d]", i), id); err != nil {
+			return err
+		}
+	}
+	if params.AmpHtml != "" && params.Html == "" {
+		return fieldError("ampHtml", "conflict", "amp html body requires an html body")
+	}
+	if params.Preheader != "" && params.Html == "" {
+		return fieldError("preheader", "conflict", "preheader requires an html body")
+	}
+	if params.ScheduledAt != nil && !params.ScheduledAt.IsZero() && !params.ScheduledAt.After(time.Now()) {
+		return fieldError("scheduledAt", "invalid", "scheduled send time %s is not in the future", params.ScheduledAt.Format(time.RFC3339))
+	}
+	if err := validateUnsubscribe(params); err != nil {
+		return err
+	}
+	for i, tag := range params.Tags {
+		if tag == "" {
+			return fieldError(fmt.Sprintf("tags[%d]", i), "required", "tag %d must not be empty", i)
+		}
+	}
+	for key := range params.Metadata {
+		if key == "" {
+			return fieldError("metadata", "invalid", "metadata keys must not be empty")
+		}
+	}
+	if params.ReturnPath != "" {
+		if addr, err := mail.ParseAddress(params.ReturnPath); err != nil || addr.Name != "" || addr.Address != params.ReturnPath {
+			return fieldError("returnPath", "invalid", "return path %q must be a bare email address", params.ReturnPath)
+		}
+	}
+	return nil
+}
+
+// validateUnsubscribe checks the unsubscribe options of params.
+func validateUnsubscribe(params *SendEmailRequest) error {
+	if params.UnsubscribeURL == "" && params.UnsubscribeEmail == "" {
+		return nil
+	}
+	for name := range params.Headers {
+		if canonical := textproto.CanonicalMIMEHeaderKey(name); canonical == "List-Unsubscribe" || canonical == "List-Unsubscribe-Post" {
+			return fieldError("headers."+name, "conflict", "header %q cannot be combined with the unsubscribe options", name)
+		}
+	}
+	if params.UnsubscribeURL != "" {
+		u, err := url.Parse(params.UnsubscribeURL)
+		if err != nil || u.Scheme != "https" || u.Host == "" || strings.ContainsAny(params.UnsubscribeURL, "<>, \r\n") {
+			return fieldError("unsubscribeUrl", "invalid", "unsubscribe url %q must be an absolute https URL", params.UnsubscribeURL)
+		}
+	}
+	if params.UnsubscribeEmail != "" {
+		if addr, err := mail.ParseAddress(params.UnsubscribeEmail); err != nil || addr.Name != "" || addr.Address != params.UnsubscribeEmail {
+			return fieldError("unsubscribeEmail", "invalid", "unsubscribe email %q must be a bare email address", params.UnsubscribeEmail)
+		}
+	}
+	return nil
+}
+
+// validateHeaderConflict returns a conflict error if headers sets one of
+// names, compared case-insensitively, since the option of the request
+// described by option sets it too.
+func validateHeaderConflict(headers map[string]string, option string, names ...string) error {
+	for name := range headers {
+		canonical := textproto.CanonicalMIMEHeaderKey(name)
+		for _, n := range names {
+			if canonical == textproto.CanonicalMIMEHeaderKey(n) {
+				return fieldError("headers."+name, "conflict", "header %q cannot be combined with %s", name, option)
+			}
+		}
+	}
+	return nil
+}
+
+// validateMessageID checks that id can be sent as a Message-ID in a header.
+// An empty id is valid.
+func validateMessageID(field, id string) error {
+	if strings.ContainsAny(strings.Trim(id, "<>"), "<> \t\r\n") {
+		return fieldError(field, "invalid", "invalid message id %q", id)
+	}
+	return nil
+}
+
+// reservedHeaders are set from SendEmailRequest fields or by the API and
+// cannot be overridden with custom headers.
+var reservedHeaders = map[string]bool{
+	"From":                      true,
+	"To":                        true,
+	"Cc":                        true,
+	"Bcc":                       true,
+	"Subject":                   true,
+	"Reply-To":                  true,
+	"Content-Type":              true,
+	"Content-Transfer-Encoding": true,
+	"Mime-Version":              true,
+}
+
+// validateHeaders checks custom header names and values. field is the name
+// of the headers field in validation errors.
+func validateHeaders(field string, headers map[string]string) error {
+	for name, value := range headers {
+		if name == "" || strings.ContainsAny(name, ": \t\r\n") {
+			return fieldError(field+"."+name, "invalid", "invalid header name %q", name)
+		}
+		if reservedHeaders[textproto.CanonicalMIMEHeaderKey(name)] {
+			return fieldError(field+"."+name, "reserved", "header %q is reserved and cannot be set directly", name)
+		}
+		if strings.ContainsAny(value, "\r\n") {
+			return fieldError(field+"."+name, "invalid", "header %q value must not contain line breaks", name)
+		}
 	}
 	return nil
 }
@@ -0,0 +1,104 @@
+package envloped
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ExportFormat is the output format of an email export.
+type ExportFormat string
+
+const (
+	// ExportFormatCSV writes a header row followed by one row per email.
+	ExportFormatCSV ExportFormat = "csv"
+
+	// ExportFormatNDJSON writes one JSON-encoded Email per line.
+	ExportFormatNDJSON ExportFormat = "ndjson"
+)
+
+// ExportEmailsRequest configures an export of the send log.
+type ExportEmailsRequest struct {
+	EmailFilter
+
+	// Format is the output format. Defaults to ExportFormatCSV.
+	Format ExportFormat
+}
+
+// exportPageSize is the number of emails fetched per page during an export.
+const exportPageSize = 1000
+
+// Export writes every email matching params to w.
+func (s *emailsSvcImpl) Export(params *ExportEmailsRequest, w io.Writer) error {
+	return s.ExportWithContext(context.Background(), params, w)
+}
+
+// ExportWithContext writes every email matching params to w using the provided context.
+// Pages are fetched and written one at a time, so memory use stays constant
+// regardless of the size of the send log. If an error occurs part-way through,
+// everything exported up to that point has already been written to w.
+func (s *emailsSvcImpl) ExportWithContext(ctx context.Context, params *ExportEmailsRequest, w io.Writer) error {
+	if params == nil {
+		params = &ExportEmailsRequest{}
+	}
+	if w == nil {
+		return fmt.Errorf("envloped: export writer must not be nil")
+	}
+
+	var write func(page []Email) error
+	switch params.Format {
+	case ExportFormatCSV, "":
+		cw := csv.NewWriter(w)
+		if err := cw.Write([]string{"id", "from", "to", "subject", "status", "createdAt"}); err != nil {
+			return fmt.Errorf("envloped: failed to write export: %w", err)
+		}
+		write = func(page []Email) error {
+			for _, e := range page {
+				cw.Write([]string{
+					e.ID,
+					e.From,
+					strings.Join(e.To, ";"),
+					e.Subject,
+					string(e.Status),
+					e.CreatedAt.UTC().Format(time.RFC3339),
+				})
+			}
+			cw.Flush()
+			return cw.Error()
+		}
+	case ExportFormatNDJSON:
+		enc := json.NewEncoder(w)
+		write = func(page []Email) error {
+			for i := range page {
+				if err := enc.Encode(&page[i]); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	default:
+		return fmt.Errorf("envloped: unsupported export format %q", params.Format)
+	}
+
+	q := params.EmailFilter.values()
+	q.Set("limit", strconv.Itoa(exportPageSize))
+
+	for {
+		page, err := s.listPage(ctx, q)
+		if err != nil {
+			return err
+		}
+		if err := write(page.Items); err != nil {
+			return fmt.Errorf("envloped: failed to write export: %w", err)
+		}
+		if page.NextCursor == "" {
+			return nil
+		}
+		q.Set("cursor", page.NextCursor)
+	}
+}
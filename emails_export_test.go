@@ -0,0 +1,135 @@
+package envloped
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// newPagedEmailServer serves two pages of the send log and checks that
+// filters and cursors are passed through.
+func newPagedEmailServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	created := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/emails" {
+			t.Errorf("expected path /v1/emails, got %s", r.URL.Path)
+		}
+		q := r.URL.Query()
+		if got := q.Get("status"); got != "bounced" {
+			t.Errorf("expected status filter %q, got %q", "bounced", got)
+		}
+		if got := q.Get("since"); got != "2025-01-01T00:00:00Z" {
+			t.Errorf("expected since filter %q, got %q", "2025-01-01T00:00:00Z", got)
+		}
+
+		var page EmailPage
+		switch q.Get("cursor") {
+		case "":
+			page.Items = []Email{
+				{ID: "msg_1", From: "a@example.com", To: []string{"b@example.com", "c@example.com"}, Subject: "One", Status: EmailStatusBounced, CreatedAt: created},
+			}
+			page.NextCursor = "cur_2"
+		case "cur_2":
+			page.Items = []Email{
+				{ID: "msg_2", From: "a@example.com", To: []string{"d@example.com"}, Subject: "Two, again", Status: EmailStatusBounced, CreatedAt: created},
+			}
+		default:
+			t.Errorf("unexpected cursor %q", q.Get("cursor"))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(page)
+	}))
+}
+
+func TestExportEmails_CSV(t *testing.T) {
+	t.Parallel()
+
+	server := newPagedEmailServer(t)
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	var buf bytes.Buffer
+	err := client.Emails.Export(&ExportEmailsRequest{
+		EmailFilter: EmailFilter{
+			Status: EmailStatusBounced,
+			Since:  time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}, &buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to parse csv output: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("expected header and 2 rows, got %d records", len(records))
+	}
+	if records[0][0] != "id" {
+		t.Errorf("expected header row, got %v", records[0])
+	}
+	if records[1][2] != "b@example.com;c@example.com" {
+		t.Errorf("unexpected to column: %q", records[1][2])
+	}
+	if records[2][3] != "Two, again" {
+		t.Errorf("unexpected subject column: %q", records[2][3])
+	}
+	if records[2][5] != "2025-01-02T03:04:05Z" {
+		t.Errorf("unexpected createdAt column: %q", records[2][5])
+	}
+}
+
+func TestExportEmails_NDJSON(t *testing.T) {
+	t.Parallel()
+
+	server := newPagedEmailServer(t)
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	var buf bytes.Buffer
+	err := client.Emails.Export(&ExportEmailsRequest{
+		EmailFilter: EmailFilter{
+			Status: EmailStatusBounced,
+			Since:  time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		Format: ExportFormatNDJSON,
+	}, &buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var ids []string
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var e Email
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			t.Fatalf("failed to decode line %q: %v", scanner.Text(), err)
+		}
+		ids = append(ids, e.ID)
+	}
+	if len(ids) != 2 || ids[0] != "msg_1" || ids[1] != "msg_2" {
+		t.Errorf("unexpected exported ids: %v", ids)
+	}
+}
+
+func TestExportEmails_UnsupportedFormat(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("key")
+	err := client.Emails.Export(&ExportEmailsRequest{Format: "xml"}, &bytes.Buffer{})
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if !contains(err.Error(), "unsupported export format") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
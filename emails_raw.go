@@ -0,0 +1,50 @@
+package envloped
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// rawMessageContentType is the Content-Type of raw message sends.
+const rawMessageContentType = "message/rfc822"
+
+// SendRaw sends a pre-built RFC 5322 message, such as one assembled for
+// net/smtp. The sender and recipients are taken from the message's From,
+// To, Cc, and Bcc headers; the Bcc header is removed before delivery.
+func (s *emailsSvcImpl) SendRaw(message io.Reader, opts ...RequestOption) (*SendEmailResponse, error) {
+	return s.SendRawWithContext(context.Background(), message, opts...)
+}
+
+// SendRawWithContext sends a pre-built RFC 5322 message using the provided
+// context. The message is streamed to the API as-is, without client-side
+// validation.
+func (s *emailsSvcImpl) SendRawWithContext(ctx context.Context, message io.Reader, opts ...RequestOption) (*SendEmailResponse, error) {
+	if message == nil {
+		return nil, fmt.Errorf("envloped: raw message must not be nil")
+	}
+	o := newRequestOptions(opts)
+	ctx, cancel := o.context(ctx)
+	defer cancel()
+
+	path := "/v1/emails/raw"
+	if s.client.sandbox {
+		path += "?sandbox=true"
+	}
+	req, err := s.client.newStreamRequest(ctx, http.MethodPost, "/v1/emails/raw", path, message, rawMessageContentType)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to create raw send request: %w", err)
+	}
+	o.apply(req)
+	if s.client.dryRun || o.dryRun {
+		return s.client.dryRunSend(req, nil, 0)
+	}
+
+	var resp SendEmailResponse
+	if err := s.client.do(req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
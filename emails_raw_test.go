@@ -0,0 +1,67 @@
+package envloped
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const testRawMessage = "From: sender@example.com\r\n" +
+	"To: recipient@example.com\r\n" +
+	"Subject: Hello\r\n" +
+	"Content-Type: text/plain; charset=UTF-8\r\n" +
+	"\r\n" +
+	"Hi there\r\n"
+
+func TestSendRaw(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/emails/raw" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if ct := r.Header.Get("Content-Type"); ct != "message/rfc822" {
+			t.Errorf("expected Content-Type message/rfc822, got %q", ct)
+		}
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != testRawMessage {
+			t.Errorf("unexpected body %q", body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(SendEmailResponse{Success: true, MessageId: "msg_raw"})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	resp, err := client.Emails.SendRaw(strings.NewReader(testRawMessage))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.MessageId != "msg_raw" {
+		t.Errorf("expected message id msg_raw, got %q", resp.MessageId)
+	}
+}
+
+func TestSendRaw_Errors(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("key")
+	if _, err := client.Emails.SendRaw(nil); err == nil || !contains(err.Error(), "raw message must not be nil") {
+		t.Errorf("expected nil message error, got %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":"missing From header"}`))
+	}))
+	defer server.Close()
+
+	_, err := newTestClient(t, server).Emails.SendRaw(strings.NewReader("Subject: x\r\n\r\n"))
+	if !errors.Is(err, ErrValidation) {
+		t.Errorf("expected ErrValidation, got %v", err)
+	}
+}
@@ -0,0 +1,97 @@
+package envloped
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/mail"
+)
+
+// ResendEmailRequest is the request body for resending an email. Empty
+// fields keep the recipients of the original email.
+type ResendEmailRequest struct {
+	// To replaces the To recipients of the original email, e.g. with a
+	// corrected address.
+	To []string `json:"to,omitempty"`
+
+	// Cc replaces the Cc recipients of the original email.
+	Cc []string `json:"cc,omitempty"`
+
+	// Bcc replaces the Bcc recipients of the original email.
+	Bcc []string `json:"bcc,omitempty"`
+}
+
+// Resend sends a previously sent email again.
+func (s *emailsSvcImpl) Resend(messageID string, params *ResendEmailRequest, opts ...RequestOption) (*SendEmailResponse, error) {
+	return s.ResendWithContext(context.Background(), messageID, params, opts...)
+}
+
+// ResendWithContext sends a previously sent email again using the provided
+// context, with the same content, to its original recipients or to those in
+// params, which may be nil. The copy is a new email with its own message ID
+// and events. Pass WithIdempotencyKey to make retries safe.
+//
+// With WithSuppressionCheck, the recipients are checked as in Send. If
+// params.To is empty, the original To recipients are fetched with Get for
+// the check; the API does not return the original Cc and Bcc recipients,
+// so only those in params are checked.
+func (s *emailsSvcImpl) ResendWithContext(ctx context.Context, messageID string, params *ResendEmailRequest, opts ...RequestOption) (*SendEmailResponse, error) {
+	o := newRequestOptions(opts)
+	ctx, cancel := o.context(ctx)
+	defer cancel()
+
+	if messageID == "" {
+		return nil, fmt.Errorf("envloped: message id is required")
+	}
+	if params == nil {
+		params = &ResendEmailRequest{}
+	}
+	for _, field := range []struct {
+		name  string
+		addrs []string
+	}{{"to", params.To}, {"cc", params.Cc}, {"bcc", params.Bcc}} {
+		for i, addr := range field.addrs {
+			if _, err := mail.ParseAddress(addr); err != nil {
+				return nil, fieldError(fmt.Sprintf("%s[%d]", field.name, i), "invalid", "invalid %s address %q", field.name, addr)
+			}
+		}
+	}
+
+	dryRun := s.client.dryRun || o.dryRun
+	if s.client.checkSuppressions && !dryRun {
+		if err := s.checkResendRecipients(ctx, messageID, params); err != nil {
+			return nil, err
+		}
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodPost, "/v1/emails/{id}/resend", emailPath(messageID, "/resend"), params)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to create resend email request: %w", err)
+	}
+	o.apply(req)
+	if dryRun {
+		return s.client.dryRunSend(req, params, 0)
+	}
+
+	var resp SendEmailResponse
+	if err := s.client.do(req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// checkResendRecipients returns a *SuppressedError if any recipient of the
+// resend of messageID is suppressed, fetching the original To recipients if
+// params does not replace them.
+func (s *emailsSvcImpl) checkResendRecipients(ctx context.Context, messageID string, params *ResendEmailRequest) error {
+	to := params.To
+	if len(to) == 0 {
+		email, err := s.GetWithContext(ctx, messageID)
+		if err != nil {
+			return fmt.Errorf("envloped: failed to get email for suppression check: %w", err)
+		}
+		to = email.To
+	}
+	return s.client.checkRecipients(ctx, &SendEmailRequest{To: to, Cc: params.Cc, Bcc: params.Bcc})
+}
@@ -0,0 +1,114 @@
+package envloped
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+)
+
+func TestEmails_Resend(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		params   *ResendEmailRequest
+		wantBody map[string][]string
+	}{
+		{"original recipients", nil, map[string][]string{}},
+		{"new recipients", &ResendEmailRequest{To: []string{"Ada <ada@example.org>"}, Bcc: []string{"audit@example.com"}}, map[string][]string{
+			"to":  {"Ada <ada@example.org>"},
+			"bcc": {"audit@example.com"},
+		}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Path != "/v1/emails/msg_1/resend" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				if got := r.Header.Get("Idempotency-Key"); got != "resend-1" {
+					t.Errorf("expected idempotency key resend-1, got %q", got)
+				}
+				body := map[string][]string{}
+				json.NewDecoder(r.Body).Decode(&body)
+				if !reflect.DeepEqual(body, tt.wantBody) {
+					t.Errorf("expected body %v, got %v", tt.wantBody, body)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"success":true,"messageId":"msg_2"}`))
+			}))
+			defer server.Close()
+
+			client := newTestClient(t, server)
+			resp, err := client.Emails.Resend("msg_1", tt.params, WithIdempotencyKey("resend-1"))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.MessageId != "msg_2" {
+				t.Errorf("expected message id msg_2, got %q", resp.MessageId)
+			}
+		})
+	}
+}
+
+func TestEmails_ResendSuppressionCheck(t *testing.T) {
+	t.Parallel()
+
+	var resends int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/emails/msg_1":
+			w.Write([]byte(`{"id":"msg_1","to":["User <user@example.com>"]}`))
+		case "/v1/suppressions/check":
+			var body struct {
+				Emails []string `json:"emails"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			if !reflect.DeepEqual(body.Emails, []string{"user@example.com", "audit@example.com"}) {
+				t.Errorf("expected original and new recipients to be checked, got %v", body.Emails)
+			}
+			w.Write([]byte(`{"data":[{"email":"user@example.com","reason":"bounce"}]}`))
+		case "/v1/emails/msg_1/resend":
+			atomic.AddInt32(&resends, 1)
+			w.Write([]byte(`{"success":true,"messageId":"msg_2"}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server).WithSuppressionCheck(true)
+	_, err := client.Emails.Resend("msg_1", &ResendEmailRequest{Bcc: []string{"audit@example.com"}})
+	if !errors.Is(err, ErrSuppressed) {
+		t.Fatalf("expected ErrSuppressed, got %v", err)
+	}
+	if n := atomic.LoadInt32(&resends); n != 0 {
+		t.Errorf("expected no resend, got %d", n)
+	}
+}
+
+func TestEmails_ResendValidation(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("key")
+	if _, err := client.Emails.Resend("", nil); err == nil {
+		t.Error("expected error for missing message id")
+	}
+
+	_, err := client.Emails.Resend("msg_1", &ResendEmailRequest{Cc: []string{"ok@example.com", "not-an-address"}})
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected ValidationError, got %v", err)
+	}
+	if len(verr.Fields) != 1 || verr.Fields[0].Field != "cc[1]" || verr.Fields[0].Code != "invalid" {
+		t.Errorf("unexpected fields %+v", verr.Fields)
+	}
+}
@@ -0,0 +1,71 @@
+package envloped
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// updateScheduleRequest is the request body for rescheduling an email.
+type updateScheduleRequest struct {
+	ScheduledAt time.Time `json:"scheduledAt"`
+}
+
+// Cancel cancels a scheduled email that has not been sent yet.
+func (s *emailsSvcImpl) Cancel(messageID string) (*Email, error) {
+	return s.CancelWithContext(context.Background(), messageID)
+}
+
+// CancelWithContext cancels a scheduled email using the provided context.
+// The returned email has EmailStatusCanceled. The API rejects the
+// cancellation once the email has been sent.
+func (s *emailsSvcImpl) CancelWithContext(ctx context.Context, messageID string) (*Email, error) {
+	if messageID == "" {
+		return nil, fmt.Errorf("envloped: message id is required")
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodPost, "/v1/emails/{id}/cancel", emailPath(messageID, "/cancel"), nil)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to create cancel email request: %w", err)
+	}
+
+	var resp Email
+	if err := s.client.do(req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// UpdateScheduled moves a scheduled email to a new send time.
+func (s *emailsSvcImpl) UpdateScheduled(messageID string, sendAt time.Time) (*Email, error) {
+	return s.UpdateScheduledWithContext(context.Background(), messageID, sendAt)
+}
+
+// UpdateScheduledWithContext moves a scheduled email to a new send time
+// using the provided context, keeping its message ID, content, and
+// recipients. sendAt must be in the future. The returned email reflects the
+// updated ScheduledAt. The API rejects the change once the email has been
+// sent or canceled.
+func (s *emailsSvcImpl) UpdateScheduledWithContext(ctx context.Context, messageID string, sendAt time.Time) (*Email, error) {
+	if messageID == "" {
+		return nil, fmt.Errorf("envloped: message id is required")
+	}
+	if !sendAt.After(time.Now()) {
+		return nil, fmt.Errorf("envloped: scheduled send time must be in the future")
+	}
+
+	body := &updateScheduleRequest{ScheduledAt: sendAt.UTC()}
+	req, err := s.client.newRequest(ctx, http.MethodPatch, "/v1/emails/{id}/schedule", emailPath(messageID, "/schedule"), body)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to create update schedule request: %w", err)
+	}
+
+	var resp Email
+	if err := s.client.do(req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
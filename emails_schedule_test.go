@@ -0,0 +1,89 @@
+package envloped
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestEmails_Schedule(t *testing.T) {
+	t.Parallel()
+
+	sendAt := time.Now().Add(2 * time.Hour).Truncate(time.Second)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/emails":
+			var req SendEmailRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			if req.ScheduledAt == nil || !req.ScheduledAt.Equal(sendAt) {
+				t.Errorf("expected scheduledAt %v, got %v", sendAt, req.ScheduledAt)
+			}
+			w.Write([]byte(`{"success":true,"messageId":"msg_1"}`))
+		case r.Method == http.MethodPatch && r.URL.Path == "/v1/emails/msg_1/schedule":
+			var body map[string]string
+			json.NewDecoder(r.Body).Decode(&body)
+			if body["scheduledAt"] != sendAt.Add(time.Hour).UTC().Format(time.RFC3339) {
+				t.Errorf("unexpected schedule body %v", body)
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"id": "msg_1", "status": "scheduled", "scheduledAt": body["scheduledAt"]})
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/emails/msg_1/cancel":
+			w.Write([]byte(`{"id":"msg_1","status":"canceled"}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	if _, err := client.Emails.Send(&SendEmailRequest{
+		From:        "hello@example.com",
+		To:          []string{"ada@example.com"},
+		Subject:     "Digest",
+		Text:        "This week",
+		ScheduledAt: &sendAt,
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	email, err := client.Emails.UpdateScheduled("msg_1", sendAt.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if email.Status != EmailStatusScheduled || email.ScheduledAt == nil || !email.ScheduledAt.Equal(sendAt.Add(time.Hour)) {
+		t.Errorf("unexpected email %+v", email)
+	}
+
+	if email, err = client.Emails.Cancel("msg_1"); err != nil || email.Status != EmailStatusCanceled {
+		t.Errorf("unexpected Cancel result %+v, %v", email, err)
+	}
+}
+
+func TestEmails_ScheduleValidation(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("key")
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{"cancel missing id", func() error { _, err := client.Emails.Cancel(""); return err }},
+		{"update missing id", func() error { _, err := client.Emails.UpdateScheduled("", time.Now().Add(time.Hour)); return err }},
+		{"update in the past", func() error {
+			_, err := client.Emails.UpdateScheduled("msg_1", time.Now().Add(-time.Minute))
+			return err
+		}},
+		{"update zero time", func() error { _, err := client.Emails.UpdateScheduled("msg_1", time.Time{}); return err }},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := tt.call(); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
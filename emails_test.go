@@ -7,6 +7,8 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -139,10 +141,572 @@ func TestSendEmail_MultipleRecipients(t *testing.T) {
 	}
 }
 
+func TestSendEmail_ReplyTo(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+
+		replyTo, ok := body["replyTo"].([]interface{})
+		if !ok || len(replyTo) != 2 {
+			t.Fatalf("expected replyTo array with 2 entries, got %v", body["replyTo"])
+		}
+		if replyTo[0] != "Support <support@example.com>" {
+			t.Errorf("unexpected replyTo[0]: %v", replyTo[0])
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(SendEmailResponse{Success: true, MessageId: "msg_reply"})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	_, err := client.Emails.Send(&SendEmailRequest{
+		From:    "noreply@example.com",
+		To:      []string{"recipient@example.com"},
+		ReplyTo: []string{"Support <support@example.com>", "tickets@example.com"},
+		Subject: "Test",
+		Html:    "<p>Hi</p>",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestSendEmail_SuppressAutoReplies(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Headers map[string]string `json:"headers"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+
+		if got := body.Headers["Auto-Submitted"]; got != "auto-generated" {
+			t.Errorf("expected Auto-Submitted %q, got %q", "auto-generated", got)
+		}
+		if got := body.Headers["X-Auto-Response-Suppress"]; got != "All" {
+			t.Errorf("expected X-Auto-Response-Suppress %q, got %q", "All", got)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(SendEmailResponse{Success: true, MessageId: "msg_auto"})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	_, err := client.Emails.Send(&SendEmailRequest{
+		From:                "sender@example.com",
+		To:                  []string{"recipient@example.com"},
+		Subject:             "Test",
+		Html:                "<p>Hi</p>",
+		SuppressAutoReplies: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestSendEmail_Threading(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Headers map[string]string `json:"headers"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+
+		if got := body.Headers["Message-ID"]; got != "<update-3@example.com>" {
+			t.Errorf("expected Message-ID %q, got %q", "<update-3@example.com>", got)
+		}
+		if got := body.Headers["In-Reply-To"]; got != "<update-2@example.com>" {
+			t.Errorf("expected In-Reply-To %q, got %q", "<update-2@example.com>", got)
+		}
+		if got := body.Headers["References"]; got != "<order-1@example.com> <update-2@example.com>" {
+			t.Errorf("unexpected References %q", got)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(SendEmailResponse{Success: true, MessageId: "msg_thread"})
+	}))
+	defer server.Close()
+
+	params := &SendEmailRequest{
+		From:       "sender@example.com",
+		To:         []string{"recipient@example.com"},
+		Subject:    "Re: Your order",
+		Text:       "Shipped",
+		MessageID:  "update-3@example.com",
+		References: []string{"<order-1@example.com>"},
+	}
+	params.AsReplyTo("update-2@example.com").AsReplyTo("<update-2@example.com>")
+	if len(params.References) != 2 {
+		t.Errorf("expected AsReplyTo not to repeat references, got %v", params.References)
+	}
+
+	client := newTestClient(t, server)
+	if _, err := client.Emails.Send(params); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestSendEmail_Unsubscribe(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Headers map[string]string `json:"headers"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+
+		want := "<mailto:unsubscribe@example.com>, <https://example.com/unsub?u=42>"
+		if got := body.Headers["List-Unsubscribe"]; got != want {
+			t.Errorf("expected List-Unsubscribe %q, got %q", want, got)
+		}
+		if got := body.Headers["List-Unsubscribe-Post"]; got != "List-Unsubscribe=One-Click" {
+			t.Errorf("expected List-Unsubscribe-Post %q, got %q", "List-Unsubscribe=One-Click", got)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(SendEmailResponse{Success: true, MessageId: "msg_unsub"})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	_, err := client.Emails.Send(&SendEmailRequest{
+		From:             "sender@example.com",
+		To:               []string{"recipient@example.com"},
+		Subject:          "Newsletter",
+		Html:             "<p>Hi</p>",
+		UnsubscribeURL:   "https://example.com/unsub?u=42",
+		UnsubscribeEmail: "unsubscribe@example.com",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestSendEmail_CustomHeaders(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req SendEmailRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+
+		if got := req.Headers["X-Entity-Ref-ID"]; got != "order-42" {
+			t.Errorf("expected X-Entity-Ref-ID %q, got %q", "order-42", got)
+		}
+		if got := req.Headers["Auto-Submitted"]; got != "auto-generated" {
+			t.Errorf("expected custom headers to merge with derived headers, got %v", req.Headers)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(SendEmailResponse{Success: true, MessageId: "msg_headers"})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	_, err := client.Emails.Send(&SendEmailRequest{
+		From:                "sender@example.com",
+		To:                  []string{"recipient@example.com"},
+		Subject:             "Test",
+		Html:                "<p>Hi</p>",
+		Headers:             map[string]string{"X-Entity-Ref-ID": "order-42"},
+		SuppressAutoReplies: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestSendEmail_Template(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+
+		if body["templateId"] != "tmpl_welcome" {
+			t.Errorf("expected templateId tmpl_welcome, got %v", body["templateId"])
+		}
+		data, _ := body["templateData"].(map[string]interface{})
+		if data["name"] != "Ann" {
+			t.Errorf("expected templateData.name Ann, got %v", body["templateData"])
+		}
+		for _, field := range []string{"subject", "html", "text"} {
+			if _, ok := body[field]; ok {
+				t.Errorf("expected %s to be omitted, got %v", field, body[field])
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(SendEmailResponse{Success: true, MessageId: "msg_tmpl"})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	resp, err := client.Emails.Send(&SendEmailRequest{
+		From:         "sender@example.com",
+		To:           []string{"recipient@example.com"},
+		TemplateID:   "tmpl_welcome",
+		TemplateData: map[string]any{"name": "Ann"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.MessageId != "msg_tmpl" {
+		t.Errorf("expected message id msg_tmpl, got %q", resp.MessageId)
+	}
+}
+
+func TestSendEmail_TagsAndMetadata(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Tags     []string          `json:"tags"`
+			Metadata map[string]string `json:"metadata"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if len(body.Tags) != 2 || body.Tags[0] != "receipt" {
+			t.Errorf("unexpected tags %v", body.Tags)
+		}
+		if body.Metadata["orderId"] != "42" || body.Metadata["userId"] != "u_7" {
+			t.Errorf("unexpected metadata %v", body.Metadata)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(SendEmailResponse{Success: true, MessageId: "msg_1"})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	_, err := client.Emails.Send(&SendEmailRequest{
+		From:     "sender@example.com",
+		To:       []string{"recipient@example.com"},
+		Subject:  "Receipt",
+		Text:     "Thanks",
+		Tags:     []string{"receipt", "shop"},
+		Metadata: map[string]string{"orderId": "42", "userId": "u_7"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestSendEmail_IdempotencyKey(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Idempotency-Key"); got != "order-42-receipt" {
+			t.Errorf("expected Idempotency-Key %q, got %q", "order-42-receipt", got)
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		if contains(string(body), "order-42-receipt") {
+			t.Errorf("expected idempotency key to be sent only as a header, got body %s", body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(SendEmailResponse{Success: true, MessageId: "msg_idem"})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	_, err := client.Emails.Send(&SendEmailRequest{
+		From:           "sender@example.com",
+		To:             []string{"recipient@example.com"},
+		Subject:        "Test",
+		Html:           "<p>Hi</p>",
+		IdempotencyKey: "order-42-receipt",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestSendEmail_NoHeadersByDefault(t *testing.T) {
+	t.Parallel()
+
+	data, err := json.Marshal(&SendEmailRequest{
+		From:    "sender@example.com",
+		To:      []string{"recipient@example.com"},
+		Subject: "Test",
+		Html:    "<p>Hi</p>",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if contains(string(data), `"headers"`) {
+		t.Errorf("expected no headers object, got %s", data)
+	}
+}
+
+func TestGetEmail_Success(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("expected GET, got %s", r.Method)
+		}
+		if r.URL.Path != "/v1/emails/msg_abc123" {
+			t.Errorf("expected path /v1/emails/msg_abc123, got %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"id": "msg_abc123",
+			"from": "sender@example.com",
+			"to": ["recipient@example.com"],
+			"subject": "Welcome",
+			"status": "delivered",
+			"createdAt": "2025-01-01T10:00:00Z",
+			"sentAt": "2025-01-01T10:00:01Z",
+			"deliveredAt": "2025-01-01T10:00:03Z",
+			"updatedAt": "2025-01-01T10:00:03Z",
+			"lastEvent": {"id": "evt_1", "type": "delivered", "messageId": "msg_abc123", "createdAt": "2025-01-01T10:00:03Z"}
+		}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	email, err := client.Emails.Get("msg_abc123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if email.Status != EmailStatusDelivered {
+		t.Errorf("expected status %q, got %q", EmailStatusDelivered, email.Status)
+	}
+	if email.DeliveredAt == nil || !email.DeliveredAt.Equal(time.Date(2025, 1, 1, 10, 0, 3, 0, time.UTC)) {
+		t.Errorf("unexpected deliveredAt: %v", email.DeliveredAt)
+	}
+	if email.LastEvent == nil || email.LastEvent.Type != EventTypeDelivered {
+		t.Errorf("unexpected last event: %+v", email.LastEvent)
+	}
+}
+
+func TestGetEmail_MissingID(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("key")
+	if _, err := client.Emails.Get(""); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func TestEmailsWaitForStatus(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		statuses []EmailStatus
+		target   EmailStatus
+		want     EmailStatus
+	}{
+		{"reaches target", []EmailStatus{EmailStatusQueued, EmailStatusSent, EmailStatusDelivered}, EmailStatusDelivered, EmailStatusDelivered},
+		{"intermediate target", []EmailStatus{EmailStatusQueued, EmailStatusSent, EmailStatusDelivered}, EmailStatusSent, EmailStatusSent},
+		{"stops on other terminal status", []EmailStatus{EmailStatusQueued, EmailStatusBounced, EmailStatusDelivered}, EmailStatusDelivered, EmailStatusBounced},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodGet || r.URL.Path != "/v1/emails/msg_1" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				n := int(atomic.AddInt32(&calls, 1))
+				if n > len(tt.statuses) {
+					n = len(tt.statuses)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(w).Encode(Email{ID: "msg_1", Status: tt.statuses[n-1]})
+			}))
+			defer server.Close()
+
+			client := newTestClient(t, server)
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			email, err := client.Emails.WaitForStatus(ctx, "msg_1", tt.target, 10*time.Millisecond)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if email.Status != tt.want {
+				t.Errorf("expected status %q, got %q", tt.want, email.Status)
+			}
+		})
+	}
+}
+
+func TestEmailsWaitForStatus_Deadline(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(Email{ID: "msg_1", Status: EmailStatusQueued})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	email, err := client.Emails.WaitForStatus(ctx, "msg_1", EmailStatusDelivered, 10*time.Millisecond)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if email == nil || email.Status != EmailStatusQueued {
+		t.Errorf("expected the last state of the email, got %+v", email)
+	}
+
+	if _, err := client.Emails.WaitForStatus(context.Background(), "msg_1", EmailStatusDelivered, 0); err == nil {
+		t.Error("expected error for non-positive poll interval")
+	}
+	if _, err := client.Emails.WaitForStatus(context.Background(), "msg_1", "", time.Second); err == nil {
+		t.Error("expected error for empty target status")
+	}
+}
+
+func TestListEmailEvents(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/v1/emails/msg_1/events" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":[
+			{"id":"evt_1","type":"queued","messageId":"msg_1","createdAt":"2024-01-01T10:00:00Z"},
+			{"id":"evt_2","type":"bounced","messageId":"msg_1","createdAt":"2024-01-01T10:00:05Z","data":{"bounceType":"hard","diagnosticCode":"550 5.1.1"}}
+		]}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+
+	events, err := client.Emails.ListEvents("msg_1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(events))
+	}
+	if events[0].Type != EventTypeQueued || events[1].Type != EventTypeBounced {
+		t.Errorf("unexpected event types %q, %q", events[0].Type, events[1].Type)
+	}
+	if events[1].Bounce == nil || events[1].Bounce.Type != BounceTypeHard {
+		t.Errorf("expected decoded hard bounce payload, got %+v", events[1].Bounce)
+	}
+
+	if _, err := client.Emails.ListEvents(""); err == nil {
+		t.Error("expected error for missing message id")
+	}
+}
+
+func TestListEmails_FiltersAndCursor(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("expected GET, got %s", r.Method)
+		}
+		if r.URL.Path != "/v1/emails" {
+			t.Errorf("expected path /v1/emails, got %s", r.URL.Path)
+		}
+
+		q := r.URL.Query()
+		want := map[string]string{
+			"status":    "delivered",
+			"since":     "2025-01-01T00:00:00Z",
+			"until":     "2025-01-31T00:00:00Z",
+			"tag":       "welcome",
+			"recipient": "user@example.com",
+			"limit":     "25",
+			"cursor":    "cur_abc",
+
+			"metadata[orderId]": "42",
+		}
+		for key, value := range want {
+			if got := q.Get(key); got != value {
+				t.Errorf("expected %s=%q, got %q", key, value, got)
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(EmailPage{
+			Items:      []Email{{ID: "msg_1"}, {ID: "msg_2"}},
+			NextCursor: "cur_def",
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	page, err := client.Emails.List(&ListEmailsOptions{
+		ListOptions: ListOptions{
+			Limit:  25,
+			Cursor: "cur_abc",
+			Since:  time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			Until:  time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC),
+		},
+		Status:    EmailStatusDelivered,
+		Tag:       "welcome",
+		Recipient: "user@example.com",
+		Metadata:  map[string]string{"orderId": "42"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(page.Items) != 2 {
+		t.Errorf("expected 2 emails, got %d", len(page.Items))
+	}
+	if page.NextCursor != "cur_def" {
+		t.Errorf("expected next cursor %q, got %q", "cur_def", page.NextCursor)
+	}
+}
+
+func TestListEmails_NoOptions(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.RawQuery != "" {
+			t.Errorf("expected no query parameters, got %q", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(EmailPage{})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	page, err := client.Emails.List(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if page.NextCursor != "" {
+		t.Errorf("expected empty next cursor, got %q", page.NextCursor)
+	}
+}
+
 // Table-driven validation tests
 func TestSendEmail_Validation(t *testing.T) {
 	t.Parallel()
 
+	past := time.Now().Add(-time.Hour)
+
 	tests := []struct {
 		name    string
 		params  *SendEmailRequest
@@ -178,6 +742,132 @@ func TestSendEmail_Validation(t *testing.T) {
 			params:  &SendEmailRequest{From: "a@b.com", To: []string{"b@c.com"}, Subject: "s"},
 			wantErr: "html or text body is required",
 		},
+		{
+			name:    "template with body",
+			params:  &SendEmailRequest{From: "a@b.com", To: []string{"b@c.com"}, TemplateID: "tmpl_1", Html: "<p>x</p>"},
+			wantErr: "must be empty when a template id is set",
+		},
+		{
+			name:    "message id without domain",
+			params:  &SendEmailRequest{From: "a@b.com", To: []string{"b@c.com"}, Subject: "s", Text: "x", MessageID: "order-42"},
+			wantErr: "must have the form unique-part@domain",
+		},
+		{
+			name: "message id with personalizations",
+			params: &SendEmailRequest{From: "a@b.com", Subject: "s", Text: "x", MessageID: "order-42@b.com",
+				Personalizations: []Personalization{{To: []string{"b@c.com"}}}},
+			wantErr: "cannot be set with personalizations",
+		},
+		{
+			name:    "invalid in-reply-to",
+			params:  &SendEmailRequest{From: "a@b.com", To: []string{"b@c.com"}, Subject: "s", Text: "x", InReplyTo: "a@b.com\r\nBcc: x@y.com"},
+			wantErr: "invalid message id",
+		},
+		{
+			name:    "empty reference",
+			params:  &SendEmailRequest{From: "a@b.com", To: []string{"b@c.com"}, Subject: "s", Text: "x", References: []string{""}},
+			wantErr: "reference 0 must not be empty",
+		},
+		{
+			name:    "amp without html",
+			params:  &SendEmailRequest{From: "a@b.com", To: []string{"b@c.com"}, Subject: "s", Text: "x", AmpHtml: "<html \u26a14email></html>"},
+			wantErr: "amp html body requires an html body",
+		},
+		{
+			name:    "scheduled in the past",
+			params:  &SendEmailRequest{From: "a@b.com", To: []string{"b@c.com"}, Subject: "s", Text: "x", ScheduledAt: &past},
+			wantErr: "is not in the future",
+		},
+		{
+			name:    "template data without template",
+			params:  &SendEmailRequest{From: "a@b.com", To: []string{"b@c.com"}, Subject: "s", Html: "<p>x</p>", TemplateData: map[string]any{"name": "Ann"}},
+			wantErr: "template data requires a template id",
+		},
+		{
+			name:    "invalid reply-to",
+			params:  &SendEmailRequest{From: "a@b.com", To: []string{"b@c.com"}, Subject: "s", Html: "<p>x</p>", ReplyTo: []string{"support@b.com", "not-an-address"}},
+			wantErr: "invalid reply-to address",
+		},
+		{
+			name:    "empty reply-to",
+			params:  &SendEmailRequest{From: "a@b.com", To: []string{"b@c.com"}, Subject: "s", Html: "<p>x</p>", ReplyTo: []string{""}},
+			wantErr: "invalid reply-to address",
+		},
+		{
+			name:    "http unsubscribe url",
+			params:  &SendEmailRequest{From: "a@b.com", To: []string{"b@c.com"}, Subject: "s", Html: "<p>x</p>", UnsubscribeURL: "http://b.com/unsub"},
+			wantErr: "must be an absolute https URL",
+		},
+		{
+			name:    "invalid unsubscribe email",
+			params:  &SendEmailRequest{From: "a@b.com", To: []string{"b@c.com"}, Subject: "s", Html: "<p>x</p>", UnsubscribeEmail: "Unsub <u@b.com>"},
+			wantErr: "must be a bare email address",
+		},
+		{
+			name:    "unsubscribe with header",
+			params:  &SendEmailRequest{From: "a@b.com", To: []string{"b@c.com"}, Subject: "s", Html: "<p>x</p>", UnsubscribeURL: "https://b.com/u", Headers: map[string]string{"list-unsubscribe": "<x>"}},
+			wantErr: "cannot be combined with the unsubscribe options",
+		},
+		{
+			name:    "importance with header",
+			params:  &SendEmailRequest{From: "a@b.com", To: []string{"b@c.com"}, Subject: "s", Html: "<p>x</p>", Importance: ImportanceHigh, Headers: map[string]string{"x-priority": "1"}},
+			wantErr: "cannot be combined with the importance",
+		},
+		{
+			name:    "suppress auto replies with header",
+			params:  &SendEmailRequest{From: "a@b.com", To: []string{"b@c.com"}, Subject: "s", Html: "<p>x</p>", SuppressAutoReplies: true, Headers: map[string]string{"auto-submitted": "auto-replied"}},
+			wantErr: "cannot be combined with suppressing auto replies",
+		},
+		{
+			name:    "message id with header",
+			params:  &SendEmailRequest{From: "a@b.com", To: []string{"b@c.com"}, Subject: "s", Html: "<p>x</p>", MessageID: "m@b.com", Headers: map[string]string{"message-id": "<x@b.com>"}},
+			wantErr: "cannot be combined with the message id",
+		},
+		{
+			name:    "in reply to with header",
+			params:  &SendEmailRequest{From: "a@b.com", To: []string{"b@c.com"}, Subject: "s", Html: "<p>x</p>", InReplyTo: "m@b.com", Headers: map[string]string{"IN-REPLY-TO": "<x@b.com>"}},
+			wantErr: "cannot be combined with in reply to",
+		},
+		{
+			name:    "references with header",
+			params:  &SendEmailRequest{From: "a@b.com", To: []string{"b@c.com"}, Subject: "s", Html: "<p>x</p>", References: []string{"m@b.com"}, Headers: map[string]string{"references": "<x@b.com>"}},
+			wantErr: "cannot be combined with references",
+		},
+		{
+			name:    "empty tag",
+			params:  &SendEmailRequest{From: "a@b.com", To: []string{"b@c.com"}, Subject: "s", Html: "<p>x</p>", Tags: []string{"ok", ""}},
+			wantErr: "tag 1 must not be empty",
+		},
+		{
+			name:    "empty metadata key",
+			params:  &SendEmailRequest{From: "a@b.com", To: []string{"b@c.com"}, Subject: "s", Html: "<p>x</p>", Metadata: map[string]string{"": "x"}},
+			wantErr: "metadata keys must not be empty",
+		},
+		{
+			name:    "reserved header",
+			params:  &SendEmailRequest{From: "a@b.com", To: []string{"b@c.com"}, Subject: "s", Html: "<p>x</p>", Headers: map[string]string{"subject": "override"}},
+			wantErr: "is reserved",
+		},
+		{
+			name:    "header injection",
+			params:  &SendEmailRequest{From: "a@b.com", To: []string{"b@c.com"}, Subject: "s", Html: "<p>x</p>", Headers: map[string]string{"X-Ref": "1\r\nBcc: evil@example.com"}},
+			wantErr: "must not contain line breaks",
+		},
+		{
+			name:    "invalid header name",
+			params:  &SendEmailRequest{From: "a@b.com", To: []string{"b@c.com"}, Subject: "s", Html: "<p>x</p>", Headers: map[string]string{"X Ref:": "1"}},
+			wantErr: "invalid header name",
+		},
+		{
+			name:    "idempotency key too long",
+			params:  &SendEmailRequest{From: "a@b.com", To: []string{"b@c.com"}, Subject: "s", Html: "<p>x</p>", IdempotencyKey: strings.Repeat("k", 256)},
+			wantErr: "idempotency key must be at most 255 characters",
+		},
+		{
+			name:    "invalid return path",
+			params:  &SendEmailRequest{From: "a@b.com", To: []string{"b@c.com"}, Subject: "s", Html: "<p>x</p>", ReturnPath: "Bounces <bounces@b.com>"},
+			wantErr: "must be a bare email address",
+		},
 	}
 
 	// Validation happens before any HTTP call, so no server needed.
@@ -238,6 +928,23 @@ func TestSendEmail_APIErrors(t *testing.T) {
 			sentinelErr:  ErrForbidden,
 			errType:      "api",
 		},
+		{
+			name:       "422 unprocessable",
+			statusCode: http.StatusUnprocessableEntity,
+			responseBody: map[string]any{
+				"error":  "Domain not verified",
+				"fields": []map[string]string{{"field": "from", "code": "unverified_domain", "message": "example.com is not verified"}},
+			},
+			sentinelErr: ErrUnprocessable,
+			errType:     "unprocessable",
+		},
+		{
+			name:         "404 not found",
+			statusCode:   http.StatusNotFound,
+			responseBody: map[string]string{"error": "Not found"},
+			sentinelErr:  ErrNotFound,
+			errType:      "api",
+		},
 		{
 			name:       "429 rate limit",
 			statusCode: http.StatusTooManyRequests,
@@ -258,8 +965,8 @@ func TestSendEmail_APIErrors(t *testing.T) {
 			name:         "500 server error",
 			statusCode:   http.StatusInternalServerError,
 			responseBody: map[string]string{"error": "Failed to send email", "details": "SES timeout"},
-			sentinelErr:  nil, // no sentinel for 500
-			errType:      "api",
+			sentinelErr:  ErrServer,
+			errType:      "server",
 		},
 	}
 
@@ -309,6 +1016,23 @@ func TestSendEmail_APIErrors(t *testing.T) {
 						t.Errorf("expected monthly limit 4000, got %d", rle.Usage.MonthlyLimit)
 					}
 				}
+			case "unprocessable":
+				var ue *UnprocessableError
+				if !errors.As(err, &ue) {
+					t.Errorf("expected *UnprocessableError, got %T: %v", err, err)
+				} else if len(ue.Fields) != 1 || ue.Fields[0].Field != "from" || ue.Fields[0].Code != "unverified_domain" {
+					t.Errorf("unexpected fields %+v", ue.Fields)
+				}
+				if errors.Is(err, ErrValidation) {
+					t.Error("expected 422 not to match ErrValidation")
+				}
+			case "server":
+				var se *ServerError
+				if !errors.As(err, &se) {
+					t.Errorf("expected *ServerError, got %T: %v", err, err)
+				} else if se.Details != "SES timeout" || se.StatusCode != tt.statusCode {
+					t.Errorf("unexpected server error %+v", se)
+				}
 			case "api":
 				var apiErr *APIError
 				if !errors.As(err, &apiErr) {
@@ -321,6 +1045,70 @@ func TestSendEmail_APIErrors(t *testing.T) {
 	}
 }
 
+func TestSendEmail_FieldErrors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("client-side", func(t *testing.T) {
+		t.Parallel()
+
+		client := NewClient("key")
+		_, err := client.Emails.Send(&SendEmailRequest{
+			From:    "sender@example.com",
+			To:      []string{"recipient@example.com"},
+			Cc:      []string{"cc@example.com", "not an address"},
+			Subject: "Test",
+			Html:    "<p>Hello</p>",
+		})
+
+		var ve *ValidationError
+		if !errors.As(err, &ve) {
+			t.Fatalf("expected *ValidationError, got %T: %v", err, err)
+		}
+		want := FieldError{Field: "cc[1]", Code: "invalid", Message: `invalid cc address "not an address"`}
+		if len(ve.Fields) != 1 || ve.Fields[0] != want {
+			t.Errorf("expected fields [%+v], got %+v", want, ve.Fields)
+		}
+	})
+
+	t.Run("api", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"Invalid request","fields":[` +
+				`{"field":"from","code":"unverified_domain","message":"domain is not verified"},` +
+				`{"field":"subject","code":"too_long","message":"subject is too long"}]}`))
+		}))
+		defer server.Close()
+
+		client := newTestClient(t, server)
+		_, err := client.Emails.Send(&SendEmailRequest{
+			From:    "sender@example.com",
+			To:      []string{"recipient@example.com"},
+			Subject: "Test",
+			Html:    "<p>Hello</p>",
+		})
+
+		var ve *ValidationError
+		if !errors.As(err, &ve) {
+			t.Fatalf("expected *ValidationError, got %T: %v", err, err)
+		}
+		if ve.StatusCode != http.StatusBadRequest || ve.Message != "Invalid request" {
+			t.Errorf("unexpected error %+v", ve.APIError)
+		}
+		if len(ve.Fields) != 2 {
+			t.Fatalf("expected 2 fields, got %+v", ve.Fields)
+		}
+		if ve.Fields[0].Field != "from" || ve.Fields[0].Code != "unverified_domain" {
+			t.Errorf("unexpected first field %+v", ve.Fields[0])
+		}
+		if ve.Fields[1].Field != "subject" || ve.Fields[1].Message != "subject is too long" {
+			t.Errorf("unexpected second field %+v", ve.Fields[1])
+		}
+	})
+}
+
 func TestSendEmailWithContext_Cancellation(t *testing.T) {
 	t.Parallel()
 
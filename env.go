@@ -0,0 +1,54 @@
+package envloped
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// Environment variables read by NewClientFromEnv.
+const (
+	// EnvAPIKey holds the API key. It is required.
+	EnvAPIKey = "ENVLOPED_API_KEY"
+
+	// EnvBaseURL holds the API base URL. It defaults to the production API.
+	EnvBaseURL = "ENVLOPED_BASE_URL"
+
+	// EnvTimeout holds the HTTP client timeout as a Go duration, such as
+	// "10s". It defaults to 30 seconds.
+	EnvTimeout = "ENVLOPED_TIMEOUT"
+)
+
+// NewClientFromEnv creates a client configured from the environment
+// variables EnvAPIKey, EnvBaseURL, and EnvTimeout, so applications can be
+// configured without code changes. It returns an error if the API key is
+// unset or a variable holds an invalid value.
+func NewClientFromEnv() (*Client, error) {
+	apiKey := strings.TrimSpace(os.Getenv(EnvAPIKey))
+	if apiKey == "" {
+		return nil, fmt.Errorf("envloped: %s is not set", EnvAPIKey)
+	}
+	c := NewClient(apiKey)
+
+	if raw := strings.TrimSpace(os.Getenv(EnvBaseURL)); raw != "" {
+		u, err := url.Parse(raw)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return nil, fmt.Errorf("envloped: invalid %s %q", EnvBaseURL, raw)
+		}
+		c.baseURL = u
+		c.customBaseURL = true
+	}
+
+	if raw := strings.TrimSpace(os.Getenv(EnvTimeout)); raw != "" {
+		d, err := time.ParseDuration(raw)
+		if err != nil || d < 0 {
+			return nil, fmt.Errorf("envloped: invalid %s %q", EnvTimeout, raw)
+		}
+		c.httpClient = &http.Client{Timeout: d}
+	}
+
+	return c, nil
+}
@@ -0,0 +1,70 @@
+package envloped
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewClientFromEnv(t *testing.T) {
+	t.Setenv(EnvAPIKey, " ev_env_key ")
+	t.Setenv(EnvBaseURL, "https://api.staging.envloped.com")
+	t.Setenv(EnvTimeout, "5s")
+
+	client, err := NewClientFromEnv()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if client.apiKey != "ev_env_key" {
+		t.Errorf("expected trimmed API key, got %q", client.apiKey)
+	}
+	if got := client.baseURL.String(); got != "https://api.staging.envloped.com" {
+		t.Errorf("unexpected base URL %q", got)
+	}
+	if client.httpClient.Timeout != 5*time.Second {
+		t.Errorf("expected 5s timeout, got %v", client.httpClient.Timeout)
+	}
+}
+
+func TestNewClientFromEnv_Defaults(t *testing.T) {
+	t.Setenv(EnvAPIKey, "ev_env_key")
+	t.Setenv(EnvBaseURL, "")
+	t.Setenv(EnvTimeout, "")
+
+	client, err := NewClientFromEnv()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := client.baseURL.String(); got != defaultBaseURL {
+		t.Errorf("expected default base URL, got %q", got)
+	}
+	if client.httpClient.Timeout != 30*time.Second {
+		t.Errorf("expected default timeout, got %v", client.httpClient.Timeout)
+	}
+}
+
+func TestNewClientFromEnv_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		wantErr string
+	}{
+		{"missing key", map[string]string{EnvAPIKey: ""}, "ENVLOPED_API_KEY is not set"},
+		{"invalid base URL", map[string]string{EnvAPIKey: "k", EnvBaseURL: "api.envloped.com"}, "invalid ENVLOPED_BASE_URL"},
+		{"invalid timeout", map[string]string{EnvAPIKey: "k", EnvTimeout: "30"}, "invalid ENVLOPED_TIMEOUT"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(EnvBaseURL, "")
+			t.Setenv(EnvTimeout, "")
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			_, err := NewClientFromEnv()
+			if err == nil || !contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -17,15 +17,17 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strings"
+	"sync/atomic"
 	"time"
 )
 
 const (
 	// version is the current SDK version. Keep in sync with Git tags.
-	version = "1.0.1"
+	version = "1.1.0"
 
 	// userAgent is sent with every request for server-side tracking.
 	userAgent = "envloped-go/" + version
@@ -38,6 +40,11 @@ const (
 )
 
 // Client handles communication with the Envloped API.
+//
+// A Client is safe for concurrent use once configured. The WithX methods
+// modify the client they are called on, so call them before the client is
+// shared between goroutines. To change the configuration of a client that
+// is already in use, configure a copy made with Clone instead.
 type Client struct {
 	// httpClient is the underlying HTTP client used for requests.
 	httpClient *http.Client
@@ -48,11 +55,117 @@ type Client struct {
 	// baseURL is the API base URL (without trailing slash).
 	baseURL *url.URL
 
+	// customBaseURL reports whether baseURL was set explicitly.
+	customBaseURL bool
+
+	// region selects a regional API endpoint instead of baseURL, if set.
+	region Region
+
 	// userAgent is the User-Agent header value.
 	userAgent string
 
+	// apiVersion is sent as the Envloped-Version header, if set.
+	apiVersion string
+
+	// tracer wraps API calls in spans, if set.
+	tracer Tracer
+
+	// metrics records API traffic, if set.
+	metrics Metrics
+
+	// resolver performs the lookups of Domains.CheckDNS, if set.
+	resolver dnsResolver
+
+	// logger receives debug logs of API requests, if set.
+	logger *slog.Logger
+
+	// logRecipients disables redaction of email addresses in debug logs.
+	logRecipients bool
+
+	// limits holds the plan limits used for client-side validation, if enabled.
+	limits atomic.Pointer[Limits]
+
+	// strictValidation enables address syntax validation before sends.
+	strictValidation bool
+
+	// checkSuppressions enables the pre-send suppression check.
+	checkSuppressions bool
+
+	// quotaGuard refuses requests locally while the rate limit is exhausted.
+	quotaGuard bool
+
+	// trackOpens and trackClicks are the tracking defaults for sends that do
+	// not set them, if configured.
+	trackOpens  *bool
+	trackClicks *bool
+
+	// sandbox sends every email in sandbox mode.
+	sandbox bool
+
+	// dryRun makes every send a dry run that does not call the API.
+	dryRun bool
+
+	// throttle paces outgoing requests, if set.
+	throttle *tokenBucket
+
+	// breaker fails requests fast while the API is failing, if set.
+	breaker *circuitBreaker
+
+	// requestTimeout bounds calls whose context has no deadline, if positive.
+	requestTimeout time.Duration
+
+	// gzipMinSize is the size from which JSON request bodies are compressed,
+	// if positive.
+	gzipMinSize int
+
+	// hedgeDelay is the delay after which GET requests are sent again, if
+	// positive.
+	hedgeDelay time.Duration
+
+	// rateLimit holds the most recently reported API rate limit.
+	rateLimit atomic.Pointer[RateLimit]
+
 	// Emails provides access to the email sending API.
 	Emails EmailsSvc
+
+	// Contacts provides access to the contacts API.
+	Contacts ContactsSvc
+
+	// Events provides access to the account event feed.
+	Events EventsSvc
+
+	// Subaccounts provides access to subaccount management for platform accounts.
+	Subaccounts SubaccountsSvc
+
+	// Domains provides access to sending domain management.
+	Domains DomainsSvc
+
+	// IPPools provides access to dedicated IP pool management.
+	IPPools IPPoolsSvc
+
+	// Webhooks provides access to webhook endpoint management.
+	Webhooks WebhooksSvc
+
+	// Templates provides access to stored email templates.
+	Templates TemplatesSvc
+
+	// Audiences provides access to contact lists.
+	Audiences AudiencesSvc
+
+	// Suppressions provides access to the suppression list.
+	Suppressions SuppressionsSvc
+
+	// Broadcasts provides access to campaign emails sent to audiences.
+	Broadcasts BroadcastsSvc
+
+	// Inbound provides access to inbound email routes.
+	Inbound InboundSvc
+
+	// Stats provides access to aggregate sending and engagement metrics.
+	Stats StatsSvc
+
+	// SMTP provides access to SMTP relay credentials.
+	SMTP SMTPSvc
 }
 
 // NewClient creates a new Envloped API client with the given API key.
@@ -68,11 +181,76 @@ func NewClient(apiKey string) *Client {
 		userAgent:  userAgent,
 	}
 
-	c.Emails = &emailsSvcImpl{client: c}
+	c.initServices()
 
 	return c
 }
 
+// initServices points the service fields at implementations backed by c.
+func (c *Client) initServices() {
+	c.Emails = &emailsSvcImpl{client: c}
+	c.Contacts = &contactsSvcImpl{client: c}
+	c.Events = &eventsSvcImpl{client: c}
+	c.Subaccounts = &subaccountsSvcImpl{client: c}
+	c.Domains = &domainsSvcImpl{client: c}
+	c.IPPools = &ipPoolsSvcImpl{client: c}
+	c.Webhooks = &webhooksSvcImpl{client: c}
+	c.Templates = &templatesSvcImpl{client: c}
+	c.Audiences = &audiencesSvcImpl{client: c}
+	c.Suppressions = &suppressionsSvcImpl{client: c}
+	c.Broadcasts = &broadcastsSvcImpl{client: c}
+	c.Inbound = &inboundSvcImpl{client: c}
+	c.Stats = &statsSvcImpl{client: c}
+	c.SMTP = &smtpSvcImpl{client: c}
+}
+
+// Clone returns a copy of c, with opts applied, that can be configured with
+// the WithX methods without affecting c, even while c is in use. The copy
+// shares c's HTTP client and request throttle. Its services are backed by
+// the copy, so services replaced on c, such as a mock assigned to Emails,
+// are not carried over.
+//
+// Multi-tenant applications can derive a client per account:
+//
+//	tenant := client.Clone(envloped.WithAPIKey(tenantKey))
+func (c *Client) Clone(opts ...Option) *Client {
+	cp := &Client{
+		httpClient:        c.httpClient,
+		apiKey:            c.apiKey,
+		baseURL:           c.baseURL,
+		customBaseURL:     c.customBaseURL,
+		region:            c.region,
+		userAgent:         c.userAgent,
+		apiVersion:        c.apiVersion,
+		tracer:            c.tracer,
+		metrics:           c.metrics,
+		resolver:          c.resolver,
+		logger:            c.logger,
+		logRecipients:     c.logRecipients,
+		strictValidation:  c.strictValidation,
+		checkSuppressions: c.checkSuppressions,
+		quotaGuard:        c.quotaGuard,
+		trackOpens:        c.trackOpens,
+		trackClicks:       c.trackClicks,
+		sandbox:           c.sandbox,
+		dryRun:            c.dryRun,
+		throttle:          c.throttle,
+		breaker:           c.breaker,
+		hedgeDelay:        c.hedgeDelay,
+		gzipMinSize:       c.gzipMinSize,
+		requestTimeout:    c.requestTimeout,
+	}
+	cp.limits.Store(c.limits.Load())
+	cp.rateLimit.Store(c.rateLimit.Load())
+	for _, opt := range opts {
+		if opt != nil {
+			opt(cp)
+		}
+	}
+	cp.initServices()
+	return cp
+}
+
 // WithBaseURL sets a custom base URL for the API client.
 // This is useful for testing or self-hosted deployments.
 // Returns the client for method chaining.
@@ -80,6 +258,7 @@ func (c *Client) WithBaseURL(rawURL string) *Client {
 	u, err := url.Parse(rawURL)
 	if err == nil {
 		c.baseURL = u
+		c.customBaseURL = true
 	}
 	return c
 }
@@ -108,7 +287,7 @@ func (c *Client) Ping() (*PingResponse, error) {
 
 // PingWithContext checks connectivity and API key validity using the given context.
 func (c *Client) PingWithContext(ctx context.Context) (*PingResponse, error) {
-	req, err := c.newRequest(ctx, http.MethodGet, "/v1/ping", nil)
+	req, err := c.newRequest(ctx, http.MethodGet, "/v1/ping", "/v1/ping", nil)
 	if err != nil {
 		return nil, fmt.Errorf("envloped: failed to create ping request: %w", err)
 	}
@@ -126,33 +305,115 @@ func Version() string {
 	return version
 }
 
-// newRequest builds a new HTTP request with authentication and standard headers.
-func (c *Client) newRequest(ctx context.Context, method, path string, body interface{}) (*http.Request, error) {
-	u, err := c.baseURL.Parse(path)
+// listResponse is the envelope of list endpoints that return all items at once.
+type listResponse[T any] struct {
+	Data []T `json:"data"`
+}
+
+// NewRequest builds a request to an API endpoint the SDK does not cover yet,
+// with authentication and standard headers set. path is resolved against the
+// base URL, e.g. "/v1/emails". A non-nil body is encoded as JSON. Send the
+// request with Do. Metrics label the request with path, with any segment
+// that is not made of lowercase letters and hyphens replaced by "{id}".
+func (c *Client) NewRequest(ctx context.Context, method, path string, body any) (*http.Request, error) {
+	route, _, _ := strings.Cut(path, "?")
+	req, err := c.newRequest(ctx, method, routeOf(route), path, body)
 	if err != nil {
-		return nil, fmt.Errorf("invalid path %q: %w", path, err)
+		return nil, fmt.Errorf("envloped: failed to create request: %w", err)
 	}
+	return req, nil
+}
 
-	var reqBody io.Reader
-	if body != nil {
-		buf := new(bytes.Buffer)
-		if err := json.NewEncoder(buf).Encode(body); err != nil {
-			return nil, fmt.Errorf("failed to encode request body: %w", err)
+// Do sends a request built with NewRequest and decodes the JSON response
+// body into v, unless v is nil. Like the SDK's own calls, it honors the
+// client's tracing, logging, rate limiting, and quota guard, and returns a
+// typed error such as *RateLimitError if the response status is not 2xx.
+//
+// A request rejected by the rate limit or quota is sent again once the limit
+// resets, up to three times, like the items of SendBulk. Other failures are
+// not retried. Requests with a body are only retried if req.GetBody is set,
+// which NewRequest does.
+//
+//	req, err := client.NewRequest(ctx, http.MethodGet, "/v1/new-endpoint", nil)
+//	if err != nil {
+//	    return err
+//	}
+//	var out NewEndpointResponse
+//	err = client.Do(req, &out)
+func (c *Client) Do(req *http.Request, v any) error {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return c.do(req, v)
+	}
+	first := true
+	return c.retryRateLimited(req.Context(), req.Method, routeOfRequest(req), func(ctx context.Context) error {
+		r := req.WithContext(ctx)
+		if !first && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return fmt.Errorf("envloped: failed to replay request body: %w", err)
+			}
+			r.Body = body
 		}
-		reqBody = buf
+		first = false
+		return c.do(r, v)
+	})
+}
+
+// newRequest builds a new HTTP request with authentication and standard headers.
+// route is the pattern of path that labels the request in metrics, e.g.
+// "/v1/emails/{id}" for "/v1/emails/msg_123".
+func (c *Client) newRequest(ctx context.Context, method, route, path string, body interface{}) (*http.Request, error) {
+	if body == nil {
+		return c.newStreamRequest(ctx, method, route, path, nil, "")
+	}
+
+	buf := new(bytes.Buffer)
+	if err := json.NewEncoder(buf).Encode(body); err != nil {
+		return nil, fmt.Errorf("failed to encode request body: %w", err)
+	}
+
+	if c.gzipMinSize <= 0 || buf.Len() < c.gzipMinSize {
+		return c.newStreamRequest(ctx, method, route, path, buf, contentType)
+	}
+	compressed, err := gzipBody(buf.Bytes())
+	if err != nil {
+		return nil, err
 	}
+	req, err := c.newStreamRequest(ctx, method, route, path, compressed, contentType)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Encoding", "gzip")
+	return req, nil
+}
 
-	req, err := http.NewRequestWithContext(ctx, method, u.String(), reqBody)
+// newStreamRequest builds a new HTTP request whose body is read from body
+// as-is, with authentication and standard headers. The Content-Type header
+// is set to bodyType when body is non-nil. route is as for newRequest.
+func (c *Client) newStreamRequest(ctx context.Context, method, route, path string, body io.Reader, bodyType string) (*http.Request, error) {
+	base, err := c.endpoint()
+	if err != nil {
+		return nil, err
+	}
+	u, err := base.Parse(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid path %q: %w", path, err)
+	}
+
+	req, err := http.NewRequestWithContext(withRoute(ctx, route), method, u.String(), body)
 	if err != nil {
 		return nil, err
 	}
 
 	req.Header.Set("Authorization", "Bearer "+c.apiKey)
 	req.Header.Set("User-Agent", c.userAgent)
+	if c.apiVersion != "" {
+		req.Header.Set(apiVersionHeader, c.apiVersion)
+	}
 	req.Header.Set("Accept", contentType)
 
 	if body != nil {
-		req.Header.Set("Content-Type", contentType)
+		req.Header.Set("Content-Type", bodyType)
 	}
 
 	return req, nil
@@ -160,16 +421,37 @@ func (c *Client) newRequest(ctx context.Context, method, path string, body inter
 
 // do executes the request and decodes the response body into target.
 // If the response status is not 2xx, it returns a typed error.
-func (c *Client) do(req *http.Request, target interface{}) error {
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("envloped: request failed: %w", err)
+func (c *Client) do(req *http.Request, target interface{}) (err error) {
+	req, cancel := c.withRequestTimeout(req)
+	defer cancel()
+
+	var status int
+	retries := retryCountOf(req.Context())
+	if c.tracer != nil {
+		ctx, span := c.tracer.Start(req.Context(), req.Method, req.URL.Path)
+		req = req.WithContext(ctx)
+		defer func() {
+			result := SpanResult{StatusCode: status, RetryCount: retries, Err: err}
+			if err == nil {
+				result.MessageID = messageIDOf(target)
+			}
+			span.End(result)
+		}()
 	}
 
-	// Handle non-2xx responses.
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return handleErrorResponse(resp)
+	var resp *http.Response
+	if c.hedgeable(req) {
+		var hedges int
+		resp, hedges, err = c.hedgedRoundTrip(req)
+		retries += hedges
+	} else {
+		resp, err = c.roundTrip(c.httpClient, req)
+	}
+	if err != nil {
+		status = statusCodeOf(err)
+		return err
 	}
+	status = resp.StatusCode
 
 	defer resp.Body.Close()
 
@@ -181,3 +463,45 @@ func (c *Client) do(req *http.Request, target interface{}) error {
 
 	return nil
 }
+
+// roundTrip executes the request with hc and returns the response if its
+// status is 2xx. Otherwise it returns a typed error. The caller must close
+// the response body.
+func (c *Client) roundTrip(hc *http.Client, req *http.Request) (*http.Response, error) {
+	if err := c.checkQuota(time.Now()); err != nil {
+		return nil, err
+	}
+	if c.throttle != nil {
+		if err := c.throttle.wait(req.Context()); err != nil {
+			return nil, err
+		}
+	}
+
+	var trial bool
+	if c.breaker != nil {
+		var err error
+		if trial, err = c.breaker.allow(time.Now()); err != nil {
+			return nil, err
+		}
+	}
+
+	start := time.Now()
+	resp, err := hc.Do(req)
+	if c.breaker != nil {
+		c.breaker.done(time.Now(), trial, resp, err)
+	}
+	c.logRequest(req, resp, time.Since(start), err)
+	c.observeRequest(req, resp, time.Since(start), err)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: request failed: %w", err)
+	}
+	c.recordRateLimit(resp)
+	recordResponseMeta(req.Context(), resp)
+
+	// Handle non-2xx responses.
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, handleErrorResponse(resp)
+	}
+
+	return resp, nil
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -76,8 +77,8 @@ func TestWithHTTPClient_Nil(t *testing.T) {
 func TestVersion(t *testing.T) {
 	t.Parallel()
 
-	if v := Version(); v != "1.0.1" {
-		t.Errorf("expected version %q, got %q", "1.0.1", v)
+	if v := Version(); v != "1.1.0" {
+		t.Errorf("expected version %q, got %q", "1.1.0", v)
 	}
 }
 
@@ -203,3 +204,120 @@ func TestChainedBuilderMethods(t *testing.T) {
 		t.Error("expected custom HTTP client")
 	}
 }
+
+func TestNewRequestAndDo(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/beta/things" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if auth := r.Header.Get("Authorization"); auth != "Bearer test_api_key_123" {
+			t.Errorf("unexpected Authorization header: %s", auth)
+		}
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["name"] == "" {
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"error":"Rate limit exceeded"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"thing_1"}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	req, err := client.NewRequest(context.Background(), http.MethodPost, "/v1/beta/things", map[string]string{"name": "a"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var out struct {
+		ID string `json:"id"`
+	}
+	if err := client.Do(req, &out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.ID != "thing_1" {
+		t.Errorf("expected id thing_1, got %q", out.ID)
+	}
+
+	req, err = client.NewRequest(context.Background(), http.MethodPost, "/v1/beta/things", map[string]string{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var rateLimitErr *RateLimitError
+	if err := client.Do(req, nil); !errors.As(err, &rateLimitErr) {
+		t.Errorf("expected *RateLimitError, got %v", err)
+	}
+}
+
+func TestDo_RetriesRateLimited(t *testing.T) {
+	t.Parallel()
+
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["name"] != "a" {
+			t.Errorf("expected the body on every attempt, got %v", body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"error":"Rate limit exceeded"}`))
+			return
+		}
+		w.Write([]byte(`{"id":"thing_1"}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	req, err := client.NewRequest(context.Background(), http.MethodPost, "/v1/beta/things", map[string]string{"name": "a"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var out struct {
+		ID string `json:"id"`
+	}
+	if err := client.Do(req, &out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.ID != "thing_1" {
+		t.Errorf("expected id thing_1, got %q", out.ID)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 2 {
+		t.Errorf("expected 2 attempts, got %d", got)
+	}
+}
+
+func TestClone(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("test_api_key_123").WithStrictValidation(true)
+	limits := &Limits{MaxRecipients: 5}
+	client.limits.Store(limits)
+
+	clone := client.Clone().WithBaseURL("https://api.staging.envloped.com")
+
+	if got := client.baseURL.String(); got != defaultBaseURL {
+		t.Errorf("expected original base URL to be unchanged, got %q", got)
+	}
+	if got := clone.baseURL.String(); got != "https://api.staging.envloped.com" {
+		t.Errorf("unexpected clone base URL %q", got)
+	}
+	if clone.apiKey != client.apiKey || !clone.strictValidation || clone.limits.Load() != limits {
+		t.Error("expected clone to copy the configuration")
+	}
+	if clone.httpClient != client.httpClient {
+		t.Error("expected clone to share the HTTP client")
+	}
+	if clone.Emails.(*emailsSvcImpl).client != clone {
+		t.Error("expected clone services to be backed by the clone")
+	}
+}
@@ -0,0 +1,168 @@
+package envlopedtest
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/envloped/envloped-go"
+)
+
+// UpdateGoldenEnv is the environment variable that makes AssertGoldenEmail
+// write the golden files instead of comparing against them, e.g.
+//
+//	ENVLOPED_UPDATE_GOLDEN=1 go test ./...
+const UpdateGoldenEnv = "ENVLOPED_UPDATE_GOLDEN"
+
+// masked replaces masked values in snapshots.
+const masked = "[MASKED]"
+
+// GoldenOption customizes an email snapshot.
+type GoldenOption func(*goldenConfig)
+
+// goldenConfig holds the snapshot options.
+type goldenConfig struct {
+	masks [][]string
+}
+
+// MaskFields replaces the values at the given paths with "[MASKED]", for
+// fields that change between runs, such as generated tokens. A path is a
+// dot-separated list of JSON field names, map keys, and array indexes of
+// the email as sent to the API; "*" matches every element, e.g.
+// "metadata.requestId" or "personalizations.*.data.token". Paths that do
+// not exist in the email are ignored.
+func MaskFields(paths ...string) GoldenOption {
+	return func(c *goldenConfig) {
+		for _, p := range paths {
+			c.masks = append(c.masks, strings.Split(p, "."))
+		}
+	}
+}
+
+// EmailSnapshot returns the JSON the API receives for params, normalized
+// for comparison: object keys are sorted, the output is indented, and
+// masked fields are replaced. Attachment content is replaced by its size,
+// so snapshots stay readable and tolerate generated files such as calendar
+// invites.
+func EmailSnapshot(params *envloped.SendEmailRequest, opts ...GoldenOption) ([]byte, error) {
+	var cfg goldenConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	data, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("envlopedtest: failed to encode email: %w", err)
+	}
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, fmt.Errorf("envlopedtest: failed to decode email: %w", err)
+	}
+
+	if doc, ok := v.(map[string]any); ok {
+		if attachments, ok := doc["attachments"].([]any); ok {
+			for _, a := range attachments {
+				if a, ok := a.(map[string]any); ok {
+					if content, ok := a["content"].(string); ok {
+						n := base64.StdEncoding.DecodedLen(len(content)) - strings.Count(content, "=")
+						a["content"] = fmt.Sprintf("[%d bytes]", n)
+					}
+				}
+			}
+		}
+	}
+	for _, path := range cfg.masks {
+		v = mask(v, path)
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// mask returns v with the values at path replaced.
+func mask(v any, path []string) any {
+	if len(path) == 0 {
+		return masked
+	}
+	switch v := v.(type) {
+	case map[string]any:
+		for key, child := range v {
+			if path[0] == "*" || path[0] == key {
+				v[key] = mask(child, path[1:])
+			}
+		}
+	case []any:
+		for i, child := range v {
+			if path[0] == "*" || path[0] == fmt.Sprint(i) {
+				v[i] = mask(child, path[1:])
+			}
+		}
+	}
+	return v
+}
+
+// AssertGoldenEmail compares the snapshot of params, see EmailSnapshot,
+// with the golden file at path, and fails the test if they differ. If the
+// UpdateGoldenEnv environment variable is set, it writes the snapshot to
+// path instead, creating its directory if needed.
+//
+//	srv := envlopedtest.NewServer()
+//	// ... exercise code that sends email with srv.Client() ...
+//	envlopedtest.AssertGoldenEmail(t, "testdata/welcome.golden.json", &srv.SentEmails()[0])
+func AssertGoldenEmail(t testing.TB, path string, params *envloped.SendEmailRequest, opts ...GoldenOption) {
+	t.Helper()
+
+	got, err := EmailSnapshot(params, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if os.Getenv(UpdateGoldenEnv) != "" {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("envlopedtest: failed to read golden file (set %s=1 to create it): %v", UpdateGoldenEnv, err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("envlopedtest: email does not match golden file %s (set %s=1 to update it):\n%s",
+			path, UpdateGoldenEnv, lineDiff(string(want), string(got)))
+	}
+}
+
+// lineDiff describes the first line where want and got differ.
+func lineDiff(want, got string) string {
+	wantLines := strings.Split(want, "\n")
+	gotLines := strings.Split(got, "\n")
+	for i := 0; i < len(wantLines) || i < len(gotLines); i++ {
+		var w, g string
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if w != g {
+			return fmt.Sprintf("line %d:\n  want: %s\n  got:  %s", i+1, w, g)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,104 @@
+package envlopedtest
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/envloped/envloped-go"
+)
+
+// recordingTB captures test failures for assertions.
+type recordingTB struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recordingTB) Helper() {}
+
+func (r *recordingTB) Errorf(format string, args ...any) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func (r *recordingTB) Fatalf(format string, args ...any) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+	runtime.Goexit()
+}
+
+// run calls fn with r in a new goroutine, so Fatalf can stop it.
+func (r *recordingTB) run(fn func(testing.TB)) {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn(r)
+	}()
+	<-done
+}
+
+func goldenEmail(token string) *envloped.SendEmailRequest {
+	return &envloped.SendEmailRequest{
+		From:     "sender@example.com",
+		To:       []string{"recipient@example.com"},
+		Subject:  "Welcome",
+		Html:     "<p>Confirm your address</p>",
+		Metadata: map[string]string{"token": token, "plan": "pro"},
+		Personalizations: []envloped.Personalization{
+			{To: []string{"a@example.com"}, Data: map[string]any{"token": token}},
+		},
+		Attachments: []envloped.Attachment{{Filename: "invite.ics", Content: []byte("BEGIN:VCALENDAR")}},
+	}
+}
+
+func TestAssertGoldenEmail(t *testing.T) {
+	t.Parallel()
+
+	mask := MaskFields("metadata.token", "personalizations.*.data.token")
+	AssertGoldenEmail(t, "testdata/welcome.golden.json", goldenEmail("tok_1"), mask)
+	AssertGoldenEmail(t, "testdata/welcome.golden.json", goldenEmail("tok_2"), mask)
+
+	changed := goldenEmail("tok_1")
+	changed.Subject = "Welcome!"
+	tb := &recordingTB{TB: t}
+	tb.run(func(tb testing.TB) { AssertGoldenEmail(tb, "testdata/welcome.golden.json", changed, mask) })
+	if len(tb.failures) != 1 || !strings.Contains(tb.failures[0], `got:    "subject": "Welcome!"`) {
+		t.Errorf("expected a failure describing the change, got %q", tb.failures)
+	}
+}
+
+func TestAssertGoldenEmail_Update(t *testing.T) {
+	t.Setenv(UpdateGoldenEnv, "1")
+
+	path := filepath.Join(t.TempDir(), "testdata", "email.golden.json")
+	AssertGoldenEmail(t, path, testEmail())
+
+	t.Setenv(UpdateGoldenEnv, "")
+	tb := &recordingTB{TB: t}
+	tb.run(func(tb testing.TB) { AssertGoldenEmail(tb, path, testEmail()) })
+	if len(tb.failures) != 0 {
+		t.Errorf("expected written golden file to match, got %q", tb.failures)
+	}
+	tb.run(func(tb testing.TB) { AssertGoldenEmail(tb, filepath.Join(t.TempDir(), "missing.json"), testEmail()) })
+	if len(tb.failures) != 1 || !strings.Contains(tb.failures[0], UpdateGoldenEnv) {
+		t.Errorf("expected a missing golden file to fail, got %q", tb.failures)
+	}
+}
+
+func TestEmailSnapshot(t *testing.T) {
+	t.Parallel()
+
+	snapshot, err := EmailSnapshot(goldenEmail("tok_1"), MaskFields("metadata.missing", "to.0"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := string(snapshot)
+	for _, want := range []string{`"content": "[15 bytes]"`, `"to": [` + "\n" + `    "[MASKED]"`, `"token": "tok_1"`} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected snapshot to contain %q, got %s", want, got)
+		}
+	}
+	if strings.Contains(got, "missing") {
+		t.Errorf("expected missing paths to be ignored, got %s", got)
+	}
+}
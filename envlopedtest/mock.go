@@ -0,0 +1,298 @@
+package envlopedtest
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/envloped/envloped-go"
+)
+
+// Call is a method call recorded by a mock.
+type Call struct {
+	// Method is the name of the method, without the WithContext suffix.
+	Method string
+
+	// Context is the context of the call, or context.Background() for
+	// methods without one.
+	Context context.Context
+
+	// Args are the arguments of the call, excluding the context and
+	// request options.
+	Args []any
+
+	// Options are the request options of the call. Methods without request
+	// options record the zero value.
+	Options envloped.CallOptions
+}
+
+// recorder records the calls made to a mock.
+type recorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+// record appends a call of method made with ctx.
+func (r *recorder) record(ctx context.Context, method string, args ...any) {
+	r.recordWithOptions(ctx, method, nil, args...)
+}
+
+// recordWithOptions appends a call of method made with ctx and opts.
+func (r *recorder) recordWithOptions(ctx context.Context, method string, opts []envloped.RequestOption, args ...any) {
+	c := Call{Method: method, Context: ctx, Args: args}
+	if opts != nil {
+		c.Options = envloped.ResolveRequestOptions(opts...)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, c)
+}
+
+// Calls returns the recorded calls, in the order they were made.
+func (r *recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Call(nil), r.calls...)
+}
+
+// CallCount returns the number of recorded calls of method.
+func (r *recorder) CallCount(method string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := 0
+	for _, c := range r.calls {
+		if c.Method == method {
+			n++
+		}
+	}
+	return n
+}
+
+// MockEmails is a programmable implementation of envloped.EmailsSvc that
+// records every call. Set the Func fields to control responses; when a
+// field is nil, the method succeeds with a default response. Methods with
+// and without a context are recorded under the same name, e.g. "Send", with
+// the context and request options of the call.
+// It is safe for concurrent use.
+//
+// Assign it to Client.Emails, or pass it wherever an envloped.EmailsSvc is
+// expected:
+//
+//	mock := &envlopedtest.MockEmails{}
+//	client := envloped.NewClient("unused")
+//	client.Emails = mock
+type MockEmails struct {
+	recorder
+
+	// SendFunc handles Send, SendWithContext, and SendWithResponse. By
+	// default, sends succeed with message IDs "mock_1", "mock_2", and so on.
+	SendFunc func(ctx context.Context, params *envloped.SendEmailRequest) (*envloped.SendEmailResponse, error)
+
+	// SendRawFunc handles SendRaw and SendRawWithContext. By default, sends
+	// succeed like those of SendFunc.
+	SendRawFunc func(ctx context.Context, message io.Reader) (*envloped.SendEmailResponse, error)
+
+	// GetFunc handles Get and GetWithContext. By default, it returns an
+	// Email with the requested ID.
+	GetFunc func(ctx context.Context, messageID string) (*envloped.Email, error)
+
+	// WaitForStatusFunc handles WaitForStatus. By default, it returns an
+	// Email with the requested ID and target status without waiting.
+	WaitForStatusFunc func(ctx context.Context, messageID string, target envloped.EmailStatus) (*envloped.Email, error)
+
+	// ListEventsFunc handles ListEvents and ListEventsWithContext. By
+	// default, it returns no events.
+	ListEventsFunc func(ctx context.Context, messageID string) ([]envloped.Event, error)
+
+	// ListFunc handles List and ListWithContext. By default, it returns an
+	// empty page.
+	ListFunc func(ctx context.Context, opts *envloped.ListEmailsOptions) (*envloped.EmailPage, error)
+
+	// ExportFunc handles Export and ExportWithContext. By default, it
+	// writes nothing.
+	ExportFunc func(ctx context.Context, params *envloped.ExportEmailsRequest, w io.Writer) error
+
+	// CancelFunc handles Cancel and CancelWithContext. By default, it
+	// returns a canceled Email with the requested ID.
+	CancelFunc func(ctx context.Context, messageID string) (*envloped.Email, error)
+
+	// UpdateScheduledFunc handles UpdateScheduled and
+	// UpdateScheduledWithContext. By default, it returns a scheduled Email
+	// with the requested ID and send time.
+	UpdateScheduledFunc func(ctx context.Context, messageID string, sendAt time.Time) (*envloped.Email, error)
+
+	// ResendFunc handles Resend and ResendWithContext. By default, resends
+	// succeed like those of SendFunc.
+	ResendFunc func(ctx context.Context, messageID string, params *envloped.ResendEmailRequest) (*envloped.SendEmailResponse, error)
+
+	seq int
+}
+
+var _ envloped.EmailsSvc = (*MockEmails)(nil)
+
+// SentEmails returns the params of every recorded send, in order.
+func (m *MockEmails) SentEmails() []*envloped.SendEmailRequest {
+	var sent []*envloped.SendEmailRequest
+	for _, c := range m.Calls() {
+		if c.Method == "Send" {
+			sent = append(sent, c.Args[0].(*envloped.SendEmailRequest))
+		}
+	}
+	return sent
+}
+
+// Send implements envloped.EmailsSvc.
+func (m *MockEmails) Send(params *envloped.SendEmailRequest, opts ...envloped.RequestOption) (*envloped.SendEmailResponse, error) {
+	return m.SendWithContext(context.Background(), params, opts...)
+}
+
+// SendWithContext implements envloped.EmailsSvc.
+func (m *MockEmails) SendWithContext(ctx context.Context, params *envloped.SendEmailRequest, opts ...envloped.RequestOption) (*envloped.SendEmailResponse, error) {
+	m.recordWithOptions(ctx, "Send", opts, params)
+	if m.SendFunc != nil {
+		return m.SendFunc(ctx, params)
+	}
+	return m.sent(), nil
+}
+
+// SendWithResponse implements envloped.EmailsSvc. On success, the response
+// metadata reports HTTP 200.
+func (m *MockEmails) SendWithResponse(ctx context.Context, params *envloped.SendEmailRequest, opts ...envloped.RequestOption) (*envloped.SendEmailResponse, *envloped.ResponseMeta, error) {
+	resp, err := m.SendWithContext(ctx, params, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, &envloped.ResponseMeta{StatusCode: http.StatusOK, Header: http.Header{}}, nil
+}
+
+// SendRaw implements envloped.EmailsSvc.
+func (m *MockEmails) SendRaw(message io.Reader, opts ...envloped.RequestOption) (*envloped.SendEmailResponse, error) {
+	return m.SendRawWithContext(context.Background(), message, opts...)
+}
+
+// SendRawWithContext implements envloped.EmailsSvc.
+func (m *MockEmails) SendRawWithContext(ctx context.Context, message io.Reader, opts ...envloped.RequestOption) (*envloped.SendEmailResponse, error) {
+	m.recordWithOptions(ctx, "SendRaw", opts, message)
+	if m.SendRawFunc != nil {
+		return m.SendRawFunc(ctx, message)
+	}
+	return m.sent(), nil
+}
+
+// sent returns a successful send response with the next message ID.
+func (m *MockEmails) sent() *envloped.SendEmailResponse {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.seq++
+	return &envloped.SendEmailResponse{Success: true, MessageId: fmt.Sprintf("mock_%d", m.seq)}
+}
+
+// Get implements envloped.EmailsSvc.
+func (m *MockEmails) Get(messageID string) (*envloped.Email, error) {
+	return m.GetWithContext(context.Background(), messageID)
+}
+
+// GetWithContext implements envloped.EmailsSvc.
+func (m *MockEmails) GetWithContext(ctx context.Context, messageID string) (*envloped.Email, error) {
+	m.record(ctx, "Get", messageID)
+	if m.GetFunc != nil {
+		return m.GetFunc(ctx, messageID)
+	}
+	return &envloped.Email{ID: messageID, Status: envloped.EmailStatusSent}, nil
+}
+
+// WaitForStatus implements envloped.EmailsSvc.
+func (m *MockEmails) WaitForStatus(ctx context.Context, messageID string, target envloped.EmailStatus, pollInterval time.Duration) (*envloped.Email, error) {
+	m.record(ctx, "WaitForStatus", messageID, target, pollInterval)
+	if m.WaitForStatusFunc != nil {
+		return m.WaitForStatusFunc(ctx, messageID, target)
+	}
+	return &envloped.Email{ID: messageID, Status: target}, nil
+}
+
+// ListEvents implements envloped.EmailsSvc.
+func (m *MockEmails) ListEvents(messageID string) ([]envloped.Event, error) {
+	return m.ListEventsWithContext(context.Background(), messageID)
+}
+
+// ListEventsWithContext implements envloped.EmailsSvc.
+func (m *MockEmails) ListEventsWithContext(ctx context.Context, messageID string) ([]envloped.Event, error) {
+	m.record(ctx, "ListEvents", messageID)
+	if m.ListEventsFunc != nil {
+		return m.ListEventsFunc(ctx, messageID)
+	}
+	return nil, nil
+}
+
+// List implements envloped.EmailsSvc.
+func (m *MockEmails) List(opts *envloped.ListEmailsOptions) (*envloped.EmailPage, error) {
+	return m.ListWithContext(context.Background(), opts)
+}
+
+// ListWithContext implements envloped.EmailsSvc.
+func (m *MockEmails) ListWithContext(ctx context.Context, opts *envloped.ListEmailsOptions) (*envloped.EmailPage, error) {
+	m.record(ctx, "List", opts)
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx, opts)
+	}
+	return &envloped.EmailPage{}, nil
+}
+
+// Export implements envloped.EmailsSvc.
+func (m *MockEmails) Export(params *envloped.ExportEmailsRequest, w io.Writer) error {
+	return m.ExportWithContext(context.Background(), params, w)
+}
+
+// ExportWithContext implements envloped.EmailsSvc.
+func (m *MockEmails) ExportWithContext(ctx context.Context, params *envloped.ExportEmailsRequest, w io.Writer) error {
+	m.record(ctx, "Export", params)
+	if m.ExportFunc != nil {
+		return m.ExportFunc(ctx, params, w)
+	}
+	return nil
+}
+
+// Cancel implements envloped.EmailsSvc.
+func (m *MockEmails) Cancel(messageID string) (*envloped.Email, error) {
+	return m.CancelWithContext(context.Background(), messageID)
+}
+
+// CancelWithContext implements envloped.EmailsSvc.
+func (m *MockEmails) CancelWithContext(ctx context.Context, messageID string) (*envloped.Email, error) {
+	m.record(ctx, "Cancel", messageID)
+	if m.CancelFunc != nil {
+		return m.CancelFunc(ctx, messageID)
+	}
+	return &envloped.Email{ID: messageID, Status: envloped.EmailStatusCanceled}, nil
+}
+
+// UpdateScheduled implements envloped.EmailsSvc.
+func (m *MockEmails) UpdateScheduled(messageID string, sendAt time.Time) (*envloped.Email, error) {
+	return m.UpdateScheduledWithContext(context.Background(), messageID, sendAt)
+}
+
+// UpdateScheduledWithContext implements envloped.EmailsSvc.
+func (m *MockEmails) UpdateScheduledWithContext(ctx context.Context, messageID string, sendAt time.Time) (*envloped.Email, error) {
+	m.record(ctx, "UpdateScheduled", messageID, sendAt)
+	if m.UpdateScheduledFunc != nil {
+		return m.UpdateScheduledFunc(ctx, messageID, sendAt)
+	}
+	return &envloped.Email{ID: messageID, Status: envloped.EmailStatusScheduled, ScheduledAt: &sendAt}, nil
+}
+
+// Resend implements envloped.EmailsSvc.
+func (m *MockEmails) Resend(messageID string, params *envloped.ResendEmailRequest, opts ...envloped.RequestOption) (*envloped.SendEmailResponse, error) {
+	return m.ResendWithContext(context.Background(), messageID, params, opts...)
+}
+
+// ResendWithContext implements envloped.EmailsSvc.
+func (m *MockEmails) ResendWithContext(ctx context.Context, messageID string, params *envloped.ResendEmailRequest, opts ...envloped.RequestOption) (*envloped.SendEmailResponse, error) {
+	m.recordWithOptions(ctx, "Resend", opts, messageID, params)
+	if m.ResendFunc != nil {
+		return m.ResendFunc(ctx, messageID, params)
+	}
+	return m.sent(), nil
+}
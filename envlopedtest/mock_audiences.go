@@ -0,0 +1,125 @@
+package envlopedtest
+
+import (
+	"context"
+
+	"github.com/envloped/envloped-go"
+)
+
+// MockAudiences is a programmable implementation of envloped.AudiencesSvc that
+// records every call, like MockEmails.
+//
+//	client.Audiences = &envlopedtest.MockAudiences{}
+type MockAudiences struct {
+	recorder
+
+	// CreateFunc handles Create and CreateWithContext. By default, it returns an
+	// empty Audience.
+	CreateFunc func(ctx context.Context, params *envloped.AudienceRequest) (*envloped.Audience, error)
+
+	// GetFunc handles Get and GetWithContext. By default, it returns an empty
+	// Audience.
+	GetFunc func(ctx context.Context, audienceID string) (*envloped.Audience, error)
+
+	// ListFunc handles List and ListWithContext. By default, it returns an empty
+	// list.
+	ListFunc func(ctx context.Context) ([]envloped.Audience, error)
+
+	// DeleteFunc handles Delete and DeleteWithContext. By default, it returns
+	// nil.
+	DeleteFunc func(ctx context.Context, audienceID string) error
+
+	// AddContactsFunc handles AddContacts and AddContactsWithContext. By
+	// default, it returns nil.
+	AddContactsFunc func(ctx context.Context, audienceID string, contactIDs []string) error
+
+	// RemoveContactsFunc handles RemoveContacts and RemoveContactsWithContext.
+	// By default, it returns nil.
+	RemoveContactsFunc func(ctx context.Context, audienceID string, contactIDs []string) error
+}
+
+var _ envloped.AudiencesSvc = (*MockAudiences)(nil)
+
+// Create implements envloped.AudiencesSvc.
+func (m *MockAudiences) Create(params *envloped.AudienceRequest) (*envloped.Audience, error) {
+	return m.CreateWithContext(context.Background(), params)
+}
+
+// CreateWithContext implements envloped.AudiencesSvc.
+func (m *MockAudiences) CreateWithContext(ctx context.Context, params *envloped.AudienceRequest) (*envloped.Audience, error) {
+	m.record(ctx, "Create", params)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, params)
+	}
+	return &envloped.Audience{}, nil
+}
+
+// Get implements envloped.AudiencesSvc.
+func (m *MockAudiences) Get(audienceID string) (*envloped.Audience, error) {
+	return m.GetWithContext(context.Background(), audienceID)
+}
+
+// GetWithContext implements envloped.AudiencesSvc.
+func (m *MockAudiences) GetWithContext(ctx context.Context, audienceID string) (*envloped.Audience, error) {
+	m.record(ctx, "Get", audienceID)
+	if m.GetFunc != nil {
+		return m.GetFunc(ctx, audienceID)
+	}
+	return &envloped.Audience{}, nil
+}
+
+// List implements envloped.AudiencesSvc.
+func (m *MockAudiences) List() ([]envloped.Audience, error) {
+	return m.ListWithContext(context.Background())
+}
+
+// ListWithContext implements envloped.AudiencesSvc.
+func (m *MockAudiences) ListWithContext(ctx context.Context) ([]envloped.Audience, error) {
+	m.record(ctx, "List")
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx)
+	}
+	return nil, nil
+}
+
+// Delete implements envloped.AudiencesSvc.
+func (m *MockAudiences) Delete(audienceID string) error {
+	return m.DeleteWithContext(context.Background(), audienceID)
+}
+
+// DeleteWithContext implements envloped.AudiencesSvc.
+func (m *MockAudiences) DeleteWithContext(ctx context.Context, audienceID string) error {
+	m.record(ctx, "Delete", audienceID)
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, audienceID)
+	}
+	return nil
+}
+
+// AddContacts implements envloped.AudiencesSvc.
+func (m *MockAudiences) AddContacts(audienceID string, contactIDs []string) error {
+	return m.AddContactsWithContext(context.Background(), audienceID, contactIDs)
+}
+
+// AddContactsWithContext implements envloped.AudiencesSvc.
+func (m *MockAudiences) AddContactsWithContext(ctx context.Context, audienceID string, contactIDs []string) error {
+	m.record(ctx, "AddContacts", audienceID, contactIDs)
+	if m.AddContactsFunc != nil {
+		return m.AddContactsFunc(ctx, audienceID, contactIDs)
+	}
+	return nil
+}
+
+// RemoveContacts implements envloped.AudiencesSvc.
+func (m *MockAudiences) RemoveContacts(audienceID string, contactIDs []string) error {
+	return m.RemoveContactsWithContext(context.Background(), audienceID, contactIDs)
+}
+
+// RemoveContactsWithContext implements envloped.AudiencesSvc.
+func (m *MockAudiences) RemoveContactsWithContext(ctx context.Context, audienceID string, contactIDs []string) error {
+	m.record(ctx, "RemoveContacts", audienceID, contactIDs)
+	if m.RemoveContactsFunc != nil {
+		return m.RemoveContactsFunc(ctx, audienceID, contactIDs)
+	}
+	return nil
+}
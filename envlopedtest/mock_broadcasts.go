@@ -0,0 +1,126 @@
+package envlopedtest
+
+import (
+	"context"
+	"time"
+
+	"github.com/envloped/envloped-go"
+)
+
+// MockBroadcasts is a programmable implementation of envloped.BroadcastsSvc that
+// records every call, like MockEmails.
+//
+//	client.Broadcasts = &envlopedtest.MockBroadcasts{}
+type MockBroadcasts struct {
+	recorder
+
+	// CreateFunc handles Create and CreateWithContext. By default, it returns an
+	// empty Broadcast.
+	CreateFunc func(ctx context.Context, params *envloped.BroadcastRequest) (*envloped.Broadcast, error)
+
+	// GetFunc handles Get and GetWithContext. By default, it returns an empty
+	// Broadcast.
+	GetFunc func(ctx context.Context, broadcastID string) (*envloped.Broadcast, error)
+
+	// ListFunc handles List and ListWithContext. By default, it returns an empty
+	// list.
+	ListFunc func(ctx context.Context) ([]envloped.Broadcast, error)
+
+	// ScheduleFunc handles Schedule and ScheduleWithContext. By default, it
+	// returns an empty Broadcast.
+	ScheduleFunc func(ctx context.Context, broadcastID string, at time.Time) (*envloped.Broadcast, error)
+
+	// CancelFunc handles Cancel and CancelWithContext. By default, it returns an
+	// empty Broadcast.
+	CancelFunc func(ctx context.Context, broadcastID string) (*envloped.Broadcast, error)
+
+	// StatsFunc handles Stats and StatsWithContext. By default, it returns an
+	// empty BroadcastStats.
+	StatsFunc func(ctx context.Context, broadcastID string) (*envloped.BroadcastStats, error)
+}
+
+var _ envloped.BroadcastsSvc = (*MockBroadcasts)(nil)
+
+// Create implements envloped.BroadcastsSvc.
+func (m *MockBroadcasts) Create(params *envloped.BroadcastRequest) (*envloped.Broadcast, error) {
+	return m.CreateWithContext(context.Background(), params)
+}
+
+// CreateWithContext implements envloped.BroadcastsSvc.
+func (m *MockBroadcasts) CreateWithContext(ctx context.Context, params *envloped.BroadcastRequest) (*envloped.Broadcast, error) {
+	m.record(ctx, "Create", params)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, params)
+	}
+	return &envloped.Broadcast{}, nil
+}
+
+// Get implements envloped.BroadcastsSvc.
+func (m *MockBroadcasts) Get(broadcastID string) (*envloped.Broadcast, error) {
+	return m.GetWithContext(context.Background(), broadcastID)
+}
+
+// GetWithContext implements envloped.BroadcastsSvc.
+func (m *MockBroadcasts) GetWithContext(ctx context.Context, broadcastID string) (*envloped.Broadcast, error) {
+	m.record(ctx, "Get", broadcastID)
+	if m.GetFunc != nil {
+		return m.GetFunc(ctx, broadcastID)
+	}
+	return &envloped.Broadcast{}, nil
+}
+
+// List implements envloped.BroadcastsSvc.
+func (m *MockBroadcasts) List() ([]envloped.Broadcast, error) {
+	return m.ListWithContext(context.Background())
+}
+
+// ListWithContext implements envloped.BroadcastsSvc.
+func (m *MockBroadcasts) ListWithContext(ctx context.Context) ([]envloped.Broadcast, error) {
+	m.record(ctx, "List")
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx)
+	}
+	return nil, nil
+}
+
+// Schedule implements envloped.BroadcastsSvc.
+func (m *MockBroadcasts) Schedule(broadcastID string, at time.Time) (*envloped.Broadcast, error) {
+	return m.ScheduleWithContext(context.Background(), broadcastID, at)
+}
+
+// ScheduleWithContext implements envloped.BroadcastsSvc.
+func (m *MockBroadcasts) ScheduleWithContext(ctx context.Context, broadcastID string, at time.Time) (*envloped.Broadcast, error) {
+	m.record(ctx, "Schedule", broadcastID, at)
+	if m.ScheduleFunc != nil {
+		return m.ScheduleFunc(ctx, broadcastID, at)
+	}
+	return &envloped.Broadcast{}, nil
+}
+
+// Cancel implements envloped.BroadcastsSvc.
+func (m *MockBroadcasts) Cancel(broadcastID string) (*envloped.Broadcast, error) {
+	return m.CancelWithContext(context.Background(), broadcastID)
+}
+
+// CancelWithContext implements envloped.BroadcastsSvc.
+func (m *MockBroadcasts) CancelWithContext(ctx context.Context, broadcastID string) (*envloped.Broadcast, error) {
+	m.record(ctx, "Cancel", broadcastID)
+	if m.CancelFunc != nil {
+		return m.CancelFunc(ctx, broadcastID)
+	}
+	return &envloped.Broadcast{}, nil
+}
+
+// Stats implements envloped.BroadcastsSvc.
+func (m *MockBroadcasts) Stats(broadcastID string) (*envloped.BroadcastStats, error) {
+	return m.StatsWithContext(context.Background(), broadcastID)
+}
+
+// StatsWithContext implements envloped.BroadcastsSvc.
+func (m *MockBroadcasts) StatsWithContext(ctx context.Context, broadcastID string) (*envloped.BroadcastStats, error) {
+	m.record(ctx, "Stats", broadcastID)
+	if m.StatsFunc != nil {
+		return m.StatsFunc(ctx, broadcastID)
+	}
+	return &envloped.BroadcastStats{}, nil
+}
@@ -0,0 +1,158 @@
+package envlopedtest
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/envloped/envloped-go"
+)
+
+// MockContacts is a programmable implementation of envloped.ContactsSvc that
+// records every call, like MockEmails.
+//
+//	client.Contacts = &envlopedtest.MockContacts{}
+type MockContacts struct {
+	recorder
+
+	// CreateFunc handles Create and CreateWithContext. By default, it returns an
+	// empty Contact.
+	CreateFunc func(ctx context.Context, params *envloped.ContactRequest) (*envloped.Contact, error)
+
+	// GetFunc handles Get and GetWithContext. By default, it returns an empty
+	// Contact.
+	GetFunc func(ctx context.Context, idOrEmail string) (*envloped.Contact, error)
+
+	// ListFunc handles List and ListWithContext. By default, it returns an empty
+	// ContactPage.
+	ListFunc func(ctx context.Context, opts *envloped.ListContactsOptions) (*envloped.ContactPage, error)
+
+	// UpdateFunc handles Update and UpdateWithContext. By default, it returns an
+	// empty Contact.
+	UpdateFunc func(ctx context.Context, idOrEmail string, params *envloped.ContactRequest) (*envloped.Contact, error)
+
+	// DeleteFunc handles Delete and DeleteWithContext. By default, it returns
+	// nil.
+	DeleteFunc func(ctx context.Context, idOrEmail string) error
+
+	// ImportFunc handles Import and ImportWithContext. By default, it returns an
+	// empty ContactImport.
+	ImportFunc func(ctx context.Context, csv io.Reader, opts *envloped.ImportOptions) (*envloped.ContactImport, error)
+
+	// GetImportFunc handles GetImport and GetImportWithContext. By default, it
+	// returns an empty ContactImport.
+	GetImportFunc func(ctx context.Context, importID string) (*envloped.ContactImport, error)
+
+	// WaitForImportFunc handles WaitForImport. By default, it returns an empty
+	// ContactImport.
+	WaitForImportFunc func(ctx context.Context, importID string, pollInterval time.Duration) (*envloped.ContactImport, error)
+}
+
+var _ envloped.ContactsSvc = (*MockContacts)(nil)
+
+// Create implements envloped.ContactsSvc.
+func (m *MockContacts) Create(params *envloped.ContactRequest) (*envloped.Contact, error) {
+	return m.CreateWithContext(context.Background(), params)
+}
+
+// CreateWithContext implements envloped.ContactsSvc.
+func (m *MockContacts) CreateWithContext(ctx context.Context, params *envloped.ContactRequest) (*envloped.Contact, error) {
+	m.record(ctx, "Create", params)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, params)
+	}
+	return &envloped.Contact{}, nil
+}
+
+// Get implements envloped.ContactsSvc.
+func (m *MockContacts) Get(idOrEmail string) (*envloped.Contact, error) {
+	return m.GetWithContext(context.Background(), idOrEmail)
+}
+
+// GetWithContext implements envloped.ContactsSvc.
+func (m *MockContacts) GetWithContext(ctx context.Context, idOrEmail string) (*envloped.Contact, error) {
+	m.record(ctx, "Get", idOrEmail)
+	if m.GetFunc != nil {
+		return m.GetFunc(ctx, idOrEmail)
+	}
+	return &envloped.Contact{}, nil
+}
+
+// List implements envloped.ContactsSvc.
+func (m *MockContacts) List(opts *envloped.ListContactsOptions) (*envloped.ContactPage, error) {
+	return m.ListWithContext(context.Background(), opts)
+}
+
+// ListWithContext implements envloped.ContactsSvc.
+func (m *MockContacts) ListWithContext(ctx context.Context, opts *envloped.ListContactsOptions) (*envloped.ContactPage, error) {
+	m.record(ctx, "List", opts)
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx, opts)
+	}
+	return &envloped.ContactPage{}, nil
+}
+
+// Update implements envloped.ContactsSvc.
+func (m *MockContacts) Update(idOrEmail string, params *envloped.ContactRequest) (*envloped.Contact, error) {
+	return m.UpdateWithContext(context.Background(), idOrEmail, params)
+}
+
+// UpdateWithContext implements envloped.ContactsSvc.
+func (m *MockContacts) UpdateWithContext(ctx context.Context, idOrEmail string, params *envloped.ContactRequest) (*envloped.Contact, error) {
+	m.record(ctx, "Update", idOrEmail, params)
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(ctx, idOrEmail, params)
+	}
+	return &envloped.Contact{}, nil
+}
+
+// Delete implements envloped.ContactsSvc.
+func (m *MockContacts) Delete(idOrEmail string) error {
+	return m.DeleteWithContext(context.Background(), idOrEmail)
+}
+
+// DeleteWithContext implements envloped.ContactsSvc.
+func (m *MockContacts) DeleteWithContext(ctx context.Context, idOrEmail string) error {
+	m.record(ctx, "Delete", idOrEmail)
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, idOrEmail)
+	}
+	return nil
+}
+
+// Import implements envloped.ContactsSvc.
+func (m *MockContacts) Import(csv io.Reader, opts *envloped.ImportOptions) (*envloped.ContactImport, error) {
+	return m.ImportWithContext(context.Background(), csv, opts)
+}
+
+// ImportWithContext implements envloped.ContactsSvc.
+func (m *MockContacts) ImportWithContext(ctx context.Context, csv io.Reader, opts *envloped.ImportOptions) (*envloped.ContactImport, error) {
+	m.record(ctx, "Import", csv, opts)
+	if m.ImportFunc != nil {
+		return m.ImportFunc(ctx, csv, opts)
+	}
+	return &envloped.ContactImport{}, nil
+}
+
+// GetImport implements envloped.ContactsSvc.
+func (m *MockContacts) GetImport(importID string) (*envloped.ContactImport, error) {
+	return m.GetImportWithContext(context.Background(), importID)
+}
+
+// GetImportWithContext implements envloped.ContactsSvc.
+func (m *MockContacts) GetImportWithContext(ctx context.Context, importID string) (*envloped.ContactImport, error) {
+	m.record(ctx, "GetImport", importID)
+	if m.GetImportFunc != nil {
+		return m.GetImportFunc(ctx, importID)
+	}
+	return &envloped.ContactImport{}, nil
+}
+
+// WaitForImport implements envloped.ContactsSvc.
+func (m *MockContacts) WaitForImport(ctx context.Context, importID string, pollInterval time.Duration) (*envloped.ContactImport, error) {
+	m.record(ctx, "WaitForImport", importID, pollInterval)
+	if m.WaitForImportFunc != nil {
+		return m.WaitForImportFunc(ctx, importID, pollInterval)
+	}
+	return &envloped.ContactImport{}, nil
+}
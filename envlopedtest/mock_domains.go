@@ -0,0 +1,193 @@
+package envlopedtest
+
+import (
+	"context"
+	"time"
+
+	"github.com/envloped/envloped-go"
+)
+
+// MockDomains is a programmable implementation of envloped.DomainsSvc that
+// records every call, like MockEmails.
+//
+//	client.Domains = &envlopedtest.MockDomains{}
+type MockDomains struct {
+	recorder
+
+	// CreateFunc handles Create and CreateWithContext. By default, it returns an
+	// empty Domain.
+	CreateFunc func(ctx context.Context, params *envloped.CreateDomainRequest) (*envloped.Domain, error)
+
+	// GetFunc handles Get and GetWithContext. By default, it returns an empty
+	// Domain.
+	GetFunc func(ctx context.Context, domainID string) (*envloped.Domain, error)
+
+	// ListFunc handles List and ListWithContext. By default, it returns an empty
+	// list.
+	ListFunc func(ctx context.Context) ([]envloped.Domain, error)
+
+	// DeleteFunc handles Delete and DeleteWithContext. By default, it returns
+	// nil.
+	DeleteFunc func(ctx context.Context, domainID string) error
+
+	// VerifyFunc handles Verify and VerifyWithContext. By default, it returns an
+	// empty Domain.
+	VerifyFunc func(ctx context.Context, domainID string) (*envloped.Domain, error)
+
+	// WaitForVerificationFunc handles WaitForVerification. By default, it
+	// returns an empty Domain.
+	WaitForVerificationFunc func(ctx context.Context, domainID string, pollInterval time.Duration) (*envloped.Domain, error)
+
+	// StatsFunc handles Stats and StatsWithContext. By default, it returns an
+	// empty DomainStats.
+	StatsFunc func(ctx context.Context, domainID string, period envloped.StatsPeriod) (*envloped.DomainStats, error)
+
+	// GetReturnPathFunc handles GetReturnPath and GetReturnPathWithContext. By
+	// default, it returns an empty ReturnPath.
+	GetReturnPathFunc func(ctx context.Context, domainID string) (*envloped.ReturnPath, error)
+
+	// SetReturnPathFunc handles SetReturnPath and SetReturnPathWithContext. By
+	// default, it returns an empty ReturnPath.
+	SetReturnPathFunc func(ctx context.Context, domainID string, subdomain string) (*envloped.ReturnPath, error)
+
+	// CheckDNSFunc handles CheckDNS and CheckDNSWithContext. By default, it
+	// returns an empty DNSCheckResult.
+	CheckDNSFunc func(ctx context.Context, domainID string) (*envloped.DNSCheckResult, error)
+}
+
+var _ envloped.DomainsSvc = (*MockDomains)(nil)
+
+// Create implements envloped.DomainsSvc.
+func (m *MockDomains) Create(params *envloped.CreateDomainRequest) (*envloped.Domain, error) {
+	return m.CreateWithContext(context.Background(), params)
+}
+
+// CreateWithContext implements envloped.DomainsSvc.
+func (m *MockDomains) CreateWithContext(ctx context.Context, params *envloped.CreateDomainRequest) (*envloped.Domain, error) {
+	m.record(ctx, "Create", params)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, params)
+	}
+	return &envloped.Domain{}, nil
+}
+
+// Get implements envloped.DomainsSvc.
+func (m *MockDomains) Get(domainID string) (*envloped.Domain, error) {
+	return m.GetWithContext(context.Background(), domainID)
+}
+
+// GetWithContext implements envloped.DomainsSvc.
+func (m *MockDomains) GetWithContext(ctx context.Context, domainID string) (*envloped.Domain, error) {
+	m.record(ctx, "Get", domainID)
+	if m.GetFunc != nil {
+		return m.GetFunc(ctx, domainID)
+	}
+	return &envloped.Domain{}, nil
+}
+
+// List implements envloped.DomainsSvc.
+func (m *MockDomains) List() ([]envloped.Domain, error) {
+	return m.ListWithContext(context.Background())
+}
+
+// ListWithContext implements envloped.DomainsSvc.
+func (m *MockDomains) ListWithContext(ctx context.Context) ([]envloped.Domain, error) {
+	m.record(ctx, "List")
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx)
+	}
+	return nil, nil
+}
+
+// Delete implements envloped.DomainsSvc.
+func (m *MockDomains) Delete(domainID string) error {
+	return m.DeleteWithContext(context.Background(), domainID)
+}
+
+// DeleteWithContext implements envloped.DomainsSvc.
+func (m *MockDomains) DeleteWithContext(ctx context.Context, domainID string) error {
+	m.record(ctx, "Delete", domainID)
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, domainID)
+	}
+	return nil
+}
+
+// Verify implements envloped.DomainsSvc.
+func (m *MockDomains) Verify(domainID string) (*envloped.Domain, error) {
+	return m.VerifyWithContext(context.Background(), domainID)
+}
+
+// VerifyWithContext implements envloped.DomainsSvc.
+func (m *MockDomains) VerifyWithContext(ctx context.Context, domainID string) (*envloped.Domain, error) {
+	m.record(ctx, "Verify", domainID)
+	if m.VerifyFunc != nil {
+		return m.VerifyFunc(ctx, domainID)
+	}
+	return &envloped.Domain{}, nil
+}
+
+// WaitForVerification implements envloped.DomainsSvc.
+func (m *MockDomains) WaitForVerification(ctx context.Context, domainID string, pollInterval time.Duration) (*envloped.Domain, error) {
+	m.record(ctx, "WaitForVerification", domainID, pollInterval)
+	if m.WaitForVerificationFunc != nil {
+		return m.WaitForVerificationFunc(ctx, domainID, pollInterval)
+	}
+	return &envloped.Domain{}, nil
+}
+
+// Stats implements envloped.DomainsSvc.
+func (m *MockDomains) Stats(domainID string, period envloped.StatsPeriod) (*envloped.DomainStats, error) {
+	return m.StatsWithContext(context.Background(), domainID, period)
+}
+
+// StatsWithContext implements envloped.DomainsSvc.
+func (m *MockDomains) StatsWithContext(ctx context.Context, domainID string, period envloped.StatsPeriod) (*envloped.DomainStats, error) {
+	m.record(ctx, "Stats", domainID, period)
+	if m.StatsFunc != nil {
+		return m.StatsFunc(ctx, domainID, period)
+	}
+	return &envloped.DomainStats{}, nil
+}
+
+// GetReturnPath implements envloped.DomainsSvc.
+func (m *MockDomains) GetReturnPath(domainID string) (*envloped.ReturnPath, error) {
+	return m.GetReturnPathWithContext(context.Background(), domainID)
+}
+
+// GetReturnPathWithContext implements envloped.DomainsSvc.
+func (m *MockDomains) GetReturnPathWithContext(ctx context.Context, domainID string) (*envloped.ReturnPath, error) {
+	m.record(ctx, "GetReturnPath", domainID)
+	if m.GetReturnPathFunc != nil {
+		return m.GetReturnPathFunc(ctx, domainID)
+	}
+	return &envloped.ReturnPath{}, nil
+}
+
+// SetReturnPath implements envloped.DomainsSvc.
+func (m *MockDomains) SetReturnPath(domainID string, subdomain string) (*envloped.ReturnPath, error) {
+	return m.SetReturnPathWithContext(context.Background(), domainID, subdomain)
+}
+
+// SetReturnPathWithContext implements envloped.DomainsSvc.
+func (m *MockDomains) SetReturnPathWithContext(ctx context.Context, domainID string, subdomain string) (*envloped.ReturnPath, error) {
+	m.record(ctx, "SetReturnPath", domainID, subdomain)
+	if m.SetReturnPathFunc != nil {
+		return m.SetReturnPathFunc(ctx, domainID, subdomain)
+	}
+	return &envloped.ReturnPath{}, nil
+}
+
+// CheckDNS implements envloped.DomainsSvc.
+func (m *MockDomains) CheckDNS(domainID string) (*envloped.DNSCheckResult, error) {
+	return m.CheckDNSWithContext(context.Background(), domainID)
+}
+
+// CheckDNSWithContext implements envloped.DomainsSvc.
+func (m *MockDomains) CheckDNSWithContext(ctx context.Context, domainID string) (*envloped.DNSCheckResult, error) {
+	m.record(ctx, "CheckDNS", domainID)
+	if m.CheckDNSFunc != nil {
+		return m.CheckDNSFunc(ctx, domainID)
+	}
+	return &envloped.DNSCheckResult{}, nil
+}
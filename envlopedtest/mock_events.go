@@ -0,0 +1,71 @@
+package envlopedtest
+
+import (
+	"context"
+	"time"
+
+	"github.com/envloped/envloped-go"
+)
+
+// MockEvents is a programmable implementation of envloped.EventsSvc that
+// records every call, like MockEmails.
+//
+//	client.Events = &envlopedtest.MockEvents{}
+type MockEvents struct {
+	recorder
+
+	// StreamChanFunc handles Stream. It cannot be named StreamFunc, which is
+	// the name of a method. By default, it returns a closed channel.
+	StreamChanFunc func(ctx context.Context, opts *envloped.EventStreamOptions) (<-chan envloped.Event, error)
+
+	// StreamHandlerFunc handles StreamFunc. By default, it returns nil
+	// without calling handler.
+	StreamHandlerFunc func(ctx context.Context, opts *envloped.EventStreamOptions, handler func(envloped.Event) error) error
+
+	// PollFunc handles Poll. By default, it returns nil, so set it when the
+	// code under test uses the poller.
+	PollFunc func(ctx context.Context, since time.Time, interval time.Duration) *envloped.EventPoller
+
+	// ListFunc handles List. By default, it returns an empty page.
+	ListFunc func(ctx context.Context, opts *envloped.ListEventsOptions) (*envloped.EventPage, error)
+}
+
+var _ envloped.EventsSvc = (*MockEvents)(nil)
+
+// Stream implements envloped.EventsSvc.
+func (m *MockEvents) Stream(ctx context.Context, opts *envloped.EventStreamOptions) (<-chan envloped.Event, error) {
+	m.record(ctx, "Stream", opts)
+	if m.StreamChanFunc != nil {
+		return m.StreamChanFunc(ctx, opts)
+	}
+	ch := make(chan envloped.Event)
+	close(ch)
+	return ch, nil
+}
+
+// StreamFunc implements envloped.EventsSvc.
+func (m *MockEvents) StreamFunc(ctx context.Context, opts *envloped.EventStreamOptions, handler func(envloped.Event) error) error {
+	m.record(ctx, "StreamFunc", opts, handler)
+	if m.StreamHandlerFunc != nil {
+		return m.StreamHandlerFunc(ctx, opts, handler)
+	}
+	return nil
+}
+
+// Poll implements envloped.EventsSvc.
+func (m *MockEvents) Poll(ctx context.Context, since time.Time, interval time.Duration) *envloped.EventPoller {
+	m.record(ctx, "Poll", since, interval)
+	if m.PollFunc != nil {
+		return m.PollFunc(ctx, since, interval)
+	}
+	return nil
+}
+
+// List implements envloped.EventsSvc.
+func (m *MockEvents) List(ctx context.Context, opts *envloped.ListEventsOptions) (*envloped.EventPage, error) {
+	m.record(ctx, "List", opts)
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx, opts)
+	}
+	return &envloped.EventPage{}, nil
+}
@@ -0,0 +1,107 @@
+package envlopedtest
+
+import (
+	"context"
+
+	"github.com/envloped/envloped-go"
+)
+
+// MockInbound is a programmable implementation of envloped.InboundSvc that
+// records every call, like MockEmails.
+//
+//	client.Inbound = &envlopedtest.MockInbound{}
+type MockInbound struct {
+	recorder
+
+	// CreateRouteFunc handles CreateRoute and CreateRouteWithContext. By
+	// default, it returns an empty InboundRoute.
+	CreateRouteFunc func(ctx context.Context, params *envloped.InboundRouteRequest) (*envloped.InboundRoute, error)
+
+	// GetRouteFunc handles GetRoute and GetRouteWithContext. By default, it
+	// returns an empty InboundRoute.
+	GetRouteFunc func(ctx context.Context, routeID string) (*envloped.InboundRoute, error)
+
+	// ListRoutesFunc handles ListRoutes and ListRoutesWithContext. By default,
+	// it returns an empty list.
+	ListRoutesFunc func(ctx context.Context) ([]envloped.InboundRoute, error)
+
+	// UpdateRouteFunc handles UpdateRoute and UpdateRouteWithContext. By
+	// default, it returns an empty InboundRoute.
+	UpdateRouteFunc func(ctx context.Context, routeID string, params *envloped.InboundRouteRequest) (*envloped.InboundRoute, error)
+
+	// DeleteRouteFunc handles DeleteRoute and DeleteRouteWithContext. By
+	// default, it returns nil.
+	DeleteRouteFunc func(ctx context.Context, routeID string) error
+}
+
+var _ envloped.InboundSvc = (*MockInbound)(nil)
+
+// CreateRoute implements envloped.InboundSvc.
+func (m *MockInbound) CreateRoute(params *envloped.InboundRouteRequest) (*envloped.InboundRoute, error) {
+	return m.CreateRouteWithContext(context.Background(), params)
+}
+
+// CreateRouteWithContext implements envloped.InboundSvc.
+func (m *MockInbound) CreateRouteWithContext(ctx context.Context, params *envloped.InboundRouteRequest) (*envloped.InboundRoute, error) {
+	m.record(ctx, "CreateRoute", params)
+	if m.CreateRouteFunc != nil {
+		return m.CreateRouteFunc(ctx, params)
+	}
+	return &envloped.InboundRoute{}, nil
+}
+
+// GetRoute implements envloped.InboundSvc.
+func (m *MockInbound) GetRoute(routeID string) (*envloped.InboundRoute, error) {
+	return m.GetRouteWithContext(context.Background(), routeID)
+}
+
+// GetRouteWithContext implements envloped.InboundSvc.
+func (m *MockInbound) GetRouteWithContext(ctx context.Context, routeID string) (*envloped.InboundRoute, error) {
+	m.record(ctx, "GetRoute", routeID)
+	if m.GetRouteFunc != nil {
+		return m.GetRouteFunc(ctx, routeID)
+	}
+	return &envloped.InboundRoute{}, nil
+}
+
+// ListRoutes implements envloped.InboundSvc.
+func (m *MockInbound) ListRoutes() ([]envloped.InboundRoute, error) {
+	return m.ListRoutesWithContext(context.Background())
+}
+
+// ListRoutesWithContext implements envloped.InboundSvc.
+func (m *MockInbound) ListRoutesWithContext(ctx context.Context) ([]envloped.InboundRoute, error) {
+	m.record(ctx, "ListRoutes")
+	if m.ListRoutesFunc != nil {
+		return m.ListRoutesFunc(ctx)
+	}
+	return nil, nil
+}
+
+// UpdateRoute implements envloped.InboundSvc.
+func (m *MockInbound) UpdateRoute(routeID string, params *envloped.InboundRouteRequest) (*envloped.InboundRoute, error) {
+	return m.UpdateRouteWithContext(context.Background(), routeID, params)
+}
+
+// UpdateRouteWithContext implements envloped.InboundSvc.
+func (m *MockInbound) UpdateRouteWithContext(ctx context.Context, routeID string, params *envloped.InboundRouteRequest) (*envloped.InboundRoute, error) {
+	m.record(ctx, "UpdateRoute", routeID, params)
+	if m.UpdateRouteFunc != nil {
+		return m.UpdateRouteFunc(ctx, routeID, params)
+	}
+	return &envloped.InboundRoute{}, nil
+}
+
+// DeleteRoute implements envloped.InboundSvc.
+func (m *MockInbound) DeleteRoute(routeID string) error {
+	return m.DeleteRouteWithContext(context.Background(), routeID)
+}
+
+// DeleteRouteWithContext implements envloped.InboundSvc.
+func (m *MockInbound) DeleteRouteWithContext(ctx context.Context, routeID string) error {
+	m.record(ctx, "DeleteRoute", routeID)
+	if m.DeleteRouteFunc != nil {
+		return m.DeleteRouteFunc(ctx, routeID)
+	}
+	return nil
+}
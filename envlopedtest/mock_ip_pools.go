@@ -0,0 +1,107 @@
+package envlopedtest
+
+import (
+	"context"
+
+	"github.com/envloped/envloped-go"
+)
+
+// MockIPPools is a programmable implementation of envloped.IPPoolsSvc that
+// records every call, like MockEmails.
+//
+//	client.IPPools = &envlopedtest.MockIPPools{}
+type MockIPPools struct {
+	recorder
+
+	// CreateFunc handles Create and CreateWithContext. By default, it returns an
+	// empty IPPool.
+	CreateFunc func(ctx context.Context, params *envloped.IPPoolRequest) (*envloped.IPPool, error)
+
+	// GetFunc handles Get and GetWithContext. By default, it returns an empty
+	// IPPool.
+	GetFunc func(ctx context.Context, name string) (*envloped.IPPool, error)
+
+	// ListFunc handles List and ListWithContext. By default, it returns an empty
+	// list.
+	ListFunc func(ctx context.Context) ([]envloped.IPPool, error)
+
+	// UpdateFunc handles Update and UpdateWithContext. By default, it returns an
+	// empty IPPool.
+	UpdateFunc func(ctx context.Context, name string, params *envloped.IPPoolRequest) (*envloped.IPPool, error)
+
+	// DeleteFunc handles Delete and DeleteWithContext. By default, it returns
+	// nil.
+	DeleteFunc func(ctx context.Context, name string) error
+}
+
+var _ envloped.IPPoolsSvc = (*MockIPPools)(nil)
+
+// Create implements envloped.IPPoolsSvc.
+func (m *MockIPPools) Create(params *envloped.IPPoolRequest) (*envloped.IPPool, error) {
+	return m.CreateWithContext(context.Background(), params)
+}
+
+// CreateWithContext implements envloped.IPPoolsSvc.
+func (m *MockIPPools) CreateWithContext(ctx context.Context, params *envloped.IPPoolRequest) (*envloped.IPPool, error) {
+	m.record(ctx, "Create", params)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, params)
+	}
+	return &envloped.IPPool{}, nil
+}
+
+// Get implements envloped.IPPoolsSvc.
+func (m *MockIPPools) Get(name string) (*envloped.IPPool, error) {
+	return m.GetWithContext(context.Background(), name)
+}
+
+// GetWithContext implements envloped.IPPoolsSvc.
+func (m *MockIPPools) GetWithContext(ctx context.Context, name string) (*envloped.IPPool, error) {
+	m.record(ctx, "Get", name)
+	if m.GetFunc != nil {
+		return m.GetFunc(ctx, name)
+	}
+	return &envloped.IPPool{}, nil
+}
+
+// List implements envloped.IPPoolsSvc.
+func (m *MockIPPools) List() ([]envloped.IPPool, error) {
+	return m.ListWithContext(context.Background())
+}
+
+// ListWithContext implements envloped.IPPoolsSvc.
+func (m *MockIPPools) ListWithContext(ctx context.Context) ([]envloped.IPPool, error) {
+	m.record(ctx, "List")
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx)
+	}
+	return nil, nil
+}
+
+// Update implements envloped.IPPoolsSvc.
+func (m *MockIPPools) Update(name string, params *envloped.IPPoolRequest) (*envloped.IPPool, error) {
+	return m.UpdateWithContext(context.Background(), name, params)
+}
+
+// UpdateWithContext implements envloped.IPPoolsSvc.
+func (m *MockIPPools) UpdateWithContext(ctx context.Context, name string, params *envloped.IPPoolRequest) (*envloped.IPPool, error) {
+	m.record(ctx, "Update", name, params)
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(ctx, name, params)
+	}
+	return &envloped.IPPool{}, nil
+}
+
+// Delete implements envloped.IPPoolsSvc.
+func (m *MockIPPools) Delete(name string) error {
+	return m.DeleteWithContext(context.Background(), name)
+}
+
+// DeleteWithContext implements envloped.IPPoolsSvc.
+func (m *MockIPPools) DeleteWithContext(ctx context.Context, name string) error {
+	m.record(ctx, "Delete", name)
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, name)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package envlopedtest
+
+import (
+	"context"
+
+	"github.com/envloped/envloped-go"
+)
+
+// MockSMTP is a programmable implementation of envloped.SMTPSvc that
+// records every call, like MockEmails.
+//
+//	client.SMTP = &envlopedtest.MockSMTP{}
+type MockSMTP struct {
+	recorder
+
+	// CreateCredentialFunc handles CreateCredential and
+	// CreateCredentialWithContext. By default, it returns an empty
+	// SMTPCredential.
+	CreateCredentialFunc func(ctx context.Context, params *envloped.CreateSMTPCredentialRequest) (*envloped.SMTPCredential, error)
+
+	// ListCredentialsFunc handles ListCredentials and
+	// ListCredentialsWithContext. By default, it returns an empty list.
+	ListCredentialsFunc func(ctx context.Context) ([]envloped.SMTPCredential, error)
+
+	// RotateCredentialFunc handles RotateCredential and
+	// RotateCredentialWithContext. By default, it returns an empty
+	// SMTPCredential.
+	RotateCredentialFunc func(ctx context.Context, credentialID string) (*envloped.SMTPCredential, error)
+
+	// RevokeCredentialFunc handles RevokeCredential and
+	// RevokeCredentialWithContext. By default, it returns nil.
+	RevokeCredentialFunc func(ctx context.Context, credentialID string) error
+}
+
+var _ envloped.SMTPSvc = (*MockSMTP)(nil)
+
+// CreateCredential implements envloped.SMTPSvc.
+func (m *MockSMTP) CreateCredential(params *envloped.CreateSMTPCredentialRequest) (*envloped.SMTPCredential, error) {
+	return m.CreateCredentialWithContext(context.Background(), params)
+}
+
+// CreateCredentialWithContext implements envloped.SMTPSvc.
+func (m *MockSMTP) CreateCredentialWithContext(ctx context.Context, params *envloped.CreateSMTPCredentialRequest) (*envloped.SMTPCredential, error) {
+	m.record(ctx, "CreateCredential", params)
+	if m.CreateCredentialFunc != nil {
+		return m.CreateCredentialFunc(ctx, params)
+	}
+	return &envloped.SMTPCredential{}, nil
+}
+
+// ListCredentials implements envloped.SMTPSvc.
+func (m *MockSMTP) ListCredentials() ([]envloped.SMTPCredential, error) {
+	return m.ListCredentialsWithContext(context.Background())
+}
+
+// ListCredentialsWithContext implements envloped.SMTPSvc.
+func (m *MockSMTP) ListCredentialsWithContext(ctx context.Context) ([]envloped.SMTPCredential, error) {
+	m.record(ctx, "ListCredentials")
+	if m.ListCredentialsFunc != nil {
+		return m.ListCredentialsFunc(ctx)
+	}
+	return nil, nil
+}
+
+// RotateCredential implements envloped.SMTPSvc.
+func (m *MockSMTP) RotateCredential(credentialID string) (*envloped.SMTPCredential, error) {
+	return m.RotateCredentialWithContext(context.Background(), credentialID)
+}
+
+// RotateCredentialWithContext implements envloped.SMTPSvc.
+func (m *MockSMTP) RotateCredentialWithContext(ctx context.Context, credentialID string) (*envloped.SMTPCredential, error) {
+	m.record(ctx, "RotateCredential", credentialID)
+	if m.RotateCredentialFunc != nil {
+		return m.RotateCredentialFunc(ctx, credentialID)
+	}
+	return &envloped.SMTPCredential{}, nil
+}
+
+// RevokeCredential implements envloped.SMTPSvc.
+func (m *MockSMTP) RevokeCredential(credentialID string) error {
+	return m.RevokeCredentialWithContext(context.Background(), credentialID)
+}
+
+// RevokeCredentialWithContext implements envloped.SMTPSvc.
+func (m *MockSMTP) RevokeCredentialWithContext(ctx context.Context, credentialID string) error {
+	m.record(ctx, "RevokeCredential", credentialID)
+	if m.RevokeCredentialFunc != nil {
+		return m.RevokeCredentialFunc(ctx, credentialID)
+	}
+	return nil
+}
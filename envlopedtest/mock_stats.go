@@ -0,0 +1,35 @@
+package envlopedtest
+
+import (
+	"context"
+
+	"github.com/envloped/envloped-go"
+)
+
+// MockStats is a programmable implementation of envloped.StatsSvc that
+// records every call, like MockEmails.
+//
+//	client.Stats = &envlopedtest.MockStats{}
+type MockStats struct {
+	recorder
+
+	// GetFunc handles Get and GetWithContext. By default, it returns an empty
+	// StatsReport.
+	GetFunc func(ctx context.Context, query *envloped.StatsQuery) (*envloped.StatsReport, error)
+}
+
+var _ envloped.StatsSvc = (*MockStats)(nil)
+
+// Get implements envloped.StatsSvc.
+func (m *MockStats) Get(query *envloped.StatsQuery) (*envloped.StatsReport, error) {
+	return m.GetWithContext(context.Background(), query)
+}
+
+// GetWithContext implements envloped.StatsSvc.
+func (m *MockStats) GetWithContext(ctx context.Context, query *envloped.StatsQuery) (*envloped.StatsReport, error) {
+	m.record(ctx, "Get", query)
+	if m.GetFunc != nil {
+		return m.GetFunc(ctx, query)
+	}
+	return &envloped.StatsReport{}, nil
+}
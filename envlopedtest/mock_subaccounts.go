@@ -0,0 +1,143 @@
+package envlopedtest
+
+import (
+	"context"
+
+	"github.com/envloped/envloped-go"
+)
+
+// MockSubaccounts is a programmable implementation of envloped.SubaccountsSvc that
+// records every call, like MockEmails.
+//
+//	client.Subaccounts = &envlopedtest.MockSubaccounts{}
+type MockSubaccounts struct {
+	recorder
+
+	// CreateFunc handles Create and CreateWithContext. By default, it returns an
+	// empty Subaccount.
+	CreateFunc func(ctx context.Context, params *envloped.CreateSubaccountRequest) (*envloped.Subaccount, error)
+
+	// GetFunc handles Get and GetWithContext. By default, it returns an empty
+	// Subaccount.
+	GetFunc func(ctx context.Context, subaccountID string) (*envloped.Subaccount, error)
+
+	// ListFunc handles List and ListWithContext. By default, it returns an empty
+	// list.
+	ListFunc func(ctx context.Context) ([]envloped.Subaccount, error)
+
+	// SuspendFunc handles Suspend and SuspendWithContext. By default, it returns
+	// an empty Subaccount.
+	SuspendFunc func(ctx context.Context, subaccountID string) (*envloped.Subaccount, error)
+
+	// ResumeFunc handles Resume and ResumeWithContext. By default, it returns an
+	// empty Subaccount.
+	ResumeFunc func(ctx context.Context, subaccountID string) (*envloped.Subaccount, error)
+
+	// UsageFunc handles Usage and UsageWithContext. By default, it returns an
+	// empty EmailUsage.
+	UsageFunc func(ctx context.Context, subaccountID string) (*envloped.EmailUsage, error)
+
+	// CreateAPIKeyFunc handles CreateAPIKey and CreateAPIKeyWithContext. By
+	// default, it returns an empty APIKey.
+	CreateAPIKeyFunc func(ctx context.Context, subaccountID string, params *envloped.CreateAPIKeyRequest) (*envloped.APIKey, error)
+}
+
+var _ envloped.SubaccountsSvc = (*MockSubaccounts)(nil)
+
+// Create implements envloped.SubaccountsSvc.
+func (m *MockSubaccounts) Create(params *envloped.CreateSubaccountRequest) (*envloped.Subaccount, error) {
+	return m.CreateWithContext(context.Background(), params)
+}
+
+// CreateWithContext implements envloped.SubaccountsSvc.
+func (m *MockSubaccounts) CreateWithContext(ctx context.Context, params *envloped.CreateSubaccountRequest) (*envloped.Subaccount, error) {
+	m.record(ctx, "Create", params)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, params)
+	}
+	return &envloped.Subaccount{}, nil
+}
+
+// Get implements envloped.SubaccountsSvc.
+func (m *MockSubaccounts) Get(subaccountID string) (*envloped.Subaccount, error) {
+	return m.GetWithContext(context.Background(), subaccountID)
+}
+
+// GetWithContext implements envloped.SubaccountsSvc.
+func (m *MockSubaccounts) GetWithContext(ctx context.Context, subaccountID string) (*envloped.Subaccount, error) {
+	m.record(ctx, "Get", subaccountID)
+	if m.GetFunc != nil {
+		return m.GetFunc(ctx, subaccountID)
+	}
+	return &envloped.Subaccount{}, nil
+}
+
+// List implements envloped.SubaccountsSvc.
+func (m *MockSubaccounts) List() ([]envloped.Subaccount, error) {
+	return m.ListWithContext(context.Background())
+}
+
+// ListWithContext implements envloped.SubaccountsSvc.
+func (m *MockSubaccounts) ListWithContext(ctx context.Context) ([]envloped.Subaccount, error) {
+	m.record(ctx, "List")
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx)
+	}
+	return nil, nil
+}
+
+// Suspend implements envloped.SubaccountsSvc.
+func (m *MockSubaccounts) Suspend(subaccountID string) (*envloped.Subaccount, error) {
+	return m.SuspendWithContext(context.Background(), subaccountID)
+}
+
+// SuspendWithContext implements envloped.SubaccountsSvc.
+func (m *MockSubaccounts) SuspendWithContext(ctx context.Context, subaccountID string) (*envloped.Subaccount, error) {
+	m.record(ctx, "Suspend", subaccountID)
+	if m.SuspendFunc != nil {
+		return m.SuspendFunc(ctx, subaccountID)
+	}
+	return &envloped.Subaccount{}, nil
+}
+
+// Resume implements envloped.SubaccountsSvc.
+func (m *MockSubaccounts) Resume(subaccountID string) (*envloped.Subaccount, error) {
+	return m.ResumeWithContext(context.Background(), subaccountID)
+}
+
+// ResumeWithContext implements envloped.SubaccountsSvc.
+func (m *MockSubaccounts) ResumeWithContext(ctx context.Context, subaccountID string) (*envloped.Subaccount, error) {
+	m.record(ctx, "Resume", subaccountID)
+	if m.ResumeFunc != nil {
+		return m.ResumeFunc(ctx, subaccountID)
+	}
+	return &envloped.Subaccount{}, nil
+}
+
+// Usage implements envloped.SubaccountsSvc.
+func (m *MockSubaccounts) Usage(subaccountID string) (*envloped.EmailUsage, error) {
+	return m.UsageWithContext(context.Background(), subaccountID)
+}
+
+// UsageWithContext implements envloped.SubaccountsSvc.
+func (m *MockSubaccounts) UsageWithContext(ctx context.Context, subaccountID string) (*envloped.EmailUsage, error) {
+	m.record(ctx, "Usage", subaccountID)
+	if m.UsageFunc != nil {
+		return m.UsageFunc(ctx, subaccountID)
+	}
+	return &envloped.EmailUsage{}, nil
+}
+
+// CreateAPIKey implements envloped.SubaccountsSvc.
+func (m *MockSubaccounts) CreateAPIKey(subaccountID string, params *envloped.CreateAPIKeyRequest) (*envloped.APIKey, error) {
+	return m.CreateAPIKeyWithContext(context.Background(), subaccountID, params)
+}
+
+// CreateAPIKeyWithContext implements envloped.SubaccountsSvc.
+func (m *MockSubaccounts) CreateAPIKeyWithContext(ctx context.Context, subaccountID string, params *envloped.CreateAPIKeyRequest) (*envloped.APIKey, error) {
+	m.record(ctx, "CreateAPIKey", subaccountID, params)
+	if m.CreateAPIKeyFunc != nil {
+		return m.CreateAPIKeyFunc(ctx, subaccountID, params)
+	}
+	return &envloped.APIKey{}, nil
+}
@@ -0,0 +1,89 @@
+package envlopedtest
+
+import (
+	"context"
+
+	"github.com/envloped/envloped-go"
+)
+
+// MockSuppressions is a programmable implementation of envloped.SuppressionsSvc that
+// records every call, like MockEmails.
+//
+//	client.Suppressions = &envlopedtest.MockSuppressions{}
+type MockSuppressions struct {
+	recorder
+
+	// ListFunc handles List and ListWithContext. By default, it returns an empty
+	// SuppressionPage.
+	ListFunc func(ctx context.Context, opts *envloped.ListSuppressionsOptions) (*envloped.SuppressionPage, error)
+
+	// AddFunc handles Add and AddWithContext. By default, it returns an empty
+	// Suppression.
+	AddFunc func(ctx context.Context, params *envloped.SuppressionRequest) (*envloped.Suppression, error)
+
+	// DeleteFunc handles Delete and DeleteWithContext. By default, it returns
+	// nil.
+	DeleteFunc func(ctx context.Context, email string) error
+
+	// CheckFunc handles Check and CheckWithContext. By default, it returns an
+	// empty list.
+	CheckFunc func(ctx context.Context, emails []string) ([]envloped.Suppression, error)
+}
+
+var _ envloped.SuppressionsSvc = (*MockSuppressions)(nil)
+
+// List implements envloped.SuppressionsSvc.
+func (m *MockSuppressions) List(opts *envloped.ListSuppressionsOptions) (*envloped.SuppressionPage, error) {
+	return m.ListWithContext(context.Background(), opts)
+}
+
+// ListWithContext implements envloped.SuppressionsSvc.
+func (m *MockSuppressions) ListWithContext(ctx context.Context, opts *envloped.ListSuppressionsOptions) (*envloped.SuppressionPage, error) {
+	m.record(ctx, "List", opts)
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx, opts)
+	}
+	return &envloped.SuppressionPage{}, nil
+}
+
+// Add implements envloped.SuppressionsSvc.
+func (m *MockSuppressions) Add(params *envloped.SuppressionRequest) (*envloped.Suppression, error) {
+	return m.AddWithContext(context.Background(), params)
+}
+
+// AddWithContext implements envloped.SuppressionsSvc.
+func (m *MockSuppressions) AddWithContext(ctx context.Context, params *envloped.SuppressionRequest) (*envloped.Suppression, error) {
+	m.record(ctx, "Add", params)
+	if m.AddFunc != nil {
+		return m.AddFunc(ctx, params)
+	}
+	return &envloped.Suppression{}, nil
+}
+
+// Delete implements envloped.SuppressionsSvc.
+func (m *MockSuppressions) Delete(email string) error {
+	return m.DeleteWithContext(context.Background(), email)
+}
+
+// DeleteWithContext implements envloped.SuppressionsSvc.
+func (m *MockSuppressions) DeleteWithContext(ctx context.Context, email string) error {
+	m.record(ctx, "Delete", email)
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, email)
+	}
+	return nil
+}
+
+// Check implements envloped.SuppressionsSvc.
+func (m *MockSuppressions) Check(emails []string) ([]envloped.Suppression, error) {
+	return m.CheckWithContext(context.Background(), emails)
+}
+
+// CheckWithContext implements envloped.SuppressionsSvc.
+func (m *MockSuppressions) CheckWithContext(ctx context.Context, emails []string) ([]envloped.Suppression, error) {
+	m.record(ctx, "Check", emails)
+	if m.CheckFunc != nil {
+		return m.CheckFunc(ctx, emails)
+	}
+	return nil, nil
+}
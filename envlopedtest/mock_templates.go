@@ -0,0 +1,107 @@
+package envlopedtest
+
+import (
+	"context"
+
+	"github.com/envloped/envloped-go"
+)
+
+// MockTemplates is a programmable implementation of envloped.TemplatesSvc that
+// records every call, like MockEmails.
+//
+//	client.Templates = &envlopedtest.MockTemplates{}
+type MockTemplates struct {
+	recorder
+
+	// CreateFunc handles Create and CreateWithContext. By default, it returns an
+	// empty Template.
+	CreateFunc func(ctx context.Context, params *envloped.TemplateRequest) (*envloped.Template, error)
+
+	// GetFunc handles Get and GetWithContext. By default, it returns an empty
+	// Template.
+	GetFunc func(ctx context.Context, templateID string) (*envloped.Template, error)
+
+	// ListFunc handles List and ListWithContext. By default, it returns an empty
+	// list.
+	ListFunc func(ctx context.Context) ([]envloped.Template, error)
+
+	// UpdateFunc handles Update and UpdateWithContext. By default, it returns an
+	// empty Template.
+	UpdateFunc func(ctx context.Context, templateID string, params *envloped.TemplateRequest) (*envloped.Template, error)
+
+	// DeleteFunc handles Delete and DeleteWithContext. By default, it returns
+	// nil.
+	DeleteFunc func(ctx context.Context, templateID string) error
+}
+
+var _ envloped.TemplatesSvc = (*MockTemplates)(nil)
+
+// Create implements envloped.TemplatesSvc.
+func (m *MockTemplates) Create(params *envloped.TemplateRequest) (*envloped.Template, error) {
+	return m.CreateWithContext(context.Background(), params)
+}
+
+// CreateWithContext implements envloped.TemplatesSvc.
+func (m *MockTemplates) CreateWithContext(ctx context.Context, params *envloped.TemplateRequest) (*envloped.Template, error) {
+	m.record(ctx, "Create", params)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, params)
+	}
+	return &envloped.Template{}, nil
+}
+
+// Get implements envloped.TemplatesSvc.
+func (m *MockTemplates) Get(templateID string) (*envloped.Template, error) {
+	return m.GetWithContext(context.Background(), templateID)
+}
+
+// GetWithContext implements envloped.TemplatesSvc.
+func (m *MockTemplates) GetWithContext(ctx context.Context, templateID string) (*envloped.Template, error) {
+	m.record(ctx, "Get", templateID)
+	if m.GetFunc != nil {
+		return m.GetFunc(ctx, templateID)
+	}
+	return &envloped.Template{}, nil
+}
+
+// List implements envloped.TemplatesSvc.
+func (m *MockTemplates) List() ([]envloped.Template, error) {
+	return m.ListWithContext(context.Background())
+}
+
+// ListWithContext implements envloped.TemplatesSvc.
+func (m *MockTemplates) ListWithContext(ctx context.Context) ([]envloped.Template, error) {
+	m.record(ctx, "List")
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx)
+	}
+	return nil, nil
+}
+
+// Update implements envloped.TemplatesSvc.
+func (m *MockTemplates) Update(templateID string, params *envloped.TemplateRequest) (*envloped.Template, error) {
+	return m.UpdateWithContext(context.Background(), templateID, params)
+}
+
+// UpdateWithContext implements envloped.TemplatesSvc.
+func (m *MockTemplates) UpdateWithContext(ctx context.Context, templateID string, params *envloped.TemplateRequest) (*envloped.Template, error) {
+	m.record(ctx, "Update", templateID, params)
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(ctx, templateID, params)
+	}
+	return &envloped.Template{}, nil
+}
+
+// Delete implements envloped.TemplatesSvc.
+func (m *MockTemplates) Delete(templateID string) error {
+	return m.DeleteWithContext(context.Background(), templateID)
+}
+
+// DeleteWithContext implements envloped.TemplatesSvc.
+func (m *MockTemplates) DeleteWithContext(ctx context.Context, templateID string) error {
+	m.record(ctx, "Delete", templateID)
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, templateID)
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+package envlopedtest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/envloped/envloped-go"
+)
+
+func TestMockEmails_Defaults(t *testing.T) {
+	t.Parallel()
+
+	mock := &MockEmails{}
+	client := envloped.NewClient("unused")
+	client.Emails = mock
+
+	first, err := client.Emails.Send(testEmail())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, _, err := client.Emails.SendWithResponse(context.Background(), testEmail())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if first.MessageId != "mock_1" || second.MessageId != "mock_2" {
+		t.Errorf("expected sequential message ids, got %q and %q", first.MessageId, second.MessageId)
+	}
+
+	email, err := client.Emails.Get("msg_1")
+	if err != nil || email.ID != "msg_1" {
+		t.Errorf("unexpected Get result %+v, %v", email, err)
+	}
+	if resp, err := client.Emails.SendRaw(bytes.NewReader(nil)); err != nil || resp.MessageId != "mock_3" {
+		t.Errorf("unexpected SendRaw result %+v, %v", resp, err)
+	}
+	if page, err := client.Emails.List(nil); err != nil || page == nil {
+		t.Errorf("unexpected List result %+v, %v", page, err)
+	}
+
+	if got := mock.CallCount("Send"); got != 2 {
+		t.Errorf("expected 2 Send calls, got %d", got)
+	}
+	if got := len(mock.SentEmails()); got != 2 {
+		t.Errorf("expected 2 sent emails, got %d", got)
+	}
+
+	calls := mock.Calls()
+	if len(calls) != 5 || calls[2].Method != "Get" || calls[2].Args[0] != "msg_1" {
+		t.Errorf("unexpected calls %+v", calls)
+	}
+}
+
+func TestMockEmails_Funcs(t *testing.T) {
+	t.Parallel()
+
+	errBoom := errors.New("boom")
+	mock := &MockEmails{
+		SendFunc: func(ctx context.Context, params *envloped.SendEmailRequest) (*envloped.SendEmailResponse, error) {
+			return nil, errBoom
+		},
+		ListEventsFunc: func(ctx context.Context, messageID string) ([]envloped.Event, error) {
+			return []envloped.Event{{Type: envloped.EventTypeDelivered}}, nil
+		},
+		ExportFunc: func(ctx context.Context, params *envloped.ExportEmailsRequest, w io.Writer) error {
+			_, err := io.WriteString(w, "id\n")
+			return err
+		},
+	}
+
+	if _, err := mock.Send(testEmail()); !errors.Is(err, errBoom) {
+		t.Errorf("expected programmed error, got %v", err)
+	}
+	if _, meta, err := mock.SendWithResponse(context.Background(), testEmail()); !errors.Is(err, errBoom) || meta != nil {
+		t.Errorf("expected programmed error and nil meta, got %v, %v", meta, err)
+	}
+	if events, err := mock.ListEvents("msg_1"); err != nil || len(events) != 1 {
+		t.Errorf("unexpected ListEvents result %+v, %v", events, err)
+	}
+
+	var buf bytes.Buffer
+	if err := mock.Export(&envloped.ExportEmailsRequest{}, &buf); err != nil || buf.String() != "id\n" {
+		t.Errorf("unexpected Export result %q, %v", buf.String(), err)
+	}
+
+	// Failed sends are still recorded.
+	if got := len(mock.SentEmails()); got != 2 {
+		t.Errorf("expected 2 recorded sends, got %d", got)
+	}
+}
+
+func TestMockEmails_RecordsContextAndOptions(t *testing.T) {
+	t.Parallel()
+
+	type key struct{}
+	ctx := context.WithValue(context.Background(), key{}, "value")
+	mock := &MockEmails{}
+
+	if _, err := mock.SendWithContext(ctx, testEmail(), envloped.WithIdempotencyKey("order-1")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := mock.Send(testEmail()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	calls := mock.Calls()
+	if len(calls) != 2 {
+		t.Fatalf("expected 2 calls, got %d", len(calls))
+	}
+	if calls[0].Context.Value(key{}) != "value" {
+		t.Error("expected the context of the call to be recorded")
+	}
+	if got := calls[0].Options.Header.Get("Idempotency-Key"); got != "order-1" {
+		t.Errorf("expected idempotency key order-1, got %q", got)
+	}
+	if got := calls[1].Options.Header.Get("Idempotency-Key"); got != "" {
+		t.Errorf("expected no idempotency key, got %q", got)
+	}
+}
+
+func TestMockServices(t *testing.T) {
+	t.Parallel()
+
+	contacts := &MockContacts{}
+	domains := &MockDomains{
+		ListFunc: func(ctx context.Context) ([]envloped.Domain, error) {
+			return []envloped.Domain{{ID: "dom_1"}}, nil
+		},
+	}
+	events := &MockEvents{}
+	client := envloped.NewClient("unused")
+	client.Contacts = contacts
+	client.Domains = domains
+	client.Events = events
+
+	if contact, err := client.Contacts.Get("ada@example.com"); err != nil || contact == nil {
+		t.Errorf("unexpected Get result %+v, %v", contact, err)
+	}
+	if got := domains.CallCount("List"); got != 0 {
+		t.Errorf("expected no List calls yet, got %d", got)
+	}
+	if list, err := client.Domains.List(); err != nil || len(list) != 1 || list[0].ID != "dom_1" {
+		t.Errorf("unexpected List result %+v, %v", list, err)
+	}
+	ch, err := client.Events.Stream(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := <-ch; ok {
+		t.Error("expected the default stream to be closed")
+	}
+
+	if calls := contacts.Calls(); len(calls) != 1 || calls[0].Method != "Get" || calls[0].Args[0] != "ada@example.com" {
+		t.Errorf("unexpected contact calls %+v", calls)
+	}
+	if got := domains.CallCount("List"); got != 1 {
+		t.Errorf("expected 1 List call, got %d", got)
+	}
+	if got := events.CallCount("Stream"); got != 1 {
+		t.Errorf("expected 1 Stream call, got %d", got)
+	}
+}
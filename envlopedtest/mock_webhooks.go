@@ -0,0 +1,143 @@
+package envlopedtest
+
+import (
+	"context"
+
+	"github.com/envloped/envloped-go"
+)
+
+// MockWebhooks is a programmable implementation of envloped.WebhooksSvc that
+// records every call, like MockEmails.
+//
+//	client.Webhooks = &envlopedtest.MockWebhooks{}
+type MockWebhooks struct {
+	recorder
+
+	// CreateFunc handles Create and CreateWithContext. By default, it returns an
+	// empty Webhook.
+	CreateFunc func(ctx context.Context, params *envloped.WebhookRequest) (*envloped.Webhook, error)
+
+	// GetFunc handles Get and GetWithContext. By default, it returns an empty
+	// Webhook.
+	GetFunc func(ctx context.Context, webhookID string) (*envloped.Webhook, error)
+
+	// ListFunc handles List and ListWithContext. By default, it returns an empty
+	// list.
+	ListFunc func(ctx context.Context) ([]envloped.Webhook, error)
+
+	// UpdateFunc handles Update and UpdateWithContext. By default, it returns an
+	// empty Webhook.
+	UpdateFunc func(ctx context.Context, webhookID string, params *envloped.WebhookRequest) (*envloped.Webhook, error)
+
+	// DeleteFunc handles Delete and DeleteWithContext. By default, it returns
+	// nil.
+	DeleteFunc func(ctx context.Context, webhookID string) error
+
+	// ListDeliveriesFunc handles ListDeliveries and ListDeliveriesWithContext.
+	// By default, it returns an empty WebhookDeliveryPage.
+	ListDeliveriesFunc func(ctx context.Context, webhookID string, opts *envloped.ListWebhookDeliveriesOptions) (*envloped.WebhookDeliveryPage, error)
+
+	// ReplayFunc handles Replay and ReplayWithContext. By default, it returns an
+	// empty list.
+	ReplayFunc func(ctx context.Context, webhookID string, eventIDs []string) ([]envloped.WebhookDelivery, error)
+}
+
+var _ envloped.WebhooksSvc = (*MockWebhooks)(nil)
+
+// Create implements envloped.WebhooksSvc.
+func (m *MockWebhooks) Create(params *envloped.WebhookRequest) (*envloped.Webhook, error) {
+	return m.CreateWithContext(context.Background(), params)
+}
+
+// CreateWithContext implements envloped.WebhooksSvc.
+func (m *MockWebhooks) CreateWithContext(ctx context.Context, params *envloped.WebhookRequest) (*envloped.Webhook, error) {
+	m.record(ctx, "Create", params)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, params)
+	}
+	return &envloped.Webhook{}, nil
+}
+
+// Get implements envloped.WebhooksSvc.
+func (m *MockWebhooks) Get(webhookID string) (*envloped.Webhook, error) {
+	return m.GetWithContext(context.Background(), webhookID)
+}
+
+// GetWithContext implements envloped.WebhooksSvc.
+func (m *MockWebhooks) GetWithContext(ctx context.Context, webhookID string) (*envloped.Webhook, error) {
+	m.record(ctx, "Get", webhookID)
+	if m.GetFunc != nil {
+		return m.GetFunc(ctx, webhookID)
+	}
+	return &envloped.Webhook{}, nil
+}
+
+// List implements envloped.WebhooksSvc.
+func (m *MockWebhooks) List() ([]envloped.Webhook, error) {
+	return m.ListWithContext(context.Background())
+}
+
+// ListWithContext implements envloped.WebhooksSvc.
+func (m *MockWebhooks) ListWithContext(ctx context.Context) ([]envloped.Webhook, error) {
+	m.record(ctx, "List")
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx)
+	}
+	return nil, nil
+}
+
+// Update implements envloped.WebhooksSvc.
+func (m *MockWebhooks) Update(webhookID string, params *envloped.WebhookRequest) (*envloped.Webhook, error) {
+	return m.UpdateWithContext(context.Background(), webhookID, params)
+}
+
+// UpdateWithContext implements envloped.WebhooksSvc.
+func (m *MockWebhooks) UpdateWithContext(ctx context.Context, webhookID string, params *envloped.WebhookRequest) (*envloped.Webhook, error) {
+	m.record(ctx, "Update", webhookID, params)
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(ctx, webhookID, params)
+	}
+	return &envloped.Webhook{}, nil
+}
+
+// Delete implements envloped.WebhooksSvc.
+func (m *MockWebhooks) Delete(webhookID string) error {
+	return m.DeleteWithContext(context.Background(), webhookID)
+}
+
+// DeleteWithContext implements envloped.WebhooksSvc.
+func (m *MockWebhooks) DeleteWithContext(ctx context.Context, webhookID string) error {
+	m.record(ctx, "Delete", webhookID)
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, webhookID)
+	}
+	return nil
+}
+
+// ListDeliveries implements envloped.WebhooksSvc.
+func (m *MockWebhooks) ListDeliveries(webhookID string, opts *envloped.ListWebhookDeliveriesOptions) (*envloped.WebhookDeliveryPage, error) {
+	return m.ListDeliveriesWithContext(context.Background(), webhookID, opts)
+}
+
+// ListDeliveriesWithContext implements envloped.WebhooksSvc.
+func (m *MockWebhooks) ListDeliveriesWithContext(ctx context.Context, webhookID string, opts *envloped.ListWebhookDeliveriesOptions) (*envloped.WebhookDeliveryPage, error) {
+	m.record(ctx, "ListDeliveries", webhookID, opts)
+	if m.ListDeliveriesFunc != nil {
+		return m.ListDeliveriesFunc(ctx, webhookID, opts)
+	}
+	return &envloped.WebhookDeliveryPage{}, nil
+}
+
+// Replay implements envloped.WebhooksSvc.
+func (m *MockWebhooks) Replay(webhookID string, eventIDs ...string) ([]envloped.WebhookDelivery, error) {
+	return m.ReplayWithContext(context.Background(), webhookID, eventIDs...)
+}
+
+// ReplayWithContext implements envloped.WebhooksSvc.
+func (m *MockWebhooks) ReplayWithContext(ctx context.Context, webhookID string, eventIDs ...string) ([]envloped.WebhookDelivery, error) {
+	m.record(ctx, "Replay", webhookID, eventIDs)
+	if m.ReplayFunc != nil {
+		return m.ReplayFunc(ctx, webhookID, eventIDs)
+	}
+	return nil, nil
+}
@@ -0,0 +1,319 @@
+package envlopedtest
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// RecorderMode selects whether a Recorder calls the real API or replays
+// recorded interactions.
+type RecorderMode int
+
+const (
+	// ModeReplay serves requests from the recorded interactions, without
+	// network access or an API key.
+	ModeReplay RecorderMode = iota
+
+	// ModeRecord forwards requests to the real API and records the
+	// interactions, to be written by Save.
+	ModeRecord
+)
+
+// redacted replaces the API key in recorded interactions.
+const redacted = "[REDACTED]"
+
+// recordedRequestHeaders are the request headers kept in recordings. Others,
+// including Authorization, are dropped.
+var recordedRequestHeaders = []string{"Content-Type", "Idempotency-Key", "Envloped-Version"}
+
+// droppedResponseHeaders are the response headers removed from recordings.
+var droppedResponseHeaders = []string{"Set-Cookie", "Date", "Content-Length", "Content-Encoding"}
+
+// Interaction is a recorded API request and its response.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is the recorded form of an API request.
+type RecordedRequest struct {
+	// Method is the HTTP method.
+	Method string `json:"method"`
+
+	// URL is the path and query of the request, without the host, so
+	// recordings replay against any base URL.
+	URL string `json:"url"`
+
+	// Header holds the recorded request headers. Authorization is never
+	// recorded.
+	Header http.Header `json:"header,omitempty"`
+
+	// Body is the request body, decompressed if it was sent gzipped.
+	Body string `json:"body,omitempty"`
+}
+
+// RecordedResponse is the recorded form of an API response.
+type RecordedResponse struct {
+	// StatusCode is the HTTP status code.
+	StatusCode int `json:"statusCode"`
+
+	// Header holds the response headers.
+	Header http.Header `json:"header,omitempty"`
+
+	// Body is the response body.
+	Body string `json:"body,omitempty"`
+}
+
+// RecorderOptions configures a Recorder.
+type RecorderOptions struct {
+	// Transport makes the real requests in ModeRecord. Defaults to
+	// http.DefaultTransport.
+	Transport http.RoundTripper
+
+	// Sanitize is called with every interaction before it is recorded, to
+	// mask personal data such as recipient addresses. The API key is
+	// always removed. It may be nil.
+	//
+	// In ModeReplay, Sanitize is called with each incoming request, with an
+	// empty Response, before it is matched, so a request matches a
+	// recording whose URL Sanitize rewrote.
+	Sanitize func(*Interaction)
+}
+
+// Recorder is an http.RoundTripper that records real API interactions to a
+// fixture file and replays them offline, so integration tests are
+// deterministic and run without an API key. In replay mode, each request is
+// sanitized as it would be recorded and served by the first unused
+// interaction recorded with the same method and URL. Streaming responses, such as Events.Stream, are not supported.
+// It is safe for concurrent use.
+//
+//	mode := envlopedtest.ModeReplay
+//	if os.Getenv("ENVLOPED_RECORD") != "" {
+//	    mode = envlopedtest.ModeRecord
+//	}
+//	rec, err := envlopedtest.NewRecorder("testdata/send.json", mode, nil)
+//	if err != nil {
+//	    t.Fatal(err)
+//	}
+//	defer rec.Save()
+//
+//	client := envloped.NewClient(os.Getenv("ENVLOPED_API_KEY")).WithHTTPClient(rec.HTTPClient())
+type Recorder struct {
+	path string
+	mode RecorderMode
+	opts RecorderOptions
+
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// NewRecorder returns a Recorder for the fixture file at path. In
+// ModeReplay, the file is read and must exist; in ModeRecord, it is written
+// by Save. opts may be nil.
+func NewRecorder(path string, mode RecorderMode, opts *RecorderOptions) (*Recorder, error) {
+	var o RecorderOptions
+	if opts != nil {
+		o = *opts
+	}
+	if o.Transport == nil {
+		o.Transport = http.DefaultTransport
+	}
+	r := &Recorder{path: path, mode: mode, opts: o}
+
+	if mode == ModeReplay {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("envlopedtest: failed to read recording: %w", err)
+		}
+		if err := json.Unmarshal(data, &r.interactions); err != nil {
+			return nil, fmt.Errorf("envlopedtest: invalid recording %s: %w", path, err)
+		}
+		r.used = make([]bool, len(r.interactions))
+	}
+	return r, nil
+}
+
+// HTTPClient returns an HTTP client that uses the recorder as its
+// transport, for use with Client.WithHTTPClient.
+func (r *Recorder) HTTPClient() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// Interactions returns the interactions recorded or loaded so far.
+func (r *Recorder) Interactions() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Interaction(nil), r.interactions...)
+}
+
+// Save writes the recorded interactions to the fixture file in ModeRecord,
+// creating its directory if needed. It does nothing in ModeReplay.
+func (r *Recorder) Save() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+	r.mu.Lock()
+	data, err := json.MarshalIndent(r.interactions, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, append(data, '\n'), 0o644)
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	if r.mode == ModeReplay {
+		return r.replay(req)
+	}
+	return r.record(req)
+}
+
+// replay serves req from the first unused matching interaction.
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		var err error
+		reqBody, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	want, err := recordedRequest(req, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	if r.opts.Sanitize != nil {
+		in := Interaction{Request: want}
+		r.opts.Sanitize(&in)
+		want = in.Request
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, in := range r.interactions {
+		if r.used[i] || in.Request.Method != want.Method || in.Request.URL != want.URL {
+			continue
+		}
+		r.used[i] = true
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", in.Response.StatusCode, http.StatusText(in.Response.StatusCode)),
+			StatusCode:    in.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        in.Response.Header.Clone(),
+			Body:          io.NopCloser(strings.NewReader(in.Response.Body)),
+			ContentLength: int64(len(in.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("envlopedtest: no recorded interaction for %s %s", req.Method, req.URL.RequestURI())
+}
+
+// record forwards req to the real API and records the interaction.
+func (r *Recorder) record(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	out := req
+	if req.Body != nil {
+		var err error
+		reqBody, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		out = req.Clone(req.Context())
+		out.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	resp, err := r.opts.Transport.RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	rr, err := recordedRequest(req, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	in := Interaction{
+		Request: rr,
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     resp.Header.Clone(),
+			Body:       string(respBody),
+		},
+	}
+	for _, key := range droppedResponseHeaders {
+		in.Response.Header.Del(key)
+	}
+
+	if key := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer "); key != "" {
+		in.Request.URL = strings.ReplaceAll(in.Request.URL, key, redacted)
+		in.Request.Body = strings.ReplaceAll(in.Request.Body, key, redacted)
+		in.Response.Body = strings.ReplaceAll(in.Response.Body, key, redacted)
+	}
+	if r.opts.Sanitize != nil {
+		r.opts.Sanitize(&in)
+	}
+
+	r.mu.Lock()
+	r.interactions = append(r.interactions, in)
+	r.mu.Unlock()
+	return resp, nil
+}
+
+// recordedRequest returns the recorded form of req, whose body has been read
+// into body.
+func recordedRequest(req *http.Request, body []byte) (RecordedRequest, error) {
+	rr := RecordedRequest{
+		Method: req.Method,
+		URL:    req.URL.RequestURI(),
+		Header: make(http.Header),
+	}
+	for _, key := range recordedRequestHeaders {
+		if v := req.Header.Values(key); len(v) > 0 {
+			rr.Header[key] = v
+		}
+	}
+	var err error
+	if rr.Body, err = decodeBody(req.Header, body); err != nil {
+		return RecordedRequest{}, err
+	}
+	return rr, nil
+}
+
+// decodeBody returns body as a string, decompressing it if header declares
+// gzip encoding.
+func decodeBody(header http.Header, body []byte) (string, error) {
+	if header.Get("Content-Encoding") != "gzip" || len(body) == 0 {
+		return string(body), nil
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("envlopedtest: invalid gzip request body: %w", err)
+	}
+	data, err := io.ReadAll(zr)
+	if err != nil {
+		return "", fmt.Errorf("envlopedtest: invalid gzip request body: %w", err)
+	}
+	return string(data), nil
+}
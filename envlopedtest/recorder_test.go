@@ -0,0 +1,123 @@
+package envlopedtest
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/envloped/envloped-go"
+)
+
+func TestRecorder_RecordAndReplay(t *testing.T) {
+	t.Parallel()
+
+	srv := NewServer()
+	defer srv.Close()
+	path := filepath.Join(t.TempDir(), "testdata", "send.json")
+
+	rec, err := NewRecorder(path, ModeRecord, &RecorderOptions{
+		Sanitize: func(in *Interaction) {
+			in.Request.Body = strings.ReplaceAll(in.Request.Body, "recipient@example.com", "user@example.test")
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client := srv.Client().WithHTTPClient(rec.HTTPClient()).WithGzip(1)
+
+	sent, err := client.Emails.Send(testEmail())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.Emails.Get("msg_missing"); !errors.Is(err, envloped.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if err := rec.Save(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	fixture := string(data)
+	if strings.Contains(fixture, APIKey) || strings.Contains(fixture, "Authorization") {
+		t.Errorf("expected the API key not to be recorded, got %s", fixture)
+	}
+	if strings.Contains(fixture, "recipient@example.com") || !strings.Contains(fixture, "user@example.test") {
+		t.Errorf("expected the recording to be sanitized, got %s", fixture)
+	}
+
+	// Replay offline with a different key and base URL.
+	replay, err := NewRecorder(path, ModeReplay, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client = envloped.NewClient("unused").
+		WithBaseURL("http://envloped.invalid").
+		WithHTTPClient(replay.HTTPClient())
+
+	resp, err := client.Emails.Send(testEmail())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.MessageId != sent.MessageId {
+		t.Errorf("expected recorded message id %q, got %q", sent.MessageId, resp.MessageId)
+	}
+	var apiErr *envloped.APIError
+	if _, err := client.Emails.Get("msg_missing"); !errors.As(err, &apiErr) || apiErr.RequestID == "" {
+		t.Errorf("expected recorded error with request id, got %v", err)
+	}
+	if _, err := client.Emails.Send(testEmail()); err == nil || !strings.Contains(err.Error(), "no recorded interaction") {
+		t.Errorf("expected error once recordings are used up, got %v", err)
+	}
+}
+
+func TestRecorder_ReplaySanitizedURL(t *testing.T) {
+	t.Parallel()
+
+	srv := NewServer()
+	defer srv.Close()
+	path := filepath.Join(t.TempDir(), "get.json")
+	opts := &RecorderOptions{
+		Sanitize: func(in *Interaction) {
+			in.Request.URL = strings.ReplaceAll(in.Request.URL, "msg_private", "msg_masked")
+		},
+	}
+
+	rec, err := NewRecorder(path, ModeRecord, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client := srv.Client().WithHTTPClient(rec.HTTPClient())
+	if _, err := client.Emails.Get("msg_private"); !errors.Is(err, envloped.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if err := rec.Save(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := rec.Interactions()[0].Request.URL; got != "/v1/emails/msg_masked" {
+		t.Errorf("expected the recorded URL to be sanitized, got %q", got)
+	}
+
+	replay, err := NewRecorder(path, ModeReplay, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client = envloped.NewClient("unused").
+		WithBaseURL("http://envloped.invalid").
+		WithHTTPClient(replay.HTTPClient())
+	if _, err := client.Emails.Get("msg_private"); !errors.Is(err, envloped.ErrNotFound) {
+		t.Errorf("expected the recorded ErrNotFound, got %v", err)
+	}
+}
+
+func TestRecorder_MissingFixture(t *testing.T) {
+	t.Parallel()
+
+	if _, err := NewRecorder(filepath.Join(t.TempDir(), "missing.json"), ModeReplay, nil); err == nil {
+		t.Error("expected error for missing recording")
+	}
+}
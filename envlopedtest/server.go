@@ -0,0 +1,249 @@
+// Package envlopedtest provides an in-memory fake of the Envloped API for
+// testing code that sends email with the envloped package.
+//
+// Usage:
+//
+//	srv := envlopedtest.NewServer()
+//	defer srv.Close()
+//
+//	client := srv.Client()
+//	// ... exercise code that sends email with client ...
+//
+//	if got := len(srv.SentEmails()); got != 1 {
+//	    t.Errorf("expected 1 email, got %d", got)
+//	}
+package envlopedtest
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/envloped/envloped-go"
+)
+
+// APIKey is the API key the fake server accepts. Requests with any other
+// key fail with HTTP 401.
+const APIKey = "ev_test_envlopedtest"
+
+// Server is a fake Envloped API server backed by httptest.Server. It accepts
+// sends, records them for assertions, and can be told to fail requests.
+// It is safe for concurrent use.
+type Server struct {
+	// URL is the base URL of the server, for use with Client.WithBaseURL.
+	URL string
+
+	srv *httptest.Server
+
+	mu          sync.Mutex
+	sent        []envloped.SendEmailRequest
+	emails      map[string]*envloped.Email
+	idempotency map[string]*envloped.SendEmailResponse
+	failures    []int
+	seq         int
+}
+
+// NewServer starts and returns a new fake server. The caller should call
+// Close when finished, to shut it down.
+func NewServer() *Server {
+	s := &Server{
+		emails:      make(map[string]*envloped.Email),
+		idempotency: make(map[string]*envloped.SendEmailResponse),
+	}
+	s.srv = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.srv.URL
+	return s
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// Client returns a client that talks to the server using APIKey.
+func (s *Server) Client() *envloped.Client {
+	return envloped.NewClient(APIKey).WithBaseURL(s.URL)
+}
+
+// SentEmails returns the emails accepted by the server, in the order they
+// were sent. Replays of an idempotency key are not recorded again.
+func (s *Server) SentEmails() []envloped.SendEmailRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]envloped.SendEmailRequest(nil), s.sent...)
+}
+
+// FailNext makes the next request fail with the given HTTP status code,
+// such as http.StatusTooManyRequests, http.StatusUnauthorized, or
+// http.StatusInternalServerError. Each call queues one failure, so calling
+// it three times fails the next three requests.
+func (s *Server) FailNext(statusCode int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = append(s.failures, statusCode)
+}
+
+// Reset forgets all sent emails and queued failures.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sent = nil
+	s.emails = make(map[string]*envloped.Email)
+	s.idempotency = make(map[string]*envloped.SendEmailResponse)
+	s.failures = nil
+}
+
+// serveHTTP routes a request to its handler.
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.seq++
+	w.Header().Set("X-Request-Id", fmt.Sprintf("req_test_%d", s.seq))
+
+	if len(s.failures) > 0 {
+		status := s.failures[0]
+		s.failures = s.failures[1:]
+		writeFailure(w, status)
+		return
+	}
+	if r.Header.Get("Authorization") != "Bearer "+APIKey {
+		writeError(w, http.StatusUnauthorized, "Invalid API key")
+		return
+	}
+
+	switch {
+	case r.URL.Path == "/v1/ping" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, envloped.PingResponse{Message: "pong", CompanyID: "company_test"})
+	case r.URL.Path == "/v1/emails" && r.Method == http.MethodPost:
+		s.handleSend(w, r)
+	case strings.HasPrefix(r.URL.Path, "/v1/emails/") && r.Method == http.MethodGet:
+		s.handleGetEmail(w, strings.TrimPrefix(r.URL.Path, "/v1/emails/"))
+	default:
+		writeError(w, http.StatusNotFound, "Not found")
+	}
+}
+
+// handleSend records an email and responds with its message ID. Sends that
+// repeat an idempotency key return the original response.
+func (s *Server) handleSend(w http.ResponseWriter, r *http.Request) {
+	body := io.Reader(r.Body)
+	if r.Header.Get("Content-Encoding") == "gzip" {
+		zr, err := gzip.NewReader(r.Body)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid gzip body")
+			return
+		}
+		body = zr
+	}
+
+	var params envloped.SendEmailRequest
+	if err := json.NewDecoder(body).Decode(&params); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON body")
+		return
+	}
+	params.IdempotencyKey = r.Header.Get("Idempotency-Key")
+
+	if params.IdempotencyKey != "" {
+		if resp, ok := s.idempotency[params.IdempotencyKey]; ok {
+			writeJSON(w, http.StatusOK, resp)
+			return
+		}
+	}
+
+	resp := &envloped.SendEmailResponse{Success: true, Sandbox: params.Sandbox}
+	now := time.Now().UTC()
+	record := func(to []string, subject string) string {
+		id := fmt.Sprintf("msg_test_%d", len(s.emails)+1)
+		s.emails[id] = &envloped.Email{
+			ID:        id,
+			From:      params.From,
+			To:        to,
+			Subject:   subject,
+			Tags:      params.Tags,
+			Metadata:  params.Metadata,
+			Status:    envloped.EmailStatusSent,
+			CreatedAt: now,
+			SentAt:    &now,
+			UpdatedAt: now,
+		}
+		return id
+	}
+	if len(params.Personalizations) > 0 {
+		for _, p := range params.Personalizations {
+			subject := p.Subject
+			if subject == "" {
+				subject = params.Subject
+			}
+			resp.MessageIds = append(resp.MessageIds, record(p.To, subject))
+		}
+		resp.MessageId = resp.MessageIds[0]
+	} else {
+		resp.MessageId = record(params.To, params.Subject)
+	}
+
+	s.sent = append(s.sent, params)
+	if params.IdempotencyKey != "" {
+		s.idempotency[params.IdempotencyKey] = resp
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// handleGetEmail responds with a previously sent email.
+func (s *Server) handleGetEmail(w http.ResponseWriter, id string) {
+	email, ok := s.emails[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Email not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, email)
+}
+
+// writeFailure writes a simulated error response for status, with the body
+// and headers the real API sends for it.
+func writeFailure(w http.ResponseWriter, status int) {
+	switch status {
+	case http.StatusTooManyRequests:
+		dailyLimit := 100
+		w.Header().Set("X-RateLimit-Limit", "10")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", "1")
+		writeJSON(w, status, map[string]any{
+			"error":   "Rate limit exceeded",
+			"message": "Daily email limit reached (100 emails).",
+			"usage": envloped.EmailUsage{
+				DailyCount:   100,
+				MonthlyCount: 100,
+				DailyLimit:   &dailyLimit,
+				MonthlyLimit: 3000,
+			},
+		})
+	case http.StatusUnauthorized:
+		writeError(w, status, "Invalid API key")
+	case http.StatusInternalServerError:
+		writeJSON(w, status, map[string]string{
+			"error":   "Failed to send email",
+			"details": "simulated server error",
+		})
+	default:
+		writeError(w, status, http.StatusText(status))
+	}
+}
+
+// writeError writes an API error response with the given message.
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
+
+// writeJSON writes v as a JSON response with the given status.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,219 @@
+package envlopedtest
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/envloped/envloped-go"
+)
+
+func testEmail() *envloped.SendEmailRequest {
+	return &envloped.SendEmailRequest{
+		From:    "sender@example.com",
+		To:      []string{"recipient@example.com"},
+		Subject: "Hello",
+		Html:    "<p>Hi</p>",
+	}
+}
+
+func TestServer_RecordsSentEmails(t *testing.T) {
+	t.Parallel()
+
+	srv := NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	resp, err := client.Emails.Send(testEmail())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Success || resp.MessageId == "" {
+		t.Fatalf("unexpected response %+v", resp)
+	}
+
+	sent := srv.SentEmails()
+	if len(sent) != 1 {
+		t.Fatalf("expected 1 sent email, got %d", len(sent))
+	}
+	if sent[0].Subject != "Hello" || sent[0].To[0] != "recipient@example.com" {
+		t.Errorf("unexpected sent email %+v", sent[0])
+	}
+
+	email, err := client.Emails.Get(resp.MessageId)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if email.ID != resp.MessageId || email.Status != envloped.EmailStatusSent {
+		t.Errorf("unexpected email %+v", email)
+	}
+}
+
+func TestServer_Personalizations(t *testing.T) {
+	t.Parallel()
+
+	srv := NewServer()
+	defer srv.Close()
+
+	params := testEmail()
+	params.To = nil
+	params.Personalizations = []envloped.Personalization{
+		{To: []string{"a@example.com"}},
+		{To: []string{"b@example.com"}},
+	}
+
+	resp, err := srv.Client().Emails.Send(params)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.MessageIds) != 2 || resp.MessageId != resp.MessageIds[0] {
+		t.Errorf("unexpected response %+v", resp)
+	}
+}
+
+func TestServer_IdempotencyKey(t *testing.T) {
+	t.Parallel()
+
+	srv := NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	params := testEmail()
+	params.IdempotencyKey = "order-42"
+
+	first, err := client.Emails.Send(params)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, err := client.Emails.Send(params)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if first.MessageId != second.MessageId {
+		t.Errorf("expected replayed message id %q, got %q", first.MessageId, second.MessageId)
+	}
+	sent := srv.SentEmails()
+	if len(sent) != 1 || sent[0].IdempotencyKey != "order-42" {
+		t.Errorf("expected one recorded send with the key, got %+v", sent)
+	}
+}
+
+func TestServer_FailNext(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		status   int
+		sentinel error
+	}{
+		{"rate limited", http.StatusTooManyRequests, envloped.ErrRateLimited},
+		{"unauthorized", http.StatusUnauthorized, envloped.ErrUnauthorized},
+		{"server error", http.StatusInternalServerError, envloped.ErrServer},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := NewServer()
+			defer srv.Close()
+			client := srv.Client()
+
+			srv.FailNext(tt.status)
+
+			_, err := client.Emails.Send(testEmail())
+			var apiErr *envloped.APIError
+			if !errors.As(err, &apiErr) || apiErr.StatusCode != tt.status {
+				t.Fatalf("expected API error with status %d, got %v", tt.status, err)
+			}
+			if apiErr.RequestID == "" {
+				t.Error("expected request id to be set")
+			}
+			if tt.sentinel != nil && !errors.Is(err, tt.sentinel) {
+				t.Errorf("expected errors.Is(%v), got %v", tt.sentinel, err)
+			}
+			if len(srv.SentEmails()) != 0 {
+				t.Error("expected failed send not to be recorded")
+			}
+
+			// Only the next request fails.
+			if _, err := client.Emails.Send(testEmail()); err != nil {
+				t.Fatalf("unexpected error after failure: %v", err)
+			}
+		})
+	}
+}
+
+func TestServer_RateLimitDetails(t *testing.T) {
+	t.Parallel()
+
+	srv := NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	srv.FailNext(http.StatusTooManyRequests)
+	_, err := client.Emails.Send(testEmail())
+
+	var rle *envloped.RateLimitError
+	if !errors.As(err, &rle) {
+		t.Fatalf("expected *RateLimitError, got %T: %v", err, err)
+	}
+	if rle.Usage == nil || rle.Usage.DailyCount != 100 {
+		t.Errorf("expected usage to be populated, got %+v", rle.Usage)
+	}
+	if rl := client.RateLimit(); rl == nil || rl.Remaining != 0 {
+		t.Errorf("expected exhausted rate limit, got %+v", rl)
+	}
+}
+
+func TestServer_WrongAPIKey(t *testing.T) {
+	t.Parallel()
+
+	srv := NewServer()
+	defer srv.Close()
+
+	client := envloped.NewClient("ev_wrong").WithBaseURL(srv.URL)
+	if _, err := client.Ping(); !errors.Is(err, envloped.ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized, got %v", err)
+	}
+	if _, err := srv.Client().Ping(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestServer_Reset(t *testing.T) {
+	t.Parallel()
+
+	srv := NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	if _, err := client.Emails.Send(testEmail()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	srv.FailNext(http.StatusInternalServerError)
+	srv.Reset()
+
+	if len(srv.SentEmails()) != 0 {
+		t.Error("expected no sent emails after reset")
+	}
+	if _, err := client.Emails.Send(testEmail()); err != nil {
+		t.Errorf("expected queued failure to be cleared, got %v", err)
+	}
+}
+
+func TestServer_GzipBody(t *testing.T) {
+	t.Parallel()
+
+	srv := NewServer()
+	defer srv.Close()
+
+	if _, err := srv.Client().WithGzip(1).Emails.Send(testEmail()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sent := srv.SentEmails(); len(sent) != 1 || sent[0].Subject != testEmail().Subject {
+		t.Errorf("expected compressed send to be recorded, got %+v", sent)
+	}
+}
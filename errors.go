@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 )
 
 // Sentinel errors for use with errors.Is().
@@ -17,11 +18,36 @@ var (
 	// This typically means the domain is not registered or not verified.
 	ErrForbidden = errors.New("forbidden")
 
+	// ErrNotFound is returned when the requested resource, such as an email,
+	// contact, or domain, does not exist (HTTP 404).
+	ErrNotFound = errors.New("not found")
+
+	// ErrServer is returned when the API failed to process a valid request
+	// (HTTP 5xx). Such errors are not caused by the request and are usually
+	// transient.
+	ErrServer = errors.New("server error")
+
 	// ErrRateLimited is returned when usage limits have been exceeded (HTTP 429).
 	ErrRateLimited = errors.New("rate limit exceeded")
 
 	// ErrValidation is returned when the request body is invalid (HTTP 400).
 	ErrValidation = errors.New("validation error")
+
+	// ErrUnprocessable is returned when the request is well-formed but cannot
+	// be processed, e.g. because the From domain is not verified (HTTP 422).
+	ErrUnprocessable = errors.New("unprocessable request")
+
+	// ErrSuppressed is returned when a send is refused because a recipient is
+	// on the suppression list. See Client.WithSuppressionCheck.
+	ErrSuppressed = errors.New("recipient suppressed")
+
+	// ErrCircuitOpen is returned without contacting the API while the circuit
+	// breaker is open. See Client.WithCircuitBreaker.
+	ErrCircuitOpen = errors.New("circuit breaker open")
+
+	// ErrQuotaExhausted is returned without contacting the API when the quota
+	// guard knows the request would be rate limited. See Client.WithQuotaGuard.
+	ErrQuotaExhausted = errors.New("quota exhausted")
 )
 
 // APIError represents a generic error response from the Envloped API.
@@ -34,14 +60,34 @@ type APIError struct {
 
 	// Details provides additional context about the error (present on 500 responses).
 	Details string `json:"details,omitempty"`
+
+	// RequestID is the value of the X-Request-Id response header, if any.
+	// Include it when contacting support about a failed request.
+	RequestID string `json:"-"`
+
+	// APIVersion is the API version the request was pinned to with
+	// Client.WithAPIVersion, if any.
+	APIVersion string `json:"-"`
 }
 
 // Error implements the error interface.
 func (e *APIError) Error() string {
 	if e.Details != "" {
-		return fmt.Sprintf("envloped: %s (status %d): %s", e.Message, e.StatusCode, e.Details)
+		return fmt.Sprintf("envloped: %s (%s): %s", e.Message, e.status(), e.Details)
+	}
+	return fmt.Sprintf("envloped: %s (%s)", e.Message, e.status())
+}
+
+// status describes the status code and, if known, the request ID.
+func (e *APIError) status() string {
+	s := fmt.Sprintf("status %d", e.StatusCode)
+	if e.RequestID != "" {
+		s += ", request id " + e.RequestID
+	}
+	if e.APIVersion != "" {
+		s += ", api version " + e.APIVersion
 	}
-	return fmt.Sprintf("envloped: %s (status %d)", e.Message, e.StatusCode)
+	return s
 }
 
 // Is enables sentinel error matching via errors.Is().
@@ -51,10 +97,16 @@ func (e *APIError) Is(target error) bool {
 		return e.StatusCode == http.StatusUnauthorized
 	case target == ErrForbidden:
 		return e.StatusCode == http.StatusForbidden
+	case target == ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
 	case target == ErrRateLimited:
 		return e.StatusCode == http.StatusTooManyRequests
 	case target == ErrValidation:
 		return e.StatusCode == http.StatusBadRequest
+	case target == ErrUnprocessable:
+		return e.StatusCode == http.StatusUnprocessableEntity
+	case target == ErrServer:
+		return e.StatusCode >= 500
 	default:
 		return false
 	}
@@ -83,9 +135,9 @@ type RateLimitError struct {
 // Error implements the error interface.
 func (e *RateLimitError) Error() string {
 	if e.Reason != "" {
-		return fmt.Sprintf("envloped: rate limit exceeded (status %d): %s", e.StatusCode, e.Reason)
+		return fmt.Sprintf("envloped: rate limit exceeded (%s): %s", e.status(), e.Reason)
 	}
-	return fmt.Sprintf("envloped: rate limit exceeded (status %d)", e.StatusCode)
+	return fmt.Sprintf("envloped: rate limit exceeded (%s)", e.status())
 }
 
 // Is enables sentinel error matching via errors.Is().
@@ -101,10 +153,67 @@ func (e *RateLimitError) Unwrap() error {
 	return &e.APIError
 }
 
-// ValidationError is returned when the API responds with HTTP 400.
+// ServerError is returned when the API responds with HTTP 5xx. It embeds
+// APIError; Details and RequestID help Envloped support investigate.
+type ServerError struct {
+	APIError
+}
+
+// Is enables sentinel error matching via errors.Is().
+func (e *ServerError) Is(target error) bool {
+	if target == ErrServer {
+		return true
+	}
+	return e.APIError.Is(target)
+}
+
+// Unwrap returns the underlying APIError for errors.Unwrap() support.
+func (e *ServerError) Unwrap() error {
+	return &e.APIError
+}
+
+// FieldError describes a problem with a single request field.
+type FieldError struct {
+	// Field is the JSON path of the offending field, e.g. "to", "cc[1]" or
+	// "personalizations[0].headers.X-Campaign".
+	Field string `json:"field"`
+
+	// Code is a machine-readable reason. Client-side checks use "required",
+	// "invalid", "conflict", "reserved", "too_long", "too_large" and "too_many";
+	// the API may report others.
+	Code string `json:"code"`
+
+	// Message is a human-readable explanation.
+	Message string `json:"message"`
+}
+
+// ValidationError is returned when the API responds with HTTP 400, and by
+// client-side checks that fail before any network call (StatusCode is zero).
 // It embeds APIError for consistency.
 type ValidationError struct {
 	APIError
+
+	// Fields lists the offending fields, so errors can be mapped back to
+	// form inputs. It may be empty if the API did not report any.
+	Fields []FieldError `json:"fields,omitempty"`
+}
+
+// fieldError returns a client-side validation error for a single field.
+// The formatted message is used both for the error and for the field.
+func fieldError(field, code, format string, args ...any) *ValidationError {
+	message := fmt.Sprintf(format, args...)
+	return &ValidationError{
+		APIError: APIError{Message: message},
+		Fields:   []FieldError{{Field: field, Code: code, Message: message}},
+	}
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	if e.StatusCode == 0 {
+		return "envloped: " + e.Message
+	}
+	return e.APIError.Error()
 }
 
 // Is enables sentinel error matching via errors.Is().
@@ -120,15 +229,67 @@ func (e *ValidationError) Unwrap() error {
 	return &e.APIError
 }
 
+// UnprocessableError is returned when the API responds with HTTP 422: the
+// request is well-formed, but semantically invalid, such as a From address
+// on an unverified domain. It embeds APIError.
+type UnprocessableError struct {
+	APIError
+
+	// Fields lists the offending fields, if the API reported any.
+	Fields []FieldError `json:"fields,omitempty"`
+}
+
+// Is enables sentinel error matching via errors.Is().
+func (e *UnprocessableError) Is(target error) bool {
+	if target == ErrUnprocessable {
+		return true
+	}
+	return e.APIError.Is(target)
+}
+
+// Unwrap returns the underlying APIError for errors.Unwrap() support.
+func (e *UnprocessableError) Unwrap() error {
+	return &e.APIError
+}
+
+// SuppressedError is returned by Emails.Send when the suppression check is
+// enabled and at least one recipient is suppressed. No email is sent.
+type SuppressedError struct {
+	// Suppressions lists the suppressed recipients.
+	Suppressions []Suppression
+}
+
+// Error implements the error interface.
+func (e *SuppressedError) Error() string {
+	emails := make([]string, len(e.Suppressions))
+	for i, s := range e.Suppressions {
+		emails[i] = fmt.Sprintf("%s (%s)", s.Email, s.Reason)
+	}
+	return fmt.Sprintf("envloped: recipient suppressed: %s", strings.Join(emails, ", "))
+}
+
+// Is enables sentinel error matching via errors.Is().
+func (e *SuppressedError) Is(target error) bool {
+	return target == ErrSuppressed
+}
+
 // handleErrorResponse parses an error response body and returns a typed error
 // based on the HTTP status code.
 func handleErrorResponse(resp *http.Response) error {
 	defer resp.Body.Close()
 
+	requestID := resp.Header.Get(requestIDHeader)
+	var apiVersion string
+	if resp.Request != nil {
+		apiVersion = resp.Request.Header.Get(apiVersionHeader)
+	}
+
 	switch resp.StatusCode {
 	case http.StatusTooManyRequests:
 		rateLimitErr := &RateLimitError{}
 		rateLimitErr.StatusCode = resp.StatusCode
+		rateLimitErr.RequestID = requestID
+		rateLimitErr.APIVersion = apiVersion
 		if err := json.NewDecoder(resp.Body).Decode(rateLimitErr); err != nil {
 			rateLimitErr.Message = http.StatusText(resp.StatusCode)
 		}
@@ -139,12 +300,27 @@ func handleErrorResponse(resp *http.Response) error {
 		return rateLimitErr
 
 	case http.StatusBadRequest:
-		apiErr := &APIError{}
-		if err := json.NewDecoder(resp.Body).Decode(apiErr); err != nil {
-			apiErr.Message = http.StatusText(resp.StatusCode)
+		validationErr := &ValidationError{}
+		if err := json.NewDecoder(resp.Body).Decode(validationErr); err != nil {
+			validationErr.Message = http.StatusText(resp.StatusCode)
 		}
-		apiErr.StatusCode = resp.StatusCode
-		return &ValidationError{APIError: *apiErr}
+		validationErr.StatusCode = resp.StatusCode
+		validationErr.RequestID = requestID
+		validationErr.APIVersion = apiVersion
+		return validationErr
+
+	case http.StatusUnprocessableEntity:
+		unprocessableErr := &UnprocessableError{}
+		if err := json.NewDecoder(resp.Body).Decode(unprocessableErr); err != nil {
+			unprocessableErr.Message = http.StatusText(resp.StatusCode)
+		}
+		unprocessableErr.StatusCode = resp.StatusCode
+		unprocessableErr.RequestID = requestID
+		unprocessableErr.APIVersion = apiVersion
+		if unprocessableErr.Message == "" {
+			unprocessableErr.Message = http.StatusText(resp.StatusCode)
+		}
+		return unprocessableErr
 
 	default:
 		apiErr := &APIError{}
@@ -152,9 +328,14 @@ func handleErrorResponse(resp *http.Response) error {
 			apiErr.Message = http.StatusText(resp.StatusCode)
 		}
 		apiErr.StatusCode = resp.StatusCode
+		apiErr.RequestID = requestID
+		apiErr.APIVersion = apiVersion
 		if apiErr.Message == "" {
 			apiErr.Message = http.StatusText(resp.StatusCode)
 		}
+		if resp.StatusCode >= 500 {
+			return &ServerError{APIError: *apiErr}
+		}
 		return apiErr
 	}
 }
@@ -0,0 +1,129 @@
+package envloped
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// DeliveryEvent is the payload of a delivered event.
+type DeliveryEvent struct {
+	// SMTPResponse is the response line returned by the recipient server.
+	SMTPResponse string `json:"smtpResponse,omitempty"`
+
+	// ProcessingTimeMs is how long delivery took after the email was accepted.
+	ProcessingTimeMs int64 `json:"processingTimeMs,omitempty"`
+}
+
+// BounceType classifies a bounce.
+type BounceType string
+
+// Bounce types.
+const (
+	// BounceTypeHard is a permanent failure; the address should not be mailed again.
+	BounceTypeHard BounceType = "hard"
+
+	// BounceTypeSoft is a temporary failure, such as a full mailbox.
+	BounceTypeSoft BounceType = "soft"
+)
+
+// BounceEvent is the payload of a bounced event.
+type BounceEvent struct {
+	// Type is whether the bounce is permanent or temporary.
+	Type BounceType `json:"bounceType"`
+
+	// SubType is a more specific classification (e.g., "mailbox_full", "no_such_user").
+	SubType string `json:"bounceSubType,omitempty"`
+
+	// DiagnosticCode is the raw diagnostic returned by the recipient server.
+	DiagnosticCode string `json:"diagnosticCode,omitempty"`
+}
+
+// ComplaintEvent is the payload of a complained (spam report) event.
+type ComplaintEvent struct {
+	// FeedbackType is the feedback loop report type (e.g., "abuse").
+	FeedbackType string `json:"feedbackType,omitempty"`
+
+	// UserAgent identifies the mailbox provider that sent the report.
+	UserAgent string `json:"userAgent,omitempty"`
+}
+
+// OpenEvent is the payload of an opened event.
+type OpenEvent struct {
+	// IPAddress is the address the tracking pixel was loaded from.
+	IPAddress string `json:"ipAddress,omitempty"`
+
+	// UserAgent is the user agent that loaded the tracking pixel.
+	UserAgent string `json:"userAgent,omitempty"`
+}
+
+// ClickEvent is the payload of a clicked event.
+type ClickEvent struct {
+	// URL is the link that was clicked.
+	URL string `json:"url"`
+
+	// IPAddress is the address the click came from.
+	IPAddress string `json:"ipAddress,omitempty"`
+
+	// UserAgent is the user agent that followed the link.
+	UserAgent string `json:"userAgent,omitempty"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler. In addition to the common
+// fields, it decodes Data into the typed payload field matching Type.
+// Unknown event types are accepted with only the common fields populated.
+func (e *Event) UnmarshalJSON(data []byte) error {
+	type alias Event
+	var a alias
+	if err := json.Unmarshal(data, &a); err != nil {
+		return err
+	}
+	*e = Event(a)
+
+	var target interface{}
+	switch e.Type {
+	case EventTypeDelivered:
+		e.Delivery = &DeliveryEvent{}
+		target = e.Delivery
+	case EventTypeBounced:
+		e.Bounce = &BounceEvent{}
+		target = e.Bounce
+	case EventTypeComplained:
+		e.Complaint = &ComplaintEvent{}
+		target = e.Complaint
+	case EventTypeOpened:
+		e.Open = &OpenEvent{}
+		target = e.Open
+	case EventTypeClicked:
+		e.Click = &ClickEvent{}
+		target = e.Click
+	}
+
+	if target != nil && len(e.Data) > 0 && string(e.Data) != "null" {
+		if err := json.Unmarshal(e.Data, target); err != nil {
+			return fmt.Errorf("envloped: invalid %s event data: %w", e.Type, err)
+		}
+	}
+
+	return nil
+}
+
+// ParseEvent decodes a single event, such as a webhook request body, and
+// populates the typed payload matching its type. Switch on Event.Type to
+// find which payload field is set:
+//
+//	switch e.Type {
+//	case envloped.EventTypeBounced:
+//	    if e.Bounce.Type == envloped.BounceTypeHard { ... }
+//	case envloped.EventTypeClicked:
+//	    log.Println("clicked", e.Click.URL)
+//	}
+func ParseEvent(data []byte) (Event, error) {
+	var e Event
+	if err := json.Unmarshal(data, &e); err != nil {
+		return Event{}, fmt.Errorf("envloped: failed to parse event: %w", err)
+	}
+	if e.Type == "" {
+		return Event{}, fmt.Errorf("envloped: failed to parse event: missing event type")
+	}
+	return e, nil
+}
@@ -0,0 +1,104 @@
+package envloped
+
+import (
+	"testing"
+)
+
+func TestParseEvent_TypedPayloads(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+		check func(t *testing.T, e Event)
+	}{
+		{
+			name:  "delivered",
+			input: `{"id":"evt_1","type":"delivered","messageId":"msg_1","data":{"smtpResponse":"250 OK"}}`,
+			check: func(t *testing.T, e Event) {
+				if e.Delivery == nil || e.Delivery.SMTPResponse != "250 OK" {
+					t.Errorf("unexpected delivery payload: %+v", e.Delivery)
+				}
+			},
+		},
+		{
+			name:  "bounced",
+			input: `{"id":"evt_2","type":"bounced","messageId":"msg_1","recipient":"a@example.com","data":{"bounceType":"hard","bounceSubType":"no_such_user"}}`,
+			check: func(t *testing.T, e Event) {
+				if e.Bounce == nil || e.Bounce.Type != BounceTypeHard || e.Bounce.SubType != "no_such_user" {
+					t.Errorf("unexpected bounce payload: %+v", e.Bounce)
+				}
+				if e.Recipient != "a@example.com" {
+					t.Errorf("unexpected recipient: %q", e.Recipient)
+				}
+			},
+		},
+		{
+			name:  "complained",
+			input: `{"id":"evt_3","type":"complained","data":{"feedbackType":"abuse"}}`,
+			check: func(t *testing.T, e Event) {
+				if e.Complaint == nil || e.Complaint.FeedbackType != "abuse" {
+					t.Errorf("unexpected complaint payload: %+v", e.Complaint)
+				}
+			},
+		},
+		{
+			name:  "opened",
+			input: `{"id":"evt_4","type":"opened","data":{"ipAddress":"198.51.100.7"}}`,
+			check: func(t *testing.T, e Event) {
+				if e.Open == nil || e.Open.IPAddress != "198.51.100.7" {
+					t.Errorf("unexpected open payload: %+v", e.Open)
+				}
+			},
+		},
+		{
+			name:  "clicked",
+			input: `{"id":"evt_5","type":"clicked","data":{"url":"https://example.com/a"}}`,
+			check: func(t *testing.T, e Event) {
+				if e.Click == nil || e.Click.URL != "https://example.com/a" {
+					t.Errorf("unexpected click payload: %+v", e.Click)
+				}
+				if e.Bounce != nil || e.Open != nil {
+					t.Error("expected only the click payload to be set")
+				}
+			},
+		},
+		{
+			name:  "unknown type",
+			input: `{"id":"evt_6","type":"scheduled","data":{"sendAt":"2025-01-01T00:00:00Z"}}`,
+			check: func(t *testing.T, e Event) {
+				if e.Type != "scheduled" || len(e.Data) == 0 {
+					t.Errorf("expected unknown event to keep type and raw data: %+v", e)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			e, err := ParseEvent([]byte(tt.input))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			tt.check(t, e)
+		})
+	}
+}
+
+func TestParseEvent_Errors(t *testing.T) {
+	t.Parallel()
+
+	inputs := []string{
+		`not json`,
+		`{"id":"evt_1"}`,
+		`{"id":"evt_1","type":"bounced","data":{"bounceType":42}}`,
+	}
+	for _, input := range inputs {
+		if _, err := ParseEvent([]byte(input)); err == nil {
+			t.Errorf("expected error for %s", input)
+		}
+	}
+}
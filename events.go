@@ -0,0 +1,377 @@
+package envloped
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EventType identifies what happened to an email.
+type EventType string
+
+// Email event types.
+const (
+	EventTypeQueued     EventType = "queued"
+	EventTypeSent       EventType = "sent"
+	EventTypeDelivered  EventType = "delivered"
+	EventTypeBounced    EventType = "bounced"
+	EventTypeComplained EventType = "complained"
+	EventTypeOpened     EventType = "opened"
+	EventTypeClicked    EventType = "clicked"
+)
+
+// Event is a single occurrence in an email's lifecycle, such as a delivery or a bounce.
+type Event struct {
+	// ID is the unique identifier of the event. It doubles as the resume
+	// token for event streams.
+	ID string `json:"id"`
+
+	// Type identifies what happened.
+	Type EventType `json:"type"`
+
+	// CreatedAt is when the event occurred.
+	CreatedAt time.Time `json:"createdAt"`
+
+	// MessageID is the ID of the email the event belongs to.
+	MessageID string `json:"messageId"`
+
+	// Recipient is the address the event applies to.
+	Recipient string `json:"recipient"`
+
+	// Tags are the tags of the email the event belongs to.
+	Tags []string `json:"tags,omitempty"`
+
+	// Metadata holds the key-value pairs of the email the event belongs to.
+	Metadata map[string]string `json:"metadata,omitempty"`
+
+	// TemplateID is the template the email was rendered from, if any.
+	TemplateID string `json:"templateId,omitempty"`
+
+	// Data holds the raw type-specific event payload.
+	Data json.RawMessage `json:"data,omitempty"`
+
+	// Delivery is the decoded payload of an EventTypeDelivered event.
+	Delivery *DeliveryEvent `json:"-"`
+
+	// Bounce is the decoded payload of an EventTypeBounced event.
+	Bounce *BounceEvent `json:"-"`
+
+	// Complaint is the decoded payload of an EventTypeComplained event.
+	Complaint *ComplaintEvent `json:"-"`
+
+	// Open is the decoded payload of an EventTypeOpened event.
+	Open *OpenEvent `json:"-"`
+
+	// Click is the decoded payload of an EventTypeClicked event.
+	Click *ClickEvent `json:"-"`
+}
+
+// EventStreamOptions configures an event stream subscription.
+type EventStreamOptions struct {
+	// Types limits the stream to the given event types. All types are streamed when empty.
+	Types []EventType
+
+	// ResumeFrom is the ID of the last event the caller processed. The stream
+	// starts with the event after it, so a consumer can pick up where it left
+	// off across restarts.
+	ResumeFrom string
+
+	// OnError is called with errors that cause the stream to reconnect, and
+	// with the final error when the stream gives up. It may be nil.
+	OnError func(error)
+}
+
+// EventsSvc defines the interface for the events service.
+// This interface can be mocked in consumer tests.
+type EventsSvc interface {
+	// Stream subscribes to the real-time event stream. Events are delivered on
+	// the returned channel until ctx is done or a non-recoverable error occurs,
+	// at which point the channel is closed.
+	Stream(ctx context.Context, opts *EventStreamOptions) (<-chan Event, error)
+
+	// StreamFunc subscribes to the real-time event stream and calls handler
+	// with each event. It blocks until ctx is done, handler returns an
+	// error, or the stream fails permanently.
+	StreamFunc(ctx context.Context, opts *EventStreamOptions, handler func(Event) error) error
+
+	// Poll returns an iterator that polls the event feed every interval,
+	// starting at since. Use it where a long-lived stream is not an option.
+	// A non-positive interval defaults to five seconds.
+	Poll(ctx context.Context, since time.Time, interval time.Duration) *EventPoller
+
+	// List returns a page of past account events matching opts, oldest
+	// first, for bulk ingestion of the event history.
+	List(ctx context.Context, opts *ListEventsOptions) (*EventPage, error)
+}
+
+// eventsSvcImpl implements EventsSvc.
+type eventsSvcImpl struct {
+	client *Client
+}
+
+const (
+	// streamInitialBackoff is the reconnect delay after the first failure.
+	streamInitialBackoff = time.Second
+
+	// streamMaxBackoff caps the exponential reconnect delay.
+	streamMaxBackoff = 30 * time.Second
+)
+
+// Stream subscribes to the real-time server-sent event stream.
+//
+// The initial connection is made before Stream returns, so authentication and
+// validation errors are reported directly. After that, dropped connections are
+// re-established automatically with exponential backoff, resuming after the
+// last delivered event so nothing is missed. The stream stops, and the channel
+// is closed, when ctx is done or the API rejects a reconnect with a client
+// error (e.g., a revoked API key).
+func (s *eventsSvcImpl) Stream(ctx context.Context, opts *EventStreamOptions) (<-chan Event, error) {
+	if opts == nil {
+		opts = &EventStreamOptions{}
+	}
+
+	st := &eventStream{
+		svc:    s,
+		opts:   opts,
+		lastID: opts.ResumeFrom,
+		retry:  streamInitialBackoff,
+		delay:  streamInitialBackoff,
+	}
+
+	resp, err := st.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan Event)
+	go st.run(ctx, resp, ch)
+
+	return ch, nil
+}
+
+// StreamFunc subscribes to the real-time event stream like Stream, but calls
+// handler with each event instead of delivering them on a channel. Events
+// are handled one at a time, in order. It returns the handler's error, which
+// stops the stream, ctx.Err() when ctx is done, or the error that ended the
+// stream otherwise. To resume after a restart, persist the ID of the last
+// handled event and pass it as opts.ResumeFrom.
+func (s *eventsSvcImpl) StreamFunc(ctx context.Context, opts *EventStreamOptions, handler func(Event) error) error {
+	if handler == nil {
+		return fmt.Errorf("envloped: event handler must not be nil")
+	}
+
+	var o EventStreamOptions
+	if opts != nil {
+		o = *opts
+	}
+	// The stream reports the error that ends it last, before closing the
+	// channel, so lastErr is safe to read once the channel is drained.
+	var lastErr error
+	o.OnError = func(err error) {
+		lastErr = err
+		if opts != nil && opts.OnError != nil {
+			opts.OnError(err)
+		}
+	}
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	events, err := s.Stream(streamCtx, &o)
+	if err != nil {
+		return err
+	}
+	for e := range events {
+		if err := handler(e); err != nil {
+			cancel()
+			for range events {
+			}
+			return err
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return lastErr
+}
+
+// eventStream holds the state of a single Stream subscription.
+type eventStream struct {
+	svc    *eventsSvcImpl
+	opts   *EventStreamOptions
+	lastID string
+	retry  time.Duration // reconnect delay after a failure, set by "retry:"
+	delay  time.Duration // delay before the next reconnect attempt
+}
+
+// connect opens the SSE connection, resuming after lastID if set.
+func (st *eventStream) connect(ctx context.Context) (*http.Response, error) {
+	c := st.svc.client
+
+	q := url.Values{}
+	if len(st.opts.Types) > 0 {
+		types := make([]string, len(st.opts.Types))
+		for i, t := range st.opts.Types {
+			types[i] = string(t)
+		}
+		q.Set("types", strings.Join(types, ","))
+	}
+
+	path := "/v1/events/stream"
+	if len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+
+	req, err := c.newRequest(ctx, http.MethodGet, "/v1/events/stream", path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to create event stream request: %w", err)
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("Cache-Control", "no-cache")
+	if st.lastID != "" {
+		req.Header.Set("Last-Event-ID", st.lastID)
+	}
+
+	// The stream is long-lived, so the client-wide timeout must not apply.
+	hc := *c.httpClient
+	hc.Timeout = 0
+
+	return c.roundTrip(&hc, req)
+}
+
+// run reads events from resp, reconnecting as needed, until ctx is done or
+// the stream fails permanently. It closes ch on return.
+func (st *eventStream) run(ctx context.Context, resp *http.Response, ch chan<- Event) {
+	defer close(ch)
+
+	for {
+		err := st.read(ctx, resp, ch)
+		resp.Body.Close()
+		if ctx.Err() != nil {
+			return
+		}
+		st.reportError(err)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(st.delay):
+			}
+
+			resp, err = st.connect(ctx)
+			if err == nil {
+				st.delay = st.retry
+				break
+			}
+			if ctx.Err() != nil {
+				return
+			}
+			st.reportError(err)
+			if isPermanentStreamError(err) {
+				return
+			}
+
+			st.delay *= 2
+			if st.delay > streamMaxBackoff {
+				st.delay = streamMaxBackoff
+			}
+		}
+	}
+}
+
+// read parses server-sent events from resp and forwards them to ch.
+// It returns when the connection ends.
+func (st *eventStream) read(ctx context.Context, resp *http.Response, ch chan<- Event) error {
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var id, data strings.Builder
+	var hasID bool
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if line == "" {
+			// A blank line dispatches the buffered event.
+			if data.Len() > 0 {
+				var e Event
+				if err := json.Unmarshal([]byte(data.String()), &e); err != nil {
+					st.reportError(fmt.Errorf("envloped: failed to decode stream event: %w", err))
+				} else {
+					if e.ID == "" && hasID {
+						e.ID = id.String()
+					}
+					if e.ID != "" {
+						// Resume after this event even if the server
+						// sent it without an "id:" field.
+						st.lastID = e.ID
+					}
+					select {
+					case ch <- e:
+					case <-ctx.Done():
+						return ctx.Err()
+					}
+				}
+			}
+			if hasID {
+				st.lastID = id.String()
+			}
+			id.Reset()
+			data.Reset()
+			hasID = false
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "id":
+			id.Reset()
+			id.WriteString(value)
+			hasID = true
+		case "data":
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(value)
+		case "retry":
+			if ms, err := strconv.Atoi(value); err == nil && ms > 0 {
+				st.retry = time.Duration(ms) * time.Millisecond
+				st.delay = st.retry
+			}
+		}
+		// Comments (lines starting with ":") and "event" fields are ignored;
+		// the event type is part of the JSON payload.
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("envloped: event stream interrupted: %w", err)
+	}
+	return errors.New("envloped: event stream closed by server")
+}
+
+// reportError passes err to the OnError callback, if any.
+func (st *eventStream) reportError(err error) {
+	if st.opts.OnError != nil && err != nil {
+		st.opts.OnError(err)
+	}
+}
+
+// isPermanentStreamError reports whether err is an API client error that a
+// reconnect cannot fix. Rate limiting is treated as transient.
+func isPermanentStreamError(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return apiErr.StatusCode >= 400 && apiErr.StatusCode < 500 &&
+		apiErr.StatusCode != http.StatusTooManyRequests
+}
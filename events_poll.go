@@ -0,0 +1,196 @@
+package envloped
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// pollPageSize is the number of events fetched per request while polling.
+	pollPageSize = 100
+
+	// defaultPollInterval is the polling interval used when Poll is given a
+	// non-positive one.
+	defaultPollInterval = 5 * time.Second
+)
+
+// ListEventsOptions configures a page of results from Events.List.
+type ListEventsOptions struct {
+	ListOptions
+
+	// Types limits the results to the given event types. All types are
+	// listed when empty.
+	Types []EventType
+}
+
+// EventPage is a single page of the account event feed, oldest first.
+type EventPage = Page[Event]
+
+// List returns a page of account events matching opts. Pass the returned
+// NextCursor as opts.Cursor to fetch the following page.
+func (s *eventsSvcImpl) List(ctx context.Context, opts *ListEventsOptions) (*EventPage, error) {
+	if opts == nil {
+		opts = &ListEventsOptions{}
+	}
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
+	q := opts.values()
+	if len(opts.Types) > 0 {
+		types := make([]string, len(opts.Types))
+		for i, t := range opts.Types {
+			types[i] = string(t)
+		}
+		q.Set("types", strings.Join(types, ","))
+	}
+
+	return s.listPage(ctx, q)
+}
+
+// listPage fetches a single page of the event feed matching q.
+func (s *eventsSvcImpl) listPage(ctx context.Context, q url.Values) (*EventPage, error) {
+	path := "/v1/events"
+	if len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodGet, "/v1/events", path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to create list events request: %w", err)
+	}
+
+	var page EventPage
+	if err := s.client.do(req, &page); err != nil {
+		return nil, err
+	}
+
+	return &page, nil
+}
+
+// Poll returns an EventPoller that reads the event feed from since onwards,
+// checking for new events every interval. A non-positive interval defaults to
+// five seconds.
+func (s *eventsSvcImpl) Poll(ctx context.Context, since time.Time, interval time.Duration) *EventPoller {
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	return &EventPoller{
+		svc:      s,
+		ctx:      ctx,
+		interval: interval,
+		since:    since,
+		seen:     make(map[string]struct{}),
+	}
+}
+
+// EventPoller is a polling iterator over the account event feed, for
+// consumers that cannot hold a stream open. It is not safe for concurrent use.
+//
+// Delivery is at-least-once: persist Checkpoint after processing each event
+// and pass it as since to Poll on restart. Events that share the checkpoint's
+// timestamp may then be delivered again, so handlers should be idempotent.
+type EventPoller struct {
+	svc      *eventsSvcImpl
+	ctx      context.Context
+	interval time.Duration
+
+	// since is the timestamp the next fetch starts from (inclusive).
+	since time.Time
+
+	// seen holds the IDs of delivered events whose timestamp equals since,
+	// so the inclusive fetch does not return them twice.
+	seen map[string]struct{}
+
+	// buf holds fetched events not yet returned by Next.
+	buf []Event
+
+	// next is the query for the following page of the current fetch, or
+	// nil when the last fetch reached the end of the feed.
+	next url.Values
+
+	// checkpoint is the timestamp of the last event returned by Next.
+	checkpoint time.Time
+
+	// polled reports whether at least one fetch has completed.
+	polled bool
+}
+
+// Next returns the next event, blocking until one is available.
+// It returns an error when the context passed to Poll is done or a
+// request fails; polling can be resumed by calling Next again.
+func (p *EventPoller) Next() (Event, error) {
+	for len(p.buf) == 0 {
+		if p.polled && p.next == nil {
+			select {
+			case <-p.ctx.Done():
+				return Event{}, p.ctx.Err()
+			case <-time.After(p.interval):
+			}
+		}
+		if err := p.fetch(); err != nil {
+			return Event{}, err
+		}
+		p.polled = true
+	}
+
+	e := p.buf[0]
+	p.buf = p.buf[1:]
+	p.checkpoint = e.CreatedAt
+	return e, nil
+}
+
+// Checkpoint returns the position of the last event returned by Next.
+// Passing it to Poll resumes the feed from that event.
+func (p *EventPoller) Checkpoint() time.Time {
+	if p.checkpoint.IsZero() {
+		return p.since
+	}
+	return p.checkpoint
+}
+
+// fetch reads one page of events into the buffer, skipping events that
+// have already been delivered. It continues from the cursor of the previous
+// page if there is one, and otherwise starts a new query from p.since.
+func (p *EventPoller) fetch() error {
+	q := p.next
+	if q == nil {
+		q = url.Values{}
+		if !p.since.IsZero() {
+			q.Set("since", p.since.UTC().Format(time.RFC3339Nano))
+		}
+		q.Set("limit", strconv.Itoa(pollPageSize))
+	}
+
+	page, err := p.svc.listPage(p.ctx, q)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range page.Items {
+		if _, ok := p.seen[e.ID]; ok {
+			continue
+		}
+		p.buf = append(p.buf, e)
+
+		switch {
+		case e.CreatedAt.After(p.since):
+			p.since = e.CreatedAt
+			p.seen = map[string]struct{}{e.ID: {}}
+		case e.CreatedAt.Equal(p.since):
+			p.seen[e.ID] = struct{}{}
+		}
+	}
+
+	p.next = nil
+	if page.NextCursor != "" {
+		q.Set("cursor", page.NextCursor)
+		p.next = q
+	}
+	return nil
+}
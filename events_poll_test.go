@@ -0,0 +1,240 @@
+package envloped
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestEventsPoll_DeduplicatesAndCheckpoints(t *testing.T) {
+	t.Parallel()
+
+	t1 := time.Date(2025, 1, 1, 0, 0, 1, 0, time.UTC)
+	t2 := time.Date(2025, 1, 1, 0, 0, 2, 0, time.UTC)
+
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/events" {
+			t.Errorf("expected path /v1/events, got %s", r.URL.Path)
+		}
+
+		var page EventPage
+		switch atomic.AddInt32(&calls, 1) {
+		case 1:
+			if got := r.URL.Query().Get("since"); got != "" {
+				t.Errorf("expected no since on first poll, got %q", got)
+			}
+			page.Items = []Event{{ID: "evt_1", CreatedAt: t1}, {ID: "evt_2", CreatedAt: t2}}
+		default:
+			if got := r.URL.Query().Get("since"); got != "2025-01-01T00:00:02Z" {
+				t.Errorf("expected since to advance to last event, got %q", got)
+			}
+			// The inclusive since returns evt_2 again alongside a new event
+			// with the same timestamp.
+			page.Items = []Event{{ID: "evt_2", CreatedAt: t2}, {ID: "evt_3", CreatedAt: t2}}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(page)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	poller := client.Events.Poll(ctx, time.Time{}, 10*time.Millisecond)
+
+	var ids []string
+	for i := 0; i < 3; i++ {
+		e, err := poller.Next()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		ids = append(ids, e.ID)
+	}
+
+	if ids[0] != "evt_1" || ids[1] != "evt_2" || ids[2] != "evt_3" {
+		t.Errorf("unexpected event order: %v", ids)
+	}
+	if cp := poller.Checkpoint(); !cp.Equal(t2) {
+		t.Errorf("expected checkpoint %v, got %v", t2, cp)
+	}
+}
+
+func TestEventsPoll_FetchesOnePageAtATime(t *testing.T) {
+	t.Parallel()
+
+	t1 := time.Date(2025, 1, 1, 0, 0, 1, 0, time.UTC)
+	t2 := time.Date(2025, 1, 1, 0, 0, 2, 0, time.UTC)
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var page EventPage
+		switch calls.Add(1) {
+		case 1:
+			page.Items = []Event{{ID: "evt_1", CreatedAt: t1}}
+			page.NextCursor = "cur_2"
+		default:
+			if got := r.URL.Query().Get("cursor"); got != "cur_2" {
+				t.Errorf("expected cursor cur_2, got %q", got)
+			}
+			page.Items = []Event{{ID: "evt_2", CreatedAt: t2}}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(page)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// The interval is longer than the test timeout, so the second page must
+	// be fetched without waiting for it.
+	poller := client.Events.Poll(ctx, time.Time{}, time.Hour)
+
+	e, err := poller.Next()
+	if err != nil || e.ID != "evt_1" {
+		t.Fatalf("expected evt_1, got %+v, %v", e, err)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("expected 1 request for the first event, got %d", n)
+	}
+
+	e, err = poller.Next()
+	if err != nil || e.ID != "evt_2" {
+		t.Fatalf("expected evt_2, got %+v, %v", e, err)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Errorf("expected 2 requests, got %d", n)
+	}
+}
+
+func TestEventsPoll_ContextDone(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(EventPage{})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	since := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	poller := client.Events.Poll(ctx, since, 10*time.Millisecond)
+	_, err := poller.Next()
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if cp := poller.Checkpoint(); !cp.Equal(since) {
+		t.Errorf("expected checkpoint to stay at since, got %v", cp)
+	}
+}
+
+func TestEventsPoll_DefaultInterval(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(EventPage{})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	poller := client.Events.Poll(ctx, time.Time{}, 0)
+	if poller.interval != defaultPollInterval {
+		t.Errorf("expected interval %v, got %v", defaultPollInterval, poller.interval)
+	}
+	if _, err := poller.Next(); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("expected 1 request before the deadline, got %d", n)
+	}
+}
+
+func TestEventsList(t *testing.T) {
+	t.Parallel()
+
+	since := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/v1/events" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		q := r.URL.Query()
+		if q.Get("types") != "bounced,complained" || q.Get("since") != "2025-01-01T00:00:00Z" || q.Get("limit") != "500" || q.Has("until") {
+			t.Errorf("unexpected query %v", q)
+		}
+
+		page := EventPage{Items: []Event{{ID: "evt_1", Type: EventTypeBounced}}, NextCursor: "cur_2"}
+		if q.Get("cursor") == "cur_2" {
+			page = EventPage{Items: []Event{{ID: "evt_2", Type: EventTypeComplained}}}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(page)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	opts := &ListEventsOptions{
+		ListOptions: ListOptions{Since: since, Limit: 500},
+		Types:       []EventType{EventTypeBounced, EventTypeComplained},
+	}
+
+	var ids []string
+	for {
+		page, err := client.Events.List(context.Background(), opts)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, e := range page.Items {
+			ids = append(ids, e.ID)
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		opts.Cursor = page.NextCursor
+	}
+
+	if len(ids) != 2 || ids[0] != "evt_1" || ids[1] != "evt_2" {
+		t.Errorf("unexpected events %v", ids)
+	}
+}
+
+func TestEventsList_Validation(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	client := NewClient("key")
+	tests := []struct {
+		name string
+		opts *ListEventsOptions
+	}{
+		{"negative limit", &ListEventsOptions{ListOptions: ListOptions{Limit: -1}}},
+		{"since after until", &ListEventsOptions{ListOptions: ListOptions{Since: now, Until: now.Add(-time.Minute)}}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if _, err := client.Events.List(context.Background(), tt.opts); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
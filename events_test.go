@@ -0,0 +1,266 @@
+package envloped
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestEventsStream_ReconnectsAndResumes(t *testing.T) {
+	t.Parallel()
+
+	var conns int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/events/stream" {
+			t.Errorf("expected path /v1/events/stream, got %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("types"); got != "bounced,complained" {
+			t.Errorf("expected types filter %q, got %q", "bounced,complained", got)
+		}
+		if accept := r.Header.Get("Accept"); accept != "text/event-stream" {
+			t.Errorf("expected Accept text/event-stream, got %q", accept)
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		switch atomic.AddInt32(&conns, 1) {
+		case 1:
+			if id := r.Header.Get("Last-Event-ID"); id != "" {
+				t.Errorf("expected no Last-Event-ID on first connect, got %q", id)
+			}
+			fmt.Fprint(w, "retry: 10\n\n")
+			fmt.Fprint(w, ": keep-alive\n\n")
+			fmt.Fprint(w, "id: evt_1\ndata: {\"type\":\"bounced\",\"messageId\":\"msg_1\"}\n\n")
+			fmt.Fprint(w, "id: evt_2\nevent: complained\ndata: {\"id\":\"evt_2\",\"type\":\"complained\",\n")
+			fmt.Fprint(w, "data: \"messageId\":\"msg_2\"}\n\n")
+			// Returning closes the connection, forcing a reconnect.
+		default:
+			if id := r.Header.Get("Last-Event-ID"); id != "evt_2" {
+				t.Errorf("expected Last-Event-ID evt_2 on reconnect, got %q", id)
+			}
+			fmt.Fprint(w, "id: evt_3\ndata: {\"type\":\"bounced\",\"messageId\":\"msg_3\"}\n\n")
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	ch, err := client.Events.Stream(ctx, &EventStreamOptions{
+		Types: []EventType{EventTypeBounced, EventTypeComplained},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got []Event
+	for e := range ch {
+		got = append(got, e)
+		if len(got) == 3 {
+			cancel()
+		}
+	}
+
+	if len(got) != 3 {
+		t.Fatalf("expected 3 events, got %d", len(got))
+	}
+	for i, want := range []string{"evt_1", "evt_2", "evt_3"} {
+		if got[i].ID != want {
+			t.Errorf("event %d: expected id %q, got %q", i, want, got[i].ID)
+		}
+	}
+	if got[1].Type != EventTypeComplained || got[1].MessageID != "msg_2" {
+		t.Errorf("unexpected multi-line event: %+v", got[1])
+	}
+}
+
+func TestEventsStream_KeepsServerRetryAndPayloadID(t *testing.T) {
+	t.Parallel()
+
+	var conns int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		switch atomic.AddInt32(&conns, 1) {
+		case 1:
+			fmt.Fprint(w, "retry: 10\n\n")
+			// No "id:" field; the ID is only in the payload.
+			fmt.Fprint(w, "data: {\"id\":\"evt_1\",\"type\":\"bounced\"}\n\n")
+		case 2:
+			if id := r.Header.Get("Last-Event-ID"); id != "evt_1" {
+				t.Errorf("expected Last-Event-ID evt_1 on reconnect, got %q", id)
+			}
+			// Close at once; the next reconnect must still wait only the
+			// server-advised 10ms, not the default backoff.
+		default:
+			fmt.Fprint(w, "data: {\"id\":\"evt_2\",\"type\":\"bounced\"}\n\n")
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	start := time.Now()
+	ch, err := client.Events.Stream(ctx, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got []Event
+	for e := range ch {
+		got = append(got, e)
+		if len(got) == 2 {
+			cancel()
+		}
+	}
+
+	if len(got) != 2 || got[1].ID != "evt_2" {
+		t.Fatalf("expected events evt_1 and evt_2, got %+v", got)
+	}
+	if elapsed := time.Since(start); elapsed >= streamInitialBackoff {
+		t.Errorf("expected reconnects to use the server retry delay, took %v", elapsed)
+	}
+}
+
+func TestEventsStream_InitialError(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"error":"Invalid API key"}`)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	_, err := client.Events.Stream(context.Background(), nil)
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized, got %v", err)
+	}
+}
+
+func TestEventsStream_StopsOnPermanentError(t *testing.T) {
+	t.Parallel()
+
+	var conns int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&conns, 1) == 1 {
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, "retry: 10\n\n")
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"error":"API key revoked"}`)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var lastErr error
+	ch, err := client.Events.Stream(ctx, &EventStreamOptions{
+		OnError: func(err error) { lastErr = err },
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for range ch {
+	}
+
+	if ctx.Err() != nil {
+		t.Fatal("expected stream to stop before the context deadline")
+	}
+	if !errors.Is(lastErr, ErrForbidden) {
+		t.Errorf("expected final error to be ErrForbidden, got %v", lastErr)
+	}
+}
+
+func TestEventsStreamFunc(t *testing.T) {
+	t.Parallel()
+
+	var conns int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		if atomic.AddInt32(&conns, 1) == 1 {
+			fmt.Fprint(w, "retry: 10\n\n")
+			fmt.Fprint(w, "id: evt_1\ndata: {\"type\":\"delivered\"}\n\n")
+			return
+		}
+		if id := r.Header.Get("Last-Event-ID"); id != "evt_1" {
+			t.Errorf("expected Last-Event-ID evt_1 on reconnect, got %q", id)
+		}
+		fmt.Fprint(w, "id: evt_2\ndata: {\"type\":\"bounced\"}\n\n")
+		fmt.Fprint(w, "id: evt_3\ndata: {\"type\":\"opened\"}\n\n")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	errStop := errors.New("stop")
+	var ids []string
+	err := client.Events.StreamFunc(ctx, nil, func(e Event) error {
+		ids = append(ids, e.ID)
+		if e.Type == EventTypeBounced {
+			return errStop
+		}
+		return nil
+	})
+	if !errors.Is(err, errStop) {
+		t.Errorf("expected handler error, got %v", err)
+	}
+	if len(ids) != 2 || ids[0] != "evt_1" || ids[1] != "evt_2" {
+		t.Errorf("unexpected events %v", ids)
+	}
+}
+
+func TestEventsStreamFunc_PermanentError(t *testing.T) {
+	t.Parallel()
+
+	var conns int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&conns, 1) == 1 {
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, "retry: 10\n\n")
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"error":"API key revoked"}`)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var reported int32
+	err := client.Events.StreamFunc(ctx, &EventStreamOptions{
+		OnError: func(error) { atomic.AddInt32(&reported, 1) },
+	}, func(Event) error { return nil })
+	if !errors.Is(err, ErrForbidden) {
+		t.Errorf("expected ErrForbidden, got %v", err)
+	}
+	if atomic.LoadInt32(&reported) == 0 {
+		t.Error("expected OnError to be called")
+	}
+
+	if err := client.Events.StreamFunc(ctx, nil, nil); err == nil {
+		t.Error("expected error for nil handler")
+	}
+}
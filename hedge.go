@@ -0,0 +1,115 @@
+package envloped
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+// WithHedging makes the client send a second, identical request when a GET
+// request, such as Ping, Emails.Get, or Emails.List, has not completed after
+// delay, and use whichever response arrives first. This trades a few extra
+// requests for lower tail latency. Pass delay <= 0 to disable it.
+// Returns the client for method chaining.
+func (c *Client) WithHedging(delay time.Duration) *Client {
+	c.hedgeDelay = max(delay, 0)
+	return c
+}
+
+// hedgeable reports whether req may be sent twice.
+func (c *Client) hedgeable(req *http.Request) bool {
+	return c.hedgeDelay > 0 && req.Method == http.MethodGet && req.Body == nil
+}
+
+// hedgeResult is the outcome of one attempt of a hedged request.
+type hedgeResult struct {
+	index int
+	resp  *http.Response
+	err   error
+	meta  *ResponseMeta
+}
+
+// hedgedRoundTrip sends req, and a copy of it if no response arrived after
+// the hedge delay. It returns the first successful response, or if both
+// attempts fail, the error of the last one, along with the number of copies
+// sent. The other attempt is canceled.
+func (c *Client) hedgedRoundTrip(req *http.Request) (*http.Response, int, error) {
+	results := make(chan hedgeResult, 2)
+	var cancels []context.CancelFunc
+	start := func() {
+		ctx, cancel := context.WithCancel(req.Context())
+		cancels = append(cancels, cancel)
+		// Each attempt records its own metadata; the winner's is copied to
+		// the caller's destination.
+		meta := &ResponseMeta{}
+		ctx = CaptureResponseMeta(ctx, meta)
+		index := len(cancels) - 1
+		go func() {
+			resp, err := c.roundTrip(c.httpClient, req.Clone(ctx))
+			results <- hedgeResult{index: index, resp: resp, err: err, meta: meta}
+		}()
+	}
+
+	start()
+	inflight := 1
+	timer := time.NewTimer(c.hedgeDelay)
+	defer timer.Stop()
+	hedge := timer.C
+
+	for {
+		select {
+		case <-hedge:
+			hedge = nil
+			inflight++
+			c.observeRetry(req.Method, routeOfRequest(req))
+			start()
+
+		case r := <-results:
+			inflight--
+			if r.err != nil && inflight > 0 {
+				continue
+			}
+			for i, cancel := range cancels {
+				if i != r.index {
+					cancel()
+				}
+			}
+			if inflight > 0 {
+				go discardHedge(results)
+			}
+
+			if dest, ok := req.Context().Value(responseMetaKey{}).(*ResponseMeta); ok && dest != nil && r.meta.StatusCode != 0 {
+				*dest = *r.meta
+			}
+			if r.err != nil {
+				cancels[r.index]()
+				return nil, len(cancels) - 1, r.err
+			}
+			r.resp.Body = &cancelOnClose{ReadCloser: r.resp.Body, cancel: cancels[r.index]}
+			return r.resp, len(cancels) - 1, nil
+		}
+	}
+}
+
+// discardHedge closes the response of the losing attempt of a hedged
+// request, which has already been canceled.
+func discardHedge(results <-chan hedgeResult) {
+	if r := <-results; r.resp != nil {
+		r.resp.Body.Close()
+	}
+}
+
+// cancelOnClose cancels the context of a request when its response body is
+// closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close implements io.Closer.
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
@@ -0,0 +1,101 @@
+package envloped
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithHedging(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		if n == 1 {
+			// The first attempt is slow.
+			select {
+			case <-r.Context().Done():
+				return
+			case <-time.After(2 * time.Second):
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Request-Id", fmt.Sprintf("req_%d", n))
+		w.Write([]byte(`{"message":"pong","companyId":"c"}`))
+	}))
+	defer server.Close()
+
+	tracer := &recordingTracer{}
+	client := newTestClient(t, server).WithHedging(10 * time.Millisecond).WithTracer(tracer)
+
+	var meta ResponseMeta
+	start := time.Now()
+	resp, err := client.PingWithContext(CaptureResponseMeta(context.Background(), &meta))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected the hedged request to win, took %v", elapsed)
+	}
+	if resp.Message != "pong" {
+		t.Errorf("unexpected response %+v", resp)
+	}
+	if meta.RequestID != "req_2" {
+		t.Errorf("expected metadata of the winning attempt, got %q", meta.RequestID)
+	}
+	if n := atomic.LoadInt32(&calls); n != 2 {
+		t.Errorf("expected 2 attempts, got %d", n)
+	}
+	if len(tracer.spans) != 1 || tracer.spans[0].result.RetryCount != 1 {
+		t.Errorf("expected one span with a retry count of 1, got %+v", tracer.spans)
+	}
+}
+
+func TestWithHedging_FastErrorIsNotHedged(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":"Email not found"}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server).WithHedging(50 * time.Millisecond)
+	if _, err := client.Emails.Get("msg_1"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	time.Sleep(80 * time.Millisecond)
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Errorf("expected 1 attempt, got %d", n)
+	}
+}
+
+func TestWithHedging_OnlyGET(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(30 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"success":true,"messageId":"msg_1"}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server).WithHedging(time.Millisecond)
+	if _, err := client.Emails.Send(bulkParams(1)[0]); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Errorf("expected sends not to be hedged, got %d attempts", n)
+	}
+}
@@ -0,0 +1,90 @@
+package envloped
+
+import (
+	"strings"
+	"unicode"
+)
+
+// htmlAttr is an attribute of an HTML tag.
+type htmlAttr struct {
+	name, value string
+}
+
+// startsTag reports whether s, which starts with "<", starts an HTML tag,
+// comment, or declaration: "<" followed by a letter, "/", or "!". Any other
+// "<", e.g. in "1 < 2", is text.
+func startsTag(s string) bool {
+	if len(s) < 2 {
+		return false
+	}
+	c := s[1]
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || c == '/' || c == '!'
+}
+
+// parseTag parses the inside of an HTML tag, e.g. `img src="x" width=600`
+// or `/p`, returning the lowercase element name, whether it is a closing
+// tag, and the attributes of an opening tag in order, with lowercase names.
+// Attribute values are returned as written, without decoding entities.
+// Declarations such as `!DOCTYPE html` have no name.
+func parseTag(tag string) (name string, closing bool, attrs []htmlAttr) {
+	tag = strings.TrimSuffix(strings.TrimSpace(tag), "/")
+	if strings.HasPrefix(tag, "!") {
+		return "", false, nil
+	}
+	if rest, ok := strings.CutPrefix(tag, "/"); ok {
+		closing = true
+		tag = rest
+	}
+	end := strings.IndexFunc(tag, unicode.IsSpace)
+	if end < 0 {
+		return strings.ToLower(tag), closing, nil
+	}
+	name = strings.ToLower(tag[:end])
+	if closing {
+		return name, closing, nil
+	}
+
+	s := tag[end:]
+	for {
+		s = strings.TrimLeftFunc(s, unicode.IsSpace)
+		if s == "" {
+			return name, closing, attrs
+		}
+		n := strings.IndexFunc(s, func(r rune) bool { return r == '=' || unicode.IsSpace(r) })
+		if n < 0 {
+			n = len(s)
+		}
+		attr := htmlAttr{name: strings.ToLower(s[:n])}
+		s = strings.TrimLeftFunc(s[n:], unicode.IsSpace)
+		if !strings.HasPrefix(s, "=") {
+			attrs = append(attrs, attr)
+			continue
+		}
+		s = strings.TrimLeftFunc(s[1:], unicode.IsSpace)
+		if s != "" && (s[0] == '"' || s[0] == '\'') {
+			if j := strings.IndexByte(s[1:], s[0]); j >= 0 {
+				attr.value, s = s[1:j+1], s[j+2:]
+			} else {
+				attr.value, s = s[1:], ""
+			}
+		} else {
+			j := strings.IndexFunc(s, unicode.IsSpace)
+			if j < 0 {
+				j = len(s)
+			}
+			attr.value, s = s[:j], s[j:]
+		}
+		attrs = append(attrs, attr)
+	}
+}
+
+// attrValue returns the value of the attribute name in attrs, or "" if
+// there is none.
+func attrValue(attrs []htmlAttr, name string) string {
+	for _, a := range attrs {
+		if a.name == name {
+			return a.value
+		}
+	}
+	return ""
+}
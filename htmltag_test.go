@@ -0,0 +1,35 @@
+package envloped
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseTag(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		tag         string
+		wantName    string
+		wantClosing bool
+		wantAttrs   []htmlAttr
+	}{
+		{"p", "p", false, nil},
+		{"/P ", "p", true, nil},
+		{"br/", "br", false, nil},
+		{"!DOCTYPE html", "", false, nil},
+		{`A data-href="x" HREF='https://a.com' hidden`, "a", false, []htmlAttr{{"data-href", "x"}, {"href", "https://a.com"}, {"hidden", ""}}},
+		{"img src = logo.png width=600 /", "img", false, []htmlAttr{{"src", "logo.png"}, {"width", "600"}}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.tag, func(t *testing.T) {
+			t.Parallel()
+
+			name, closing, attrs := parseTag(tt.tag)
+			if name != tt.wantName || closing != tt.wantClosing || !reflect.DeepEqual(attrs, tt.wantAttrs) {
+				t.Errorf("parseTag(%q) = %q, %v, %v; want %q, %v, %v", tt.tag, name, closing, attrs, tt.wantName, tt.wantClosing, tt.wantAttrs)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package envloped
+
+// Importance is how mail clients should flag an email, e.g. with a red
+// exclamation mark for ImportanceHigh.
+type Importance string
+
+// Importance levels.
+const (
+	ImportanceHigh   Importance = "high"
+	ImportanceNormal Importance = "normal"
+	ImportanceLow    Importance = "low"
+)
+
+// importanceHeaders maps each importance to the values of the X-Priority,
+// Importance, and X-MSMail-Priority headers, which different clients read.
+var importanceHeaders = map[Importance][3]string{
+	ImportanceHigh:   {"1 (Highest)", "High", "High"},
+	ImportanceNormal: {"3 (Normal)", "Normal", "Normal"},
+	ImportanceLow:    {"5 (Lowest)", "Low", "Low"},
+}
+
+// valid reports whether i is empty or a known importance.
+func (i Importance) valid() bool {
+	_, ok := importanceHeaders[i]
+	return ok || i == ""
+}
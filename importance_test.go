@@ -0,0 +1,59 @@
+package envloped
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestSendEmailRequest_Importance(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		importance   Importance
+		wantPriority string
+		wantHeader   string
+	}{
+		{"", "", ""},
+		{ImportanceHigh, "1 (Highest)", "High"},
+		{ImportanceNormal, "3 (Normal)", "Normal"},
+		{ImportanceLow, "5 (Lowest)", "Low"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(string(tt.importance), func(t *testing.T) {
+			t.Parallel()
+
+			data, err := json.Marshal(SendEmailRequest{From: "a@b.com", Importance: tt.importance})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var body struct {
+				Headers map[string]string `json:"headers"`
+			}
+			if err := json.Unmarshal(data, &body); err != nil {
+				t.Fatalf("failed to decode request body: %v", err)
+			}
+			if got := body.Headers["X-Priority"]; got != tt.wantPriority {
+				t.Errorf("expected X-Priority %q, got %q", tt.wantPriority, got)
+			}
+			if got := body.Headers["Importance"]; got != tt.wantHeader {
+				t.Errorf("expected Importance %q, got %q", tt.wantHeader, got)
+			}
+			if got := body.Headers["X-MSMail-Priority"]; got != tt.wantHeader {
+				t.Errorf("expected X-MSMail-Priority %q, got %q", tt.wantHeader, got)
+			}
+		})
+	}
+}
+
+func TestSendEmailRequest_ImportanceValidation(t *testing.T) {
+	t.Parallel()
+
+	err := validateSendEmailRequest(&SendEmailRequest{
+		From: "a@b.com", To: []string{"b@c.com"}, Subject: "s", Text: "x", Importance: "urgent",
+	})
+	if err == nil || !strings.Contains(err.Error(), `unknown importance "urgent"`) {
+		t.Errorf("expected unknown importance error, got %v", err)
+	}
+}
@@ -0,0 +1,342 @@
+package envloped
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// InboundRoute forwards email received at an address or domain to a
+// webhook endpoint.
+type InboundRoute struct {
+	// ID is the unique identifier of the route.
+	ID string `json:"id"`
+
+	// Match is the recipient the route applies to: an address, such as
+	// "support@in.yourdomain.com", or a domain, such as "in.yourdomain.com",
+	// to match every address at it.
+	Match string `json:"match"`
+
+	// URL is the HTTPS endpoint received messages are posted to, as
+	// InboundMessage JSON.
+	URL string `json:"url"`
+
+	// IncludeRaw reports whether posted messages include the raw MIME
+	// message in InboundMessage.Raw.
+	IncludeRaw bool `json:"includeRaw"`
+
+	// Enabled reports whether received messages are currently forwarded.
+	Enabled bool `json:"enabled"`
+
+	// CreatedAt is when the route was created.
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// InboundRouteRequest is the request body for creating or updating an
+// inbound route. On update, zero-valued fields are left unchanged.
+type InboundRouteRequest struct {
+	// Match is the address or domain to receive email for. Required on
+	// create. The domain must be a verified domain with its MX record
+	// pointing to Envloped.
+	Match string `json:"match,omitempty"`
+
+	// URL is the HTTPS endpoint received messages are posted to. Required
+	// on create.
+	URL string `json:"url,omitempty"`
+
+	// IncludeRaw includes the raw MIME message in posted messages. Routes
+	// do not include it when nil.
+	IncludeRaw *bool `json:"includeRaw,omitempty"`
+
+	// Enabled pauses or resumes forwarding. Routes are enabled on create
+	// when nil.
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// InboundAttachment is a file attached to a received message.
+type InboundAttachment struct {
+	// Filename is the name of the file as sent.
+	Filename string `json:"filename"`
+
+	// ContentType is the MIME type of the file.
+	ContentType string `json:"contentType"`
+
+	// ContentID identifies inline attachments referenced from the HTML
+	// body with "cid:" URLs. It is empty for regular attachments.
+	ContentID string `json:"contentId,omitempty"`
+
+	// Size is the size of the file in bytes.
+	Size int64 `json:"size"`
+
+	// Content is the decoded file content.
+	Content []byte `json:"content"`
+}
+
+// InboundMessage is the payload an inbound route posts for each received
+// message.
+type InboundMessage struct {
+	// ID is the unique identifier of the received message.
+	ID string `json:"id"`
+
+	// RouteID is the route the message was received through.
+	RouteID string `json:"routeId"`
+
+	// From is the sender, as in the From header (e.g., "Ada <ada@example.com>").
+	From string `json:"from"`
+
+	// To are the addresses in the To header.
+	To []string `json:"to"`
+
+	// Cc are the addresses in the Cc header.
+	Cc []string `json:"cc,omitempty"`
+
+	// ReplyTo are the addresses in the Reply-To header.
+	ReplyTo []string `json:"replyTo,omitempty"`
+
+	// Recipient is the envelope recipient the route matched.
+	Recipient string `json:"recipient"`
+
+	// Subject is the subject line.
+	Subject string `json:"subject"`
+
+	// Text is the plain text body, if any.
+	Text string `json:"text,omitempty"`
+
+	// Html is the HTML body, if any.
+	Html string `json:"html,omitempty"`
+
+	// MessageID is the Message-ID header, without angle brackets.
+	MessageID string `json:"messageId,omitempty"`
+
+	// InReplyTo is the Message-ID the message replies to, if any. Match it
+	// against the MessageId of sent emails to thread replies.
+	InReplyTo string `json:"inReplyTo,omitempty"`
+
+	// References lists the Message-IDs of the thread, oldest first.
+	References []string `json:"references,omitempty"`
+
+	// Headers holds all message headers, with canonical keys.
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// Attachments are the files attached to the message.
+	Attachments []InboundAttachment `json:"attachments,omitempty"`
+
+	// SPF is the result of the SPF check of the sender (e.g., "pass", "fail").
+	SPF string `json:"spf,omitempty"`
+
+	// DKIM is the result of the DKIM signature check (e.g., "pass", "none").
+	DKIM string `json:"dkim,omitempty"`
+
+	// Raw is the raw MIME message, if the route has IncludeRaw set.
+	Raw []byte `json:"raw,omitempty"`
+
+	// ReceivedAt is when the message was received.
+	ReceivedAt time.Time `json:"receivedAt"`
+}
+
+// ParseInboundMessage decodes the body of a request posted by an inbound
+// route.
+func ParseInboundMessage(data []byte) (*InboundMessage, error) {
+	var msg InboundMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return nil, fmt.Errorf("envloped: invalid inbound message: %w", err)
+	}
+	return &msg, nil
+}
+
+// InboundSvc defines the interface for the inbound email service.
+// This interface can be mocked in consumer tests.
+type InboundSvc interface {
+	// CreateRoute creates a route that forwards received email to a webhook.
+	CreateRoute(params *InboundRouteRequest) (*InboundRoute, error)
+
+	// CreateRouteWithContext creates an inbound route using the provided context.
+	CreateRouteWithContext(ctx context.Context, params *InboundRouteRequest) (*InboundRoute, error)
+
+	// GetRoute retrieves an inbound route by ID.
+	GetRoute(routeID string) (*InboundRoute, error)
+
+	// GetRouteWithContext retrieves an inbound route using the provided context.
+	GetRouteWithContext(ctx context.Context, routeID string) (*InboundRoute, error)
+
+	// ListRoutes returns all inbound routes.
+	ListRoutes() ([]InboundRoute, error)
+
+	// ListRoutesWithContext returns all inbound routes using the provided context.
+	ListRoutesWithContext(ctx context.Context) ([]InboundRoute, error)
+
+	// UpdateRoute changes an inbound route.
+	UpdateRoute(routeID string, params *InboundRouteRequest) (*InboundRoute, error)
+
+	// UpdateRouteWithContext updates an inbound route using the provided context.
+	UpdateRouteWithContext(ctx context.Context, routeID string, params *InboundRouteRequest) (*InboundRoute, error)
+
+	// DeleteRoute removes an inbound route. Email to its address or domain
+	// is rejected afterwards.
+	DeleteRoute(routeID string) error
+
+	// DeleteRouteWithContext removes an inbound route using the provided context.
+	DeleteRouteWithContext(ctx context.Context, routeID string) error
+}
+
+// inboundSvcImpl implements InboundSvc.
+type inboundSvcImpl struct {
+	client *Client
+}
+
+// inboundRoutePath returns the API path of an inbound route.
+func inboundRoutePath(routeID string) string {
+	return "/v1/inbound/routes/" + url.PathEscape(routeID)
+}
+
+// CreateRoute creates a route that forwards received email to a webhook.
+func (s *inboundSvcImpl) CreateRoute(params *InboundRouteRequest) (*InboundRoute, error) {
+	return s.CreateRouteWithContext(context.Background(), params)
+}
+
+// CreateRouteWithContext creates an inbound route using the provided context.
+func (s *inboundSvcImpl) CreateRouteWithContext(ctx context.Context, params *InboundRouteRequest) (*InboundRoute, error) {
+	if params == nil {
+		return nil, fmt.Errorf("envloped: inbound route params must not be nil")
+	}
+	if err := validateInboundMatch(params.Match); err != nil {
+		return nil, err
+	}
+	if err := validateWebhookURL(params.URL); err != nil {
+		return nil, err
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodPost, "/v1/inbound/routes", "/v1/inbound/routes", params)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to create inbound route request: %w", err)
+	}
+
+	var resp InboundRoute
+	if err := s.client.do(req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// GetRoute retrieves an inbound route by ID.
+func (s *inboundSvcImpl) GetRoute(routeID string) (*InboundRoute, error) {
+	return s.GetRouteWithContext(context.Background(), routeID)
+}
+
+// GetRouteWithContext retrieves an inbound route using the provided context.
+func (s *inboundSvcImpl) GetRouteWithContext(ctx context.Context, routeID string) (*InboundRoute, error) {
+	if routeID == "" {
+		return nil, fmt.Errorf("envloped: inbound route id is required")
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodGet, "/v1/inbound/routes/{id}", inboundRoutePath(routeID), nil)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to create get inbound route request: %w", err)
+	}
+
+	var resp InboundRoute
+	if err := s.client.do(req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// ListRoutes returns all inbound routes.
+func (s *inboundSvcImpl) ListRoutes() ([]InboundRoute, error) {
+	return s.ListRoutesWithContext(context.Background())
+}
+
+// ListRoutesWithContext returns all inbound routes using the provided context.
+func (s *inboundSvcImpl) ListRoutesWithContext(ctx context.Context) ([]InboundRoute, error) {
+	req, err := s.client.newRequest(ctx, http.MethodGet, "/v1/inbound/routes", "/v1/inbound/routes", nil)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to create list inbound routes request: %w", err)
+	}
+
+	var resp listResponse[InboundRoute]
+	if err := s.client.do(req, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Data, nil
+}
+
+// UpdateRoute changes an inbound route.
+func (s *inboundSvcImpl) UpdateRoute(routeID string, params *InboundRouteRequest) (*InboundRoute, error) {
+	return s.UpdateRouteWithContext(context.Background(), routeID, params)
+}
+
+// UpdateRouteWithContext updates an inbound route using the provided context.
+func (s *inboundSvcImpl) UpdateRouteWithContext(ctx context.Context, routeID string, params *InboundRouteRequest) (*InboundRoute, error) {
+	if routeID == "" {
+		return nil, fmt.Errorf("envloped: inbound route id is required")
+	}
+	if params == nil {
+		return nil, fmt.Errorf("envloped: inbound route params must not be nil")
+	}
+	if params.Match != "" {
+		if err := validateInboundMatch(params.Match); err != nil {
+			return nil, err
+		}
+	}
+	if params.URL != "" {
+		if err := validateWebhookURL(params.URL); err != nil {
+			return nil, err
+		}
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodPatch, "/v1/inbound/routes/{id}", inboundRoutePath(routeID), params)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to create update inbound route request: %w", err)
+	}
+
+	var resp InboundRoute
+	if err := s.client.do(req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// DeleteRoute removes an inbound route.
+func (s *inboundSvcImpl) DeleteRoute(routeID string) error {
+	return s.DeleteRouteWithContext(context.Background(), routeID)
+}
+
+// DeleteRouteWithContext removes an inbound route using the provided context.
+func (s *inboundSvcImpl) DeleteRouteWithContext(ctx context.Context, routeID string) error {
+	if routeID == "" {
+		return fmt.Errorf("envloped: inbound route id is required")
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodDelete, "/v1/inbound/routes/{id}", inboundRoutePath(routeID), nil)
+	if err != nil {
+		return fmt.Errorf("envloped: failed to create delete inbound route request: %w", err)
+	}
+
+	return s.client.do(req, nil)
+}
+
+// validateInboundMatch checks that match is an address or a domain name.
+func validateInboundMatch(match string) error {
+	if match == "" {
+		return fmt.Errorf("envloped: inbound route match is required")
+	}
+	domain := match
+	if at := strings.LastIndex(match, "@"); at >= 0 {
+		if at == 0 {
+			return fmt.Errorf("envloped: invalid inbound route match %q", match)
+		}
+		domain = match[at+1:]
+	}
+	if !strings.Contains(domain, ".") || strings.ContainsAny(domain, " /@") {
+		return fmt.Errorf("envloped: invalid inbound route match %q", match)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package envloped
+
+import (
+	"fmt"
+	"io"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"unicode/utf8"
+)
+
+// ParseInboundMIME reads a raw RFC 5322 message from r, such as
+// InboundMessage.Raw or the body of an inbound webhook that delivers raw
+// MIME, and returns it as a structured InboundMessage. The From, To, Cc,
+// Reply-To, Subject, Message-ID, In-Reply-To, and References headers map to
+// the corresponding fields, and every header is kept in Headers. The first
+// text/plain and text/html parts become Text and Html, and all other parts
+// become Attachments, with transfer encodings decoded.
+//
+// Since received mail comes from arbitrary senders, text in charsets other
+// than UTF-8, US-ASCII, and ISO-8859-1 is kept as-is with invalid UTF-8
+// sequences replaced, instead of failing. ID, RouteID, Recipient, and
+// ReceivedAt are not part of the message and are left empty.
+func ParseInboundMIME(r io.Reader) (*InboundMessage, error) {
+	msg, err := mail.ReadMessage(r)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to parse message: %w", err)
+	}
+
+	in := &InboundMessage{}
+	if in.From, err = messageAddress(msg.Header, "From"); err != nil {
+		return nil, err
+	}
+	if in.To, err = messageAddressList(msg.Header, "To"); err != nil {
+		return nil, err
+	}
+	if in.Cc, err = messageAddressList(msg.Header, "Cc"); err != nil {
+		return nil, err
+	}
+	if in.ReplyTo, err = messageAddressList(msg.Header, "Reply-To"); err != nil {
+		return nil, err
+	}
+	in.Subject = decodeHeaderValue(msg.Header.Get("Subject"))
+	in.MessageID = strings.Trim(msg.Header.Get("Message-Id"), "<> ")
+	in.InReplyTo = strings.Trim(msg.Header.Get("In-Reply-To"), "<> ")
+	for _, id := range strings.Fields(msg.Header.Get("References")) {
+		in.References = append(in.References, strings.Trim(id, "<>"))
+	}
+
+	for name, values := range msg.Header {
+		if len(values) == 0 {
+			continue
+		}
+		if in.Headers == nil {
+			in.Headers = make(map[string]string)
+		}
+		in.Headers[textproto.CanonicalMIMEHeaderKey(name)] = decodeHeaderValue(values[0])
+	}
+
+	err = walkMessageParts(textproto.MIMEHeader(msg.Header), msg.Body, func(p *messagePart) error {
+		if !p.attachment && p.filename == "" && p.contentID == "" {
+			switch {
+			case p.mediaType == "text/plain" && in.Text == "":
+				in.Text = decodeInboundText(p.content, p.charset)
+				return nil
+			case p.mediaType == "text/html" && in.Html == "":
+				in.Html = decodeInboundText(p.content, p.charset)
+				return nil
+			}
+		}
+
+		filename := p.filename
+		if filename == "" {
+			filename = fmt.Sprintf("attachment-%d", len(in.Attachments)+1)
+		}
+		in.Attachments = append(in.Attachments, InboundAttachment{
+			Filename:    filename,
+			ContentType: p.mediaType,
+			ContentID:   p.contentID,
+			Size:        int64(len(p.content)),
+			Content:     p.content,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return in, nil
+}
+
+// decodeInboundText converts text in the given charset to a UTF-8 string,
+// replacing invalid sequences if the charset is not supported.
+func decodeInboundText(content []byte, charset string) string {
+	if s, err := decodeCharset(content, charset); err == nil {
+		return s
+	}
+	if utf8.Valid(content) {
+		return string(content)
+	}
+	return strings.ToValidUTF8(string(content), string(utf8.RuneError))
+}
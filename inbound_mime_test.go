@@ -0,0 +1,97 @@
+package envloped
+
+import (
+	"bytes"
+	"net/mail"
+	"strings"
+	"testing"
+)
+
+const testInboundMessage = "From: Ada <ada@example.com>\r\n" +
+	"To: support@in.example.com\r\n" +
+	"Subject: Re: Your order\r\n" +
+	"Message-ID: <reply-1@mail.example.com>\r\n" +
+	"In-Reply-To: <msg_123@envloped.com>\r\n" +
+	"References: <msg_100@envloped.com>\r\n <msg_123@envloped.com>\r\n" +
+	"MIME-Version: 1.0\r\n" +
+	"Content-Type: multipart/related; boundary=rel\r\n" +
+	"\r\n" +
+	"--rel\r\n" +
+	"Content-Type: multipart/alternative; boundary=alt\r\n" +
+	"\r\n" +
+	"--alt\r\n" +
+	"Content-Type: text/plain; charset=windows-1252\r\n" +
+	"\r\n" +
+	"Thanks \x93so much\x94\r\n" +
+	"--alt\r\n" +
+	"Content-Type: text/html; charset=ISO-8859-1\r\n" +
+	"Content-Transfer-Encoding: quoted-printable\r\n" +
+	"\r\n" +
+	"<p>Caf=E9 <img src=3D\"cid:logo@x\"></p>\r\n" +
+	"--alt--\r\n" +
+	"--rel\r\n" +
+	"Content-Type: image/png\r\n" +
+	"Content-ID: <logo@x>\r\n" +
+	"Content-Transfer-Encoding: base64\r\n" +
+	"\r\n" +
+	"AQID\r\n" +
+	"--rel--\r\n"
+
+func TestParseInboundMIME(t *testing.T) {
+	t.Parallel()
+
+	msg, err := ParseInboundMIME(strings.NewReader(testInboundMessage))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	from, err := mail.ParseAddress(msg.From)
+	if err != nil || from.Name != "Ada" || from.Address != "ada@example.com" {
+		t.Errorf("unexpected from %q", msg.From)
+	}
+	if len(msg.To) != 1 || msg.To[0] != "support@in.example.com" {
+		t.Errorf("unexpected to %q", msg.To)
+	}
+	if msg.Subject != "Re: Your order" || msg.MessageID != "reply-1@mail.example.com" || msg.InReplyTo != "msg_123@envloped.com" {
+		t.Errorf("unexpected headers %+v", msg)
+	}
+	if len(msg.References) != 2 || msg.References[1] != "msg_123@envloped.com" {
+		t.Errorf("unexpected references %q", msg.References)
+	}
+	if msg.Headers["Mime-Version"] != "1.0" {
+		t.Errorf("expected all headers to be kept, got %v", msg.Headers)
+	}
+	if msg.Html != `<p>Café <img src="cid:logo@x"></p>` {
+		t.Errorf("unexpected html %q", msg.Html)
+	}
+	if !strings.HasPrefix(msg.Text, "Thanks ") || !strings.Contains(msg.Text, "so much") {
+		t.Errorf("expected text in an unsupported charset to be kept, got %q", msg.Text)
+	}
+	if len(msg.Attachments) != 1 {
+		t.Fatalf("expected 1 attachment, got %+v", msg.Attachments)
+	}
+	a := msg.Attachments[0]
+	if a.ContentID != "logo@x" || a.ContentType != "image/png" || a.Size != 3 || !bytes.Equal(a.Content, []byte{1, 2, 3}) {
+		t.Errorf("unexpected attachment %+v", a)
+	}
+}
+
+func TestParseInboundMIME_Raw(t *testing.T) {
+	t.Parallel()
+
+	payload, err := ParseInboundMessage([]byte(`{"id":"in_1","raw":"RnJvbTogYWRhQGV4YW1wbGUuY29tDQpTdWJqZWN0OiBIaQ0KDQpIZWxsbw=="}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	msg, err := ParseInboundMIME(bytes.NewReader(payload.Raw))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if msg.From != "ada@example.com" || msg.Subject != "Hi" || msg.Text != "Hello" {
+		t.Errorf("unexpected message %+v", msg)
+	}
+
+	if _, err := ParseInboundMIME(strings.NewReader("not a message")); err == nil {
+		t.Error("expected error for invalid message")
+	}
+}
@@ -0,0 +1,151 @@
+package envloped
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestInbound_Routes(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/inbound/routes":
+			var req InboundRouteRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			if req.Match != "support@in.example.com" || req.IncludeRaw == nil || !*req.IncludeRaw {
+				t.Errorf("unexpected create body %+v", req)
+			}
+			json.NewEncoder(w).Encode(InboundRoute{ID: "rt_1", Match: req.Match, URL: req.URL, IncludeRaw: true, Enabled: true})
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/inbound/routes/rt_1":
+			json.NewEncoder(w).Encode(InboundRoute{ID: "rt_1", Match: "support@in.example.com"})
+		case r.Method == http.MethodPatch && r.URL.Path == "/v1/inbound/routes/rt_1":
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			if body["enabled"] != false || len(body) != 1 {
+				t.Errorf("unexpected update body %v", body)
+			}
+			json.NewEncoder(w).Encode(InboundRoute{ID: "rt_1", Enabled: false})
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/inbound/routes":
+			json.NewEncoder(w).Encode(map[string]interface{}{"data": []InboundRoute{{ID: "rt_1"}, {ID: "rt_2"}}})
+		case r.Method == http.MethodDelete && r.URL.Path == "/v1/inbound/routes/rt_1":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+
+	route, err := client.Inbound.CreateRoute(&InboundRouteRequest{
+		Match:      "support@in.example.com",
+		URL:        "https://example.com/hooks/inbound",
+		IncludeRaw: Bool(true),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if route.ID != "rt_1" || !route.Enabled || !route.IncludeRaw {
+		t.Errorf("unexpected route %+v", route)
+	}
+
+	if route, err = client.Inbound.GetRoute("rt_1"); err != nil || route.Match != "support@in.example.com" {
+		t.Errorf("unexpected GetRoute result %+v, %v", route, err)
+	}
+	if route, err = client.Inbound.UpdateRoute("rt_1", &InboundRouteRequest{Enabled: Bool(false)}); err != nil || route.Enabled {
+		t.Errorf("unexpected UpdateRoute result %+v, %v", route, err)
+	}
+	if routes, err := client.Inbound.ListRoutes(); err != nil || len(routes) != 2 {
+		t.Errorf("unexpected ListRoutes result %+v, %v", routes, err)
+	}
+	if err := client.Inbound.DeleteRoute("rt_1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestInbound_Validation(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("key")
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{"nil params", func() error { _, err := client.Inbound.CreateRoute(nil); return err }},
+		{"missing match", func() error {
+			_, err := client.Inbound.CreateRoute(&InboundRouteRequest{URL: "https://example.com/in"})
+			return err
+		}},
+		{"invalid match", func() error {
+			_, err := client.Inbound.CreateRoute(&InboundRouteRequest{Match: "@example.com", URL: "https://example.com/in"})
+			return err
+		}},
+		{"http url", func() error {
+			_, err := client.Inbound.CreateRoute(&InboundRouteRequest{Match: "in.example.com", URL: "http://example.com/in"})
+			return err
+		}},
+		{"invalid match on update", func() error {
+			_, err := client.Inbound.UpdateRoute("rt_1", &InboundRouteRequest{Match: "localhost"})
+			return err
+		}},
+		{"missing id", func() error { _, err := client.Inbound.GetRoute(""); return err }},
+		{"missing id on delete", func() error { return client.Inbound.DeleteRoute("") }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := tt.call(); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestParseInboundMessage(t *testing.T) {
+	t.Parallel()
+
+	body := []byte(`{
+		"id": "in_1",
+		"routeId": "rt_1",
+		"from": "Ada <ada@example.com>",
+		"to": ["support@in.example.com"],
+		"recipient": "support@in.example.com",
+		"subject": "Re: Your order",
+		"text": "Thanks!",
+		"messageId": "abc@mail.example.com",
+		"inReplyTo": "msg_123@envloped.com",
+		"references": ["msg_123@envloped.com"],
+		"headers": {"X-Mailer": "Mail"},
+		"attachments": [{"filename": "photo.png", "contentType": "image/png", "size": 3, "content": "AQID"}],
+		"spf": "pass",
+		"raw": "RnJvbTogYWRh",
+		"receivedAt": "2025-01-02T03:04:05Z"
+	}`)
+
+	msg, err := ParseInboundMessage(body)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if msg.InReplyTo != "msg_123@envloped.com" || msg.Subject != "Re: Your order" || msg.SPF != "pass" {
+		t.Errorf("unexpected message %+v", msg)
+	}
+	if len(msg.Attachments) != 1 || string(msg.Attachments[0].Content) != "\x01\x02\x03" {
+		t.Errorf("expected decoded attachment content, got %+v", msg.Attachments)
+	}
+	if string(msg.Raw) != "From: ada" {
+		t.Errorf("expected decoded raw message, got %q", msg.Raw)
+	}
+	if msg.ReceivedAt.IsZero() {
+		t.Error("expected receivedAt to be parsed")
+	}
+
+	if _, err := ParseInboundMessage([]byte("not json")); err == nil {
+		t.Error("expected error for invalid payload")
+	}
+}
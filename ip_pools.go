@@ -0,0 +1,192 @@
+package envloped
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// IPPool is a named group of dedicated sending IPs. Sending different kinds
+// of traffic through different pools keeps their reputations separate.
+type IPPool struct {
+	// Name is the unique name of the pool, used in SendEmailRequest.IPPool.
+	Name string `json:"name"`
+
+	// IPs are the dedicated IP addresses assigned to the pool.
+	IPs []string `json:"ips"`
+
+	// CreatedAt is when the pool was created.
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// IPPoolRequest is the request body for creating or updating an IP pool.
+type IPPoolRequest struct {
+	// Name is the unique name of the pool. It is ignored on update.
+	Name string `json:"name,omitempty"`
+
+	// IPs are the dedicated IP addresses to assign to the pool.
+	IPs []string `json:"ips"`
+}
+
+// IPPoolsSvc defines the interface for the IP pools service.
+// This interface can be mocked in consumer tests.
+type IPPoolsSvc interface {
+	// Create creates a new IP pool.
+	Create(params *IPPoolRequest) (*IPPool, error)
+
+	// CreateWithContext creates a new IP pool using the provided context.
+	CreateWithContext(ctx context.Context, params *IPPoolRequest) (*IPPool, error)
+
+	// Get retrieves an IP pool by name.
+	Get(name string) (*IPPool, error)
+
+	// GetWithContext retrieves an IP pool using the provided context.
+	GetWithContext(ctx context.Context, name string) (*IPPool, error)
+
+	// List returns all IP pools.
+	List() ([]IPPool, error)
+
+	// ListWithContext returns all IP pools using the provided context.
+	ListWithContext(ctx context.Context) ([]IPPool, error)
+
+	// Update replaces the IPs assigned to a pool.
+	Update(name string, params *IPPoolRequest) (*IPPool, error)
+
+	// UpdateWithContext updates an IP pool using the provided context.
+	UpdateWithContext(ctx context.Context, name string, params *IPPoolRequest) (*IPPool, error)
+
+	// Delete deletes an IP pool. Its IPs return to the default pool.
+	Delete(name string) error
+
+	// DeleteWithContext deletes an IP pool using the provided context.
+	DeleteWithContext(ctx context.Context, name string) error
+}
+
+// ipPoolsSvcImpl implements IPPoolsSvc.
+type ipPoolsSvcImpl struct {
+	client *Client
+}
+
+// ipPoolPath returns the API path of an IP pool.
+func ipPoolPath(name string) string {
+	return "/v1/ip-pools/" + url.PathEscape(name)
+}
+
+// Create creates a new IP pool.
+func (s *ipPoolsSvcImpl) Create(params *IPPoolRequest) (*IPPool, error) {
+	return s.CreateWithContext(context.Background(), params)
+}
+
+// CreateWithContext creates a new IP pool using the provided context.
+func (s *ipPoolsSvcImpl) CreateWithContext(ctx context.Context, params *IPPoolRequest) (*IPPool, error) {
+	if params == nil {
+		return nil, fmt.Errorf("envloped: ip pool params must not be nil")
+	}
+	if params.Name == "" {
+		return nil, fmt.Errorf("envloped: ip pool name is required")
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodPost, "/v1/ip-pools", "/v1/ip-pools", params)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to create ip pool request: %w", err)
+	}
+
+	var resp IPPool
+	if err := s.client.do(req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// Get retrieves an IP pool by name.
+func (s *ipPoolsSvcImpl) Get(name string) (*IPPool, error) {
+	return s.GetWithContext(context.Background(), name)
+}
+
+// GetWithContext retrieves an IP pool using the provided context.
+func (s *ipPoolsSvcImpl) GetWithContext(ctx context.Context, name string) (*IPPool, error) {
+	if name == "" {
+		return nil, fmt.Errorf("envloped: ip pool name is required")
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodGet, "/v1/ip-pools/{id}", ipPoolPath(name), nil)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to create get ip pool request: %w", err)
+	}
+
+	var resp IPPool
+	if err := s.client.do(req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// List returns all IP pools.
+func (s *ipPoolsSvcImpl) List() ([]IPPool, error) {
+	return s.ListWithContext(context.Background())
+}
+
+// ListWithContext returns all IP pools using the provided context.
+func (s *ipPoolsSvcImpl) ListWithContext(ctx context.Context) ([]IPPool, error) {
+	req, err := s.client.newRequest(ctx, http.MethodGet, "/v1/ip-pools", "/v1/ip-pools", nil)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to create list ip pools request: %w", err)
+	}
+
+	var resp listResponse[IPPool]
+	if err := s.client.do(req, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Data, nil
+}
+
+// Update replaces the IPs assigned to a pool.
+func (s *ipPoolsSvcImpl) Update(name string, params *IPPoolRequest) (*IPPool, error) {
+	return s.UpdateWithContext(context.Background(), name, params)
+}
+
+// UpdateWithContext updates an IP pool using the provided context.
+func (s *ipPoolsSvcImpl) UpdateWithContext(ctx context.Context, name string, params *IPPoolRequest) (*IPPool, error) {
+	if name == "" {
+		return nil, fmt.Errorf("envloped: ip pool name is required")
+	}
+	if params == nil {
+		return nil, fmt.Errorf("envloped: ip pool params must not be nil")
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodPatch, "/v1/ip-pools/{id}", ipPoolPath(name), params)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to create update ip pool request: %w", err)
+	}
+
+	var resp IPPool
+	if err := s.client.do(req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// Delete deletes an IP pool.
+func (s *ipPoolsSvcImpl) Delete(name string) error {
+	return s.DeleteWithContext(context.Background(), name)
+}
+
+// DeleteWithContext deletes an IP pool using the provided context.
+func (s *ipPoolsSvcImpl) DeleteWithContext(ctx context.Context, name string) error {
+	if name == "" {
+		return fmt.Errorf("envloped: ip pool name is required")
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodDelete, "/v1/ip-pools/{id}", ipPoolPath(name), nil)
+	if err != nil {
+		return fmt.Errorf("envloped: failed to create delete ip pool request: %w", err)
+	}
+
+	return s.client.do(req, nil)
+}
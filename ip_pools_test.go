@@ -0,0 +1,93 @@
+package envloped
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestIPPools_CRUD(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/ip-pools":
+			var req IPPoolRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			json.NewEncoder(w).Encode(IPPool{Name: req.Name, IPs: req.IPs})
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/ip-pools":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": []IPPool{{Name: "marketing"}, {Name: "transactional"}},
+			})
+		case r.Method == http.MethodPatch && r.URL.Path == "/v1/ip-pools/marketing":
+			var req IPPoolRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			json.NewEncoder(w).Encode(IPPool{Name: "marketing", IPs: req.IPs})
+		case r.Method == http.MethodDelete && r.URL.Path == "/v1/ip-pools/marketing":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+
+	pool, err := client.IPPools.Create(&IPPoolRequest{Name: "marketing", IPs: []string{"192.0.2.10"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pool.Name != "marketing" || len(pool.IPs) != 1 {
+		t.Errorf("unexpected pool: %+v", pool)
+	}
+
+	pools, err := client.IPPools.List()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pools) != 2 {
+		t.Errorf("expected 2 pools, got %d", len(pools))
+	}
+
+	pool, err = client.IPPools.Update("marketing", &IPPoolRequest{IPs: []string{"192.0.2.10", "192.0.2.11"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pool.IPs) != 2 {
+		t.Errorf("expected 2 IPs, got %v", pool.IPs)
+	}
+
+	if err := client.IPPools.Delete("marketing"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestSendEmail_IPPool(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req SendEmailRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.IPPool != "transactional" {
+			t.Errorf("expected ipPool %q, got %q", "transactional", req.IPPool)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(SendEmailResponse{Success: true, MessageId: "msg_pool"})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	_, err := client.Emails.Send(&SendEmailRequest{
+		From:    "sender@example.com",
+		To:      []string{"recipient@example.com"},
+		Subject: "Test",
+		Html:    "<p>Hi</p>",
+		IPPool:  "transactional",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,143 @@
+//go:build go1.23
+
+package envloped
+
+import (
+	"context"
+	"iter"
+	"net/http"
+)
+
+// AllEmails returns an iterator over every sent email matching opts, fetching
+// pages from svc as needed. opts.Cursor sets the page to start at, and opts
+// is not modified.
+//
+//	for email, err := range envloped.AllEmails(ctx, client.Emails, &envloped.ListEmailsOptions{Limit: 100}) {
+//	    if err != nil {
+//	        return err
+//	    }
+//	    fmt.Println(email.ID)
+//	}
+func AllEmails(ctx context.Context, svc EmailsSvc, opts *ListEmailsOptions) iter.Seq2[Email, error] {
+	var o ListEmailsOptions
+	if opts != nil {
+		o = *opts
+	}
+	return paginate(ctx, clientOf(svc), "/v1/emails", o.Cursor, func(ctx context.Context, cursor string) (*Page[Email], error) {
+		o.Cursor = cursor
+		return svc.ListWithContext(ctx, &o)
+	})
+}
+
+// AllContacts returns an iterator over every contact matching opts, fetching
+// pages from svc as needed. opts.Cursor sets the page to start at, and opts
+// is not modified.
+func AllContacts(ctx context.Context, svc ContactsSvc, opts *ListContactsOptions) iter.Seq2[Contact, error] {
+	var o ListContactsOptions
+	if opts != nil {
+		o = *opts
+	}
+	return paginate(ctx, clientOf(svc), "/v1/contacts", o.Cursor, func(ctx context.Context, cursor string) (*Page[Contact], error) {
+		o.Cursor = cursor
+		return svc.ListWithContext(ctx, &o)
+	})
+}
+
+// AllSuppressions returns an iterator over every suppressed address matching
+// opts, fetching pages from svc as needed. opts.Cursor sets the page to start
+// at, and opts is not modified.
+func AllSuppressions(ctx context.Context, svc SuppressionsSvc, opts *ListSuppressionsOptions) iter.Seq2[Suppression, error] {
+	var o ListSuppressionsOptions
+	if opts != nil {
+		o = *opts
+	}
+	return paginate(ctx, clientOf(svc), "/v1/suppressions", o.Cursor, func(ctx context.Context, cursor string) (*Page[Suppression], error) {
+		o.Cursor = cursor
+		return svc.ListWithContext(ctx, &o)
+	})
+}
+
+// AllEvents returns an iterator over every account event matching opts,
+// oldest first, fetching pages from svc as needed. opts.Cursor sets the page
+// to start at, and opts is not modified.
+func AllEvents(ctx context.Context, svc EventsSvc, opts *ListEventsOptions) iter.Seq2[Event, error] {
+	var o ListEventsOptions
+	if opts != nil {
+		o = *opts
+	}
+	return paginate(ctx, clientOf(svc), "/v1/events", o.Cursor, func(ctx context.Context, cursor string) (*Page[Event], error) {
+		o.Cursor = cursor
+		return svc.List(ctx, &o)
+	})
+}
+
+// AllWebhookDeliveries returns an iterator over every delivery of a webhook
+// matching opts, fetching pages from svc as needed. opts.Cursor sets the page
+// to start at, and opts is not modified.
+func AllWebhookDeliveries(ctx context.Context, svc WebhooksSvc, webhookID string, opts *ListWebhookDeliveriesOptions) iter.Seq2[WebhookDelivery, error] {
+	var o ListWebhookDeliveriesOptions
+	if opts != nil {
+		o = *opts
+	}
+	return paginate(ctx, clientOf(svc), "/v1/webhooks/{id}/deliveries", o.Cursor, func(ctx context.Context, cursor string) (*Page[WebhookDelivery], error) {
+		o.Cursor = cursor
+		return svc.ListDeliveriesWithContext(ctx, webhookID, &o)
+	})
+}
+
+// paginate returns an iterator over the items of consecutive pages, starting
+// at cursor. fetch returns the page at a cursor using ctx. If c is non-nil,
+// pages rejected by its rate limit are fetched again once it resets. After
+// an error, the iterator yields it once and stops.
+func paginate[T any](ctx context.Context, c *Client, route, cursor string, fetch func(ctx context.Context, cursor string) (*Page[T], error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for {
+			var page *Page[T]
+			fetchPage := func(ctx context.Context) error {
+				var err error
+				page, err = fetch(ctx, cursor)
+				return err
+			}
+			var err error
+			if c != nil {
+				err = c.retryRateLimited(ctx, http.MethodGet, route, fetchPage)
+			} else {
+				err = fetchPage(ctx)
+			}
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range page.Items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if page.NextCursor == "" {
+				return
+			}
+			cursor = page.NextCursor
+		}
+	}
+}
+
+// clientOf returns the client backing svc, or nil if svc is not one of the
+// SDK's own services, e.g. a mock.
+func clientOf(svc any) *Client {
+	switch s := svc.(type) {
+	case *emailsSvcImpl:
+		return s.client
+	case *contactsSvcImpl:
+		return s.client
+	case *suppressionsSvcImpl:
+		return s.client
+	case *eventsSvcImpl:
+		return s.client
+	case *webhooksSvcImpl:
+		return s.client
+	default:
+		return nil
+	}
+}
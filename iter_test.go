@@ -0,0 +1,182 @@
+//go:build go1.23
+
+package envloped
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestEmailsAll(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("status") != "delivered" {
+			t.Errorf("expected filter to be kept on every page, got %q", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			json.NewEncoder(w).Encode(EmailPage{Items: []Email{{ID: "msg_1"}, {ID: "msg_2"}}, NextCursor: "cur_2"})
+		case "cur_2":
+			// The first attempt at the second page is rate limited.
+			if atomic.AddInt32(&calls, 1) == 1 {
+				w.Header().Set("X-RateLimit-Limit", "10")
+				w.Header().Set("X-RateLimit-Remaining", "0")
+				w.Header().Set("X-RateLimit-Reset", "0")
+				w.WriteHeader(http.StatusTooManyRequests)
+				w.Write([]byte(`{"error":"rate limit exceeded"}`))
+				return
+			}
+			json.NewEncoder(w).Encode(EmailPage{Items: []Email{{ID: "msg_3"}}})
+		default:
+			t.Errorf("unexpected cursor %q", r.URL.Query().Get("cursor"))
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	opts := &ListEmailsOptions{Status: EmailStatusDelivered}
+
+	var ids []string
+	for email, err := range AllEmails(context.Background(), client.Emails, opts) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		ids = append(ids, email.ID)
+	}
+
+	if len(ids) != 3 || ids[0] != "msg_1" || ids[2] != "msg_3" {
+		t.Errorf("unexpected emails %v", ids)
+	}
+	if opts.Cursor != "" {
+		t.Errorf("expected opts not to be modified, got cursor %q", opts.Cursor)
+	}
+}
+
+func TestEventsAll_StopsEarly(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(EventPage{Items: []Event{{ID: "evt_1"}, {ID: "evt_2"}}, NextCursor: "more"})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	for e, err := range AllEvents(context.Background(), client.Events, nil) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if e.ID == "evt_1" {
+			break
+		}
+	}
+	if n := atomic.LoadInt32(&requests); n != 1 {
+		t.Errorf("expected 1 request, got %d", n)
+	}
+}
+
+func TestSuppressionsAll_Error(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("cursor") == "" {
+			json.NewEncoder(w).Encode(SuppressionPage{Items: []Suppression{{Email: "a@example.com"}}, NextCursor: "cur_2"})
+			return
+		}
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"error":"forbidden"}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	var emails []string
+	var errs []error
+	for s, err := range AllSuppressions(context.Background(), client.Suppressions, nil) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		emails = append(emails, s.Email)
+	}
+	if len(emails) != 1 || len(errs) != 1 || !errors.Is(errs[0], ErrForbidden) {
+		t.Errorf("expected one suppression then ErrForbidden, got %v and %v", emails, errs)
+	}
+}
+
+func TestAllIterators_Paths(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/contacts":
+			json.NewEncoder(w).Encode(ContactPage{Items: []Contact{{Email: "a@example.com"}}})
+		case "/v1/webhooks/wh_1/deliveries":
+			json.NewEncoder(w).Encode(WebhookDeliveryPage{Items: []WebhookDelivery{{ID: "dlv_1"}}})
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	ctx := context.Background()
+
+	var n int
+	for _, err := range AllContacts(ctx, client.Contacts, nil) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		n++
+	}
+	for _, err := range AllWebhookDeliveries(ctx, client.Webhooks, "wh_1", nil) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		n++
+	}
+	if n != 2 {
+		t.Errorf("expected 2 items, got %d", n)
+	}
+}
+
+// pagedEmails is an EmailsSvc that is not backed by a Client, such as a mock.
+type pagedEmails struct {
+	EmailsSvc
+	pages map[string]*EmailPage
+}
+
+func (p *pagedEmails) ListWithContext(ctx context.Context, opts *ListEmailsOptions) (*EmailPage, error) {
+	return p.pages[opts.Cursor], nil
+}
+
+func TestAllEmails_CustomService(t *testing.T) {
+	t.Parallel()
+
+	svc := &pagedEmails{pages: map[string]*EmailPage{
+		"":      {Items: []Email{{ID: "msg_1"}}, NextCursor: "cur_2"},
+		"cur_2": {Items: []Email{{ID: "msg_2"}}},
+	}}
+
+	var ids []string
+	for email, err := range AllEmails(context.Background(), svc, nil) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		ids = append(ids, email.ID)
+	}
+	if len(ids) != 2 || ids[0] != "msg_1" || ids[1] != "msg_2" {
+		t.Errorf("unexpected emails %v", ids)
+	}
+}
@@ -0,0 +1,117 @@
+package envloped
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// Limits describes the sending limits of the account's current plan.
+// A zero value for any limit means the API did not report one.
+type Limits struct {
+	// MaxRecipients is the maximum number of recipients per email.
+	MaxRecipients int `json:"maxRecipients"`
+
+	// MaxAttachmentSize is the maximum size of a single attachment, in bytes.
+	MaxAttachmentSize int64 `json:"maxAttachmentSize"`
+
+	// MaxMessageSize is the maximum combined size of the bodies and
+	// attachments of an email, in bytes.
+	MaxMessageSize int64 `json:"maxMessageSize"`
+
+	// MaxBatchSize is the maximum number of emails per batch request.
+	MaxBatchSize int `json:"maxBatchSize"`
+
+	// RequestsPerSecond is the API request rate limit.
+	RequestsPerSecond int `json:"requestsPerSecond"`
+
+	// DailyEmailLimit is the maximum number of emails per day. nil means unlimited.
+	DailyEmailLimit *int `json:"dailyEmailLimit"`
+
+	// MonthlyEmailLimit is the maximum number of emails per month.
+	MonthlyEmailLimit int `json:"monthlyEmailLimit"`
+}
+
+// Limits returns the sending limits of the account's current plan.
+func (c *Client) Limits() (*Limits, error) {
+	return c.LimitsWithContext(context.Background())
+}
+
+// LimitsWithContext returns the plan limits using the given context.
+func (c *Client) LimitsWithContext(ctx context.Context) (*Limits, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, "/v1/limits", "/v1/limits", nil)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to create limits request: %w", err)
+	}
+
+	var resp Limits
+	if err := c.do(req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// WithLimits enables client-side validation against the given plan limits,
+// so requests that exceed them fail before any network call.
+// Pass nil to disable limit validation. Returns the client for method chaining.
+func (c *Client) WithLimits(limits *Limits) *Client {
+	c.limits.Store(limits)
+	return c
+}
+
+// LoadLimits fetches the plan limits from the API and enables client-side
+// validation against them, as if passed to WithLimits.
+func (c *Client) LoadLimits(ctx context.Context) (*Limits, error) {
+	limits, err := c.LimitsWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.WithLimits(limits)
+	return limits, nil
+}
+
+// validate checks params against the limits. A nil receiver applies only
+// the SDK's default limits.
+func (l *Limits) validate(params *SendEmailRequest) error {
+	maxAttachment := int64(defaultMaxAttachmentSize)
+	if l != nil && l.MaxAttachmentSize > 0 {
+		maxAttachment = l.MaxAttachmentSize
+	}
+	if err := validateAttachments(params.Attachments, maxAttachment); err != nil {
+		return err
+	}
+	if l == nil {
+		return nil
+	}
+	if err := l.validateRecipients("to", len(params.To)+len(params.Cc)+len(params.Bcc)); err != nil {
+		return err
+	}
+	if l.MaxBatchSize > 0 && len(params.Personalizations) > l.MaxBatchSize {
+		return fieldError("personalizations", "too_many", "too many personalizations (%d), plan allows at most %d", len(params.Personalizations), l.MaxBatchSize)
+	}
+	for i, p := range params.Personalizations {
+		if err := l.validateRecipients(fmt.Sprintf("personalizations[%d].to", i), len(p.To)+len(params.Cc)+len(params.Bcc)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// maxMessageSize returns the limit on the combined size of the bodies and
+// attachments of an email. A nil receiver returns the SDK's default.
+func (l *Limits) maxMessageSize() int64 {
+	if l != nil && l.MaxMessageSize > 0 {
+		return l.MaxMessageSize
+	}
+	return defaultMaxMessageSize
+}
+
+// validateRecipients checks the recipient count n of a single message against
+// MaxRecipients. field is the name of the recipients field in validation errors.
+func (l *Limits) validateRecipients(field string, n int) error {
+	if l.MaxRecipients > 0 && n > l.MaxRecipients {
+		return fieldError(field, "too_many", "too many recipients (%d), plan allows at most %d", n, l.MaxRecipients)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package envloped
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestLimits_Success(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("expected GET, got %s", r.Method)
+		}
+		if r.URL.Path != "/v1/limits" {
+			t.Errorf("expected path /v1/limits, got %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"maxRecipients":50,"maxAttachmentSize":10485760,"maxBatchSize":100,"requestsPerSecond":10,"dailyEmailLimit":null,"monthlyEmailLimit":50000}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	limits, err := client.Limits()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if limits.MaxRecipients != 50 || limits.MaxAttachmentSize != 10485760 || limits.MaxBatchSize != 100 {
+		t.Errorf("unexpected limits: %+v", limits)
+	}
+	if limits.DailyEmailLimit != nil {
+		t.Errorf("expected unlimited daily limit, got %d", *limits.DailyEmailLimit)
+	}
+}
+
+func TestLoadLimits_ValidatesRecipients(t *testing.T) {
+	t.Parallel()
+
+	var sends int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/limits":
+			json.NewEncoder(w).Encode(Limits{MaxRecipients: 2})
+		case "/v1/emails":
+			atomic.AddInt32(&sends, 1)
+			json.NewEncoder(w).Encode(SendEmailResponse{Success: true, MessageId: "msg_1"})
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	if _, err := client.LoadLimits(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err := client.Emails.Send(&SendEmailRequest{
+		From:    "sender@example.com",
+		To:      []string{"a@example.com", "b@example.com", "c@example.com"},
+		Subject: "Test",
+		Html:    "<p>Hi</p>",
+	})
+	if err == nil || !contains(err.Error(), "too many recipients") {
+		t.Errorf("expected too many recipients error, got %v", err)
+	}
+	if n := atomic.LoadInt32(&sends); n != 0 {
+		t.Errorf("expected no send request, got %d", n)
+	}
+
+	client.WithLimits(nil)
+	_, err = client.Emails.Send(&SendEmailRequest{
+		From:    "sender@example.com",
+		To:      []string{"a@example.com", "b@example.com", "c@example.com"},
+		Subject: "Test",
+		Html:    "<p>Hi</p>",
+	})
+	if err != nil {
+		t.Errorf("expected send to succeed with limits disabled, got %v", err)
+	}
+}
@@ -0,0 +1,183 @@
+package envloped
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// LintRule identifies a check performed by Lint.
+type LintRule string
+
+// Lint rules.
+const (
+	// LintExternalCSS flags stylesheets loaded with <link> or @import, which
+	// Gmail and most webmail clients do not load.
+	LintExternalCSS LintRule = "external-css"
+
+	// LintForm flags forms and form controls, which most clients disable or
+	// strip.
+	LintForm LintRule = "form"
+
+	// LintScript flags <script> elements, event handler attributes, and
+	// javascript: URLs. Every client strips them, and some spam filters
+	// penalize them.
+	LintScript LintRule = "script"
+
+	// LintEmbed flags <iframe>, <object>, <embed>, <video>, and <audio>
+	// elements, which most clients do not render.
+	LintEmbed LintRule = "embed"
+
+	// LintImageWidth flags images wider than LintMaxImageWidth pixels, which
+	// overflow the reading pane of most clients.
+	LintImageWidth LintRule = "image-width"
+
+	// LintDataURI flags images embedded as data: URIs, which Gmail and
+	// Outlook do not display.
+	LintDataURI LintRule = "data-uri"
+)
+
+// LintMaxImageWidth is the widest image, in pixels, that Lint accepts. It
+// is the common width of email layouts.
+const LintMaxImageWidth = 600
+
+// LintIssue is a construct found by Lint that is known to break in email
+// clients.
+type LintIssue struct {
+	// Rule is the check that found the issue.
+	Rule LintRule
+
+	// Line is the 1-based line of the HTML the issue was found on.
+	Line int
+
+	// Message describes the issue.
+	Message string
+}
+
+// String formats the issue as "line 3: message (rule)".
+func (i LintIssue) String() string {
+	return fmt.Sprintf("line %d: %s (%s)", i.Line, i.Message, i.Rule)
+}
+
+// Lint checks an HTML email body for constructs known to break in email
+// clients: external stylesheets, forms, scripts, embedded media, images
+// wider than LintMaxImageWidth, and data: URI images. It returns the issues
+// in document order, or nil if there are none. Content of HTML comments,
+// such as Outlook conditional comments, is not checked.
+//
+// Lint does not change or send anything. Call it in template tests, so
+// authors get feedback before an email reaches customers:
+//
+//	for _, issue := range envloped.Lint(html) {
+//	    t.Error(issue)
+//	}
+func Lint(html string) []LintIssue {
+	var issues []LintIssue
+	pos := 0
+	add := func(rule LintRule, format string, args ...any) {
+		issues = append(issues, LintIssue{
+			Rule:    rule,
+			Line:    strings.Count(html[:pos], "\n") + 1,
+			Message: fmt.Sprintf(format, args...),
+		})
+	}
+
+	for {
+		i := strings.IndexByte(html[pos:], '<')
+		if i < 0 {
+			break
+		}
+		pos += i
+		rest := html[pos:]
+
+		if !startsTag(rest) {
+			pos++
+			continue
+		}
+		if strings.HasPrefix(rest, "<!--") {
+			end := strings.Index(rest, "-->")
+			if end < 0 {
+				break
+			}
+			pos += end + len("-->")
+			continue
+		}
+		end := strings.IndexByte(rest, '>')
+		if end < 0 {
+			break
+		}
+		name, closing, attrs := parseTag(rest[1:end])
+		if closing {
+			pos += end + 1
+			continue
+		}
+
+		switch name {
+		case "link":
+			if strings.EqualFold(attrValue(attrs, "rel"), "stylesheet") {
+				add(LintExternalCSS, "external stylesheet %q is not loaded by most clients; inline the CSS", attrValue(attrs, "href"))
+			}
+		case "style":
+			content := rest[end+1:]
+			if j := indexFold(content, "</style"); j >= 0 {
+				content = content[:j]
+			}
+			if indexFold(content, "@import") >= 0 {
+				add(LintExternalCSS, "@import is not loaded by most clients; inline the CSS")
+			}
+		case "script":
+			add(LintScript, "<script> is removed by every client")
+		case "form", "input", "select", "textarea":
+			add(LintForm, "<%s> is disabled or removed by most clients; link to a web page instead", name)
+		case "iframe", "object", "embed", "video", "audio":
+			add(LintEmbed, "<%s> is not rendered by most clients; link to the content instead", name)
+		case "img":
+			if w, ok := lintImageWidth(attrValue(attrs, "width"), attrValue(attrs, "style")); ok && w > LintMaxImageWidth {
+				add(LintImageWidth, "image %q is %dpx wide, wider than %dpx", attrValue(attrs, "src"), w, LintMaxImageWidth)
+			}
+			if strings.HasPrefix(strings.ToLower(strings.TrimSpace(attrValue(attrs, "src"))), "data:") {
+				add(LintDataURI, "data: URI images are not displayed by Gmail and Outlook; host the image or attach it inline")
+			}
+		}
+		for _, a := range attrs {
+			if strings.HasPrefix(a.name, "on") && len(a.name) > 2 {
+				add(LintScript, "event handler attribute %s on <%s> is removed by every client", a.name, name)
+			} else if (a.name == "href" || a.name == "src" || a.name == "action") &&
+				strings.HasPrefix(strings.ToLower(strings.TrimSpace(a.value)), "javascript:") {
+				add(LintScript, "javascript: URL on <%s> is removed by every client", name)
+			}
+		}
+
+		pos += end + 1
+		if name == "script" || name == "style" {
+			// Skip the element's content up to its closing tag.
+			if j := indexFold(html[pos:], "</"+name); j >= 0 {
+				pos += j
+			} else {
+				break
+			}
+		}
+	}
+	return issues
+}
+
+// lintImageWidth returns the pixel width of an image from its width
+// attribute or the width property of its style attribute.
+func lintImageWidth(width, style string) (int, bool) {
+	if w, err := strconv.Atoi(strings.TrimSuffix(strings.TrimSpace(width), "px")); err == nil {
+		return w, true
+	}
+	for _, decl := range strings.Split(style, ";") {
+		prop, value, ok := strings.Cut(decl, ":")
+		if !ok || !strings.EqualFold(strings.TrimSpace(prop), "width") {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		if px, ok := strings.CutSuffix(strings.ToLower(value), "px"); ok {
+			if w, err := strconv.Atoi(strings.TrimSpace(px)); err == nil {
+				return w, true
+			}
+		}
+	}
+	return 0, false
+}
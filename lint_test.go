@@ -0,0 +1,55 @@
+package envloped
+
+import (
+	"testing"
+)
+
+func TestLint(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		html string
+		want []LintRule
+	}{
+		{"clean", `<table width="600"><tr><td><img src="https://cdn.example.com/logo.png" width="600"><a href="https://example.com">Open</a></td></tr></table>`, nil},
+		{"external stylesheet", `<link rel="stylesheet" href="https://example.com/main.css"><link rel="icon" href="/favicon.ico">`, []LintRule{LintExternalCSS}},
+		{"css import", `<style>@import url("https://fonts.example.com/font.css"); p { color: red }</style>`, []LintRule{LintExternalCSS}},
+		{"form", `<form action="/subscribe"><input type="email" name="email"></form>`, []LintRule{LintForm, LintForm}},
+		{"script", `<script>document.write("<form>")</script><p onclick="track()">Hi</p><a href=" JavaScript:void(0)">x</a>`, []LintRule{LintScript, LintScript, LintScript}},
+		{"embed", `<iframe src="https://www.youtube.com/embed/x"></iframe><video src="a.mp4"></video>`, []LintRule{LintEmbed, LintEmbed}},
+		{"wide image", `<img src="hero.png" width=1200><img src="banner.png" style="display:block; WIDTH: 800px">`, []LintRule{LintImageWidth, LintImageWidth}},
+		{"data uri", `<img alt="logo" src="data:image/png;base64,iVBORw0KGgo=">`, []LintRule{LintDataURI}},
+		{"less than before tag", `<p>1 < 2 <img src="data:image/png;base64,iVBORw0KGgo="></p>`, []LintRule{LintDataURI}},
+		{"data attribute", `<img data-src="data:image/png;base64,iVBORw0KGgo=" src="https://cdn.example.com/logo.png">`, nil},
+		{"comments ignored", `<!--[if mso]><script>x()</script><![endif]--><p>Hi</p>`, nil},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			issues := Lint(tt.html)
+			if len(issues) != len(tt.want) {
+				t.Fatalf("expected %d issues, got %v", len(tt.want), issues)
+			}
+			for i, issue := range issues {
+				if issue.Rule != tt.want[i] {
+					t.Errorf("issue %d: expected rule %q, got %v", i, tt.want[i], issue)
+				}
+			}
+		})
+	}
+}
+
+func TestLint_Line(t *testing.T) {
+	t.Parallel()
+
+	issues := Lint("<html>\n<body>\n<p>Hi</p>\n<form>\n</form>\n</body>\n</html>")
+	if len(issues) != 1 {
+		t.Fatalf("expected 1 issue, got %v", issues)
+	}
+	if got, want := issues[0].String(), "line 4: <form> is disabled or removed by most clients; link to a web page instead (form)"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,88 @@
+package envloped
+
+import (
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// redacted replaces sensitive values in log output.
+const redacted = "[REDACTED]"
+
+// WithLogger enables debug logging of every API request: method, path,
+// status code, latency, and the API request ID. The Authorization header is
+// never logged, and email addresses in paths and query parameters are
+// redacted unless WithLogRecipients(true) is set.
+// Pass nil to disable logging. Returns the client for method chaining.
+func (c *Client) WithLogger(logger *slog.Logger) *Client {
+	c.logger = logger
+	return c
+}
+
+// WithLogRecipients controls whether email addresses appear unredacted in
+// debug logs. It is off by default; enable it only where logs may contain
+// personal data. Returns the client for method chaining.
+func (c *Client) WithLogRecipients(enabled bool) *Client {
+	c.logRecipients = enabled
+	return c
+}
+
+// logRequest logs a completed API request at debug level.
+// resp is nil if no response was received.
+func (c *Client) logRequest(req *http.Request, resp *http.Response, latency time.Duration, err error) {
+	if c.logger == nil {
+		return
+	}
+	ctx := req.Context()
+	if !c.logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+
+	path := req.URL.RequestURI()
+	if !c.logRecipients {
+		path = redactAddresses(req.URL)
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("path", path),
+		slog.Duration("latency", latency),
+	}
+	if resp != nil {
+		attrs = append(attrs,
+			slog.Int("status", resp.StatusCode),
+			slog.String("request_id", resp.Header.Get(requestIDHeader)),
+		)
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+
+	c.logger.LogAttrs(ctx, slog.LevelDebug, "envloped: api request", attrs...)
+}
+
+// redactAddresses returns the request URI of u with every path segment and
+// query value that contains an email address replaced by a placeholder.
+func redactAddresses(u *url.URL) string {
+	segments := strings.Split(u.EscapedPath(), "/")
+	for i, seg := range segments {
+		if unescaped, err := url.PathUnescape(seg); err == nil && strings.Contains(unescaped, "@") {
+			segments[i] = redacted
+		}
+	}
+	out := strings.Join(segments, "/")
+
+	if u.RawQuery == "" {
+		return out
+	}
+	pairs := strings.Split(u.RawQuery, "&")
+	for i, pair := range pairs {
+		key, value, _ := strings.Cut(pair, "=")
+		if unescaped, err := url.QueryUnescape(value); err == nil && strings.Contains(unescaped, "@") {
+			pairs[i] = key + "=" + redacted
+		}
+	}
+	return out + "?" + strings.Join(pairs, "&")
+}
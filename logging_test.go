@@ -0,0 +1,111 @@
+package envloped
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestLogger_LogsRequest(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req_123")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":[],"nextCursor":""}`))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client := newTestClient(t, server).WithLogger(logger)
+
+	_, err := client.Emails.List(&ListEmailsOptions{Recipient: "user@example.com"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{"level=DEBUG", "method=GET", `path="/v1/emails?recipient=[REDACTED]"`, "status=200", "request_id=req_123", "latency="} {
+		if !contains(out, want) {
+			t.Errorf("log %q missing %q", out, want)
+		}
+	}
+	for _, secret := range []string{"user@example.com", "test_api_key_123", "Bearer"} {
+		if contains(out, secret) {
+			t.Errorf("log %q leaks %q", out, secret)
+		}
+	}
+}
+
+func TestLogger_LogRecipients(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":[]}`))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client := newTestClient(t, server).WithLogger(logger).WithLogRecipients(true)
+
+	_, err := client.Emails.List(&ListEmailsOptions{Recipient: "user@example.com"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !contains(buf.String(), "user%40example.com") {
+		t.Errorf("log %q missing recipient", buf.String())
+	}
+}
+
+func TestLogger_InfoLevelSilent(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"message":"pong"}`))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+	client := newTestClient(t, server).WithLogger(logger)
+
+	if _, err := client.Ping(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("expected no output at info level, got %q", buf.String())
+	}
+}
+
+func TestRedactAddresses(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		raw  string
+		want string
+	}{
+		{"no address", "/v1/emails/msg_1", "/v1/emails/msg_1"},
+		{"path segment", "/v1/suppressions/user%40example.com", "/v1/suppressions/[REDACTED]"},
+		{"query value", "/v1/emails?limit=5&recipient=user%40example.com", "/v1/emails?limit=5&recipient=[REDACTED]"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			u, err := url.Parse(tt.raw)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := redactAddresses(u); got != tt.want {
+				t.Errorf("redactAddresses(%q) = %q, want %q", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
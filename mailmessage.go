@@ -0,0 +1,276 @@
+package envloped
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strings"
+)
+
+// ignoredMessageHeaders are headers of a parsed message that are neither
+// mapped to SendEmailRequest fields nor kept as custom headers, because the
+// API sets them itself.
+var ignoredMessageHeaders = map[string]bool{
+	"Date":           true,
+	"Message-Id":     true,
+	"Return-Path":    true,
+	"Received":       true,
+	"Dkim-Signature": true,
+}
+
+// FromMailMessage converts a parsed RFC 5322 message into a SendEmailRequest,
+// to ease migrating code that builds messages for SMTP. The From, To, Cc,
+// Bcc, Reply-To, Subject, In-Reply-To, and References headers map to the
+// corresponding fields, other headers become custom Headers, the first
+// text/plain, text/html, and text/x-amp-html parts become Text, Html, and
+// AmpHtml, and all other parts become Attachments.
+//
+// Text parts must be encoded in UTF-8, US-ASCII, or ISO-8859-1. The returned
+// request is not validated; Emails.Send validates it.
+func FromMailMessage(msg *mail.Message) (*SendEmailRequest, error) {
+	if msg == nil {
+		return nil, fmt.Errorf("envloped: mail message must not be nil")
+	}
+
+	params := &SendEmailRequest{}
+	var err error
+	if params.From, err = messageAddress(msg.Header, "From"); err != nil {
+		return nil, err
+	}
+	if params.To, err = messageAddressList(msg.Header, "To"); err != nil {
+		return nil, err
+	}
+	if params.Cc, err = messageAddressList(msg.Header, "Cc"); err != nil {
+		return nil, err
+	}
+	if params.Bcc, err = messageAddressList(msg.Header, "Bcc"); err != nil {
+		return nil, err
+	}
+	if params.ReplyTo, err = messageAddressList(msg.Header, "Reply-To"); err != nil {
+		return nil, err
+	}
+	params.Subject = decodeHeaderValue(msg.Header.Get("Subject"))
+	params.InReplyTo = strings.Trim(msg.Header.Get("In-Reply-To"), "<> ")
+	for _, id := range strings.Fields(msg.Header.Get("References")) {
+		params.References = append(params.References, strings.Trim(id, "<>"))
+	}
+
+	for name, values := range msg.Header {
+		name = textproto.CanonicalMIMEHeaderKey(name)
+		if reservedHeaders[name] || ignoredMessageHeaders[name] || name == "In-Reply-To" || name == "References" ||
+			strings.HasPrefix(name, "Content-") || len(values) == 0 {
+			continue
+		}
+		if params.Headers == nil {
+			params.Headers = make(map[string]string)
+		}
+		params.Headers[name] = values[0]
+	}
+
+	if err := addMessagePart(params, textproto.MIMEHeader(msg.Header), msg.Body); err != nil {
+		return nil, err
+	}
+	return params, nil
+}
+
+// ParseMessage reads an RFC 5322 message from r and converts it with
+// FromMailMessage. Libraries that write messages to an io.Writer, such as
+// gomail, can be adapted through a bytes.Buffer:
+//
+//	var buf bytes.Buffer
+//	if _, err := m.WriteTo(&buf); err != nil {
+//	    return err
+//	}
+//	params, err := envloped.ParseMessage(&buf)
+func ParseMessage(r io.Reader) (*SendEmailRequest, error) {
+	msg, err := mail.ReadMessage(r)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to parse message: %w", err)
+	}
+	return FromMailMessage(msg)
+}
+
+// messageAddress returns the first address of the named header, or "" if
+// the header is absent.
+func messageAddress(h mail.Header, name string) (string, error) {
+	addrs, err := messageAddressList(h, name)
+	if err != nil || len(addrs) == 0 {
+		return "", err
+	}
+	return addrs[0], nil
+}
+
+// messageAddressList returns the addresses of the named header, formatted
+// for SendEmailRequest, or nil if the header is absent.
+func messageAddressList(h mail.Header, name string) ([]string, error) {
+	list, err := h.AddressList(name)
+	if errors.Is(err, mail.ErrHeaderNotPresent) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("envloped: invalid %s header: %w", name, err)
+	}
+	addrs := make([]string, len(list))
+	for i, a := range list {
+		addrs[i] = Address{Name: a.Name, Email: a.Address}.String()
+	}
+	return addrs, nil
+}
+
+// decodeHeaderValue decodes RFC 2047 encoded words in a header value,
+// returning it unchanged if it cannot be decoded.
+func decodeHeaderValue(s string) string {
+	dec := mime.WordDecoder{CharsetReader: charsetReader}
+	if decoded, err := dec.DecodeHeader(s); err == nil {
+		return decoded
+	}
+	return s
+}
+
+// messagePart is a decoded leaf part of a MIME message.
+type messagePart struct {
+	// mediaType is the lowercase media type, e.g. "text/plain".
+	mediaType string
+
+	// charset is the charset parameter of the Content-Type header, if any.
+	charset string
+
+	// attachment reports whether the part has an attachment disposition.
+	attachment bool
+
+	// filename is the decoded file name, if any.
+	filename string
+
+	// contentID is the Content-ID header without angle brackets, if any.
+	contentID string
+
+	// content is the part's body with its transfer encoding removed.
+	content []byte
+}
+
+// walkMessageParts calls fn with every leaf part of the MIME entity with
+// header h and body, in order, walking into multipart parts.
+func walkMessageParts(h textproto.MIMEHeader, body io.Reader, fn func(*messagePart) error) error {
+	mediaType, mediaParams := "text/plain", map[string]string{}
+	if ct := h.Get("Content-Type"); ct != "" {
+		var err error
+		if mediaType, mediaParams, err = mime.ParseMediaType(ct); err != nil {
+			return fmt.Errorf("envloped: invalid content type %q: %w", ct, err)
+		}
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		mr := multipart.NewReader(body, mediaParams["boundary"])
+		for {
+			part, err := mr.NextPart()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("envloped: failed to read message part: %w", err)
+			}
+			if err := walkMessageParts(part.Header, part, fn); err != nil {
+				return err
+			}
+		}
+	}
+
+	switch strings.ToLower(h.Get("Content-Transfer-Encoding")) {
+	case "base64":
+		body = base64.NewDecoder(base64.StdEncoding, body)
+	case "quoted-printable":
+		body = quotedprintable.NewReader(body)
+	}
+	content, err := io.ReadAll(body)
+	if err != nil {
+		return fmt.Errorf("envloped: failed to read message part: %w", err)
+	}
+
+	disposition, dispositionParams, _ := mime.ParseMediaType(h.Get("Content-Disposition"))
+	filename := dispositionParams["filename"]
+	if filename == "" {
+		filename = mediaParams["name"]
+	}
+
+	return fn(&messagePart{
+		mediaType:  mediaType,
+		charset:    mediaParams["charset"],
+		attachment: disposition == "attachment",
+		filename:   decodeHeaderValue(filename),
+		contentID:  strings.Trim(h.Get("Content-Id"), "<> "),
+		content:    content,
+	})
+}
+
+// addMessagePart adds a MIME part with header h and body to params, walking
+// into multipart parts.
+func addMessagePart(params *SendEmailRequest, h textproto.MIMEHeader, body io.Reader) error {
+	return walkMessageParts(h, body, func(p *messagePart) error {
+		var err error
+		if !p.attachment && p.filename == "" {
+			switch {
+			case p.mediaType == "text/plain" && params.Text == "":
+				params.Text, err = decodeCharset(p.content, p.charset)
+				return err
+			case p.mediaType == "text/html" && params.Html == "":
+				params.Html, err = decodeCharset(p.content, p.charset)
+				return err
+			case p.mediaType == "text/x-amp-html" && params.AmpHtml == "":
+				params.AmpHtml, err = decodeCharset(p.content, p.charset)
+				return err
+			}
+		}
+
+		filename := p.filename
+		if filename == "" {
+			filename = fmt.Sprintf("attachment-%d", len(params.Attachments)+1)
+		}
+		params.Attachments = append(params.Attachments, Attachment{
+			Filename:    filename,
+			Content:     p.content,
+			ContentType: p.mediaType,
+		})
+		return nil
+	})
+}
+
+// decodeCharset converts text in the given charset to a UTF-8 string.
+func decodeCharset(content []byte, charset string) (string, error) {
+	switch strings.ToLower(charset) {
+	case "", "utf-8", "utf8", "us-ascii":
+		return string(content), nil
+	case "iso-8859-1", "latin1":
+		return latin1ToUTF8(content), nil
+	default:
+		return "", fmt.Errorf("envloped: unsupported charset %q", charset)
+	}
+}
+
+// charsetReader decodes RFC 2047 encoded words in charsets beyond the ones
+// mime.WordDecoder supports natively.
+func charsetReader(charset string, input io.Reader) (io.Reader, error) {
+	content, err := io.ReadAll(input)
+	if err != nil {
+		return nil, err
+	}
+	s, err := decodeCharset(content, charset)
+	if err != nil {
+		return nil, err
+	}
+	return strings.NewReader(s), nil
+}
+
+// latin1ToUTF8 converts ISO-8859-1 text, where every byte is a code point.
+func latin1ToUTF8(b []byte) string {
+	runes := make([]rune, len(b))
+	for i, c := range b {
+		runes[i] = rune(c)
+	}
+	return string(runes)
+}
@@ -0,0 +1,150 @@
+package envloped
+
+import (
+	"net/mail"
+	"strings"
+	"testing"
+)
+
+const testMultipartMessage = "From: =?UTF-8?Q?Zo=C3=AB?= <zoe@example.com>\r\n" +
+	"To: a@example.com, \"B, Bo\" <b@example.com>\r\n" +
+	"Cc: c@example.com\r\n" +
+	"Bcc: d@example.com\r\n" +
+	"Reply-To: support@example.com\r\n" +
+	"Subject: =?UTF-8?B?SGVsbG8gd29ybGQg8J+Riw==?=\r\n" +
+	"Date: Mon, 02 Mar 2026 10:00:00 +0000\r\n" +
+	"Message-ID: <abc@example.com>\r\n" +
+	"In-Reply-To: <parent@example.com>\r\n" +
+	"References: <root@example.com> <parent@example.com>\r\n" +
+	"MIME-Version: 1.0\r\n" +
+	"X-Campaign: spring\r\n" +
+	"Content-Type: multipart/mixed; boundary=outer\r\n" +
+	"\r\n" +
+	"--outer\r\n" +
+	"Content-Type: multipart/alternative; boundary=inner\r\n" +
+	"\r\n" +
+	"--inner\r\n" +
+	"Content-Type: text/plain; charset=UTF-8\r\n" +
+	"Content-Transfer-Encoding: quoted-printable\r\n" +
+	"\r\n" +
+	"Caf=C3=A9 opens at 9.\r\n" +
+	"--inner\r\n" +
+	"Content-Type: text/x-amp-html; charset=UTF-8\r\n" +
+	"\r\n" +
+	"<html \u26a14email><body>Menu</body></html>\r\n" +
+	"--inner\r\n" +
+	"Content-Type: text/html; charset=UTF-8\r\n" +
+	"Content-Transfer-Encoding: base64\r\n" +
+	"\r\n" +
+	"PHA+Q2Fmw6kgb3BlbnMgYXQgOS48L3A+\r\n" +
+	"--inner--\r\n" +
+	"--outer\r\n" +
+	"Content-Type: application/pdf; name=\"menu.pdf\"\r\n" +
+	"Content-Disposition: attachment; filename=\"menu.pdf\"\r\n" +
+	"Content-Transfer-Encoding: base64\r\n" +
+	"\r\n" +
+	"JVBERi0x\r\n" +
+	"LjQ=\r\n" +
+	"--outer--\r\n"
+
+func TestFromMailMessage(t *testing.T) {
+	t.Parallel()
+
+	msg, err := mail.ReadMessage(strings.NewReader(testMultipartMessage))
+	if err != nil {
+		t.Fatalf("failed to read message: %v", err)
+	}
+
+	params, err := FromMailMessage(msg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if params.From != `=?utf-8?q?Zo=C3=AB?= <zoe@example.com>` {
+		t.Errorf("unexpected from %q", params.From)
+	}
+	if len(params.To) != 2 || params.To[0] != "a@example.com" || params.To[1] != `"B, Bo" <b@example.com>` {
+		t.Errorf("unexpected to %q", params.To)
+	}
+	if len(params.Cc) != 1 || len(params.Bcc) != 1 || len(params.ReplyTo) != 1 {
+		t.Errorf("unexpected cc/bcc/reply-to %q %q %q", params.Cc, params.Bcc, params.ReplyTo)
+	}
+	if params.Subject != "Hello world 👋" {
+		t.Errorf("unexpected subject %q", params.Subject)
+	}
+	if params.Text != "Café opens at 9." {
+		t.Errorf("unexpected text %q", params.Text)
+	}
+	if params.Html != "<p>Café opens at 9.</p>" {
+		t.Errorf("unexpected html %q", params.Html)
+	}
+	if params.AmpHtml != "<html \u26a14email><body>Menu</body></html>" {
+		t.Errorf("unexpected amp html %q", params.AmpHtml)
+	}
+	if len(params.Attachments) != 1 {
+		t.Fatalf("expected 1 attachment, got %d", len(params.Attachments))
+	}
+	a := params.Attachments[0]
+	if a.Filename != "menu.pdf" || a.ContentType != "application/pdf" || string(a.Content) != "%PDF-1.4" {
+		t.Errorf("unexpected attachment %+v", a)
+	}
+	if params.InReplyTo != "parent@example.com" || len(params.References) != 2 || params.References[0] != "root@example.com" {
+		t.Errorf("unexpected threading %q %q", params.InReplyTo, params.References)
+	}
+	if len(params.Headers) != 1 || params.Headers["X-Campaign"] != "spring" {
+		t.Errorf("expected only X-Campaign header, got %v", params.Headers)
+	}
+	if err := validateSendEmailRequest(params); err != nil {
+		t.Errorf("expected converted request to be valid, got %v", err)
+	}
+}
+
+func TestParseMessage_SinglePart(t *testing.T) {
+	t.Parallel()
+
+	raw := "From: sender@example.com\r\n" +
+		"To: recipient@example.com\r\n" +
+		"Subject: Hi\r\n" +
+		"Content-Type: text/plain; charset=ISO-8859-1\r\n" +
+		"\r\n" +
+		"Gr\xfc\xdfe\r\n"
+
+	params, err := ParseMessage(strings.NewReader(raw))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if params.Text != "Grüße\r\n" || params.Html != "" || len(params.Attachments) != 0 {
+		t.Errorf("unexpected params %+v", params)
+	}
+}
+
+func TestFromMailMessage_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		raw     string
+		wantErr string
+	}{
+		{"invalid to", "From: a@example.com\r\nTo: not an address\r\n\r\nx", "invalid To header"},
+		{"unsupported charset", "From: a@example.com\r\nContent-Type: text/plain; charset=koi8-r\r\n\r\nx", `unsupported charset "koi8-r"`},
+		{"invalid content type", "From: a@example.com\r\nContent-Type: /\r\n\r\nx", "invalid content type"},
+		{"not a message", "no headers here", "failed to parse message"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := ParseMessage(strings.NewReader(tt.raw))
+			if err == nil || !contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	if _, err := FromMailMessage(nil); err == nil {
+		t.Error("expected error for nil message")
+	}
+}
@@ -0,0 +1,103 @@
+package envloped
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Metrics receives measurements of the client's API traffic. It lets the SDK
+// report to a metrics backend without depending on one; see the
+// promenvloped module for a Prometheus implementation. Implementations must
+// be safe for concurrent use.
+type Metrics interface {
+	// ObserveRequest records a completed HTTP request to the API. Requests
+	// refused locally, e.g. by the quota guard, are not recorded.
+	ObserveRequest(m RequestMetric)
+
+	// ObserveRetry records that the SDK repeats a request, e.g. a hedged
+	// read or a rate limited bulk send.
+	ObserveRetry(method, route string)
+}
+
+// RequestMetric describes a completed HTTP request to the API.
+type RequestMetric struct {
+	// Method is the HTTP method.
+	Method string
+
+	// Route is the pattern of the request path, with resource IDs and names
+	// replaced by "{id}", e.g. "/v1/emails/{id}/events", to keep metric
+	// cardinality low.
+	Route string
+
+	// StatusCode is the HTTP status code, or 0 if no response was received.
+	StatusCode int
+
+	// Duration is the time until the response headers were received.
+	Duration time.Duration
+
+	// Err is the transport error, if no response was received.
+	Err error
+}
+
+// WithMetrics sets a Metrics implementation that records every HTTP request
+// to the API. Returns the client for method chaining.
+func (c *Client) WithMetrics(metrics Metrics) *Client {
+	c.metrics = metrics
+	return c
+}
+
+// observeRequest reports a completed HTTP request to the metrics, if set.
+func (c *Client) observeRequest(req *http.Request, resp *http.Response, d time.Duration, err error) {
+	if c.metrics == nil {
+		return
+	}
+	m := RequestMetric{Method: req.Method, Route: routeOfRequest(req), Duration: d, Err: err}
+	if resp != nil {
+		m.StatusCode = resp.StatusCode
+	}
+	c.metrics.ObserveRequest(m)
+}
+
+// observeRetry reports a retry of a request to route to the metrics, if set.
+func (c *Client) observeRetry(method, route string) {
+	if c.metrics != nil {
+		c.metrics.ObserveRetry(method, route)
+	}
+}
+
+// routeKey is the context key of the route of a request.
+type routeKey struct{}
+
+// withRoute returns a copy of ctx that carries the route of a request.
+func withRoute(ctx context.Context, route string) context.Context {
+	return context.WithValue(ctx, routeKey{}, route)
+}
+
+// routeOfRequest returns the route the request was built for. Requests not
+// built by the client are labeled by routeOf.
+func routeOfRequest(req *http.Request) string {
+	if route, ok := req.Context().Value(routeKey{}).(string); ok {
+		return route
+	}
+	return routeOf(req.URL.Path)
+}
+
+// routeOf guesses the route of a path the SDK does not know, such as one
+// passed to NewRequest, by replacing resource IDs with "{id}". Segments
+// made of lowercase letters and hyphens are taken as resource names; any
+// other segment, such as "msg_123", "user@example.com", or "example.com",
+// is an ID. The version segment is kept.
+func routeOf(path string) string {
+	segments := strings.Split(path, "/")
+	for i, s := range segments {
+		if s == "" || (i == 1 && s == "v1") {
+			continue
+		}
+		if strings.Trim(s, "abcdefghijklmnopqrstuvwxyz-") != "" {
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
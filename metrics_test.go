@@ -0,0 +1,174 @@
+package envloped
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingMetrics records observations for assertions.
+type recordingMetrics struct {
+	mu       sync.Mutex
+	requests []RequestMetric
+	retries  []string
+}
+
+func (m *recordingMetrics) ObserveRequest(r RequestMetric) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests = append(m.requests, r)
+}
+
+func (m *recordingMetrics) ObserveRetry(method, route string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.retries = append(m.retries, method+" "+route)
+}
+
+func TestWithMetrics(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v1/emails/msg_missing" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"not found"}`))
+			return
+		}
+		w.Write([]byte(`{"id":"msg_1"}`))
+	}))
+	defer server.Close()
+
+	metrics := &recordingMetrics{}
+	client := newTestClient(t, server).WithMetrics(metrics)
+
+	client.Emails.Get("msg_1")
+	client.Emails.Get("msg_missing")
+
+	if len(metrics.requests) != 2 {
+		t.Fatalf("expected 2 requests, got %+v", metrics.requests)
+	}
+	for i, want := range []int{http.StatusOK, http.StatusNotFound} {
+		got := metrics.requests[i]
+		if got.Method != http.MethodGet || got.Route != "/v1/emails/{id}" || got.StatusCode != want {
+			t.Errorf("unexpected metric %+v", got)
+		}
+		if got.Duration <= 0 || got.Err != nil {
+			t.Errorf("unexpected metric %+v", got)
+		}
+	}
+
+	if clone := client.Clone(); clone.metrics != metrics {
+		t.Error("expected clone to keep the metrics")
+	}
+}
+
+func TestWithMetrics_Routes(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	metrics := &recordingMetrics{}
+	client := newTestClient(t, server).WithMetrics(metrics)
+
+	client.IPPools.Get("marketing")
+	client.Suppressions.Delete("user@example.com")
+	req, err := client.NewRequest(context.Background(), http.MethodGet, "/v1/beta/things/thing_1?expand=true", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client.Do(req, nil)
+
+	want := []string{"/v1/ip-pools/{id}", "/v1/suppressions/{id}", "/v1/beta/things/{id}"}
+	if len(metrics.requests) != len(want) {
+		t.Fatalf("expected %d requests, got %+v", len(want), metrics.requests)
+	}
+	for i, route := range want {
+		if got := metrics.requests[i].Route; got != route {
+			t.Errorf("expected route %q, got %q", route, got)
+		}
+	}
+}
+
+func TestWithMetrics_TransportError(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	metrics := &recordingMetrics{}
+	client := newTestClient(t, server).WithMetrics(metrics)
+
+	if _, err := client.Ping(); err == nil {
+		t.Fatal("expected error")
+	}
+	if len(metrics.requests) != 1 || metrics.requests[0].StatusCode != 0 || metrics.requests[0].Err == nil {
+		t.Errorf("expected transport error to be recorded, got %+v", metrics.requests)
+	}
+}
+
+func TestWithMetrics_HedgeRetry(t *testing.T) {
+	t.Parallel()
+
+	var once sync.Once
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		slow := false
+		once.Do(func() { slow = true })
+		if slow {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-time.After(2 * time.Second):
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"message":"pong","companyId":"c"}`))
+	}))
+	defer server.Close()
+
+	metrics := &recordingMetrics{}
+	client := newTestClient(t, server).WithHedging(10 * time.Millisecond).WithMetrics(metrics)
+
+	if _, err := client.PingWithContext(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	metrics.mu.Lock()
+	defer metrics.mu.Unlock()
+	if len(metrics.retries) != 1 || metrics.retries[0] != "GET /v1/ping" {
+		t.Errorf("expected the hedged attempt to be recorded as a retry, got %v", metrics.retries)
+	}
+}
+
+func TestRouteOf(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/v1/emails", "/v1/emails"},
+		{"/v1/emails/msg_123", "/v1/emails/{id}"},
+		{"/v1/emails/msg_123/events", "/v1/emails/{id}/events"},
+		{"/v1/suppressions/user@example.com", "/v1/suppressions/{id}"},
+		{"/v1/domains/example.com/verify", "/v1/domains/{id}/verify"},
+		{"/v1/ip-pools", "/v1/ip-pools"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+			if got := routeOf(tt.path); got != tt.want {
+				t.Errorf("routeOf(%q) = %q, want %q", tt.path, got, tt.want)
+			}
+		})
+	}
+}
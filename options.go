@@ -0,0 +1,51 @@
+package envloped
+
+import (
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Option overrides the configuration of a client made with Client.Clone.
+type Option func(*Client)
+
+// WithAPIKey makes the client authenticate with apiKey, to act on behalf of
+// another account. The account-specific state copied from the original
+// client, namely the plan limits, the last reported rate limit, and the
+// request throttle, is reset for the new key.
+func WithAPIKey(apiKey string) Option {
+	return func(c *Client) {
+		key := strings.TrimSpace(apiKey)
+		if key == c.apiKey {
+			return
+		}
+		c.apiKey = key
+		c.limits.Store(nil)
+		c.rateLimit.Store(nil)
+		if c.throttle != nil {
+			c.throttle = newTokenBucket(c.throttle.rate, int(c.throttle.burst))
+		}
+	}
+}
+
+// WithBaseURL makes the client use the API at rawURL. Invalid URLs are
+// ignored, as with Client.WithBaseURL.
+func WithBaseURL(rawURL string) Option {
+	return func(c *Client) {
+		if u, err := url.Parse(rawURL); err == nil {
+			c.baseURL = u
+			c.customBaseURL = true
+		}
+	}
+}
+
+// WithClientTimeout sets the timeout of the client's HTTP requests. The
+// client keeps sharing the transport, and so the connection pool, of the
+// original client.
+func WithClientTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		hc := *c.httpClient
+		hc.Timeout = d
+		c.httpClient = &hc
+	}
+}
@@ -0,0 +1,57 @@
+package envloped
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestClone_Options(t *testing.T) {
+	t.Parallel()
+
+	transport := &http.Transport{}
+	client := NewClient("key_a").
+		WithHTTPClient(&http.Client{Transport: transport, Timeout: 30 * time.Second}).
+		WithRateLimit(10, 5)
+	client.limits.Store(&Limits{MaxRecipients: 5})
+	client.rateLimit.Store(&RateLimit{Limit: 10})
+
+	clone := client.Clone(
+		WithAPIKey(" key_b "),
+		WithBaseURL("https://api.eu.envloped.com"),
+		WithClientTimeout(5*time.Second),
+		nil,
+	)
+
+	if clone.apiKey != "key_b" || client.apiKey != "key_a" {
+		t.Errorf("unexpected API keys %q and %q", client.apiKey, clone.apiKey)
+	}
+	if got := clone.baseURL.String(); got != "https://api.eu.envloped.com" {
+		t.Errorf("unexpected base URL %q", got)
+	}
+	if clone.httpClient.Timeout != 5*time.Second || client.httpClient.Timeout != 30*time.Second {
+		t.Errorf("unexpected timeouts %v and %v", client.httpClient.Timeout, clone.httpClient.Timeout)
+	}
+	if clone.httpClient.Transport != transport {
+		t.Error("expected clone to share the transport")
+	}
+	if clone.limits.Load() != nil || clone.rateLimit.Load() != nil {
+		t.Error("expected account state to be reset for the new key")
+	}
+	if clone.throttle == client.throttle || clone.throttle.rate != 10 || clone.throttle.burst != 5 {
+		t.Errorf("expected a separate throttle with the same settings, got %+v", clone.throttle)
+	}
+	if clone.Emails.(*emailsSvcImpl).client != clone {
+		t.Error("expected clone services to be backed by the clone")
+	}
+}
+
+func TestClone_SameAPIKeyKeepsState(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("key_a").WithRateLimit(10, 5)
+	clone := client.Clone(WithAPIKey("key_a"))
+	if clone.throttle != client.throttle {
+		t.Error("expected throttle to be shared for the same key")
+	}
+}
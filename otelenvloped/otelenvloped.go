@@ -0,0 +1,106 @@
+// Package otelenvloped provides OpenTelemetry tracing for the Envloped Go SDK.
+//
+// It lives in its own module so the core SDK stays free of third-party
+// dependencies.
+//
+// Usage:
+//
+//	tracer := otelenvloped.NewTracer(otelenvloped.WithTracerProvider(tp))
+//	client := envloped.NewClient("ev_your_api_key").WithTracer(tracer)
+package otelenvloped
+
+import (
+	"context"
+
+	envloped "github.com/envloped/envloped-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName identifies this package as the instrumentation scope.
+const instrumentationName = "github.com/envloped/envloped-go/otelenvloped"
+
+// Attribute keys set on spans.
+const (
+	// MessageIDKey is the Envloped message ID of the email the call created or returned.
+	MessageIDKey = attribute.Key("envloped.message_id")
+
+	// RetryCountKey is the number of earlier attempts of the call, including
+	// a hedged request.
+	RetryCountKey = attribute.Key("envloped.retry_count")
+
+	methodKey     = attribute.Key("http.request.method")
+	pathKey       = attribute.Key("url.path")
+	statusCodeKey = attribute.Key("http.response.status_code")
+)
+
+// config holds the tracer options.
+type config struct {
+	provider trace.TracerProvider
+}
+
+// Option configures the tracer returned by NewTracer.
+type Option func(*config)
+
+// WithTracerProvider sets the TracerProvider spans are created from.
+// Defaults to the global provider from otel.GetTracerProvider.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(c *config) {
+		if provider != nil {
+			c.provider = provider
+		}
+	}
+}
+
+// NewTracer returns an envloped.Tracer that records a client span for every
+// API call, with the HTTP method, path, status code, message ID, and retry
+// count as attributes.
+func NewTracer(opts ...Option) envloped.Tracer {
+	cfg := config{provider: otel.GetTracerProvider()}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return &tracer{
+		tracer: cfg.provider.Tracer(instrumentationName, trace.WithInstrumentationVersion(envloped.Version())),
+	}
+}
+
+// tracer implements envloped.Tracer.
+type tracer struct {
+	tracer trace.Tracer
+}
+
+// Start implements envloped.Tracer.
+func (t *tracer) Start(ctx context.Context, method, path string) (context.Context, envloped.Span) {
+	ctx, span := t.tracer.Start(ctx, "envloped "+method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			methodKey.String(method),
+			pathKey.String(path),
+		),
+	)
+	return ctx, &spanAdapter{span: span}
+}
+
+// spanAdapter implements envloped.Span.
+type spanAdapter struct {
+	span trace.Span
+}
+
+// End implements envloped.Span.
+func (s *spanAdapter) End(result envloped.SpanResult) {
+	s.span.SetAttributes(RetryCountKey.Int(result.RetryCount))
+	if result.StatusCode != 0 {
+		s.span.SetAttributes(statusCodeKey.Int(result.StatusCode))
+	}
+	if result.MessageID != "" {
+		s.span.SetAttributes(MessageIDKey.String(result.MessageID))
+	}
+	if result.Err != nil {
+		s.span.RecordError(result.Err)
+		s.span.SetStatus(codes.Error, result.Err.Error())
+	}
+	s.span.End()
+}
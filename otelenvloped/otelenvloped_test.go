@@ -0,0 +1,93 @@
+package otelenvloped
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	envloped "github.com/envloped/envloped-go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracer_RecordsSpan(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(envloped.SendEmailResponse{Success: true, MessageId: "msg_otel"})
+	}))
+	defer server.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	client := envloped.NewClient("key").
+		WithBaseURL(server.URL).
+		WithTracer(NewTracer(WithTracerProvider(provider)))
+
+	_, err := client.Emails.Send(&envloped.SendEmailRequest{
+		From:    "sender@example.com",
+		To:      []string{"recipient@example.com"},
+		Subject: "Test",
+		Html:    "<p>Hi</p>",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	span := spans[0]
+	if span.Name() != "envloped POST" {
+		t.Errorf("unexpected span name %q", span.Name())
+	}
+
+	attrs := map[attribute.Key]attribute.Value{}
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	if v := attrs[pathKey].AsString(); v != "/v1/emails" {
+		t.Errorf("expected path attribute /v1/emails, got %q", v)
+	}
+	if v := attrs[statusCodeKey].AsInt64(); v != 200 {
+		t.Errorf("expected status attribute 200, got %d", v)
+	}
+	if v := attrs[MessageIDKey].AsString(); v != "msg_otel" {
+		t.Errorf("expected message id attribute msg_otel, got %q", v)
+	}
+	if v, ok := attrs[RetryCountKey]; !ok || v.AsInt64() != 0 {
+		t.Errorf("expected retry count attribute 0, got %v", v.AsInt64())
+	}
+}
+
+func TestTracer_RecordsError(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"error":"Domain not verified"}`))
+	}))
+	defer server.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	client := envloped.NewClient("key").
+		WithBaseURL(server.URL).
+		WithTracer(NewTracer(WithTracerProvider(provider)))
+
+	if _, err := client.Ping(); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	span := recorder.Ended()[0]
+	if span.Status().Code != codes.Error {
+		t.Errorf("expected error status, got %v", span.Status().Code)
+	}
+}
@@ -0,0 +1,202 @@
+package envloped
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// defaultOutboxMaxAttempts is the default number of times an outbox entry
+// is sent before it is given up.
+const defaultOutboxMaxAttempts = 10
+
+// OutboxEntry is an email persisted in an OutboxStore until it is sent.
+type OutboxEntry struct {
+	// ID identifies the entry in the store.
+	ID string `json:"id"`
+
+	// IdempotencyKey is sent with every attempt, so an email that was sent
+	// but not yet removed from the store when the process stopped is not
+	// delivered twice.
+	IdempotencyKey string `json:"idempotencyKey"`
+
+	// Request is the email to send, in the form it is sent to the API.
+	Request *SendEmailRequest `json:"request"`
+
+	// Attempts is the number of failed send attempts.
+	Attempts int `json:"attempts"`
+
+	// LastError describes the most recent failed attempt, if any.
+	LastError string `json:"lastError,omitempty"`
+
+	// CreatedAt is when the email was added to the outbox.
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// OutboxStore persists outbox entries. NewMemoryStore and NewFileStore
+// provide implementations; implement it on top of a database to share the
+// outbox between processes or hosts. Implementations must be safe for
+// concurrent use.
+type OutboxStore interface {
+	// Put adds entry to the store, or replaces the entry with the same ID.
+	Put(ctx context.Context, entry *OutboxEntry) error
+
+	// List returns all entries in the store, oldest first.
+	List(ctx context.Context) ([]*OutboxEntry, error)
+
+	// Delete removes the entry with the given ID. Deleting an entry that
+	// does not exist is not an error.
+	Delete(ctx context.Context, id string) error
+}
+
+// OutboxOptions configures an Outbox.
+type OutboxOptions struct {
+	// MaxAttempts is the number of times an email is tried before it is
+	// given up. Defaults to 10. Emails that fail with an error that is not
+	// retryable (see IsRetryable) are given up immediately.
+	MaxAttempts int
+
+	// OnFailure is called with emails that were given up, after they were
+	// removed from the store. It may be nil.
+	OnFailure func(entry *OutboxEntry, err error)
+
+	// OnError is called with store errors that occur while Run flushes the
+	// outbox. It may be nil.
+	OnError func(error)
+}
+
+// Outbox persists emails in an OutboxStore before sending them, so critical
+// transactional mail survives process restarts and API outages. Emails
+// remain in the store until the API confirms they were sent.
+//
+//	outbox := envloped.NewOutbox(client, envloped.NewFileStore("/var/lib/app/outbox"), nil)
+//	go outbox.Run(ctx, 10*time.Second)
+//
+//	if _, err := outbox.Enqueue(ctx, params); err != nil {
+//	    return err
+//	}
+type Outbox struct {
+	client *Client
+	store  OutboxStore
+	opts   OutboxOptions
+}
+
+// NewOutbox returns an Outbox that stores emails in store and sends them
+// with client. opts may be nil.
+func NewOutbox(client *Client, store OutboxStore, opts *OutboxOptions) *Outbox {
+	var o OutboxOptions
+	if opts != nil {
+		o = *opts
+	}
+	if o.MaxAttempts <= 0 {
+		o.MaxAttempts = defaultOutboxMaxAttempts
+	}
+	return &Outbox{client: client, store: store, opts: o}
+}
+
+// Enqueue validates params and persists it in the store, returning the ID
+// of the new entry. The email is sent by the next Flush. Attachments read
+// from a Reader are uploaded first, so the stored email references them by
+// ID. If params has no IdempotencyKey, the entry ID is used.
+func (o *Outbox) Enqueue(ctx context.Context, params *SendEmailRequest) (string, error) {
+	if err := validateSendEmailRequest(params); err != nil {
+		return "", err
+	}
+	params, err := o.client.uploadAttachments(ctx, params)
+	if err != nil {
+		return "", err
+	}
+	params = params.withAutoText()
+	params = params.withPreheader()
+
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("envloped: failed to generate outbox entry id: %w", err)
+	}
+	entry := &OutboxEntry{
+		ID:             hex.EncodeToString(b),
+		IdempotencyKey: params.IdempotencyKey,
+		Request:        params,
+		CreatedAt:      time.Now().UTC(),
+	}
+	if entry.IdempotencyKey == "" {
+		entry.IdempotencyKey = entry.ID
+	}
+
+	if err := o.store.Put(ctx, entry); err != nil {
+		return "", fmt.Errorf("envloped: failed to store outbox entry: %w", err)
+	}
+	return entry.ID, nil
+}
+
+// Flush tries to send every email in the store, oldest first. Sent emails
+// are removed from the store. Emails that fail with a retryable error stay
+// in the store for the next Flush, until they reach MaxAttempts. Flush only
+// returns store errors and the context's error; send failures are recorded
+// in the entries and reported to OnFailure.
+func (o *Outbox) Flush(ctx context.Context) error {
+	entries, err := o.store.List(ctx)
+	if err != nil {
+		return fmt.Errorf("envloped: failed to list outbox entries: %w", err)
+	}
+
+	for _, entry := range entries {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if entry.Attempts > 0 {
+			o.client.observeRetry(http.MethodPost, "/v1/emails")
+		}
+		req := *entry.Request
+		req.IdempotencyKey = entry.IdempotencyKey
+		_, sendErr := o.client.Emails.SendWithContext(withRetryCount(ctx, entry.Attempts), &req)
+		if sendErr == nil {
+			if err := o.store.Delete(ctx, entry.ID); err != nil {
+				return fmt.Errorf("envloped: failed to delete outbox entry: %w", err)
+			}
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		entry.Attempts++
+		entry.LastError = sendErr.Error()
+		if IsRetryable(sendErr) && entry.Attempts < o.opts.MaxAttempts {
+			if err := o.store.Put(ctx, entry); err != nil {
+				return fmt.Errorf("envloped: failed to update outbox entry: %w", err)
+			}
+			continue
+		}
+
+		if err := o.store.Delete(ctx, entry.ID); err != nil {
+			return fmt.Errorf("envloped: failed to delete outbox entry: %w", err)
+		}
+		if o.opts.OnFailure != nil {
+			o.opts.OnFailure(entry, sendErr)
+		}
+	}
+	return nil
+}
+
+// Run flushes the outbox immediately and then every interval until ctx is
+// done, which it returns as its error. Store errors are passed to OnError.
+func (o *Outbox) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := o.Flush(ctx); err != nil && ctx.Err() == nil && o.opts.OnError != nil {
+			o.opts.OnError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
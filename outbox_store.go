@@ -0,0 +1,171 @@
+package envloped
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// MemoryStore is an OutboxStore that keeps entries in memory. Entries do not
+// survive a restart, so it suits tests and buffering through API outages.
+type MemoryStore struct {
+	mu      sync.Mutex
+	entries map[string][]byte
+}
+
+var _ OutboxStore = (*MemoryStore)(nil)
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{entries: make(map[string][]byte)}
+}
+
+// Put implements OutboxStore. The entry is stored in encoded form, like in
+// persistent stores, so later changes to it are not reflected in the store.
+func (s *MemoryStore) Put(ctx context.Context, entry *OutboxEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[entry.ID] = data
+	return nil
+}
+
+// List implements OutboxStore.
+func (s *MemoryStore) List(ctx context.Context) ([]*OutboxEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries := make([]*OutboxEntry, 0, len(s.entries))
+	for _, data := range s.entries {
+		var entry OutboxEntry
+		if err := json.Unmarshal(data, &entry); err != nil {
+			return nil, err
+		}
+		entries = append(entries, &entry)
+	}
+	sortOutboxEntries(entries)
+	return entries, nil
+}
+
+// Delete implements OutboxStore.
+func (s *MemoryStore) Delete(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, id)
+	return nil
+}
+
+// FileStore is an OutboxStore that keeps each entry in a JSON file in a
+// directory, so entries survive process restarts. Files are replaced
+// atomically, so a crash never leaves a partially written entry.
+type FileStore struct {
+	dir string
+}
+
+var _ OutboxStore = (*FileStore)(nil)
+
+// NewFileStore returns a FileStore that keeps entries in dir. The directory
+// is created when the first entry is stored.
+func NewFileStore(dir string) *FileStore {
+	return &FileStore{dir: dir}
+}
+
+// Put implements OutboxStore.
+func (s *FileStore) Put(ctx context.Context, entry *OutboxEntry) error {
+	path, err := s.path(entry.ID)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(s.dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// List implements OutboxStore.
+func (s *FileStore) List(ctx context.Context) ([]*OutboxEntry, error) {
+	names, err := filepath.Glob(filepath.Join(s.dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*OutboxEntry, 0, len(names))
+	for _, name := range names {
+		data, err := os.ReadFile(name)
+		if errors.Is(err, fs.ErrNotExist) {
+			// Deleted since the directory was read.
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		var entry OutboxEntry
+		if err := json.Unmarshal(data, &entry); err != nil {
+			return nil, fmt.Errorf("invalid outbox entry %s: %w", name, err)
+		}
+		entries = append(entries, &entry)
+	}
+	sortOutboxEntries(entries)
+	return entries, nil
+}
+
+// Delete implements OutboxStore.
+func (s *FileStore) Delete(ctx context.Context, id string) error {
+	path, err := s.path(id)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// path returns the file of the entry with the given ID.
+func (s *FileStore) path(id string) (string, error) {
+	if id == "" || strings.ContainsAny(id, `/\.`) {
+		return "", fmt.Errorf("invalid outbox entry id %q", id)
+	}
+	return filepath.Join(s.dir, id+".json"), nil
+}
+
+// sortOutboxEntries sorts entries oldest first.
+func sortOutboxEntries(entries []*OutboxEntry) {
+	sort.Slice(entries, func(i, j int) bool {
+		if !entries[i].CreatedAt.Equal(entries[j].CreatedAt) {
+			return entries[i].CreatedAt.Before(entries[j].CreatedAt)
+		}
+		return entries[i].ID < entries[j].ID
+	})
+}
@@ -0,0 +1,251 @@
+package envloped
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestOutboxStores(t *testing.T) {
+	t.Parallel()
+
+	stores := map[string]OutboxStore{
+		"memory": NewMemoryStore(),
+		"file":   NewFileStore(filepath.Join(t.TempDir(), "outbox")),
+	}
+
+	for name, store := range stores {
+		store := store
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			entries, err := store.List(ctx)
+			if err != nil || len(entries) != 0 {
+				t.Fatalf("expected empty store, got %v, %v", entries, err)
+			}
+
+			now := time.Now().UTC()
+			newer := &OutboxEntry{ID: "b", IdempotencyKey: "key-b", CreatedAt: now.Add(time.Second), Request: &SendEmailRequest{
+				From:                "sender@example.com",
+				To:                  []string{"recipient@example.com"},
+				SuppressAutoReplies: true,
+				Attachments:         []Attachment{{Filename: "a.txt", Content: []byte("hi")}},
+			}}
+			older := &OutboxEntry{ID: "a", CreatedAt: now, Request: &SendEmailRequest{From: "sender@example.com"}}
+			for _, e := range []*OutboxEntry{newer, older} {
+				if err := store.Put(ctx, e); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+			newer.Attempts = 2
+			if err := store.Put(ctx, newer); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			entries, err = store.List(ctx)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(entries) != 2 || entries[0].ID != "a" || entries[1].ID != "b" {
+				t.Fatalf("expected entries a, b, got %+v", entries)
+			}
+			got := entries[1]
+			if got.Attempts != 2 || got.IdempotencyKey != "key-b" {
+				t.Errorf("unexpected entry %+v", got)
+			}
+			if got.Request.Headers["Auto-Submitted"] != "auto-generated" {
+				t.Errorf("expected header options to be stored as headers, got %v", got.Request.Headers)
+			}
+			if len(got.Request.Attachments) != 1 || string(got.Request.Attachments[0].Content) != "hi" {
+				t.Errorf("unexpected attachments %+v", got.Request.Attachments)
+			}
+
+			if err := store.Delete(ctx, "a"); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := store.Delete(ctx, "a"); err != nil {
+				t.Errorf("expected deleting a missing entry to succeed, got %v", err)
+			}
+			entries, _ = store.List(ctx)
+			if len(entries) != 1 || entries[0].ID != "b" {
+				t.Errorf("expected only b to remain, got %+v", entries)
+			}
+		})
+	}
+}
+
+func TestFileStore_InvalidID(t *testing.T) {
+	t.Parallel()
+
+	store := NewFileStore(t.TempDir())
+	if err := store.Put(context.Background(), &OutboxEntry{ID: "../escape"}); err == nil {
+		t.Error("expected error for id with path separators")
+	}
+}
+
+func TestOutbox_Flush(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var keys []string
+	var htmls []string
+	status := map[string]int{
+		"ok@example.com":      http.StatusOK,
+		"later@example.com":   http.StatusServiceUnavailable,
+		"invalid@example.com": http.StatusUnprocessableEntity,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		to := body["to"].([]any)[0].(string)
+
+		mu.Lock()
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		if html, ok := body["html"].(string); ok {
+			htmls = append(htmls, html)
+		}
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status[to])
+		if status[to] == http.StatusOK {
+			w.Write([]byte(`{"success":true,"messageId":"msg_1"}`))
+		} else {
+			w.Write([]byte(`{"error":"failed"}`))
+		}
+	}))
+	defer server.Close()
+
+	var failed []*OutboxEntry
+	store := NewFileStore(t.TempDir())
+	outbox := NewOutbox(newTestClient(t, server), store, &OutboxOptions{
+		MaxAttempts: 2,
+		OnFailure:   func(entry *OutboxEntry, err error) { failed = append(failed, entry) },
+	})
+
+	ctx := context.Background()
+	send := func(to string) *SendEmailRequest {
+		return &SendEmailRequest{
+			From:      "sender@example.com",
+			To:        []string{to},
+			Subject:   "Receipt",
+			Html:      "<p>Thanks</p>",
+			Preheader: "Your receipt",
+		}
+	}
+	if _, err := outbox.Enqueue(ctx, &SendEmailRequest{From: "sender@example.com"}); err == nil {
+		t.Error("expected invalid email to be rejected")
+	}
+	okID, err := outbox.Enqueue(ctx, send("ok@example.com"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := outbox.Enqueue(ctx, send("later@example.com")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := outbox.Enqueue(ctx, send("invalid@example.com")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := outbox.Flush(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	entries, _ := store.List(ctx)
+	if len(entries) != 1 || entries[0].Request.To[0] != "later@example.com" || entries[0].Attempts != 1 {
+		t.Fatalf("expected only the retryable email to remain, got %+v", entries)
+	}
+	if !strings.Contains(entries[0].LastError, "status 503") {
+		t.Errorf("expected last error to be recorded, got %q", entries[0].LastError)
+	}
+	if len(failed) != 1 || failed[0].Request.To[0] != "invalid@example.com" {
+		t.Errorf("expected the invalid email to be given up, got %+v", failed)
+	}
+
+	if err := outbox.Flush(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if entries, _ := store.List(ctx); len(entries) != 0 {
+		t.Errorf("expected email to be given up after max attempts, got %+v", entries)
+	}
+	if len(failed) != 2 {
+		t.Errorf("expected 2 failures, got %d", len(failed))
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if keys[0] != okID {
+		t.Errorf("expected entry id as idempotency key, got %q", keys[0])
+	}
+	if keys[1] != keys[3] {
+		t.Errorf("expected retries to reuse the idempotency key, got %q and %q", keys[1], keys[3])
+	}
+	for _, html := range htmls {
+		if strings.Count(html, "Your receipt") != 1 {
+			t.Errorf("expected preheader to be inserted once, got %q", html)
+		}
+	}
+}
+
+func TestOutbox_Run(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"success":true,"messageId":"msg_1"}`))
+	}))
+	defer server.Close()
+
+	store := NewMemoryStore()
+	outbox := NewOutbox(newTestClient(t, server), store, nil)
+	if _, err := outbox.Enqueue(context.Background(), bulkParams(1)[0]); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- outbox.Run(ctx, time.Millisecond) }()
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		entries, _ := store.List(context.Background())
+		if len(entries) == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("expected outbox to be flushed")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestFileStore_SurvivesRestart(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	outbox := NewOutbox(NewClient("key"), NewFileStore(dir), nil)
+	if _, err := outbox.Enqueue(context.Background(), bulkParams(1)[0]); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	files, _ := os.ReadDir(dir)
+	if len(files) != 1 {
+		t.Fatalf("expected 1 file, got %d", len(files))
+	}
+	entries, err := NewFileStore(dir).List(context.Background())
+	if err != nil || len(entries) != 1 {
+		t.Errorf("expected entry to be read by a new store, got %v, %v", entries, err)
+	}
+}
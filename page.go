@@ -0,0 +1,87 @@
+package envloped
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Page is a single page of results from a List endpoint. Pass NextCursor as
+// the Cursor of the list options to fetch the following page.
+type Page[T any] struct {
+	// Items holds the items on this page, in the order documented by the
+	// List method.
+	Items []T `json:"data"`
+
+	// NextCursor is the cursor of the following page, or empty on the last page.
+	NextCursor string `json:"nextCursor"`
+
+	// HasMore reports whether a page follows this one.
+	HasMore bool `json:"hasMore"`
+}
+
+// pageJSON is the JSON representation of a Page.
+type pageJSON[T any] Page[T]
+
+// UnmarshalJSON implements json.Unmarshaler. HasMore is derived from
+// NextCursor if the response does not include it.
+func (p *Page[T]) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, (*pageJSON[T])(p)); err != nil {
+		return err
+	}
+	p.HasMore = p.HasMore || p.NextCursor != ""
+	return nil
+}
+
+// ListOptions holds the pagination and time range options shared by List
+// endpoints. Endpoint-specific options embed it. Zero-valued fields are not
+// applied.
+type ListOptions struct {
+	// Limit is the maximum number of items per page. The API default applies when zero.
+	Limit int
+
+	// Cursor is the NextCursor of the previous page. Leave empty for the first page.
+	Cursor string
+
+	// Since only lists items created at or after this time.
+	Since time.Time
+
+	// Until only lists items created before this time.
+	Until time.Time
+}
+
+// validate checks the options for values the API would reject.
+func (o *ListOptions) validate() error {
+	if o.Limit < 0 {
+		return fmt.Errorf("envloped: limit must not be negative")
+	}
+	if !o.Since.IsZero() && !o.Until.IsZero() && !o.Since.Before(o.Until) {
+		return fmt.Errorf("envloped: since must be before until")
+	}
+	return nil
+}
+
+// values encodes the options as URL query parameters.
+func (o *ListOptions) values() url.Values {
+	q := url.Values{}
+	if o.Limit > 0 {
+		q.Set("limit", strconv.Itoa(o.Limit))
+	}
+	if o.Cursor != "" {
+		q.Set("cursor", o.Cursor)
+	}
+	setTimeRange(q, o.Since, o.Until)
+	return q
+}
+
+// setTimeRange sets the since and until query parameters of q, if non-zero.
+func setTimeRange(q url.Values, since, until time.Time) {
+	if !since.IsZero() {
+		q.Set("since", since.UTC().Format(time.RFC3339Nano))
+	}
+	if !until.IsZero() {
+		q.Set("until", until.UTC().Format(time.RFC3339Nano))
+	}
+}
@@ -0,0 +1,70 @@
+package envloped
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestListOptions(t *testing.T) {
+	t.Parallel()
+
+	since := time.Date(2025, 1, 1, 0, 0, 0, 500, time.FixedZone("CET", 3600))
+	o := &ListOptions{Limit: 50, Cursor: "cur_1", Since: since}
+	if err := o.validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := o.values().Encode(); got != "cursor=cur_1&limit=50&since=2024-12-31T23%3A00%3A00.0000005Z" {
+		t.Errorf("unexpected query %q", got)
+	}
+	if got := (&ListOptions{}).values(); len(got) != 0 {
+		t.Errorf("expected empty query, got %v", got)
+	}
+
+	invalid := []ListOptions{
+		{Limit: -1},
+		{Since: since, Until: since},
+		{Since: since, Until: since.Add(-time.Second)},
+	}
+	for _, o := range invalid {
+		o := o
+		if err := o.validate(); err == nil {
+			t.Errorf("expected error for %+v", o)
+		}
+	}
+}
+
+func TestPage_UnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		body    string
+		hasMore bool
+	}{
+		{"last page", `{"data":[{"id":"msg_1"}]}`, false},
+		{"cursor only", `{"data":[{"id":"msg_1"}],"nextCursor":"cur_2"}`, true},
+		{"has more", `{"data":[],"nextCursor":"cur_2","hasMore":true}`, true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var page EmailPage
+			if err := json.Unmarshal([]byte(tt.body), &page); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if page.HasMore != tt.hasMore {
+				t.Errorf("expected HasMore %v, got %v", tt.hasMore, page.HasMore)
+			}
+		})
+	}
+
+	var page EmailPage
+	if err := json.Unmarshal([]byte(`{"data":[{"id":"msg_1"}]}`), &page); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(page.Items) != 1 || page.Items[0].ID != "msg_1" {
+		t.Errorf("unexpected items %+v", page.Items)
+	}
+}
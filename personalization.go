@@ -0,0 +1,40 @@
+package envloped
+
+import "fmt"
+
+// Personalization is one individualized copy of an email within a single send
+// request. Each personalization is delivered as a separate message to its own
+// recipients, with its own variables and optional overrides.
+type Personalization struct {
+	// To is the list of recipients of this copy.
+	To []string `json:"to"`
+
+	// Data holds the variables for this copy. They are substituted into the
+	// template, or into {{name}} placeholders of Subject, Html, and Text, and
+	// take precedence over the request's TemplateData.
+	Data map[string]any `json:"data,omitempty"`
+
+	// Subject overrides the request's subject line for this copy.
+	Subject string `json:"subject,omitempty"`
+
+	// Headers are merged over the request's custom headers for this copy.
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// validatePersonalizations checks each personalization's recipients and headers.
+// It reports whether every personalization overrides the subject.
+func validatePersonalizations(list []Personalization) (allHaveSubject bool, err error) {
+	allHaveSubject = true
+	for i, p := range list {
+		if len(p.To) == 0 {
+			return false, fieldError(fmt.Sprintf("personalizations[%d].to", i), "required", "personalization %d: at least one to address is required", i)
+		}
+		if err := validateHeaders(fmt.Sprintf("personalizations[%d].headers", i), p.Headers); err != nil {
+			return false, err
+		}
+		if p.Subject == "" {
+			allHaveSubject = false
+		}
+	}
+	return allHaveSubject, nil
+}
@@ -0,0 +1,128 @@
+package envloped
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSendEmail_Personalizations(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+
+		if _, ok := body["to"]; ok {
+			t.Errorf("expected top-level to to be omitted, got %v", body["to"])
+		}
+		list, _ := body["personalizations"].([]interface{})
+		if len(list) != 2 {
+			t.Fatalf("expected 2 personalizations, got %v", body["personalizations"])
+		}
+		first, _ := list[0].(map[string]interface{})
+		if data, _ := first["data"].(map[string]interface{}); data["name"] != "Ann" {
+			t.Errorf("expected data.name Ann, got %v", first["data"])
+		}
+		if first["subject"] != "Hi Ann" {
+			t.Errorf("expected subject override, got %v", first["subject"])
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"success":true,"messageIds":["msg_1","msg_2"]}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	resp, err := client.Emails.Send(&SendEmailRequest{
+		From:    "sender@example.com",
+		Subject: "Hello",
+		Html:    "<p>Hello {{name}}</p>",
+		Personalizations: []Personalization{
+			{To: []string{"ann@example.com"}, Data: map[string]any{"name": "Ann"}, Subject: "Hi Ann"},
+			{To: []string{"bob@example.com"}, Data: map[string]any{"name": "Bob"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.MessageIds) != 2 || resp.MessageIds[1] != "msg_2" {
+		t.Errorf("unexpected message ids %v", resp.MessageIds)
+	}
+}
+
+func TestSendEmail_PersonalizationValidation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		params  *SendEmailRequest
+		limits  *Limits
+		wantErr string
+	}{
+		{
+			name: "to alongside personalizations",
+			params: &SendEmailRequest{From: "a@b.com", To: []string{"b@c.com"}, Subject: "s", Html: "h",
+				Personalizations: []Personalization{{To: []string{"c@d.com"}}}},
+			wantErr: "to must be empty when personalizations are set",
+		},
+		{
+			name: "personalization without recipients",
+			params: &SendEmailRequest{From: "a@b.com", Subject: "s", Html: "h",
+				Personalizations: []Personalization{{To: []string{"c@d.com"}}, {}}},
+			wantErr: "personalization 1: at least one to address is required",
+		},
+		{
+			name: "reserved personalization header",
+			params: &SendEmailRequest{From: "a@b.com", Subject: "s", Html: "h",
+				Personalizations: []Personalization{{To: []string{"c@d.com"}, Headers: map[string]string{"To": "x@y.com"}}}},
+			wantErr: "is reserved",
+		},
+		{
+			name: "subject missing on some copies",
+			params: &SendEmailRequest{From: "a@b.com", Html: "h",
+				Personalizations: []Personalization{{To: []string{"c@d.com"}, Subject: "s"}, {To: []string{"d@e.com"}}}},
+			wantErr: "subject is required",
+		},
+		{
+			name: "too many personalizations",
+			params: &SendEmailRequest{From: "a@b.com", Subject: "s", Html: "h",
+				Personalizations: []Personalization{{To: []string{"c@d.com"}}, {To: []string{"d@e.com"}}}},
+			limits:  &Limits{MaxBatchSize: 1},
+			wantErr: "too many personalizations (2)",
+		},
+		{
+			name: "too many recipients in a copy",
+			params: &SendEmailRequest{From: "a@b.com", Subject: "s", Html: "h",
+				Personalizations: []Personalization{{To: []string{"c@d.com", "d@e.com"}}}},
+			limits:  &Limits{MaxRecipients: 1},
+			wantErr: "too many recipients (2)",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := NewClient("key").WithLimits(tt.limits)
+			_, err := client.Emails.Send(tt.params)
+			if err == nil || !contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestSendEmail_PersonalizedSubjects(t *testing.T) {
+	t.Parallel()
+
+	params := &SendEmailRequest{From: "a@b.com", Html: "h",
+		Personalizations: []Personalization{{To: []string{"c@d.com"}, Subject: "s1"}, {To: []string{"d@e.com"}, Subject: "s2"}}}
+	if err := validateSendEmailRequest(params); err != nil {
+		t.Errorf("expected per-copy subjects to satisfy the subject requirement, got %v", err)
+	}
+}
@@ -0,0 +1,36 @@
+package envloped
+
+import (
+	"html"
+	"strings"
+)
+
+// preheaderPadding follows the preheader so inbox previews do not continue
+// with the start of the visible body.
+var preheaderPadding = strings.Repeat("&#847;&zwnj;&nbsp;", 50)
+
+// withPreheader returns params with Preheader inserted into Html. params
+// itself is not modified.
+func (r *SendEmailRequest) withPreheader() *SendEmailRequest {
+	if r.Preheader == "" || r.Html == "" {
+		return r
+	}
+	cp := *r
+	cp.Html = insertPreheader(r.Html, r.Preheader)
+	return &cp
+}
+
+// insertPreheader inserts text as a hidden element right after the opening
+// <body> tag of doc, or at its start if it has none.
+func insertPreheader(doc, text string) string {
+	block := `<div style="display:none;font-size:1px;line-height:1px;max-height:0;max-width:0;opacity:0;overflow:hidden;mso-hide:all">` +
+		html.EscapeString(text) + preheaderPadding + `</div>`
+
+	if i := indexFold(doc, "<body"); i >= 0 {
+		if end := strings.IndexByte(doc[i:], '>'); end >= 0 {
+			at := i + end + 1
+			return doc[:at] + block + doc[at:]
+		}
+	}
+	return block + doc
+}
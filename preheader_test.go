@@ -0,0 +1,88 @@
+package envloped
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestInsertPreheader(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		doc        string
+		wantPrefix string
+		wantSuffix string
+	}{
+		{"fragment", "<p>Hi</p>", `<div style="display:none;`, "</div><p>Hi</p>"},
+		{"document", `<html><BODY class="x"><p>Hi</p></BODY></html>`, `<html><BODY class="x"><div style="display:none;`, "</div><p>Hi</p></BODY></html>"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := insertPreheader(tt.doc, "Your <order> shipped")
+			if !strings.HasPrefix(got, tt.wantPrefix) || !strings.HasSuffix(got, tt.wantSuffix) {
+				t.Errorf("unexpected result %q", got)
+			}
+			if !strings.Contains(got, "Your &lt;order&gt; shipped&#847;") {
+				t.Errorf("expected escaped preheader followed by padding, got %q", got)
+			}
+		})
+	}
+}
+
+func TestSendEmail_Preheader(t *testing.T) {
+	t.Parallel()
+
+	var body map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"success":true,"messageId":"msg_1"}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	params := &SendEmailRequest{
+		From:      "sender@example.com",
+		To:        []string{"recipient@example.com"},
+		Subject:   "Shipped",
+		Html:      "<p>Hello</p>",
+		Preheader: "Arriving Tuesday",
+		AutoText:  true,
+	}
+	if _, err := client.Emails.Send(params); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	html, _ := body["html"].(string)
+	if !strings.Contains(html, "Arriving Tuesday") || !strings.HasSuffix(html, "<p>Hello</p>") {
+		t.Errorf("expected preheader in html, got %q", html)
+	}
+	if body["text"] != "Hello" {
+		t.Errorf("expected text without preheader, got %v", body["text"])
+	}
+	if _, ok := body["preheader"]; ok {
+		t.Error("expected preheader not to be sent as a field")
+	}
+	if params.Html != "<p>Hello</p>" {
+		t.Errorf("expected caller's params to be unmodified, got %q", params.Html)
+	}
+
+	_, err := client.Emails.Send(&SendEmailRequest{
+		From:      "sender@example.com",
+		To:        []string{"recipient@example.com"},
+		Subject:   "Shipped",
+		Text:      "Hello",
+		Preheader: "Arriving Tuesday",
+	})
+	if err == nil || !contains(err.Error(), "preheader requires an html body") {
+		t.Errorf("expected preheader validation error, got %v", err)
+	}
+}
@@ -0,0 +1,23 @@
+module github.com/envloped/envloped-go/promenvloped
+
+go 1.21
+
+require (
+	github.com/envloped/envloped-go v1.1.0
+	github.com/prometheus/client_golang v1.19.1
+)
+
+require (
+	github.com/beorn7/perks v1.0.1 // indirect
+	github.com/cespare/xxhash/v2 v2.2.0 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/prometheus/client_model v0.5.0 // indirect
+	github.com/prometheus/common v0.48.0 // indirect
+	github.com/prometheus/procfs v0.12.0 // indirect
+	golang.org/x/sys v0.17.0 // indirect
+	google.golang.org/protobuf v1.33.0 // indirect
+)
+
+// v1.1.0 is the first release with the Metrics API. The replace directive only
+// applies when developing in this repository.
+replace github.com/envloped/envloped-go => ../
@@ -0,0 +1,127 @@
+// Package promenvloped provides Prometheus metrics for the Envloped Go SDK.
+//
+// It lives in its own module so the core SDK stays free of third-party
+// dependencies.
+//
+// Usage:
+//
+//	metrics := promenvloped.NewMetrics(promenvloped.WithRegisterer(prometheus.DefaultRegisterer))
+//	client := envloped.NewClient("ev_your_api_key").WithMetrics(metrics)
+package promenvloped
+
+import (
+	"strconv"
+
+	envloped "github.com/envloped/envloped-go"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metric label names.
+const (
+	methodLabel = "method"
+	routeLabel  = "route"
+	statusLabel = "status"
+)
+
+// config holds the metrics options.
+type config struct {
+	registerer prometheus.Registerer
+	namespace  string
+	buckets    []float64
+}
+
+// Option configures the metrics returned by NewMetrics.
+type Option func(*config)
+
+// WithRegisterer sets the Registerer the metrics are registered with.
+// Defaults to prometheus.DefaultRegisterer.
+func WithRegisterer(registerer prometheus.Registerer) Option {
+	return func(c *config) {
+		if registerer != nil {
+			c.registerer = registerer
+		}
+	}
+}
+
+// WithNamespace sets the namespace prefixed to the metric names. Defaults to
+// "envloped".
+func WithNamespace(namespace string) Option {
+	return func(c *config) {
+		c.namespace = namespace
+	}
+}
+
+// WithBuckets sets the buckets of the latency histogram, in seconds.
+// Defaults to prometheus.DefBuckets.
+func WithBuckets(buckets []float64) Option {
+	return func(c *config) {
+		if len(buckets) > 0 {
+			c.buckets = buckets
+		}
+	}
+}
+
+// Metrics implements envloped.Metrics with Prometheus collectors:
+//
+//   - envloped_requests_total counts requests by method, route, and status;
+//     the status is "error" if no response was received.
+//   - envloped_request_duration_seconds is a histogram of request latency by
+//     method and route.
+//   - envloped_retries_total counts retries by method and route.
+type Metrics struct {
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	retries  *prometheus.CounterVec
+}
+
+var _ envloped.Metrics = (*Metrics)(nil)
+
+// NewMetrics creates the collectors and registers them. It panics if they
+// are already registered with the Registerer, like prometheus.MustRegister;
+// share one Metrics between clients instead.
+func NewMetrics(opts ...Option) *Metrics {
+	cfg := config{
+		registerer: prometheus.DefaultRegisterer,
+		namespace:  "envloped",
+		buckets:    prometheus.DefBuckets,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	m := &Metrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: cfg.namespace,
+			Name:      "requests_total",
+			Help:      "Number of requests to the Envloped API.",
+		}, []string{methodLabel, routeLabel, statusLabel}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: cfg.namespace,
+			Name:      "request_duration_seconds",
+			Help:      "Latency of requests to the Envloped API.",
+			Buckets:   cfg.buckets,
+		}, []string{methodLabel, routeLabel}),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: cfg.namespace,
+			Name:      "retries_total",
+			Help:      "Number of requests to the Envloped API that were retried.",
+		}, []string{methodLabel, routeLabel}),
+	}
+	cfg.registerer.MustRegister(m.requests, m.duration, m.retries)
+	return m
+}
+
+// ObserveRequest implements envloped.Metrics.
+func (m *Metrics) ObserveRequest(r envloped.RequestMetric) {
+	status := "error"
+	if r.StatusCode != 0 {
+		status = strconv.Itoa(r.StatusCode)
+	}
+	m.requests.WithLabelValues(r.Method, r.Route, status).Inc()
+	m.duration.WithLabelValues(r.Method, r.Route).Observe(r.Duration.Seconds())
+}
+
+// ObserveRetry implements envloped.Metrics.
+func (m *Metrics) ObserveRetry(method, route string) {
+	m.retries.WithLabelValues(method, route).Inc()
+}
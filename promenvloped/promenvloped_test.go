@@ -0,0 +1,85 @@
+package promenvloped
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	envloped "github.com/envloped/envloped-go"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMetrics_RecordsRequests(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/msg_missing") {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"not found"}`))
+			return
+		}
+		w.Write([]byte(`{"id":"msg_1","status":"delivered"}`))
+	}))
+	defer server.Close()
+
+	registry := prometheus.NewRegistry()
+	metrics := NewMetrics(WithRegisterer(registry))
+	client := envloped.NewClient("key").WithBaseURL(server.URL).WithMetrics(metrics)
+
+	if _, err := client.Emails.Get("msg_1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.Emails.Get("msg_2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.Emails.Get("msg_missing"); !errors.Is(err, envloped.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+
+	if got := testutil.ToFloat64(metrics.requests.WithLabelValues("GET", "/v1/emails/{id}", "200")); got != 2 {
+		t.Errorf("expected 2 successful requests, got %v", got)
+	}
+	if got := testutil.ToFloat64(metrics.requests.WithLabelValues("GET", "/v1/emails/{id}", "404")); got != 1 {
+		t.Errorf("expected 1 failed request, got %v", got)
+	}
+	if got := testutil.CollectAndCount(metrics.duration); got != 1 {
+		t.Errorf("expected 1 latency series, got %d", got)
+	}
+
+	metrics.ObserveRetry("POST", "/v1/emails")
+	if got := testutil.ToFloat64(metrics.retries.WithLabelValues("POST", "/v1/emails")); got != 1 {
+		t.Errorf("expected 1 retry, got %v", got)
+	}
+}
+
+func TestMetrics_TransportError(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	registry := prometheus.NewRegistry()
+	metrics := NewMetrics(WithRegisterer(registry), WithNamespace("mail"))
+	client := envloped.NewClient("key").WithBaseURL(server.URL).WithMetrics(metrics)
+
+	if _, err := client.Emails.Get("msg_1"); err == nil {
+		t.Fatal("expected error")
+	}
+	if got := testutil.ToFloat64(metrics.requests.WithLabelValues("GET", "/v1/emails/{id}", "error")); got != 1 {
+		t.Errorf("expected 1 transport error, got %v", got)
+	}
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, f := range families {
+		if !strings.HasPrefix(f.GetName(), "mail_") {
+			t.Errorf("expected namespace prefix, got %s", f.GetName())
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package envloped
+
+import (
+	"fmt"
+	"time"
+)
+
+// WithQuotaGuard makes the client refuse requests locally, with a
+// *QuotaExhaustedError, while the last reported rate limit shows no requests
+// remaining and its reset time has not passed. This saves round trips that
+// would certainly be rejected, e.g. during bulk jobs.
+// Returns the client for method chaining.
+func (c *Client) WithQuotaGuard(enabled bool) *Client {
+	c.quotaGuard = enabled
+	return c
+}
+
+// QuotaExhaustedError is returned by the quota guard when a request is refused
+// without contacting the API. See Client.WithQuotaGuard.
+type QuotaExhaustedError struct {
+	// Reset is when the quota is replenished and requests may be retried.
+	Reset time.Time
+}
+
+// Error implements the error interface.
+func (e *QuotaExhaustedError) Error() string {
+	return fmt.Sprintf("envloped: quota exhausted until %s", e.Reset.Format(time.RFC3339))
+}
+
+// Is enables sentinel error matching via errors.Is().
+func (e *QuotaExhaustedError) Is(target error) bool {
+	return target == ErrQuotaExhausted
+}
+
+// checkQuota returns a *QuotaExhaustedError if the quota guard is enabled and
+// the last reported rate limit is exhausted at now. A rate limit without a
+// known Remaining is never exhausted.
+func (c *Client) checkQuota(now time.Time) error {
+	if !c.quotaGuard {
+		return nil
+	}
+	rl := c.rateLimit.Load()
+	if rl == nil || rl.Remaining != 0 || rl.Reset.IsZero() || !now.Before(rl.Reset) {
+		return nil
+	}
+	return &QuotaExhaustedError{Reset: rl.Reset}
+}
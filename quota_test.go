@@ -0,0 +1,79 @@
+package envloped
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestQuotaGuard_RefusesWhileExhausted(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("X-RateLimit-Limit", "10")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+		w.Write([]byte(`{"message":"pong"}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server).WithQuotaGuard(true)
+
+	if _, err := client.Ping(); err != nil {
+		t.Fatalf("unexpected error on first request: %v", err)
+	}
+
+	_, err := client.Ping()
+	if !errors.Is(err, ErrQuotaExhausted) {
+		t.Fatalf("expected ErrQuotaExhausted, got %v", err)
+	}
+	var quotaErr *QuotaExhaustedError
+	if !errors.As(err, &quotaErr) || quotaErr.Reset.IsZero() {
+		t.Errorf("expected *QuotaExhaustedError with reset time, got %v", err)
+	}
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Errorf("expected 1 API call, got %d", n)
+	}
+}
+
+func TestCheckQuota(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		guard   bool
+		limit   *RateLimit
+		wantErr bool
+	}{
+		{"guard disabled", false, &RateLimit{Remaining: 0, Reset: now.Add(time.Minute)}, false},
+		{"no rate limit seen", true, nil, false},
+		{"requests remaining", true, &RateLimit{Remaining: 3, Reset: now.Add(time.Minute)}, false},
+		{"reset passed", true, &RateLimit{Remaining: 0, Reset: now.Add(-time.Second)}, false},
+		{"unknown reset", true, &RateLimit{Remaining: 0}, false},
+		{"unknown remaining", true, &RateLimit{Limit: 100, Remaining: -1, Reset: now.Add(time.Minute)}, false},
+		{"exhausted", true, &RateLimit{Remaining: 0, Reset: now.Add(time.Minute)}, true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := NewClient("key").WithQuotaGuard(tt.guard)
+			client.rateLimit.Store(tt.limit)
+
+			err := client.checkQuota(now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkQuota() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
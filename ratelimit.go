@@ -0,0 +1,117 @@
+package envloped
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// rateLimitMaxRetries is how many times a rate limited call is retried
+	// by retryRateLimited.
+	rateLimitMaxRetries = 3
+
+	// rateLimitInitialBackoff is the wait before retrying a rate limited
+	// call when the API did not report when the limit resets.
+	rateLimitInitialBackoff = time.Second
+
+	// rateLimitMaxBackoff caps the wait before retrying a rate limited call.
+	rateLimitMaxBackoff = 30 * time.Second
+)
+
+// RateLimit describes the API request quota reported by the X-RateLimit-*
+// response headers.
+type RateLimit struct {
+	// Limit is the number of requests allowed in the current window, or -1
+	// if the response did not report it.
+	Limit int
+
+	// Remaining is the number of requests left in the current window, or -1
+	// if the response did not report it.
+	Remaining int
+
+	// Reset is when the current window ends and Remaining is replenished.
+	Reset time.Time
+}
+
+// epochThreshold separates X-RateLimit-Reset values given as Unix timestamps
+// from values given as seconds until the reset.
+const epochThreshold = 1_000_000_000
+
+// parseRateLimit extracts the rate limit headers of a response received at
+// now. It returns nil if the response carries no rate limit headers.
+func parseRateLimit(h http.Header, now time.Time) *RateLimit {
+	limit, errLimit := strconv.Atoi(h.Get("X-RateLimit-Limit"))
+	remaining, errRemaining := strconv.Atoi(h.Get("X-RateLimit-Remaining"))
+	if errLimit != nil && errRemaining != nil {
+		return nil
+	}
+
+	rl := &RateLimit{Limit: limit, Remaining: remaining}
+	if errLimit != nil {
+		rl.Limit = -1
+	}
+	if errRemaining != nil {
+		rl.Remaining = -1
+	}
+	if reset, err := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		if reset >= epochThreshold {
+			rl.Reset = time.Unix(reset, 0)
+		} else {
+			rl.Reset = now.Add(time.Duration(reset) * time.Second)
+		}
+	}
+	return rl
+}
+
+// RateLimit returns the rate limit reported by the most recent API response
+// that carried rate limit headers, or nil if none has been seen yet.
+// Use it to slow down before the API starts rejecting requests.
+func (c *Client) RateLimit() *RateLimit {
+	rl := c.rateLimit.Load()
+	if rl == nil {
+		return nil
+	}
+	cp := *rl
+	return &cp
+}
+
+// recordRateLimit remembers the rate limit reported by resp, if any.
+func (c *Client) recordRateLimit(resp *http.Response) {
+	if rl := parseRateLimit(resp.Header, time.Now()); rl != nil {
+		c.rateLimit.Store(rl)
+	}
+}
+
+// retryRateLimited calls fn, retrying while it fails because of the rate
+// limit or quota. It waits until the limit resets, if the API reported when,
+// or with exponential backoff otherwise, and gives up after
+// rateLimitMaxRetries retries or when ctx is done, returning the last error.
+// method and route label the retries for Metrics. fn is called with a
+// context that reports the attempt to the Tracer.
+func (c *Client) retryRateLimited(ctx context.Context, method, route string, fn func(ctx context.Context) error) error {
+	backoff := rateLimitInitialBackoff
+	for attempt := 0; ; attempt++ {
+		err := fn(withRetryCount(ctx, attempt))
+		if err == nil || attempt == rateLimitMaxRetries ||
+			!(errors.Is(err, ErrRateLimited) || errors.Is(err, ErrQuotaExhausted)) {
+			return err
+		}
+
+		wait := backoff
+		if reset := rateLimitReset(c, err); !reset.IsZero() {
+			wait = time.Until(reset)
+		}
+		wait = min(max(wait, 0), rateLimitMaxBackoff)
+		backoff *= 2
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(wait):
+		}
+		c.observeRetry(method, route)
+	}
+}
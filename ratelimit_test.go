@@ -0,0 +1,117 @@
+package envloped
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestParseRateLimit(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    *RateLimit
+	}{
+		{
+			name:    "no headers",
+			headers: nil,
+			want:    nil,
+		},
+		{
+			name: "reset in seconds",
+			headers: map[string]string{
+				"X-RateLimit-Limit":     "100",
+				"X-RateLimit-Remaining": "42",
+				"X-RateLimit-Reset":     "30",
+			},
+			want: &RateLimit{Limit: 100, Remaining: 42, Reset: now.Add(30 * time.Second)},
+		},
+		{
+			name: "reset as unix timestamp",
+			headers: map[string]string{
+				"X-RateLimit-Limit":     "100",
+				"X-RateLimit-Remaining": "0",
+				"X-RateLimit-Reset":     "1704110460",
+			},
+			want: &RateLimit{Limit: 100, Remaining: 0, Reset: time.Unix(1704110460, 0)},
+		},
+		{
+			name:    "remaining only",
+			headers: map[string]string{"X-RateLimit-Remaining": "5"},
+			want:    &RateLimit{Limit: -1, Remaining: 5},
+		},
+		{
+			name: "limit and reset only",
+			headers: map[string]string{
+				"X-RateLimit-Limit": "100",
+				"X-RateLimit-Reset": "30",
+			},
+			want: &RateLimit{Limit: 100, Remaining: -1, Reset: now.Add(30 * time.Second)},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			h := http.Header{}
+			for k, v := range tt.headers {
+				h.Set(k, v)
+			}
+			got := parseRateLimit(h, now)
+
+			if tt.want == nil {
+				if got != nil {
+					t.Fatalf("expected nil, got %+v", got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatal("expected rate limit, got nil")
+			}
+			if got.Limit != tt.want.Limit || got.Remaining != tt.want.Remaining || !got.Reset.Equal(tt.want.Reset) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClientRateLimit(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "10")
+		w.Header().Set("X-RateLimit-Remaining", "7")
+		w.Header().Set("X-RateLimit-Reset", "60")
+		w.Write([]byte(`{"message":"pong"}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	if rl := client.RateLimit(); rl != nil {
+		t.Fatalf("expected no rate limit before first request, got %+v", rl)
+	}
+
+	var meta ResponseMeta
+	if _, err := client.PingWithContext(CaptureResponseMeta(context.Background(), &meta)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rl := client.RateLimit()
+	if rl == nil || rl.Limit != 10 || rl.Remaining != 7 {
+		t.Fatalf("unexpected client rate limit: %+v", rl)
+	}
+	if rl.Reset.IsZero() {
+		t.Error("expected reset time to be set")
+	}
+	if meta.RateLimit == nil || meta.RateLimit.Remaining != 7 {
+		t.Errorf("unexpected response rate limit: %+v", meta.RateLimit)
+	}
+}
@@ -0,0 +1,46 @@
+package envloped
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// Region is an Envloped data region. Emails sent through a regional API
+// endpoint, and the data stored about them, stay in that region.
+type Region string
+
+// Supported regions.
+const (
+	RegionUS Region = "us"
+	RegionEU Region = "eu"
+)
+
+// regionBaseURLs maps each region to its API endpoint.
+var regionBaseURLs = map[Region]*url.URL{
+	RegionUS: {Scheme: "https", Host: "api.envloped.com"},
+	RegionEU: {Scheme: "https", Host: "api.eu.envloped.com"},
+}
+
+// WithRegion makes the client use the API endpoint of region, for data
+// residency requirements such as GDPR. Requests fail if the region is
+// unknown or a custom base URL is also set with WithBaseURL.
+// Returns the client for method chaining.
+func (c *Client) WithRegion(region Region) *Client {
+	c.region = region
+	return c
+}
+
+// endpoint returns the base URL requests are resolved against.
+func (c *Client) endpoint() (*url.URL, error) {
+	if c.region == "" {
+		return c.baseURL, nil
+	}
+	if c.customBaseURL {
+		return nil, fmt.Errorf("envloped: region %q and a custom base URL are both set", c.region)
+	}
+	u, ok := regionBaseURLs[c.region]
+	if !ok {
+		return nil, fmt.Errorf("envloped: unknown region %q", c.region)
+	}
+	return u, nil
+}
@@ -0,0 +1,46 @@
+package envloped
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestWithRegion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		client  *Client
+		wantURL string
+		wantErr string
+	}{
+		{"default", NewClient("k"), "https://api.envloped.com/v1/ping", ""},
+		{"eu", NewClient("k").WithRegion(RegionEU), "https://api.eu.envloped.com/v1/ping", ""},
+		{"us", NewClient("k").WithRegion(RegionUS), "https://api.envloped.com/v1/ping", ""},
+		{"unknown", NewClient("k").WithRegion("mars"), "", `unknown region "mars"`},
+		{"with base URL", NewClient("k").WithBaseURL("https://proxy.example.com").WithRegion(RegionEU), "", "custom base URL are both set"},
+		{"cloned with base URL", NewClient("k").WithRegion(RegionEU).Clone(WithBaseURL("https://proxy.example.com")), "", "custom base URL are both set"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req, err := tt.client.newRequest(context.Background(), http.MethodGet, "/v1/ping", "/v1/ping", nil)
+			if tt.wantErr != "" {
+				if err == nil || !contains(err.Error(), tt.wantErr) {
+					t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := req.URL.String(); got != tt.wantURL {
+				t.Errorf("expected URL %q, got %q", tt.wantURL, got)
+			}
+		})
+	}
+}
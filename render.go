@@ -0,0 +1,37 @@
+package envloped
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+)
+
+// RenderTemplate executes tmpl with data and returns the result as the HTML
+// body, together with a plain text body derived from it with HTMLToText:
+//
+//	tmpl := template.Must(template.ParseFiles("welcome.html"))
+//	html, text, err := envloped.RenderTemplate(tmpl, user)
+//	if err != nil {
+//	    return err
+//	}
+//	params := &envloped.SendEmailRequest{
+//	    // ...
+//	    Html: html,
+//	    Text: text,
+//	}
+//
+// Because tmpl is an html/template, values in data are escaped for the
+// context they appear in.
+func RenderTemplate(tmpl *template.Template, data any) (html, text string, err error) {
+	if tmpl == nil {
+		return "", "", fmt.Errorf("envloped: template must not be nil")
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", "", fmt.Errorf("envloped: failed to render template %q: %w", tmpl.Name(), err)
+	}
+
+	html = buf.String()
+	return html, HTMLToText(html), nil
+}
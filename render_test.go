@@ -0,0 +1,45 @@
+package envloped
+
+import (
+	"html/template"
+	"testing"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	t.Parallel()
+
+	tmpl := template.Must(template.New("welcome").Parse(
+		`<h1>Hi {{.Name}}</h1><p>Your plan: <a href="{{.URL}}">{{.Plan}}</a></p>`))
+
+	html, text, err := RenderTemplate(tmpl, map[string]string{
+		"Name": "<Bo & Co>",
+		"Plan": "Pro",
+		"URL":  "https://example.com/plans?p=pro&x=1",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wantHTML := `<h1>Hi &lt;Bo &amp; Co&gt;</h1><p>Your plan: <a href="https://example.com/plans?p=pro&amp;x=1">Pro</a></p>`
+	if html != wantHTML {
+		t.Errorf("html = %q, want %q", html, wantHTML)
+	}
+	wantText := "Hi <Bo & Co>\n\nYour plan: Pro (https://example.com/plans?p=pro&x=1)"
+	if text != wantText {
+		t.Errorf("text = %q, want %q", text, wantText)
+	}
+}
+
+func TestRenderTemplate_Errors(t *testing.T) {
+	t.Parallel()
+
+	if _, _, err := RenderTemplate(nil, nil); err == nil || !contains(err.Error(), "template must not be nil") {
+		t.Errorf("expected nil template error, got %v", err)
+	}
+
+	tmpl := template.Must(template.New("broken").Option("missingkey=error").Parse(`{{.Missing}}`))
+	_, _, err := RenderTemplate(tmpl, map[string]string{})
+	if err == nil || !contains(err.Error(), `failed to render template "broken"`) {
+		t.Errorf("expected render error, got %v", err)
+	}
+}
@@ -0,0 +1,104 @@
+package envloped
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// RequestOption customizes a single API call, without changing the client
+// shared by other calls.
+//
+//	resp, err := client.Emails.SendWithContext(ctx, params,
+//	    envloped.WithIdempotencyKey("order-1234"),
+//	    envloped.WithTimeout(5*time.Second),
+//	)
+type RequestOption func(*requestOptions)
+
+// requestOptions holds the settings of a single API call.
+type requestOptions struct {
+	header  http.Header
+	timeout time.Duration
+	dryRun  bool
+}
+
+// WithHeader sets an HTTP header on the API request, replacing any value
+// the SDK would send. To set a header on the email itself, use
+// SendEmailRequest.Headers instead.
+func WithHeader(key, value string) RequestOption {
+	return func(o *requestOptions) {
+		if o.header == nil {
+			o.header = make(http.Header)
+		}
+		o.header.Set(key, value)
+	}
+}
+
+// WithTimeout bounds the duration of the call, including client-side checks
+// such as attachment uploads. It applies in addition to any deadline of the
+// context and the timeout of the HTTP client.
+func WithTimeout(d time.Duration) RequestOption {
+	return func(o *requestOptions) {
+		o.timeout = d
+	}
+}
+
+// WithIdempotencyKey sends the call with the given Idempotency-Key header,
+// overriding SendEmailRequest.IdempotencyKey. Retries with the same key
+// never double-deliver.
+func WithIdempotencyKey(key string) RequestOption {
+	return WithHeader("Idempotency-Key", key)
+}
+
+// CallOptions is the combined effect of the RequestOptions passed to a call.
+// It lets wrappers and mocks of the service interfaces, such as those of the
+// envlopedtest package, inspect the options they receive.
+type CallOptions struct {
+	// Header holds the HTTP headers set by WithHeader and
+	// WithIdempotencyKey.
+	Header http.Header
+
+	// Timeout is the timeout set by WithTimeout, or 0.
+	Timeout time.Duration
+
+	// DryRun reports whether WithDryRun was given.
+	DryRun bool
+}
+
+// ResolveRequestOptions returns the combined effect of opts, applied in
+// order.
+func ResolveRequestOptions(opts ...RequestOption) CallOptions {
+	o := newRequestOptions(opts)
+	header := o.header
+	if header == nil {
+		header = http.Header{}
+	}
+	return CallOptions{Header: header, Timeout: o.timeout, DryRun: o.dryRun}
+}
+
+// newRequestOptions applies opts in order.
+func newRequestOptions(opts []RequestOption) *requestOptions {
+	o := &requestOptions{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(o)
+		}
+	}
+	return o
+}
+
+// context returns ctx bounded by the configured timeout, if any. The caller
+// must call the returned cancel function.
+func (o *requestOptions) context(ctx context.Context) (context.Context, context.CancelFunc) {
+	if o.timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, o.timeout)
+}
+
+// apply sets the configured headers on req.
+func (o *requestOptions) apply(req *http.Request) {
+	for key, values := range o.header {
+		req.Header[key] = values
+	}
+}
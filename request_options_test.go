@@ -0,0 +1,89 @@
+package envloped
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSendEmail_RequestOptions(t *testing.T) {
+	t.Parallel()
+
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"success":true,"messageId":"msg_1"}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	_, err := client.Emails.Send(&SendEmailRequest{
+		From:           "sender@example.com",
+		To:             []string{"recipient@example.com"},
+		Subject:        "Hello",
+		Text:           "Hi",
+		IdempotencyKey: "from-params",
+	}, WithHeader("X-Tenant", "acme"), WithIdempotencyKey("from-option"), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := header.Get("X-Tenant"); got != "acme" {
+		t.Errorf("expected X-Tenant acme, got %q", got)
+	}
+	if got := header.Get("Idempotency-Key"); got != "from-option" {
+		t.Errorf("expected Idempotency-Key from option, got %q", got)
+	}
+	if got := header.Get("Authorization"); got != "Bearer test_api_key_123" {
+		t.Errorf("expected Authorization to be kept, got %q", got)
+	}
+}
+
+func TestSendEmail_WithTimeout(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	_, err := client.Emails.SendWithContext(context.Background(), &SendEmailRequest{
+		From:    "sender@example.com",
+		To:      []string{"recipient@example.com"},
+		Subject: "Hello",
+		Text:    "Hi",
+	}, WithTimeout(10*time.Millisecond))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestResolveRequestOptions(t *testing.T) {
+	t.Parallel()
+
+	got := ResolveRequestOptions(
+		WithIdempotencyKey("order-1"),
+		WithHeader("X-Trace", "abc"),
+		WithTimeout(5*time.Second),
+		nil,
+		WithDryRun(),
+	)
+	if got.Header.Get("Idempotency-Key") != "order-1" || got.Header.Get("X-Trace") != "abc" {
+		t.Errorf("unexpected headers %v", got.Header)
+	}
+	if got.Timeout != 5*time.Second || !got.DryRun {
+		t.Errorf("unexpected options %+v", got)
+	}
+
+	if none := ResolveRequestOptions(); none.Header == nil || len(none.Header) != 0 || none.DryRun {
+		t.Errorf("expected empty options, got %+v", none)
+	}
+}
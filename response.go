@@ -0,0 +1,67 @@
+package envloped
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// requestIDHeader is the response header carrying the API request ID.
+const requestIDHeader = "X-Request-Id"
+
+// ResponseMeta holds metadata about an API response.
+type ResponseMeta struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+
+	// Header holds the response headers.
+	Header http.Header
+
+	// RequestID is the value of the X-Request-Id response header.
+	// Include it when contacting support about a request.
+	RequestID string
+
+	// RateLimit is the rate limit reported by the response headers, or nil
+	// if the response carried none.
+	RateLimit *RateLimit
+
+	// Response is the raw HTTP response. Its body has already been consumed.
+	Response *http.Response
+}
+
+// responseMetaKey is the context key under which CaptureResponseMeta stores
+// its destination.
+type responseMetaKey struct{}
+
+// CaptureResponseMeta returns a copy of ctx that makes any API call made with
+// it record the response metadata into meta. Metadata is recorded for error
+// responses too. If several calls share the context, meta holds the last one.
+//
+//	var meta envloped.ResponseMeta
+//	ctx = envloped.CaptureResponseMeta(ctx, &meta)
+//	email, err := client.Emails.GetWithContext(ctx, id)
+//	log.Println(meta.RequestID)
+func CaptureResponseMeta(ctx context.Context, meta *ResponseMeta) context.Context {
+	return context.WithValue(ctx, responseMetaKey{}, meta)
+}
+
+// recordResponseMeta stores metadata about resp into the destination
+// registered on ctx via CaptureResponseMeta, if any.
+func recordResponseMeta(ctx context.Context, resp *http.Response) {
+	meta, ok := ctx.Value(responseMetaKey{}).(*ResponseMeta)
+	if !ok || meta == nil {
+		return
+	}
+	*meta = newResponseMeta(resp)
+}
+
+// newResponseMeta extracts the metadata of resp.
+func newResponseMeta(resp *http.Response) ResponseMeta {
+	return ResponseMeta{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		RequestID:  resp.Header.Get(requestIDHeader),
+		RateLimit:  parseRateLimit(resp.Header, time.Now()),
+		Response:   resp,
+	}
+}
@@ -0,0 +1,161 @@
+package envloped
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCaptureResponseMeta_Success(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req_abc")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"message":"pong","companyId":"c_1"}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+
+	var meta ResponseMeta
+	ctx := CaptureResponseMeta(context.Background(), &meta)
+	if _, err := client.PingWithContext(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if meta.StatusCode != http.StatusOK {
+		t.Errorf("expected status 200, got %d", meta.StatusCode)
+	}
+	if meta.RequestID != "req_abc" {
+		t.Errorf("expected request id req_abc, got %q", meta.RequestID)
+	}
+	if got := meta.Header.Get("Content-Type"); got != "application/json" {
+		t.Errorf("expected Content-Type header, got %q", got)
+	}
+}
+
+func TestCaptureResponseMeta_Error(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req_err")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":"Email not found"}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+
+	var meta ResponseMeta
+	ctx := CaptureResponseMeta(context.Background(), &meta)
+	_, err := client.Emails.GetWithContext(ctx, "msg_1")
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %T", err)
+	}
+	if apiErr.RequestID != "req_err" {
+		t.Errorf("expected error request id req_err, got %q", apiErr.RequestID)
+	}
+	if !contains(err.Error(), "request id req_err") {
+		t.Errorf("expected request id in message, got %q", err.Error())
+	}
+	if meta.StatusCode != http.StatusNotFound || meta.RequestID != "req_err" {
+		t.Errorf("unexpected meta: %+v", meta)
+	}
+}
+
+func TestAPIError_RequestIDOnTypedErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		status int
+		body   string
+	}{
+		{"validation", http.StatusBadRequest, `{"error":"Invalid"}`},
+		{"rate limit", http.StatusTooManyRequests, `{"error":"Too many","message":"Daily limit"}`},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("X-Request-Id", "req_typed")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			_, err := newTestClient(t, server).Ping()
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("expected *APIError, got %T", err)
+			}
+			if apiErr.RequestID != "req_typed" {
+				t.Errorf("expected request id req_typed, got %q", apiErr.RequestID)
+			}
+			if !contains(err.Error(), "request id req_typed") {
+				t.Errorf("expected request id in message, got %q", err.Error())
+			}
+		})
+	}
+}
+
+func TestSendWithResponse(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req_send")
+		w.Header().Set("X-RateLimit-Remaining", "9")
+		w.Write([]byte(`{"success":true,"messageId":"msg_1"}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+
+	resp, meta, err := client.Emails.SendWithResponse(context.Background(), &SendEmailRequest{
+		From:    "sender@example.com",
+		To:      []string{"user@example.com"},
+		Subject: "Hi",
+		Text:    "Hello",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.MessageId != "msg_1" {
+		t.Errorf("expected message id msg_1, got %q", resp.MessageId)
+	}
+	if meta == nil {
+		t.Fatal("expected response metadata")
+	}
+	if meta.RequestID != "req_send" {
+		t.Errorf("expected request id req_send, got %q", meta.RequestID)
+	}
+	if got := meta.Header.Get("X-RateLimit-Remaining"); got != "9" {
+		t.Errorf("expected rate limit header, got %q", got)
+	}
+	if meta.Response == nil || meta.Response.StatusCode != http.StatusOK {
+		t.Errorf("expected raw response with status 200, got %+v", meta.Response)
+	}
+}
+
+func TestSendWithResponse_ValidationErrorHasNoMeta(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("test_api_key_123")
+
+	_, meta, err := client.Emails.SendWithResponse(context.Background(), &SendEmailRequest{})
+	if err == nil {
+		t.Fatal("expected validation error")
+	}
+	if meta != nil {
+		t.Errorf("expected nil metadata, got %+v", meta)
+	}
+}
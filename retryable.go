@@ -0,0 +1,55 @@
+package envloped
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+)
+
+// IsRetryable reports whether a failed call may succeed if repeated later,
+// for callers that build their own retry loops. Rate limiting (HTTP 429,
+// including ErrQuotaExhausted), server errors (HTTP 5xx), request timeouts
+// (HTTP 408), an open circuit breaker (ErrCircuitOpen), and transient
+// network failures such as timeouts, reset connections, and connections
+// closed early (io.EOF, io.ErrUnexpectedEOF) are retryable. Validation
+// errors, other API errors, and canceled contexts are not.
+//
+// A network failure after the request was written does not mean the API
+// rejected it: a send may have been accepted before the connection broke.
+// Only retry sends that set SendEmailRequest.IdempotencyKey, so that the
+// API recognizes the repeat and never delivers the email twice.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	if errors.Is(err, ErrQuotaExhausted) || errors.Is(err, ErrCircuitOpen) {
+		return true
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		switch {
+		case apiErr.StatusCode == http.StatusTooManyRequests,
+			apiErr.StatusCode == http.StatusRequestTimeout,
+			apiErr.StatusCode >= 500:
+			return true
+		default:
+			return false
+		}
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.EOF)
+}
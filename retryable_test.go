@@ -0,0 +1,68 @@
+package envloped
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestIsRetryable(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"rate limited", &RateLimitError{APIError: APIError{StatusCode: http.StatusTooManyRequests}}, true},
+		{"quota exhausted", &QuotaExhaustedError{Reset: time.Now()}, true},
+		{"server error", &ServerError{APIError: APIError{StatusCode: http.StatusBadGateway}}, true},
+		{"request timeout", &APIError{StatusCode: http.StatusRequestTimeout}, true},
+		{"unauthorized", &APIError{StatusCode: http.StatusUnauthorized}, false},
+		{"api validation", &ValidationError{APIError: APIError{StatusCode: http.StatusBadRequest}}, false},
+		{"client validation", fieldError("to", "required", "to is required"), false},
+		{"suppressed", &SuppressedError{}, false},
+		{"canceled", fmt.Errorf("envloped: request failed: %w", &url.Error{Op: "Post", URL: "x", Err: context.Canceled}), false},
+		{"deadline", fmt.Errorf("envloped: request failed: %w", &url.Error{Op: "Post", URL: "x", Err: context.DeadlineExceeded}), true},
+		{"connection reset", fmt.Errorf("envloped: request failed: %w", &url.Error{Op: "Post", URL: "x", Err: syscall.ECONNRESET}), true},
+		{"unexpected eof", fmt.Errorf("envloped: request failed: %w", io.ErrUnexpectedEOF), true},
+		{"other", errors.New("boom"), false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := IsRetryable(tt.err); got != tt.want {
+				t.Errorf("IsRetryable(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsRetryable_ClientTimeout(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server).WithHTTPClient(&http.Client{Timeout: 10 * time.Millisecond})
+	_, err := client.Ping()
+	if err == nil || !IsRetryable(err) {
+		t.Errorf("expected retryable timeout error, got %v", err)
+	}
+}
@@ -0,0 +1,22 @@
+package envloped
+
+// WithSandbox sets whether every send is made in sandbox mode, which
+// processes emails like any other send, including events and webhooks, but
+// does not deliver them. Enable it in staging environments to exercise the
+// full sending path without emailing real recipients. It applies to Send and
+// SendRaw. Returns the client for method chaining.
+func (c *Client) WithSandbox(enabled bool) *Client {
+	c.sandbox = enabled
+	return c
+}
+
+// withSandbox returns params with Sandbox set if the client is in sandbox
+// mode. params itself is not modified.
+func (c *Client) withSandbox(params *SendEmailRequest) *SendEmailRequest {
+	if !c.sandbox || params.Sandbox {
+		return params
+	}
+	cp := *params
+	cp.Sandbox = true
+	return &cp
+}
@@ -0,0 +1,66 @@
+package envloped
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWithSandbox(t *testing.T) {
+	t.Parallel()
+
+	var body map[string]any
+	var rawQuery string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/emails/raw" {
+			rawQuery = r.URL.RawQuery
+		} else {
+			json.NewDecoder(r.Body).Decode(&body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"success":true,"messageId":"msg_1","sandbox":true}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server).WithSandbox(true)
+	params := &SendEmailRequest{
+		From:    "sender@example.com",
+		To:      []string{"recipient@example.com"},
+		Subject: "Hello",
+		Text:    "Hi",
+	}
+	resp, err := client.Emails.Send(params)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if body["sandbox"] != true {
+		t.Errorf("expected sandbox in request body, got %v", body["sandbox"])
+	}
+	if !resp.Sandbox {
+		t.Error("expected sandbox in response")
+	}
+	if params.Sandbox {
+		t.Error("expected caller's params to be unmodified")
+	}
+
+	if _, err := client.Emails.SendRaw(strings.NewReader("From: a@example.com\r\n\r\nHi")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rawQuery != "sandbox=true" {
+		t.Errorf("expected sandbox query for raw sends, got %q", rawQuery)
+	}
+}
+
+func TestSendEmail_SandboxOmittedByDefault(t *testing.T) {
+	t.Parallel()
+
+	data, err := json.Marshal(&SendEmailRequest{From: "sender@example.com"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(string(data), "sandbox") {
+		t.Errorf("expected sandbox to be omitted, got %s", data)
+	}
+}
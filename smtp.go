@@ -0,0 +1,186 @@
+package envloped
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// SMTPCredential is a username and password for the Envloped SMTP relay,
+// for systems that can send email over SMTP but not through the HTTP API.
+type SMTPCredential struct {
+	// ID is the unique identifier of the credential.
+	ID string `json:"id"`
+
+	// Name is a label to identify the credential, e.g. the system using it.
+	Name string `json:"name"`
+
+	// Username is the SMTP AUTH username.
+	Username string `json:"username"`
+
+	// Password is the SMTP AUTH password. It is only returned when the
+	// credential is created or rotated.
+	Password string `json:"password,omitempty"`
+
+	// Host is the hostname of the SMTP relay, e.g. "smtp.envloped.com".
+	Host string `json:"host"`
+
+	// Port is the submission port of the SMTP relay, which requires
+	// STARTTLS.
+	Port int `json:"port"`
+
+	// CreatedAt is when the credential was created.
+	CreatedAt time.Time `json:"createdAt"`
+
+	// RotatedAt is when the password was last rotated, if it was.
+	RotatedAt *time.Time `json:"rotatedAt,omitempty"`
+
+	// LastUsedAt is when the credential last authenticated, if it has.
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+}
+
+// CreateSMTPCredentialRequest is the request body for creating an SMTP
+// relay credential.
+type CreateSMTPCredentialRequest struct {
+	// Name is a label to identify the credential.
+	Name string `json:"name"`
+}
+
+// SMTPSvc defines the interface for the SMTP relay credentials service.
+// This interface can be mocked in consumer tests.
+type SMTPSvc interface {
+	// CreateCredential creates an SMTP relay credential.
+	CreateCredential(params *CreateSMTPCredentialRequest) (*SMTPCredential, error)
+
+	// CreateCredentialWithContext creates an SMTP relay credential using the provided context.
+	CreateCredentialWithContext(ctx context.Context, params *CreateSMTPCredentialRequest) (*SMTPCredential, error)
+
+	// ListCredentials returns all active SMTP relay credentials.
+	ListCredentials() ([]SMTPCredential, error)
+
+	// ListCredentialsWithContext returns all active SMTP relay credentials using the provided context.
+	ListCredentialsWithContext(ctx context.Context) ([]SMTPCredential, error)
+
+	// RotateCredential replaces the password of an SMTP relay credential.
+	RotateCredential(credentialID string) (*SMTPCredential, error)
+
+	// RotateCredentialWithContext replaces the password using the provided context.
+	RotateCredentialWithContext(ctx context.Context, credentialID string) (*SMTPCredential, error)
+
+	// RevokeCredential permanently disables an SMTP relay credential.
+	RevokeCredential(credentialID string) error
+
+	// RevokeCredentialWithContext revokes a credential using the provided context.
+	RevokeCredentialWithContext(ctx context.Context, credentialID string) error
+}
+
+// smtpSvcImpl implements SMTPSvc.
+type smtpSvcImpl struct {
+	client *Client
+}
+
+// smtpCredentialPath returns the API path of an SMTP credential, with
+// optional suffix.
+func smtpCredentialPath(credentialID, suffix string) string {
+	return "/v1/smtp/credentials/" + url.PathEscape(credentialID) + suffix
+}
+
+// CreateCredential creates an SMTP relay credential.
+func (s *smtpSvcImpl) CreateCredential(params *CreateSMTPCredentialRequest) (*SMTPCredential, error) {
+	return s.CreateCredentialWithContext(context.Background(), params)
+}
+
+// CreateCredentialWithContext creates an SMTP relay credential using the
+// provided context. The returned Password is only available in this
+// response; store it securely.
+func (s *smtpSvcImpl) CreateCredentialWithContext(ctx context.Context, params *CreateSMTPCredentialRequest) (*SMTPCredential, error) {
+	if params == nil {
+		return nil, fmt.Errorf("envloped: create smtp credential params must not be nil")
+	}
+	if params.Name == "" {
+		return nil, fmt.Errorf("envloped: smtp credential name is required")
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodPost, "/v1/smtp/credentials", "/v1/smtp/credentials", params)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to create smtp credential request: %w", err)
+	}
+
+	var resp SMTPCredential
+	if err := s.client.do(req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// ListCredentials returns all active SMTP relay credentials.
+func (s *smtpSvcImpl) ListCredentials() ([]SMTPCredential, error) {
+	return s.ListCredentialsWithContext(context.Background())
+}
+
+// ListCredentialsWithContext returns all active SMTP relay credentials using
+// the provided context. Passwords are not included.
+func (s *smtpSvcImpl) ListCredentialsWithContext(ctx context.Context) ([]SMTPCredential, error) {
+	req, err := s.client.newRequest(ctx, http.MethodGet, "/v1/smtp/credentials", "/v1/smtp/credentials", nil)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to create list smtp credentials request: %w", err)
+	}
+
+	var resp listResponse[SMTPCredential]
+	if err := s.client.do(req, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Data, nil
+}
+
+// RotateCredential replaces the password of an SMTP relay credential.
+func (s *smtpSvcImpl) RotateCredential(credentialID string) (*SMTPCredential, error) {
+	return s.RotateCredentialWithContext(context.Background(), credentialID)
+}
+
+// RotateCredentialWithContext replaces the password of an SMTP relay
+// credential using the provided context, keeping its username. The previous
+// password stops working immediately, so update the systems using it right
+// away. The new Password is only available in this response.
+func (s *smtpSvcImpl) RotateCredentialWithContext(ctx context.Context, credentialID string) (*SMTPCredential, error) {
+	if credentialID == "" {
+		return nil, fmt.Errorf("envloped: smtp credential id is required")
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodPost, "/v1/smtp/credentials/{id}/rotate", smtpCredentialPath(credentialID, "/rotate"), nil)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to create rotate smtp credential request: %w", err)
+	}
+
+	var resp SMTPCredential
+	if err := s.client.do(req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// RevokeCredential permanently disables an SMTP relay credential.
+func (s *smtpSvcImpl) RevokeCredential(credentialID string) error {
+	return s.RevokeCredentialWithContext(context.Background(), credentialID)
+}
+
+// RevokeCredentialWithContext permanently disables an SMTP relay credential
+// using the provided context. Connections authenticating with it are
+// rejected from then on.
+func (s *smtpSvcImpl) RevokeCredentialWithContext(ctx context.Context, credentialID string) error {
+	if credentialID == "" {
+		return fmt.Errorf("envloped: smtp credential id is required")
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodDelete, "/v1/smtp/credentials/{id}", smtpCredentialPath(credentialID, ""), nil)
+	if err != nil {
+		return fmt.Errorf("envloped: failed to create revoke smtp credential request: %w", err)
+	}
+
+	return s.client.do(req, nil)
+}
@@ -0,0 +1,326 @@
+package envloped
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/mail"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// DefaultSMTPRelayAddr is the address of the Envloped SMTP relay.
+const DefaultSMTPRelayAddr = "smtp.envloped.com:587"
+
+// SMTPFallbackOptions configures an SMTPFallback.
+type SMTPFallbackOptions struct {
+	// Addr is the host:port of the SMTP relay. Defaults to
+	// DefaultSMTPRelayAddr.
+	Addr string
+
+	// Username and Password authenticate with the relay. Create them with
+	// Client.SMTP.CreateCredential.
+	Username string
+	Password string
+
+	// TLSConfig configures STARTTLS, which is used whenever the relay
+	// offers it. If nil, the default configuration for the relay host is
+	// used.
+	TLSConfig *tls.Config
+
+	// ShouldFallback reports whether a failed API call should be retried
+	// through the relay. Defaults to IsUnreachable.
+	ShouldFallback func(err error) bool
+
+	// OnFallback is called with the API error before an email is sent
+	// through the relay, e.g. to log or count fallbacks. It may be nil.
+	OnFallback func(ctx context.Context, err error)
+}
+
+// SMTPFallback is an EmailsSvc that sends through another EmailsSvc, usually
+// Client.Emails, and submits emails to the Envloped SMTP relay instead when
+// the HTTP API is unreachable, for senders that must not lose email during
+// an outage:
+//
+//	client.Emails = envloped.NewSMTPFallback(client.Emails, &envloped.SMTPFallbackOptions{
+//	    Username: os.Getenv("ENVLOPED_SMTP_USERNAME"),
+//	    Password: os.Getenv("ENVLOPED_SMTP_PASSWORD"),
+//	})
+//
+// Sends that fall back return a SendEmailResponse with Relayed set, whose
+// MessageId is the Message-ID header of the email rather than an API message
+// ID. Tags, metadata, tracking settings, and IP pools are not applied to
+// relayed emails. Requests that only the API can send, such as template,
+// personalized, scheduled, and sandbox sends or attachments that are streamed
+// or uploaded, return the API error instead. Methods other than sends are
+// passed through unchanged.
+//
+// It is safe for concurrent use.
+type SMTPFallback struct {
+	EmailsSvc
+
+	opts SMTPFallbackOptions
+}
+
+var _ EmailsSvc = (*SMTPFallback)(nil)
+
+// NewSMTPFallback returns an SMTPFallback that sends through primary and
+// falls back to the SMTP relay configured by opts.
+func NewSMTPFallback(primary EmailsSvc, opts *SMTPFallbackOptions) *SMTPFallback {
+	f := &SMTPFallback{EmailsSvc: primary}
+	if opts != nil {
+		f.opts = *opts
+	}
+	if f.opts.Addr == "" {
+		f.opts.Addr = DefaultSMTPRelayAddr
+	}
+	if f.opts.ShouldFallback == nil {
+		f.opts.ShouldFallback = IsUnreachable
+	}
+	return f
+}
+
+// IsUnreachable reports whether a failed call never reached the Envloped API,
+// so an email it tried to send was certainly not accepted: connection and DNS
+// failures, gateway errors (HTTP 502, 503, and 504), and an open circuit
+// breaker. Timeouts and other server errors are not included, since the API
+// may have accepted the email before the call failed.
+func IsUnreachable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, ErrCircuitOpen) {
+		return true
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial" && !opErr.Timeout()
+}
+
+// Send sends an email, falling back to the SMTP relay if the API is unreachable.
+func (f *SMTPFallback) Send(params *SendEmailRequest, opts ...RequestOption) (*SendEmailResponse, error) {
+	return f.SendWithContext(context.Background(), params, opts...)
+}
+
+// SendWithContext sends an email using the provided context, falling back to
+// the SMTP relay if the API is unreachable.
+func (f *SMTPFallback) SendWithContext(ctx context.Context, params *SendEmailRequest, opts ...RequestOption) (*SendEmailResponse, error) {
+	resp, err := f.EmailsSvc.SendWithContext(ctx, params, opts...)
+	if err == nil || !f.opts.ShouldFallback(err) {
+		return resp, err
+	}
+	return f.relay(ctx, params, err)
+}
+
+// SendWithResponse sends an email like SendWithContext. The returned
+// metadata is nil if the email was relayed.
+func (f *SMTPFallback) SendWithResponse(ctx context.Context, params *SendEmailRequest, opts ...RequestOption) (*SendEmailResponse, *ResponseMeta, error) {
+	resp, meta, err := f.EmailsSvc.SendWithResponse(ctx, params, opts...)
+	if err == nil || !f.opts.ShouldFallback(err) {
+		return resp, meta, err
+	}
+	resp, err = f.relay(ctx, params, err)
+	return resp, nil, err
+}
+
+// SendRaw sends a pre-built RFC 5322 message, falling back to the SMTP relay
+// if the API is unreachable.
+func (f *SMTPFallback) SendRaw(message io.Reader, opts ...RequestOption) (*SendEmailResponse, error) {
+	return f.SendRawWithContext(context.Background(), message, opts...)
+}
+
+// SendRawWithContext sends a pre-built RFC 5322 message using the provided
+// context, falling back to the SMTP relay if the API is unreachable. The
+// message is buffered in memory, so it can be sent again.
+func (f *SMTPFallback) SendRawWithContext(ctx context.Context, message io.Reader, opts ...RequestOption) (*SendEmailResponse, error) {
+	if message == nil {
+		return nil, fmt.Errorf("envloped: raw message must not be nil")
+	}
+	raw, err := io.ReadAll(message)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to read raw message: %w", err)
+	}
+
+	resp, err := f.EmailsSvc.SendRawWithContext(ctx, bytes.NewReader(raw), opts...)
+	if err == nil || !f.opts.ShouldFallback(err) || f.sandboxed() {
+		return resp, err
+	}
+
+	msg, env, messageID, buildErr := rawSMTPMessage(raw)
+	if buildErr != nil {
+		return nil, err
+	}
+	return f.submit(ctx, msg, env, messageID, err)
+}
+
+// relay sends params through the SMTP relay after the API failed with
+// apiErr. If params cannot be relayed, apiErr is returned.
+func (f *SMTPFallback) relay(ctx context.Context, params *SendEmailRequest, apiErr error) (*SendEmailResponse, error) {
+	if params == nil || f.sandboxed() {
+		return nil, apiErr
+	}
+	msg, env, messageID, err := buildSMTPMessage(params, time.Now())
+	if err != nil {
+		return nil, apiErr
+	}
+	return f.submit(ctx, msg, env, messageID, apiErr)
+}
+
+// sandboxed reports whether the primary service is a client in sandbox mode,
+// whose emails must not be delivered through the relay.
+func (f *SMTPFallback) sandboxed() bool {
+	s, ok := f.EmailsSvc.(*emailsSvcImpl)
+	return ok && s.client.sandbox
+}
+
+// submit delivers msg to the SMTP relay after the API failed with apiErr.
+func (f *SMTPFallback) submit(ctx context.Context, msg []byte, env smtpEnvelope, messageID string, apiErr error) (*SendEmailResponse, error) {
+	if f.opts.OnFallback != nil {
+		f.opts.OnFallback(ctx, apiErr)
+	}
+	if err := f.sendMail(ctx, env, msg); err != nil {
+		return nil, fmt.Errorf("envloped: smtp fallback failed: %w (api error: %v)", err, apiErr)
+	}
+	return &SendEmailResponse{Success: true, MessageId: messageID, Relayed: true}, nil
+}
+
+// sendMail submits msg to the relay, like smtp.SendMail, but honors ctx.
+func (f *SMTPFallback) sendMail(ctx context.Context, env smtpEnvelope, msg []byte) error {
+	host, _, err := net.SplitHostPort(f.opts.Addr)
+	if err != nil {
+		return fmt.Errorf("invalid relay address %q: %w", f.opts.Addr, err)
+	}
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", f.opts.Addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		config := &tls.Config{ServerName: host}
+		if f.opts.TLSConfig != nil {
+			config = f.opts.TLSConfig.Clone()
+			if config.ServerName == "" {
+				config.ServerName = host
+			}
+		}
+		if err := c.StartTLS(config); err != nil {
+			return err
+		}
+	}
+	if f.opts.Username != "" {
+		// PlainAuth refuses to send the password over an unencrypted
+		// connection, except to localhost.
+		if err := c.Auth(smtp.PlainAuth("", f.opts.Username, f.opts.Password, host)); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(env.from); err != nil {
+		return err
+	}
+	for _, rcpt := range env.to {
+		if err := c.Rcpt(rcpt); err != nil {
+			return err
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+// rawSMTPMessage prepares a raw message for the SMTP relay, taking the
+// envelope from its From, To, Cc, and Bcc headers and removing the Bcc
+// header, like the API does.
+func rawSMTPMessage(raw []byte) ([]byte, smtpEnvelope, string, error) {
+	var env smtpEnvelope
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		return nil, env, "", err
+	}
+	from, err := mail.ParseAddress(msg.Header.Get("From"))
+	if err != nil {
+		return nil, env, "", err
+	}
+	env.from = from.Address
+	for _, name := range []string{"To", "Cc", "Bcc"} {
+		list, err := msg.Header.AddressList(name)
+		if err != nil && !errors.Is(err, mail.ErrHeaderNotPresent) {
+			return nil, env, "", err
+		}
+		for _, a := range list {
+			env.to = append(env.to, a.Address)
+		}
+	}
+	if len(env.to) == 0 {
+		return nil, env, "", fmt.Errorf("message has no recipients")
+	}
+	messageID := strings.Trim(msg.Header.Get("Message-Id"), "<> ")
+	return removeBccHeader(raw), env, messageID, nil
+}
+
+// removeBccHeader returns raw without its Bcc header lines.
+func removeBccHeader(raw []byte) []byte {
+	var out bytes.Buffer
+	r := bufio.NewReader(bytes.NewReader(raw))
+	inBcc := false
+	for {
+		line, err := r.ReadBytes('\n')
+		if len(bytes.TrimRight(line, "\r\n")) == 0 {
+			// End of the header: copy the body unchanged.
+			out.Write(line)
+			rest, _ := io.ReadAll(r)
+			out.Write(rest)
+			return out.Bytes()
+		}
+		if line[0] != ' ' && line[0] != '\t' {
+			inBcc = len(line) >= 4 && strings.EqualFold(string(line[:4]), "bcc:")
+		}
+		if !inBcc {
+			out.Write(line)
+		}
+		if err != nil {
+			return out.Bytes()
+		}
+	}
+}
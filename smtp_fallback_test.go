@@ -0,0 +1,297 @@
+package envloped
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+)
+
+// fakeSMTPMessage is a message received by a fakeSMTPServer.
+type fakeSMTPMessage struct {
+	auth string
+	from string
+	to   []string
+	data string
+}
+
+// fakeSMTPServer is a minimal SMTP server on localhost, without STARTTLS.
+type fakeSMTPServer struct {
+	addr string
+
+	mu       sync.Mutex
+	messages []fakeSMTPMessage
+}
+
+func newFakeSMTPServer(t *testing.T) *fakeSMTPServer {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { l.Close() })
+
+	s := &fakeSMTPServer{addr: l.Addr().String()}
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *fakeSMTPServer) serve(conn net.Conn) {
+	defer conn.Close()
+	tp := textproto.NewConn(conn)
+	tp.PrintfLine("220 localhost ESMTP")
+
+	var msg fakeSMTPMessage
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(verb) {
+		case "EHLO":
+			tp.PrintfLine("250-localhost")
+			tp.PrintfLine("250 AUTH PLAIN")
+		case "AUTH":
+			decoded, _ := base64.StdEncoding.DecodeString(strings.TrimPrefix(arg, "PLAIN "))
+			msg.auth = string(decoded)
+			tp.PrintfLine("235 authenticated")
+		case "MAIL":
+			msg.from = strings.Trim(strings.TrimPrefix(arg, "FROM:"), "<>")
+			tp.PrintfLine("250 ok")
+		case "RCPT":
+			msg.to = append(msg.to, strings.Trim(strings.TrimPrefix(arg, "TO:"), "<>"))
+			tp.PrintfLine("250 ok")
+		case "DATA":
+			tp.PrintfLine("354 go ahead")
+			data, err := tp.ReadDotBytes() // converts line endings to \n
+			if err != nil {
+				return
+			}
+			msg.data = string(data)
+			s.mu.Lock()
+			s.messages = append(s.messages, msg)
+			s.mu.Unlock()
+			msg = fakeSMTPMessage{}
+			tp.PrintfLine("250 queued")
+		case "QUIT":
+			tp.PrintfLine("221 bye")
+			return
+		default:
+			tp.PrintfLine("250 ok")
+		}
+	}
+}
+
+func (s *fakeSMTPServer) received() []fakeSMTPMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]fakeSMTPMessage(nil), s.messages...)
+}
+
+// failingEmails is an EmailsSvc whose sends fail with err.
+type failingEmails struct {
+	EmailsSvc
+	err   error
+	calls int
+}
+
+func (f *failingEmails) SendWithContext(ctx context.Context, params *SendEmailRequest, opts ...RequestOption) (*SendEmailResponse, error) {
+	f.calls++
+	return nil, f.err
+}
+
+func (f *failingEmails) SendWithResponse(ctx context.Context, params *SendEmailRequest, opts ...RequestOption) (*SendEmailResponse, *ResponseMeta, error) {
+	f.calls++
+	return nil, nil, f.err
+}
+
+func (f *failingEmails) SendRawWithContext(ctx context.Context, message io.Reader, opts ...RequestOption) (*SendEmailResponse, error) {
+	f.calls++
+	io.Copy(io.Discard, message)
+	return nil, f.err
+}
+
+var errConnRefused = fmt.Errorf("Post \"https://api.envloped.com/v1/emails\": %w",
+	&net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED})
+
+func TestSMTPFallback_Send(t *testing.T) {
+	t.Parallel()
+
+	server := newFakeSMTPServer(t)
+	var fallbackErr error
+	f := NewSMTPFallback(&failingEmails{err: errConnRefused}, &SMTPFallbackOptions{
+		Addr:       server.addr,
+		Username:   "envloped_1",
+		Password:   "secret",
+		OnFallback: func(ctx context.Context, err error) { fallbackErr = err },
+	})
+
+	resp, err := f.Send(&SendEmailRequest{
+		From:        "Shop <shop@example.com>",
+		To:          []string{"Zoë <zoe@example.org>"},
+		Bcc:         []string{"audit@example.com"},
+		Subject:     "Your receipt ✓",
+		Html:        "<p>Thanks for your order</p>",
+		Text:        "Thanks for your order",
+		ReturnPath:  "bounces@mail.example.com",
+		Importance:  ImportanceHigh,
+		Attachments: []Attachment{{Filename: "receipt.pdf", Content: []byte("%PDF-1.4")}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Relayed || !strings.HasSuffix(resp.MessageId, "@example.com") {
+		t.Errorf("unexpected response %+v", resp)
+	}
+	if !errors.Is(fallbackErr, syscall.ECONNREFUSED) {
+		t.Errorf("expected OnFallback with the API error, got %v", fallbackErr)
+	}
+
+	received := server.received()
+	if len(received) != 1 {
+		t.Fatalf("expected 1 relayed message, got %d", len(received))
+	}
+	msg := received[0]
+	if msg.auth != "\x00envloped_1\x00secret" {
+		t.Errorf("unexpected auth %q", msg.auth)
+	}
+	if msg.from != "bounces@mail.example.com" || strings.Join(msg.to, ",") != "zoe@example.org,audit@example.com" {
+		t.Errorf("unexpected envelope %q %q", msg.from, msg.to)
+	}
+	if strings.Contains(msg.data, "audit@example.com") {
+		t.Error("expected the Bcc recipient to be left out of the message")
+	}
+	if !strings.Contains(msg.data, "Message-ID: <"+resp.MessageId+">\n") || !strings.Contains(msg.data, "X-Priority: 1 (Highest)\n") {
+		t.Errorf("expected Message-ID and priority headers, got\n%s", msg.data)
+	}
+
+	params, err := ParseMessage(strings.NewReader(msg.data))
+	if err != nil {
+		t.Fatalf("failed to parse relayed message: %v", err)
+	}
+	if params.Subject != "Your receipt ✓" || params.Text != "Thanks for your order" || params.Html != "<p>Thanks for your order</p>" {
+		t.Errorf("unexpected relayed content %+v", params)
+	}
+	if len(params.To) != 1 || !strings.Contains(params.To[0], "zoe@example.org") {
+		t.Errorf("unexpected relayed to %q", params.To)
+	}
+	if len(params.Attachments) != 1 || params.Attachments[0].Filename != "receipt.pdf" || string(params.Attachments[0].Content) != "%PDF-1.4" {
+		t.Errorf("unexpected relayed attachments %+v", params.Attachments)
+	}
+}
+
+func TestSMTPFallback_NoFallback(t *testing.T) {
+	t.Parallel()
+
+	server := newFakeSMTPServer(t)
+	params := &SendEmailRequest{From: "shop@example.com", To: []string{"a@example.org"}, Subject: "s", Text: "x"}
+	tests := []struct {
+		name   string
+		err    error
+		params *SendEmailRequest
+	}{
+		{"validation error", fieldError("to", "required", "at least one to address is required"), params},
+		{"server error", &ServerError{APIError{StatusCode: 500, Message: "internal"}}, params},
+		{"canceled", context.Canceled, params},
+		{"template send", errConnRefused, &SendEmailRequest{From: "shop@example.com", To: []string{"a@example.org"}, TemplateID: "tmpl_1"}},
+		{"sandbox send", errConnRefused, &SendEmailRequest{From: "shop@example.com", To: []string{"a@example.org"}, Subject: "s", Text: "x", Sandbox: true}},
+		{"uploaded attachment", errConnRefused, &SendEmailRequest{From: "shop@example.com", To: []string{"a@example.org"}, Subject: "s", Text: "x",
+			Attachments: []Attachment{{Filename: "terms.pdf", ID: "att_1"}}}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := NewSMTPFallback(&failingEmails{err: tt.err}, &SMTPFallbackOptions{Addr: server.addr})
+			if _, err := f.Send(tt.params); err != tt.err {
+				t.Errorf("expected the API error %v, got %v", tt.err, err)
+			}
+		})
+	}
+	if n := len(server.received()); n != 0 {
+		t.Errorf("expected no relayed messages, got %d", n)
+	}
+}
+
+func TestSMTPFallback_SendRaw(t *testing.T) {
+	t.Parallel()
+
+	server := newFakeSMTPServer(t)
+	f := NewSMTPFallback(&failingEmails{err: &ServerError{APIError{StatusCode: 503}}}, &SMTPFallbackOptions{Addr: server.addr})
+
+	raw := "From: shop@example.com\r\n" +
+		"To: a@example.org\r\n" +
+		"Bcc: audit@example.com,\r\n" +
+		" archive@example.com\r\n" +
+		"Subject: Hi\r\n" +
+		"Message-ID: <raw-1@example.com>\r\n" +
+		"\r\n" +
+		"Hello\r\n"
+	resp, err := f.SendRaw(strings.NewReader(raw))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Relayed || resp.MessageId != "raw-1@example.com" {
+		t.Errorf("unexpected response %+v", resp)
+	}
+
+	received := server.received()
+	if len(received) != 1 {
+		t.Fatalf("expected 1 relayed message, got %d", len(received))
+	}
+	want := "From: shop@example.com\nTo: a@example.org\nSubject: Hi\nMessage-ID: <raw-1@example.com>\n\nHello\n"
+	if received[0].data != want {
+		t.Errorf("expected Bcc to be removed, got %q", received[0].data)
+	}
+	if len(received[0].to) != 3 {
+		t.Errorf("expected 3 envelope recipients, got %q", received[0].to)
+	}
+}
+
+func TestIsUnreachable(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"connection refused", errConnRefused, true},
+		{"dns", &net.DNSError{Err: "no such host", Name: "api.envloped.com"}, true},
+		{"circuit open", fmt.Errorf("envloped: %w", ErrCircuitOpen), true},
+		{"bad gateway", &ServerError{APIError{StatusCode: 502}}, true},
+		{"service unavailable", &ServerError{APIError{StatusCode: 503}}, true},
+		{"internal server error", &ServerError{APIError{StatusCode: 500}}, false},
+		{"rate limited", &RateLimitError{APIError: APIError{StatusCode: 429}}, false},
+		{"deadline", context.DeadlineExceeded, false},
+		{"reset", syscall.ECONNRESET, false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := IsUnreachable(tt.err); got != tt.want {
+				t.Errorf("IsUnreachable(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
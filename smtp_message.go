@@ -0,0 +1,274 @@
+package envloped
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// errNotRelayable is returned by buildSMTPMessage for requests that only the
+// HTTP API can send.
+var errNotRelayable = errors.New("request cannot be sent through the smtp relay")
+
+// smtpEnvelope is the SMTP envelope of a message: the MAIL FROM address and
+// the RCPT TO addresses, which include Bcc recipients.
+type smtpEnvelope struct {
+	from string
+	to   []string
+}
+
+// buildSMTPMessage renders params as an RFC 5322 message for submission to
+// the SMTP relay, returning its Message-ID without angle brackets. Requests
+// that need the API, such as templates, personalizations, scheduled and
+// sandbox sends, and streamed or uploaded attachments, return an error
+// wrapping errNotRelayable.
+func buildSMTPMessage(params *SendEmailRequest, date time.Time) ([]byte, smtpEnvelope, string, error) {
+	var env smtpEnvelope
+	switch {
+	case params.TemplateID != "":
+		return nil, env, "", fmt.Errorf("%w: templates are rendered by the API", errNotRelayable)
+	case len(params.Personalizations) > 0:
+		return nil, env, "", fmt.Errorf("%w: personalizations are expanded by the API", errNotRelayable)
+	case params.ScheduledAt != nil:
+		return nil, env, "", fmt.Errorf("%w: scheduled sends are held by the API", errNotRelayable)
+	case params.Sandbox:
+		return nil, env, "", fmt.Errorf("%w: the relay has no sandbox mode", errNotRelayable)
+	}
+	for _, a := range params.Attachments {
+		if a.streamed() {
+			return nil, env, "", fmt.Errorf("%w: attachment %q is streamed or uploaded", errNotRelayable, a.Filename)
+		}
+	}
+	params = params.withAutoText().withPreheader()
+
+	from, err := mail.ParseAddress(params.From)
+	if err != nil {
+		return nil, env, "", fmt.Errorf("envloped: invalid from address %q: %w", params.From, err)
+	}
+	env.from = from.Address
+	if params.ReturnPath != "" {
+		env.from = params.ReturnPath
+	}
+
+	var h bytes.Buffer
+	writeHeader := func(name, value string) {
+		fmt.Fprintf(&h, "%s: %s\r\n", name, value)
+	}
+	writeHeader("From", (&mail.Address{Name: from.Name, Address: from.Address}).String())
+	for _, field := range []struct {
+		name  string
+		addrs []string
+	}{{"To", params.To}, {"Cc", params.Cc}, {"Bcc", params.Bcc}, {"Reply-To", params.ReplyTo}} {
+		if len(field.addrs) == 0 {
+			continue
+		}
+		formatted := make([]string, len(field.addrs))
+		for i, s := range field.addrs {
+			addr, err := mail.ParseAddress(s)
+			if err != nil {
+				return nil, env, "", fmt.Errorf("envloped: invalid %s address %q: %w", strings.ToLower(field.name), s, err)
+			}
+			formatted[i] = addr.String()
+			if field.name != "Reply-To" {
+				env.to = append(env.to, addr.Address)
+			}
+		}
+		if field.name != "Bcc" {
+			writeHeader(field.name, strings.Join(formatted, ", "))
+		}
+	}
+
+	messageID := strings.Trim(params.MessageID, "<>")
+	if messageID == "" {
+		messageID = newMessageID(from.Address)
+	}
+	writeHeader("Subject", mime.QEncoding.Encode("utf-8", params.Subject))
+	writeHeader("Date", date.Format(time.RFC1123Z))
+	writeHeader("Message-ID", "<"+messageID+">")
+	writeHeader("MIME-Version", "1.0")
+
+	custom := params.wireHeaders()
+	names := make([]string, 0, len(custom))
+	for name := range custom {
+		if textproto.CanonicalMIMEHeaderKey(name) != "Message-Id" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		writeHeader(name, mime.QEncoding.Encode("utf-8", custom[name]))
+	}
+
+	var body bytes.Buffer
+	bodyHeader, err := writeSMTPBody(&body, params)
+	if err != nil {
+		return nil, env, "", err
+	}
+	for _, name := range []string{"Content-Type", "Content-Transfer-Encoding"} {
+		if value := bodyHeader.Get(name); value != "" {
+			writeHeader(name, value)
+		}
+	}
+	h.WriteString("\r\n")
+	h.Write(body.Bytes())
+	return h.Bytes(), env, messageID, nil
+}
+
+// writeSMTPBody writes the body of params to w, returning the Content-Type
+// and Content-Transfer-Encoding headers of the message.
+func writeSMTPBody(w io.Writer, params *SendEmailRequest) (textproto.MIMEHeader, error) {
+	var alternatives []textPart
+	if params.Text != "" {
+		alternatives = append(alternatives, textPart{"text/plain", params.Text})
+	}
+	if params.AmpHtml != "" {
+		alternatives = append(alternatives, textPart{"text/x-amp-html", params.AmpHtml})
+	}
+	if params.Html != "" {
+		alternatives = append(alternatives, textPart{"text/html", params.Html})
+	}
+
+	if len(params.Attachments) == 0 {
+		if len(alternatives) == 1 {
+			return alternatives[0].mimeHeader(), alternatives[0].writeBody(w)
+		}
+		mw := multipart.NewWriter(w)
+		return multipartHeader("alternative", mw), writeAlternatives(mw, alternatives)
+	}
+
+	mw := multipart.NewWriter(w)
+	switch len(alternatives) {
+	case 0:
+	case 1:
+		pw, err := mw.CreatePart(alternatives[0].mimeHeader())
+		if err != nil {
+			return nil, err
+		}
+		if err := alternatives[0].writeBody(pw); err != nil {
+			return nil, err
+		}
+	default:
+		boundary := multipart.NewWriter(nil).Boundary()
+		pw, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type": {"multipart/alternative; boundary=" + boundary},
+		})
+		if err != nil {
+			return nil, err
+		}
+		alt := multipart.NewWriter(pw)
+		if err := alt.SetBoundary(boundary); err != nil {
+			return nil, err
+		}
+		if err := writeAlternatives(alt, alternatives); err != nil {
+			return nil, err
+		}
+	}
+	for i := range params.Attachments {
+		if err := writeSMTPAttachment(mw, &params.Attachments[i]); err != nil {
+			return nil, err
+		}
+	}
+	return multipartHeader("mixed", mw), mw.Close()
+}
+
+// multipartHeader returns the header of a multipart entity written by mw.
+func multipartHeader(subtype string, mw *multipart.Writer) textproto.MIMEHeader {
+	return textproto.MIMEHeader{"Content-Type": {"multipart/" + subtype + "; boundary=" + mw.Boundary()}}
+}
+
+// writeAlternatives writes each part to mw and closes it.
+func writeAlternatives(mw *multipart.Writer, parts []textPart) error {
+	for _, p := range parts {
+		pw, err := mw.CreatePart(p.mimeHeader())
+		if err != nil {
+			return err
+		}
+		if err := p.writeBody(pw); err != nil {
+			return err
+		}
+	}
+	return mw.Close()
+}
+
+// textPart is a UTF-8 text body of a message.
+type textPart struct {
+	mediaType string
+	content   string
+}
+
+// mimeHeader returns the headers of the part.
+func (p textPart) mimeHeader() textproto.MIMEHeader {
+	return textproto.MIMEHeader{
+		"Content-Type":              {p.mediaType + "; charset=utf-8"},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	}
+}
+
+// writeBody writes the quoted-printable content of the part.
+func (p textPart) writeBody(w io.Writer) error {
+	qw := quotedprintable.NewWriter(w)
+	if _, err := io.WriteString(qw, p.content); err != nil {
+		return err
+	}
+	return qw.Close()
+}
+
+// writeSMTPAttachment writes a as a base64-encoded part of mw.
+func writeSMTPAttachment(mw *multipart.Writer, a *Attachment) error {
+	content := a.Content
+	if len(content) == 0 {
+		var err error
+		if content, err = base64.StdEncoding.DecodeString(a.Base64Content); err != nil {
+			return fmt.Errorf("envloped: attachment %q: invalid base64 content: %w", a.Filename, err)
+		}
+	}
+	contentType := a.ContentType
+	if contentType == "" {
+		if contentType = mime.TypeByExtension(filepath.Ext(a.Filename)); contentType == "" {
+			contentType = "application/octet-stream"
+		}
+	}
+
+	pw, err := mw.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {contentType},
+		"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": a.Filename})},
+		"Content-Transfer-Encoding": {"base64"},
+	})
+	if err != nil {
+		return err
+	}
+
+	encoded := base64.StdEncoding.EncodeToString(content)
+	for len(encoded) > 76 {
+		if _, err := io.WriteString(pw, encoded[:76]+"\r\n"); err != nil {
+			return err
+		}
+		encoded = encoded[76:]
+	}
+	_, err = io.WriteString(pw, encoded+"\r\n")
+	return err
+}
+
+// newMessageID returns a random Message-ID on the domain of from.
+func newMessageID(from string) string {
+	domain := "envloped.com"
+	if i := strings.LastIndexByte(from, '@'); i >= 0 {
+		domain = from[i+1:]
+	}
+	var b [16]byte
+	rand.Read(b[:])
+	return hex.EncodeToString(b[:]) + "@" + domain
+}
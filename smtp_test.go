@@ -0,0 +1,90 @@
+package envloped
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSMTP_Credentials(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/smtp/credentials":
+			var req CreateSMTPCredentialRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Fatalf("failed to decode request body: %v", err)
+			}
+			if req.Name != "legacy-erp" {
+				t.Errorf("unexpected create request: %+v", req)
+			}
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":"smtp_1","name":"legacy-erp","username":"envloped_1","password":"secret-1","host":"smtp.envloped.com","port":587}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/smtp/credentials":
+			w.Write([]byte(`{"data":[{"id":"smtp_1","username":"envloped_1"},{"id":"smtp_2","username":"envloped_2"}]}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/smtp/credentials/smtp_1/rotate":
+			w.Write([]byte(`{"id":"smtp_1","username":"envloped_1","password":"secret-2","rotatedAt":"2026-10-17T09:00:00Z"}`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/v1/smtp/credentials/smtp_2":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	cred, err := client.SMTP.CreateCredential(&CreateSMTPCredentialRequest{Name: "legacy-erp"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cred.Username != "envloped_1" || cred.Password != "secret-1" || cred.Host != "smtp.envloped.com" || cred.Port != 587 {
+		t.Errorf("unexpected credential %+v", cred)
+	}
+
+	creds, err := client.SMTP.ListCredentials()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(creds) != 2 || creds[1].ID != "smtp_2" {
+		t.Errorf("unexpected credentials %+v", creds)
+	}
+
+	rotated, err := client.SMTP.RotateCredential("smtp_1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rotated.Password != "secret-2" || rotated.RotatedAt == nil {
+		t.Errorf("unexpected rotated credential %+v", rotated)
+	}
+
+	if err := client.SMTP.RevokeCredential("smtp_2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestSMTP_Validation(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("key")
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{"create nil params", func() error { _, err := client.SMTP.CreateCredential(nil); return err }},
+		{"create missing name", func() error { _, err := client.SMTP.CreateCredential(&CreateSMTPCredentialRequest{}); return err }},
+		{"rotate missing id", func() error { _, err := client.SMTP.RotateCredential(""); return err }},
+		{"revoke missing id", func() error { return client.SMTP.RevokeCredential("") }},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := tt.call(); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
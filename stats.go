@@ -0,0 +1,141 @@
+package envloped
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// StatsGroupBy selects how the Stats service breaks down its metrics.
+type StatsGroupBy string
+
+// Statistics breakdowns.
+const (
+	// StatsByDay returns one row per UTC day.
+	StatsByDay StatsGroupBy = "day"
+
+	// StatsByTag returns one row per tag. An email with several tags counts
+	// towards each of them.
+	StatsByTag StatsGroupBy = "tag"
+
+	// StatsByDomain returns one row per sending domain.
+	StatsByDomain StatsGroupBy = "domain"
+)
+
+// StatsQuery selects the metrics returned by Stats.Get.
+type StatsQuery struct {
+	// GroupBy selects the breakdown of the rows. Defaults to StatsByDay.
+	GroupBy StatsGroupBy
+
+	// Since only counts emails sent at or after this time. The API default
+	// of 30 days ago applies when zero.
+	Since time.Time
+
+	// Until only counts emails sent before this time. Defaults to now.
+	Until time.Time
+
+	// Tag only counts emails with this tag.
+	Tag string
+
+	// Domain only counts emails sent from this domain.
+	Domain string
+}
+
+// StatsRow holds the metrics of one day, tag, or domain.
+type StatsRow struct {
+	// Key is the tag or domain of the row, or the day formatted as
+	// "2006-01-02" for StatsByDay.
+	Key string `json:"key"`
+
+	// Start is the beginning of the time range the row covers.
+	Start time.Time `json:"start"`
+
+	// End is the end of the time range the row covers.
+	End time.Time `json:"end"`
+
+	DeliveryStats
+}
+
+// StatsReport is the result of Stats.Get.
+type StatsReport struct {
+	// GroupBy is the breakdown of Rows.
+	GroupBy StatsGroupBy `json:"groupBy"`
+
+	// Since is the beginning of the reported time range.
+	Since time.Time `json:"since"`
+
+	// Until is the end of the reported time range.
+	Until time.Time `json:"until"`
+
+	// Totals holds the metrics over the whole time range.
+	Totals DeliveryStats `json:"totals"`
+
+	// Rows holds the metrics per day, tag, or domain. Days are in
+	// chronological order; tags and domains are ordered by Sent, highest
+	// first.
+	Rows []StatsRow `json:"rows"`
+}
+
+// StatsSvc defines the interface for the statistics service.
+// This interface can be mocked in consumer tests.
+type StatsSvc interface {
+	// Get returns aggregate sending, delivery, and engagement metrics.
+	Get(query *StatsQuery) (*StatsReport, error)
+
+	// GetWithContext returns aggregate metrics using the provided context.
+	GetWithContext(ctx context.Context, query *StatsQuery) (*StatsReport, error)
+}
+
+// statsSvcImpl implements StatsSvc.
+type statsSvcImpl struct {
+	client *Client
+}
+
+// Get returns aggregate sending, delivery, and engagement metrics.
+func (s *statsSvcImpl) Get(query *StatsQuery) (*StatsReport, error) {
+	return s.GetWithContext(context.Background(), query)
+}
+
+// GetWithContext returns aggregate metrics using the provided context. The
+// metrics are computed by the API from all events of the account, so they
+// cover emails sent by any client. A nil query returns daily metrics for
+// the last 30 days.
+func (s *statsSvcImpl) GetWithContext(ctx context.Context, query *StatsQuery) (*StatsReport, error) {
+	if query == nil {
+		query = &StatsQuery{}
+	}
+	switch query.GroupBy {
+	case "", StatsByDay, StatsByTag, StatsByDomain:
+	default:
+		return nil, fmt.Errorf("envloped: invalid stats group by %q", query.GroupBy)
+	}
+	if !query.Since.IsZero() && !query.Until.IsZero() && !query.Since.Before(query.Until) {
+		return nil, fmt.Errorf("envloped: stats since must be before until")
+	}
+
+	q := (&EmailFilter{Since: query.Since, Until: query.Until, Tag: query.Tag}).values()
+	if query.GroupBy != "" {
+		q.Set("groupBy", string(query.GroupBy))
+	}
+	if query.Domain != "" {
+		q.Set("domain", query.Domain)
+	}
+
+	path := "/v1/stats"
+	if len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodGet, "/v1/stats", path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to create stats request: %w", err)
+	}
+
+	var resp StatsReport
+	if err := s.client.do(req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
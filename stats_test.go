@@ -0,0 +1,92 @@
+package envloped
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestStats_Get(t *testing.T) {
+	t.Parallel()
+
+	since := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/v1/stats" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		q := r.URL.Query()
+		if q.Get("groupBy") != "tag" || q.Get("since") != "2024-03-01T00:00:00Z" || q.Get("domain") != "example.com" || q.Has("until") {
+			t.Errorf("unexpected query %v", q)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"groupBy": "tag",
+			"since": "2024-03-01T00:00:00Z",
+			"until": "2024-03-08T00:00:00Z",
+			"totals": {"sent": 100, "delivered": 98, "bounced": 2, "opened": 40, "clicked": 10, "deliveryRate": 0.98},
+			"rows": [
+				{"key": "welcome", "sent": 80, "delivered": 79, "opened": 35},
+				{"key": "receipt", "sent": 20, "delivered": 19, "bounced": 1}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	report, err := client.Stats.Get(&StatsQuery{GroupBy: StatsByTag, Since: since, Domain: "example.com"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.GroupBy != StatsByTag || !report.Since.Equal(since) || report.Totals.Sent != 100 || report.Totals.DeliveryRate != 0.98 {
+		t.Errorf("unexpected report %+v", report)
+	}
+	if len(report.Rows) != 2 || report.Rows[0].Key != "welcome" || report.Rows[0].Opened != 35 || report.Rows[1].Bounced != 1 {
+		t.Errorf("unexpected rows %+v", report.Rows)
+	}
+}
+
+func TestStats_GetDefaults(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.RawQuery != "" {
+			t.Errorf("expected no query, got %q", r.URL.RawQuery)
+		}
+		json.NewEncoder(w).Encode(StatsReport{GroupBy: StatsByDay, Rows: []StatsRow{{Key: "2024-03-01"}}})
+	}))
+	defer server.Close()
+
+	report, err := newTestClient(t, server).Stats.Get(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.GroupBy != StatsByDay || len(report.Rows) != 1 {
+		t.Errorf("unexpected report %+v", report)
+	}
+}
+
+func TestStats_Validation(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	tests := []struct {
+		name  string
+		query *StatsQuery
+	}{
+		{"invalid group by", &StatsQuery{GroupBy: "week"}},
+		{"since after until", &StatsQuery{Since: now, Until: now.Add(-time.Hour)}},
+		{"empty range", &StatsQuery{Since: now, Until: now}},
+	}
+	client := NewClient("key")
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if _, err := client.Stats.Get(tt.query); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,291 @@
+package envloped
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// SubaccountStatus is the state of a subaccount.
+type SubaccountStatus string
+
+// Subaccount statuses.
+const (
+	SubaccountStatusActive    SubaccountStatus = "active"
+	SubaccountStatusSuspended SubaccountStatus = "suspended"
+)
+
+// Subaccount is an isolated sending account owned by a platform account.
+type Subaccount struct {
+	// ID is the unique identifier of the subaccount.
+	ID string `json:"id"`
+
+	// Name is the display name of the subaccount.
+	Name string `json:"name"`
+
+	// Status is the current state of the subaccount.
+	Status SubaccountStatus `json:"status"`
+
+	// MonthlyLimit caps the subaccount's monthly email volume. nil means it
+	// shares the parent account's limit.
+	MonthlyLimit *int `json:"monthlyLimit,omitempty"`
+
+	// CreatedAt is when the subaccount was created.
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// CreateSubaccountRequest is the request body for creating a subaccount.
+type CreateSubaccountRequest struct {
+	// Name is the display name of the subaccount.
+	Name string `json:"name"`
+
+	// MonthlyLimit optionally caps the subaccount's monthly email volume.
+	MonthlyLimit *int `json:"monthlyLimit,omitempty"`
+}
+
+// APIKeyScope is a permission granted to an API key.
+type APIKeyScope string
+
+// API key scopes.
+const (
+	APIKeyScopeFullAccess APIKeyScope = "full_access"
+	APIKeyScopeSend       APIKeyScope = "emails:send"
+	APIKeyScopeRead       APIKeyScope = "emails:read"
+	APIKeyScopeDomains    APIKeyScope = "domains:manage"
+)
+
+// CreateAPIKeyRequest is the request body for issuing an API key.
+type CreateAPIKeyRequest struct {
+	// Name is a label to identify the key.
+	Name string `json:"name"`
+
+	// Scopes limits what the key can do. Defaults to APIKeyScopeSend when empty.
+	Scopes []APIKeyScope `json:"scopes,omitempty"`
+}
+
+// APIKey is an API key issued for a subaccount.
+type APIKey struct {
+	// ID is the unique identifier of the key.
+	ID string `json:"id"`
+
+	// Name is the label given to the key.
+	Name string `json:"name"`
+
+	// Token is the secret key value. It is only returned when the key is created.
+	Token string `json:"token,omitempty"`
+
+	// Scopes are the permissions granted to the key.
+	Scopes []APIKeyScope `json:"scopes"`
+
+	// CreatedAt is when the key was issued.
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// SubaccountsSvc defines the interface for the subaccounts service.
+// This interface can be mocked in consumer tests.
+type SubaccountsSvc interface {
+	// Create creates a new subaccount.
+	Create(params *CreateSubaccountRequest) (*Subaccount, error)
+
+	// CreateWithContext creates a new subaccount using the provided context.
+	CreateWithContext(ctx context.Context, params *CreateSubaccountRequest) (*Subaccount, error)
+
+	// Get retrieves a subaccount by ID.
+	Get(subaccountID string) (*Subaccount, error)
+
+	// GetWithContext retrieves a subaccount using the provided context.
+	GetWithContext(ctx context.Context, subaccountID string) (*Subaccount, error)
+
+	// List returns all subaccounts.
+	List() ([]Subaccount, error)
+
+	// ListWithContext returns all subaccounts using the provided context.
+	ListWithContext(ctx context.Context) ([]Subaccount, error)
+
+	// Suspend stops a subaccount from sending until it is resumed.
+	Suspend(subaccountID string) (*Subaccount, error)
+
+	// SuspendWithContext suspends a subaccount using the provided context.
+	SuspendWithContext(ctx context.Context, subaccountID string) (*Subaccount, error)
+
+	// Resume re-enables sending for a suspended subaccount.
+	Resume(subaccountID string) (*Subaccount, error)
+
+	// ResumeWithContext resumes a subaccount using the provided context.
+	ResumeWithContext(ctx context.Context, subaccountID string) (*Subaccount, error)
+
+	// Usage returns the email usage counters of a subaccount.
+	Usage(subaccountID string) (*EmailUsage, error)
+
+	// UsageWithContext returns subaccount usage using the provided context.
+	UsageWithContext(ctx context.Context, subaccountID string) (*EmailUsage, error)
+
+	// CreateAPIKey issues a scoped API key for a subaccount.
+	CreateAPIKey(subaccountID string, params *CreateAPIKeyRequest) (*APIKey, error)
+
+	// CreateAPIKeyWithContext issues a scoped API key using the provided context.
+	CreateAPIKeyWithContext(ctx context.Context, subaccountID string, params *CreateAPIKeyRequest) (*APIKey, error)
+}
+
+// subaccountsSvcImpl implements SubaccountsSvc.
+type subaccountsSvcImpl struct {
+	client *Client
+}
+
+// subaccountPath returns the API path of a subaccount, with optional suffix.
+func subaccountPath(subaccountID, suffix string) string {
+	return "/v1/subaccounts/" + url.PathEscape(subaccountID) + suffix
+}
+
+// Create creates a new subaccount.
+func (s *subaccountsSvcImpl) Create(params *CreateSubaccountRequest) (*Subaccount, error) {
+	return s.CreateWithContext(context.Background(), params)
+}
+
+// CreateWithContext creates a new subaccount using the provided context.
+func (s *subaccountsSvcImpl) CreateWithContext(ctx context.Context, params *CreateSubaccountRequest) (*Subaccount, error) {
+	if params == nil {
+		return nil, fmt.Errorf("envloped: create subaccount params must not be nil")
+	}
+	if params.Name == "" {
+		return nil, fmt.Errorf("envloped: subaccount name is required")
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodPost, "/v1/subaccounts", "/v1/subaccounts", params)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to create subaccount request: %w", err)
+	}
+
+	var resp Subaccount
+	if err := s.client.do(req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// Get retrieves a subaccount by ID.
+func (s *subaccountsSvcImpl) Get(subaccountID string) (*Subaccount, error) {
+	return s.GetWithContext(context.Background(), subaccountID)
+}
+
+// GetWithContext retrieves a subaccount using the provided context.
+func (s *subaccountsSvcImpl) GetWithContext(ctx context.Context, subaccountID string) (*Subaccount, error) {
+	return s.subaccountRequest(ctx, http.MethodGet, subaccountID, "")
+}
+
+// List returns all subaccounts.
+func (s *subaccountsSvcImpl) List() ([]Subaccount, error) {
+	return s.ListWithContext(context.Background())
+}
+
+// ListWithContext returns all subaccounts using the provided context.
+func (s *subaccountsSvcImpl) ListWithContext(ctx context.Context) ([]Subaccount, error) {
+	req, err := s.client.newRequest(ctx, http.MethodGet, "/v1/subaccounts", "/v1/subaccounts", nil)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to create list subaccounts request: %w", err)
+	}
+
+	var resp listResponse[Subaccount]
+	if err := s.client.do(req, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Data, nil
+}
+
+// Suspend stops a subaccount from sending until it is resumed.
+func (s *subaccountsSvcImpl) Suspend(subaccountID string) (*Subaccount, error) {
+	return s.SuspendWithContext(context.Background(), subaccountID)
+}
+
+// SuspendWithContext suspends a subaccount using the provided context.
+func (s *subaccountsSvcImpl) SuspendWithContext(ctx context.Context, subaccountID string) (*Subaccount, error) {
+	return s.subaccountRequest(ctx, http.MethodPost, subaccountID, "/suspend")
+}
+
+// Resume re-enables sending for a suspended subaccount.
+func (s *subaccountsSvcImpl) Resume(subaccountID string) (*Subaccount, error) {
+	return s.ResumeWithContext(context.Background(), subaccountID)
+}
+
+// ResumeWithContext resumes a subaccount using the provided context.
+func (s *subaccountsSvcImpl) ResumeWithContext(ctx context.Context, subaccountID string) (*Subaccount, error) {
+	return s.subaccountRequest(ctx, http.MethodPost, subaccountID, "/resume")
+}
+
+// subaccountRequest performs a body-less request against a single subaccount.
+func (s *subaccountsSvcImpl) subaccountRequest(ctx context.Context, method, subaccountID, suffix string) (*Subaccount, error) {
+	if subaccountID == "" {
+		return nil, fmt.Errorf("envloped: subaccount id is required")
+	}
+
+	req, err := s.client.newRequest(ctx, method, "/v1/subaccounts/{id}"+suffix, subaccountPath(subaccountID, suffix), nil)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to create subaccount request: %w", err)
+	}
+
+	var resp Subaccount
+	if err := s.client.do(req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// Usage returns the email usage counters of a subaccount.
+func (s *subaccountsSvcImpl) Usage(subaccountID string) (*EmailUsage, error) {
+	return s.UsageWithContext(context.Background(), subaccountID)
+}
+
+// UsageWithContext returns subaccount usage using the provided context.
+func (s *subaccountsSvcImpl) UsageWithContext(ctx context.Context, subaccountID string) (*EmailUsage, error) {
+	if subaccountID == "" {
+		return nil, fmt.Errorf("envloped: subaccount id is required")
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodGet, "/v1/subaccounts/{id}/usage", subaccountPath(subaccountID, "/usage"), nil)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to create subaccount usage request: %w", err)
+	}
+
+	var resp EmailUsage
+	if err := s.client.do(req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// CreateAPIKey issues a scoped API key for a subaccount.
+func (s *subaccountsSvcImpl) CreateAPIKey(subaccountID string, params *CreateAPIKeyRequest) (*APIKey, error) {
+	return s.CreateAPIKeyWithContext(context.Background(), subaccountID, params)
+}
+
+// CreateAPIKeyWithContext issues a scoped API key using the provided context.
+// The returned key's Token is only available in this response; store it securely.
+func (s *subaccountsSvcImpl) CreateAPIKeyWithContext(ctx context.Context, subaccountID string, params *CreateAPIKeyRequest) (*APIKey, error) {
+	if subaccountID == "" {
+		return nil, fmt.Errorf("envloped: subaccount id is required")
+	}
+	if params == nil {
+		return nil, fmt.Errorf("envloped: create api key params must not be nil")
+	}
+	if params.Name == "" {
+		return nil, fmt.Errorf("envloped: api key name is required")
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodPost, "/v1/subaccounts/{id}/api-keys", subaccountPath(subaccountID, "/api-keys"), params)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to create api key request: %w", err)
+	}
+
+	var resp APIKey
+	if err := s.client.do(req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
@@ -0,0 +1,121 @@
+package envloped
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSubaccounts_CreateAndList(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/subaccounts":
+			var req CreateSubaccountRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Fatalf("failed to decode request body: %v", err)
+			}
+			if req.Name != "Acme" || req.MonthlyLimit == nil || *req.MonthlyLimit != 1000 {
+				t.Errorf("unexpected create request: %+v", req)
+			}
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(Subaccount{ID: "sub_1", Name: req.Name, Status: SubaccountStatusActive})
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/subaccounts":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": []Subaccount{{ID: "sub_1"}, {ID: "sub_2"}},
+			})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	limit := 1000
+	sub, err := client.Subaccounts.Create(&CreateSubaccountRequest{Name: "Acme", MonthlyLimit: &limit})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sub.ID != "sub_1" || sub.Status != SubaccountStatusActive {
+		t.Errorf("unexpected subaccount: %+v", sub)
+	}
+
+	subs, err := client.Subaccounts.List()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(subs) != 2 {
+		t.Errorf("expected 2 subaccounts, got %d", len(subs))
+	}
+}
+
+func TestSubaccounts_SuspendUsageAndKeys(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/subaccounts/sub_1/suspend":
+			json.NewEncoder(w).Encode(Subaccount{ID: "sub_1", Status: SubaccountStatusSuspended})
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/subaccounts/sub_1/usage":
+			json.NewEncoder(w).Encode(EmailUsage{DailyCount: 5, MonthlyCount: 120, MonthlyLimit: 1000})
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/subaccounts/sub_1/api-keys":
+			var req CreateAPIKeyRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			if len(req.Scopes) != 1 || req.Scopes[0] != APIKeyScopeSend {
+				t.Errorf("unexpected scopes: %v", req.Scopes)
+			}
+			json.NewEncoder(w).Encode(APIKey{ID: "key_1", Name: req.Name, Token: "ev_secret", Scopes: req.Scopes})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+
+	sub, err := client.Subaccounts.Suspend("sub_1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sub.Status != SubaccountStatusSuspended {
+		t.Errorf("expected suspended status, got %q", sub.Status)
+	}
+
+	usage, err := client.Subaccounts.Usage("sub_1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if usage.MonthlyCount != 120 {
+		t.Errorf("expected monthly count 120, got %d", usage.MonthlyCount)
+	}
+
+	key, err := client.Subaccounts.CreateAPIKey("sub_1", &CreateAPIKeyRequest{
+		Name:   "tenant-send",
+		Scopes: []APIKeyScope{APIKeyScopeSend},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if key.Token != "ev_secret" {
+		t.Errorf("expected token to be returned, got %q", key.Token)
+	}
+}
+
+func TestSubaccounts_Validation(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("key")
+	if _, err := client.Subaccounts.Get(""); err == nil {
+		t.Error("expected error for empty subaccount id")
+	}
+	if _, err := client.Subaccounts.Create(&CreateSubaccountRequest{}); err == nil {
+		t.Error("expected error for missing name")
+	}
+	if _, err := client.Subaccounts.CreateAPIKey("sub_1", nil); err == nil {
+		t.Error("expected error for nil params")
+	}
+}
@@ -0,0 +1,97 @@
+package envloped
+
+import (
+	"context"
+	"fmt"
+)
+
+// SuppressionSyncOptions configures a SuppressionSync.
+type SuppressionSyncOptions struct {
+	// SoftBounces also marks addresses undeliverable on soft bounces, such
+	// as a full mailbox. By default, only hard bounces and complaints are.
+	SoftBounces bool
+
+	// OnSuppress is called with every address marked undeliverable, after
+	// it was added to the suppression list, e.g. to flag the user in a local
+	// database. Returning an error makes Handle fail, so the event is
+	// retried. It may be nil.
+	OnSuppress func(ctx context.Context, s Suppression) error
+}
+
+// SuppressionSync marks addresses undeliverable when bounce and complaint
+// events arrive. Each address is added to the account suppression list and
+// passed to OnSuppress, keeping a local user table in sync without custom
+// glue. Other events are ignored. Its Handle method fits webhooks.NewHandler
+// and Events.StreamFunc:
+//
+//	sync := envloped.NewSuppressionSync(client, &envloped.SuppressionSyncOptions{
+//	    OnSuppress: func(ctx context.Context, s envloped.Suppression) error {
+//	        return users.MarkUndeliverable(ctx, s.Email, string(s.Reason))
+//	    },
+//	})
+//	http.Handle("/hooks/envloped", webhooks.NewHandler(secret, sync.Handle))
+//
+// It is safe for concurrent use.
+type SuppressionSync struct {
+	client *Client
+	opts   SuppressionSyncOptions
+}
+
+// NewSuppressionSync returns a SuppressionSync that adds addresses to the
+// suppression list of client. client may be nil to only call OnSuppress,
+// e.g. if the account already suppresses bounced addresses. opts may be nil.
+func NewSuppressionSync(client *Client, opts *SuppressionSyncOptions) *SuppressionSync {
+	s := &SuppressionSync{client: client}
+	if opts != nil {
+		s.opts = *opts
+	}
+	return s
+}
+
+// Handle processes a single event.
+func (s *SuppressionSync) Handle(e Event) error {
+	return s.HandleWithContext(context.Background(), e)
+}
+
+// HandleWithContext processes a single event using the provided context.
+// Bounces without a bounce type are treated as hard bounces.
+func (s *SuppressionSync) HandleWithContext(ctx context.Context, e Event) error {
+	reason, ok := s.suppressionReason(e)
+	if !ok {
+		return nil
+	}
+	if e.Recipient == "" {
+		return fmt.Errorf("envloped: %s event %s has no recipient", e.Type, e.ID)
+	}
+
+	suppression := Suppression{Email: e.Recipient, Reason: reason, CreatedAt: e.CreatedAt}
+	if s.client != nil {
+		added, err := s.client.Suppressions.AddWithContext(ctx, &SuppressionRequest{Email: e.Recipient, Reason: reason})
+		if err != nil {
+			return fmt.Errorf("envloped: failed to suppress %s: %w", e.Recipient, err)
+		}
+		if added != nil && added.Email != "" {
+			suppression = *added
+		}
+	}
+
+	if s.opts.OnSuppress != nil {
+		return s.opts.OnSuppress(ctx, suppression)
+	}
+	return nil
+}
+
+// suppressionReason returns the reason to suppress the recipient of e, and
+// whether it should be suppressed at all.
+func (s *SuppressionSync) suppressionReason(e Event) (SuppressionReason, bool) {
+	switch e.Type {
+	case EventTypeComplained:
+		return SuppressionReasonComplaint, true
+	case EventTypeBounced:
+		if e.Bounce != nil && e.Bounce.Type == BounceTypeSoft && !s.opts.SoftBounces {
+			return "", false
+		}
+		return SuppressionReasonBounce, true
+	}
+	return "", false
+}
@@ -0,0 +1,119 @@
+package envloped
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestSuppressionSync(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var added []SuppressionRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/suppressions" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var req SuppressionRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		mu.Lock()
+		added = append(added, req)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(Suppression{Email: req.Email, Reason: req.Reason})
+	}))
+	defer server.Close()
+
+	var synced []Suppression
+	s := NewSuppressionSync(newTestClient(t, server), &SuppressionSyncOptions{
+		OnSuppress: func(ctx context.Context, s Suppression) error {
+			synced = append(synced, s)
+			return nil
+		},
+	})
+
+	events := []Event{
+		{ID: "evt_1", Type: EventTypeBounced, Recipient: "hard@example.com", Bounce: &BounceEvent{Type: BounceTypeHard}},
+		{ID: "evt_2", Type: EventTypeBounced, Recipient: "soft@example.com", Bounce: &BounceEvent{Type: BounceTypeSoft}},
+		{ID: "evt_3", Type: EventTypeComplained, Recipient: "spam@example.com"},
+		{ID: "evt_4", Type: EventTypeDelivered, Recipient: "ok@example.com"},
+		{ID: "evt_5", Type: EventTypeBounced, Recipient: "unknown@example.com"},
+	}
+	for _, e := range events {
+		if err := s.Handle(e); err != nil {
+			t.Fatalf("unexpected error for %s: %v", e.ID, err)
+		}
+	}
+
+	want := []Suppression{
+		{Email: "hard@example.com", Reason: SuppressionReasonBounce},
+		{Email: "spam@example.com", Reason: SuppressionReasonComplaint},
+		{Email: "unknown@example.com", Reason: SuppressionReasonBounce},
+	}
+	if len(added) != len(want) || len(synced) != len(want) {
+		t.Fatalf("expected %d suppressions, got %+v added and %+v synced", len(want), added, synced)
+	}
+	for i, w := range want {
+		if added[i].Email != w.Email || added[i].Reason != w.Reason || synced[i].Email != w.Email || synced[i].Reason != w.Reason {
+			t.Errorf("suppression %d: expected %+v, got %+v added and %+v synced", i, w, added[i], synced[i])
+		}
+	}
+}
+
+func TestSuppressionSync_CallbackOnly(t *testing.T) {
+	t.Parallel()
+
+	errDB := errors.New("database unavailable")
+	var got []Suppression
+	s := NewSuppressionSync(nil, &SuppressionSyncOptions{
+		SoftBounces: true,
+		OnSuppress: func(ctx context.Context, s Suppression) error {
+			got = append(got, s)
+			if s.Email == "fail@example.com" {
+				return errDB
+			}
+			return nil
+		},
+	})
+
+	if err := s.Handle(Event{Type: EventTypeBounced, Recipient: "soft@example.com", Bounce: &BounceEvent{Type: BounceTypeSoft}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 1 || got[0].Reason != SuppressionReasonBounce {
+		t.Errorf("expected soft bounce to be synced, got %+v", got)
+	}
+	if err := s.Handle(Event{Type: EventTypeComplained, Recipient: "fail@example.com"}); !errors.Is(err, errDB) {
+		t.Errorf("expected callback error, got %v", err)
+	}
+	if err := s.Handle(Event{ID: "evt_1", Type: EventTypeComplained}); err == nil {
+		t.Error("expected error for event without recipient")
+	}
+}
+
+func TestSuppressionSync_APIError(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"error":"forbidden"}`))
+	}))
+	defer server.Close()
+
+	called := false
+	s := NewSuppressionSync(newTestClient(t, server), &SuppressionSyncOptions{
+		OnSuppress: func(context.Context, Suppression) error { called = true; return nil },
+	})
+	err := s.Handle(Event{Type: EventTypeComplained, Recipient: "spam@example.com"})
+	if !errors.Is(err, ErrForbidden) {
+		t.Errorf("expected ErrForbidden, got %v", err)
+	}
+	if called {
+		t.Error("expected OnSuppress not to be called when the API fails")
+	}
+}
@@ -0,0 +1,251 @@
+package envloped
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"time"
+)
+
+// SuppressionReason is why an address is on the suppression list.
+type SuppressionReason string
+
+// Suppression reasons.
+const (
+	SuppressionReasonBounce      SuppressionReason = "bounce"
+	SuppressionReasonComplaint   SuppressionReason = "complaint"
+	SuppressionReasonUnsubscribe SuppressionReason = "unsubscribe"
+	SuppressionReasonManual      SuppressionReason = "manual"
+)
+
+// Suppression is an address the API will not deliver to.
+type Suppression struct {
+	// Email is the suppressed address.
+	Email string `json:"email"`
+
+	// Reason is why the address was suppressed.
+	Reason SuppressionReason `json:"reason"`
+
+	// CreatedAt is when the address was suppressed.
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// SuppressionRequest is the request body for suppressing an address.
+type SuppressionRequest struct {
+	// Email is the address to suppress. Required.
+	Email string `json:"email"`
+
+	// Reason is why the address is suppressed. Defaults to SuppressionReasonManual when empty.
+	Reason SuppressionReason `json:"reason,omitempty"`
+}
+
+// ListSuppressionsOptions configures a page of results from Suppressions.List.
+// Zero-valued fields are not applied.
+type ListSuppressionsOptions struct {
+	ListOptions
+
+	// Reason only matches suppressions with this reason.
+	Reason SuppressionReason
+}
+
+// SuppressionPage is a single page of the suppression list, newest first.
+type SuppressionPage = Page[Suppression]
+
+// checkSuppressionsRequest is the request body of the suppression check endpoint.
+type checkSuppressionsRequest struct {
+	Emails []string `json:"emails"`
+}
+
+// SuppressionsSvc defines the interface for the suppressions service.
+// This interface can be mocked in consumer tests.
+type SuppressionsSvc interface {
+	// List returns a page of suppressed addresses matching opts.
+	List(opts *ListSuppressionsOptions) (*SuppressionPage, error)
+
+	// ListWithContext returns a page of suppressed addresses using the provided context.
+	ListWithContext(ctx context.Context, opts *ListSuppressionsOptions) (*SuppressionPage, error)
+
+	// Add suppresses an address.
+	Add(params *SuppressionRequest) (*Suppression, error)
+
+	// AddWithContext suppresses an address using the provided context.
+	AddWithContext(ctx context.Context, params *SuppressionRequest) (*Suppression, error)
+
+	// Delete removes an address from the suppression list.
+	Delete(email string) error
+
+	// DeleteWithContext removes an address from the suppression list using the provided context.
+	DeleteWithContext(ctx context.Context, email string) error
+
+	// Check returns the suppressions of those addresses in emails that are suppressed.
+	Check(emails []string) ([]Suppression, error)
+
+	// CheckWithContext checks addresses against the suppression list using the provided context.
+	CheckWithContext(ctx context.Context, emails []string) ([]Suppression, error)
+}
+
+// suppressionsSvcImpl implements SuppressionsSvc.
+type suppressionsSvcImpl struct {
+	client *Client
+}
+
+// List returns a page of suppressed addresses matching opts.
+func (s *suppressionsSvcImpl) List(opts *ListSuppressionsOptions) (*SuppressionPage, error) {
+	return s.ListWithContext(context.Background(), opts)
+}
+
+// ListWithContext returns a page of suppressed addresses using the provided context.
+// Pass the returned NextCursor as opts.Cursor to fetch the following page.
+func (s *suppressionsSvcImpl) ListWithContext(ctx context.Context, opts *ListSuppressionsOptions) (*SuppressionPage, error) {
+	if opts == nil {
+		opts = &ListSuppressionsOptions{}
+	}
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
+	q := opts.values()
+	if opts.Reason != "" {
+		q.Set("reason", string(opts.Reason))
+	}
+
+	path := "/v1/suppressions"
+	if len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodGet, "/v1/suppressions", path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to create list suppressions request: %w", err)
+	}
+
+	var page SuppressionPage
+	if err := s.client.do(req, &page); err != nil {
+		return nil, err
+	}
+
+	return &page, nil
+}
+
+// Add suppresses an address.
+func (s *suppressionsSvcImpl) Add(params *SuppressionRequest) (*Suppression, error) {
+	return s.AddWithContext(context.Background(), params)
+}
+
+// AddWithContext suppresses an address using the provided context.
+func (s *suppressionsSvcImpl) AddWithContext(ctx context.Context, params *SuppressionRequest) (*Suppression, error) {
+	if params == nil {
+		return nil, fmt.Errorf("envloped: suppression params must not be nil")
+	}
+	if params.Email == "" {
+		return nil, fmt.Errorf("envloped: suppression email is required")
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodPost, "/v1/suppressions", "/v1/suppressions", params)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to create add suppression request: %w", err)
+	}
+
+	var resp Suppression
+	if err := s.client.do(req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// Delete removes an address from the suppression list.
+func (s *suppressionsSvcImpl) Delete(email string) error {
+	return s.DeleteWithContext(context.Background(), email)
+}
+
+// DeleteWithContext removes an address from the suppression list using the provided context.
+func (s *suppressionsSvcImpl) DeleteWithContext(ctx context.Context, email string) error {
+	if email == "" {
+		return fmt.Errorf("envloped: suppression email is required")
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodDelete, "/v1/suppressions/{id}", "/v1/suppressions/"+url.PathEscape(email), nil)
+	if err != nil {
+		return fmt.Errorf("envloped: failed to create delete suppression request: %w", err)
+	}
+
+	return s.client.do(req, nil)
+}
+
+// Check returns the suppressions of those addresses in emails that are suppressed.
+func (s *suppressionsSvcImpl) Check(emails []string) ([]Suppression, error) {
+	return s.CheckWithContext(context.Background(), emails)
+}
+
+// CheckWithContext checks addresses against the suppression list using the
+// provided context. It returns an empty slice if none are suppressed.
+func (s *suppressionsSvcImpl) CheckWithContext(ctx context.Context, emails []string) ([]Suppression, error) {
+	if len(emails) == 0 {
+		return nil, fmt.Errorf("envloped: at least one email is required")
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodPost, "/v1/suppressions/check", "/v1/suppressions/check", &checkSuppressionsRequest{Emails: emails})
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to create check suppressions request: %w", err)
+	}
+
+	var resp listResponse[Suppression]
+	if err := s.client.do(req, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Data, nil
+}
+
+// WithSuppressionCheck makes Emails.Send and Emails.Resend check every
+// recipient against the suppression list first, and fail with a
+// *SuppressedError instead of sending if any is suppressed. This costs one
+// extra API call per send, and another to fetch the original recipients of a
+// resend that keeps them.
+// Returns the client for method chaining.
+func (c *Client) WithSuppressionCheck(enabled bool) *Client {
+	c.checkSuppressions = enabled
+	return c
+}
+
+// checkRecipients returns a *SuppressedError if any recipient of params is suppressed.
+func (c *Client) checkRecipients(ctx context.Context, params *SendEmailRequest) error {
+	recipients := sendRecipients(params)
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	suppressed, err := c.Suppressions.CheckWithContext(ctx, recipients)
+	if err != nil {
+		return fmt.Errorf("envloped: suppression check failed: %w", err)
+	}
+	if len(suppressed) > 0 {
+		return &SuppressedError{Suppressions: suppressed}
+	}
+	return nil
+}
+
+// sendRecipients returns the bare addresses of all recipients of params.
+func sendRecipients(params *SendEmailRequest) []string {
+	var out []string
+	add := func(list []string) {
+		for _, raw := range list {
+			if addr, err := mail.ParseAddress(raw); err == nil {
+				out = append(out, addr.Address)
+			} else {
+				out = append(out, raw)
+			}
+		}
+	}
+
+	add(params.To)
+	add(params.Cc)
+	add(params.Bcc)
+	for _, p := range params.Personalizations {
+		add(p.To)
+	}
+	return out
+}
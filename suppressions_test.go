@@ -0,0 +1,134 @@
+package envloped
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestSuppressions_ListAddDelete(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/suppressions":
+			if got := r.URL.Query().Get("reason"); got != "bounce" {
+				t.Errorf("expected reason=bounce, got %q", got)
+			}
+			json.NewEncoder(w).Encode(SuppressionPage{Items: []Suppression{{Email: "a@example.com", Reason: SuppressionReasonBounce}}})
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/suppressions":
+			var req SuppressionRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			json.NewEncoder(w).Encode(Suppression{Email: req.Email, Reason: req.Reason})
+		case r.Method == http.MethodDelete && r.URL.EscapedPath() == "/v1/suppressions/a@example.com":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.EscapedPath())
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+
+	page, err := client.Suppressions.List(&ListSuppressionsOptions{Reason: SuppressionReasonBounce})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(page.Items) != 1 || page.Items[0].Reason != SuppressionReasonBounce {
+		t.Errorf("unexpected page: %+v", page)
+	}
+
+	sup, err := client.Suppressions.Add(&SuppressionRequest{Email: "b@example.com", Reason: SuppressionReasonManual})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sup.Email != "b@example.com" {
+		t.Errorf("unexpected suppression: %+v", sup)
+	}
+
+	if err := client.Suppressions.Delete("a@example.com"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestSuppressions_Check(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/suppressions/check" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var body struct {
+			Emails []string `json:"emails"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if len(body.Emails) != 2 {
+			t.Errorf("expected 2 emails, got %v", body.Emails)
+		}
+		w.Write([]byte(`{"data":[{"email":"b@example.com","reason":"complaint"}]}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+
+	got, err := client.Suppressions.Check([]string{"a@example.com", "b@example.com"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 1 || got[0].Reason != SuppressionReasonComplaint {
+		t.Errorf("unexpected result: %+v", got)
+	}
+
+	if _, err := client.Suppressions.Check(nil); err == nil {
+		t.Error("expected error for empty email list")
+	}
+}
+
+func TestSendEmail_SuppressionCheck(t *testing.T) {
+	t.Parallel()
+
+	var sends int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/suppressions/check":
+			var body struct {
+				Emails []string `json:"emails"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			if len(body.Emails) != 1 || body.Emails[0] != "user@example.com" {
+				t.Errorf("expected bare recipient address, got %v", body.Emails)
+			}
+			w.Write([]byte(`{"data":[{"email":"user@example.com","reason":"bounce"}]}`))
+		case "/v1/emails":
+			atomic.AddInt32(&sends, 1)
+			w.Write([]byte(`{"success":true,"messageId":"msg_1"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server).WithSuppressionCheck(true)
+
+	_, err := client.Emails.Send(&SendEmailRequest{
+		From:    "sender@example.com",
+		To:      []string{"User <user@example.com>"},
+		Subject: "Hi",
+		Text:    "Hello",
+	})
+	if !errors.Is(err, ErrSuppressed) {
+		t.Fatalf("expected ErrSuppressed, got %v", err)
+	}
+	var supErr *SuppressedError
+	if !errors.As(err, &supErr) || len(supErr.Suppressions) != 1 {
+		t.Fatalf("expected *SuppressedError with 1 suppression, got %v", err)
+	}
+	if !contains(err.Error(), "user@example.com (bounce)") {
+		t.Errorf("unexpected error message %q", err.Error())
+	}
+	if n := atomic.LoadInt32(&sends); n != 0 {
+		t.Errorf("expected no send, got %d", n)
+	}
+}
@@ -0,0 +1,217 @@
+package envloped
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Template is an email template stored on the server. Templates are rendered
+// with the TemplateData of each send, so only variables travel per message.
+type Template struct {
+	// ID is the unique identifier of the template.
+	ID string `json:"id"`
+
+	// Name is a human-readable name for the template.
+	Name string `json:"name"`
+
+	// Subject is the subject line template.
+	Subject string `json:"subject"`
+
+	// Html is the HTML body template.
+	Html string `json:"html,omitempty"`
+
+	// Text is the plain text body template.
+	Text string `json:"text,omitempty"`
+
+	// CreatedAt is when the template was created.
+	CreatedAt time.Time `json:"createdAt"`
+
+	// UpdatedAt is when the template was last changed.
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// TemplateRequest is the request body for creating or updating a template.
+// On update, zero-valued fields are left unchanged.
+type TemplateRequest struct {
+	// Name is a human-readable name for the template. Required on create.
+	Name string `json:"name,omitempty"`
+
+	// Subject is the subject line template. Required on create.
+	Subject string `json:"subject,omitempty"`
+
+	// Html is the HTML body template. On create, at least one of Html or Text is required.
+	Html string `json:"html,omitempty"`
+
+	// Text is the plain text body template. On create, at least one of Html or Text is required.
+	Text string `json:"text,omitempty"`
+}
+
+// TemplatesSvc defines the interface for the templates service.
+// This interface can be mocked in consumer tests.
+type TemplatesSvc interface {
+	// Create stores a new template.
+	Create(params *TemplateRequest) (*Template, error)
+
+	// CreateWithContext stores a new template using the provided context.
+	CreateWithContext(ctx context.Context, params *TemplateRequest) (*Template, error)
+
+	// Get retrieves a template by ID.
+	Get(templateID string) (*Template, error)
+
+	// GetWithContext retrieves a template using the provided context.
+	GetWithContext(ctx context.Context, templateID string) (*Template, error)
+
+	// List returns all templates.
+	List() ([]Template, error)
+
+	// ListWithContext returns all templates using the provided context.
+	ListWithContext(ctx context.Context) ([]Template, error)
+
+	// Update changes a template's name, subject, or bodies.
+	Update(templateID string, params *TemplateRequest) (*Template, error)
+
+	// UpdateWithContext updates a template using the provided context.
+	UpdateWithContext(ctx context.Context, templateID string, params *TemplateRequest) (*Template, error)
+
+	// Delete removes a template.
+	Delete(templateID string) error
+
+	// DeleteWithContext removes a template using the provided context.
+	DeleteWithContext(ctx context.Context, templateID string) error
+}
+
+// templatesSvcImpl implements TemplatesSvc.
+type templatesSvcImpl struct {
+	client *Client
+}
+
+// templatePath returns the API path of a template.
+func templatePath(templateID string) string {
+	return "/v1/templates/" + url.PathEscape(templateID)
+}
+
+// Create stores a new template.
+func (s *templatesSvcImpl) Create(params *TemplateRequest) (*Template, error) {
+	return s.CreateWithContext(context.Background(), params)
+}
+
+// CreateWithContext stores a new template using the provided context.
+func (s *templatesSvcImpl) CreateWithContext(ctx context.Context, params *TemplateRequest) (*Template, error) {
+	if params == nil {
+		return nil, fmt.Errorf("envloped: template params must not be nil")
+	}
+	if params.Name == "" {
+		return nil, fmt.Errorf("envloped: template name is required")
+	}
+	if params.Subject == "" {
+		return nil, fmt.Errorf("envloped: template subject is required")
+	}
+	if params.Html == "" && params.Text == "" {
+		return nil, fmt.Errorf("envloped: template html or text body is required")
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodPost, "/v1/templates", "/v1/templates", params)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to create template request: %w", err)
+	}
+
+	var resp Template
+	if err := s.client.do(req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// Get retrieves a template by ID.
+func (s *templatesSvcImpl) Get(templateID string) (*Template, error) {
+	return s.GetWithContext(context.Background(), templateID)
+}
+
+// GetWithContext retrieves a template using the provided context.
+func (s *templatesSvcImpl) GetWithContext(ctx context.Context, templateID string) (*Template, error) {
+	if templateID == "" {
+		return nil, fmt.Errorf("envloped: template id is required")
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodGet, "/v1/templates/{id}", templatePath(templateID), nil)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to create get template request: %w", err)
+	}
+
+	var resp Template
+	if err := s.client.do(req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// List returns all templates.
+func (s *templatesSvcImpl) List() ([]Template, error) {
+	return s.ListWithContext(context.Background())
+}
+
+// ListWithContext returns all templates using the provided context.
+func (s *templatesSvcImpl) ListWithContext(ctx context.Context) ([]Template, error) {
+	req, err := s.client.newRequest(ctx, http.MethodGet, "/v1/templates", "/v1/templates", nil)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to create list templates request: %w", err)
+	}
+
+	var resp listResponse[Template]
+	if err := s.client.do(req, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Data, nil
+}
+
+// Update changes a template's name, subject, or bodies.
+func (s *templatesSvcImpl) Update(templateID string, params *TemplateRequest) (*Template, error) {
+	return s.UpdateWithContext(context.Background(), templateID, params)
+}
+
+// UpdateWithContext updates a template using the provided context.
+func (s *templatesSvcImpl) UpdateWithContext(ctx context.Context, templateID string, params *TemplateRequest) (*Template, error) {
+	if templateID == "" {
+		return nil, fmt.Errorf("envloped: template id is required")
+	}
+	if params == nil {
+		return nil, fmt.Errorf("envloped: template params must not be nil")
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodPatch, "/v1/templates/{id}", templatePath(templateID), params)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to create update template request: %w", err)
+	}
+
+	var resp Template
+	if err := s.client.do(req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// Delete removes a template.
+func (s *templatesSvcImpl) Delete(templateID string) error {
+	return s.DeleteWithContext(context.Background(), templateID)
+}
+
+// DeleteWithContext removes a template using the provided context.
+func (s *templatesSvcImpl) DeleteWithContext(ctx context.Context, templateID string) error {
+	if templateID == "" {
+		return fmt.Errorf("envloped: template id is required")
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodDelete, "/v1/templates/{id}", templatePath(templateID), nil)
+	if err != nil {
+		return fmt.Errorf("envloped: failed to create delete template request: %w", err)
+	}
+
+	return s.client.do(req, nil)
+}
@@ -0,0 +1,132 @@
+package envloped
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTemplates_CRUD(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/templates":
+			var req TemplateRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			if req.Name != "welcome" || req.Html == "" {
+				t.Errorf("unexpected create body: %+v", req)
+			}
+			json.NewEncoder(w).Encode(Template{ID: "tmpl_1", Name: req.Name, Subject: req.Subject, Html: req.Html})
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/templates/tmpl_1":
+			json.NewEncoder(w).Encode(Template{ID: "tmpl_1", Name: "welcome"})
+		case r.Method == http.MethodPatch && r.URL.Path == "/v1/templates/tmpl_1":
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			if body["subject"] != "Welcome aboard, {{name}}" {
+				t.Errorf("unexpected update body: %v", body)
+			}
+			if _, ok := body["name"]; ok {
+				t.Errorf("expected name to be omitted from update, got %v", body)
+			}
+			json.NewEncoder(w).Encode(Template{ID: "tmpl_1", Subject: "Welcome aboard, {{name}}"})
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/templates":
+			json.NewEncoder(w).Encode(map[string]interface{}{"data": []Template{{ID: "tmpl_1"}, {ID: "tmpl_2"}}})
+		case r.Method == http.MethodDelete && r.URL.Path == "/v1/templates/tmpl_1":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+
+	tmpl, err := client.Templates.Create(&TemplateRequest{
+		Name:    "welcome",
+		Subject: "Welcome, {{name}}",
+		Html:    "<p>Hi {{name}}</p>",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tmpl.ID != "tmpl_1" {
+		t.Errorf("expected id tmpl_1, got %q", tmpl.ID)
+	}
+
+	if tmpl, err = client.Templates.Get("tmpl_1"); err != nil || tmpl.Name != "welcome" {
+		t.Fatalf("unexpected get result: %+v, %v", tmpl, err)
+	}
+
+	tmpl, err = client.Templates.Update("tmpl_1", &TemplateRequest{Subject: "Welcome aboard, {{name}}"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tmpl.Subject != "Welcome aboard, {{name}}" {
+		t.Errorf("unexpected subject %q", tmpl.Subject)
+	}
+
+	list, err := client.Templates.List()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(list) != 2 {
+		t.Errorf("expected 2 templates, got %d", len(list))
+	}
+
+	if err := client.Templates.Delete("tmpl_1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestTemplates_Validation(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("key")
+
+	tests := []struct {
+		name    string
+		call    func() error
+		wantErr string
+	}{
+		{
+			name:    "nil create params",
+			call:    func() error { _, err := client.Templates.Create(nil); return err },
+			wantErr: "template params must not be nil",
+		},
+		{
+			name:    "missing name",
+			call:    func() error { _, err := client.Templates.Create(&TemplateRequest{Subject: "s", Html: "h"}); return err },
+			wantErr: "template name is required",
+		},
+		{
+			name:    "missing subject",
+			call:    func() error { _, err := client.Templates.Create(&TemplateRequest{Name: "n", Html: "h"}); return err },
+			wantErr: "template subject is required",
+		},
+		{
+			name:    "missing body",
+			call:    func() error { _, err := client.Templates.Create(&TemplateRequest{Name: "n", Subject: "s"}); return err },
+			wantErr: "template html or text body is required",
+		},
+		{
+			name:    "missing id",
+			call:    func() error { _, err := client.Templates.Get(""); return err },
+			wantErr: "template id is required",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.call()
+			if err == nil || !contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package envloped
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// WithRateLimit makes the client pace its own requests to at most rps
+// requests per second, allowing bursts of up to burst requests. Requests
+// beyond the limit wait for a free slot, or fail when their context is done.
+// Pass rps <= 0 to disable pacing. Returns the client for method chaining.
+func (c *Client) WithRateLimit(rps float64, burst int) *Client {
+	if rps <= 0 {
+		c.throttle = nil
+		return c
+	}
+	c.throttle = newTokenBucket(rps, burst)
+	return c
+}
+
+// tokenBucket is a token bucket rate limiter safe for concurrent use.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64 // tokens added per second
+	burst  float64 // bucket capacity
+	tokens float64 // may go negative while callers wait for reserved tokens
+	last   time.Time
+}
+
+// newTokenBucket returns a full bucket refilled at rate tokens per second.
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// wait blocks until a token is available or ctx is done.
+func (b *tokenBucket) wait(ctx context.Context) error {
+	delay := b.reserve(time.Now())
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		b.cancel()
+		return fmt.Errorf("envloped: waiting for rate limiter: %w", ctx.Err())
+	}
+}
+
+// reserve takes a token and returns how long the caller must wait for it.
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	b.tokens--
+
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// cancel returns a reserved token that was not used.
+func (b *tokenBucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens = min(b.burst, b.tokens+1)
+}
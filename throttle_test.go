@@ -0,0 +1,89 @@
+package envloped
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTokenBucket_Reserve(t *testing.T) {
+	t.Parallel()
+
+	start := time.Now()
+	b := newTokenBucket(10, 2)
+	b.last = start
+
+	// The burst is available immediately.
+	for i := 0; i < 2; i++ {
+		if d := b.reserve(start); d != 0 {
+			t.Fatalf("reservation %d: expected no wait, got %v", i, d)
+		}
+	}
+
+	// The next token arrives after 1/rate.
+	if d := b.reserve(start); d != 100*time.Millisecond {
+		t.Errorf("expected 100ms wait, got %v", d)
+	}
+
+	// Refill is capped at the burst size.
+	later := start.Add(time.Hour)
+	b.reserve(later)
+	if b.tokens != 1 {
+		t.Errorf("expected 1 token left after refill, got %v", b.tokens)
+	}
+}
+
+func TestTokenBucket_WaitCanceled(t *testing.T) {
+	t.Parallel()
+
+	b := newTokenBucket(0.001, 1)
+	if err := b.wait(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err := b.wait(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	if b.tokens < -0.01 {
+		t.Errorf("expected canceled reservation to be returned, tokens = %v", b.tokens)
+	}
+}
+
+func TestWithRateLimit_PacesRequests(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"message":"pong"}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server).WithRateLimit(20, 1)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if _, err := client.Ping(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// One request is free; the other two wait 50ms each.
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("expected requests to be paced, took %v", elapsed)
+	}
+}
+
+func TestWithRateLimit_Disable(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("test_api_key_123").WithRateLimit(5, 1).WithRateLimit(0, 0)
+	if client.throttle != nil {
+		t.Error("expected rate limiting to be disabled")
+	}
+}
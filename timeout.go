@@ -0,0 +1,32 @@
+package envloped
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// WithRequestTimeout bounds each API call whose context has no deadline,
+// such as calls made with context.Background() by the methods without a
+// context parameter, so they fail after d rather than waiting for the HTTP
+// client's timeout. Calls whose context already has a deadline are left
+// alone. The event stream is not affected. Pass d <= 0 to disable it.
+// Returns the client for method chaining.
+func (c *Client) WithRequestTimeout(d time.Duration) *Client {
+	c.requestTimeout = max(d, 0)
+	return c
+}
+
+// withRequestTimeout returns req bounded by the default request timeout if
+// its context has no deadline. The caller must call the returned cancel
+// function once the response has been read.
+func (c *Client) withRequestTimeout(req *http.Request) (*http.Request, context.CancelFunc) {
+	if c.requestTimeout <= 0 {
+		return req, func() {}
+	}
+	if _, ok := req.Context().Deadline(); ok {
+		return req, func() {}
+	}
+	ctx, cancel := context.WithTimeout(req.Context(), c.requestTimeout)
+	return req.WithContext(ctx), cancel
+}
@@ -0,0 +1,43 @@
+package envloped
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWithRequestTimeout(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-time.After(100 * time.Millisecond):
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"message":"pong","companyId":"c"}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server).WithRequestTimeout(10 * time.Millisecond)
+
+	start := time.Now()
+	_, err := client.Ping()
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 80*time.Millisecond {
+		t.Errorf("expected call to fail fast, took %v", elapsed)
+	}
+
+	// A deadline set by the caller takes precedence.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if _, err := client.PingWithContext(ctx); err != nil {
+		t.Errorf("expected caller's deadline to be used, got %v", err)
+	}
+}
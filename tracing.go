@@ -0,0 +1,88 @@
+package envloped
+
+import (
+	"context"
+	"errors"
+)
+
+// Tracer starts a span for every API call made by the client. It lets the SDK
+// report to a tracing backend without depending on one; see the otelenvloped
+// module for an OpenTelemetry implementation. Implementations must be safe
+// for concurrent use.
+type Tracer interface {
+	// Start starts a span for an API call and returns a context carrying it.
+	// The returned context is used for the outgoing HTTP request, so trace
+	// propagation through the transport works as usual.
+	Start(ctx context.Context, method, path string) (context.Context, Span)
+}
+
+// Span is an in-progress API call started by a Tracer.
+type Span interface {
+	// End completes the span with the outcome of the call.
+	End(result SpanResult)
+}
+
+// SpanResult describes the outcome of an API call.
+type SpanResult struct {
+	// StatusCode is the HTTP status code, or 0 if no response was received.
+	StatusCode int
+
+	// MessageID is the ID of the email the call created or returned, if any.
+	MessageID string
+
+	// RetryCount is the number of earlier attempts of the call, e.g. rate
+	// limited sends retried by a BulkSender, plus the hedged request, if one
+	// was sent. It is 0 for a call that was sent once.
+	RetryCount int
+
+	// Err is the error returned to the caller, or nil on success.
+	Err error
+}
+
+// WithTracer sets a Tracer that wraps every API call in a span.
+// Returns the client for method chaining.
+func (c *Client) WithTracer(tracer Tracer) *Client {
+	c.tracer = tracer
+	return c
+}
+
+// retryCountKey is the context key of the number of earlier attempts of a
+// call.
+type retryCountKey struct{}
+
+// withRetryCount returns a copy of ctx marking calls made with it as retries
+// after n earlier attempts.
+func withRetryCount(ctx context.Context, n int) context.Context {
+	if n == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, retryCountKey{}, n)
+}
+
+// retryCountOf returns the number of earlier attempts set by withRetryCount.
+func retryCountOf(ctx context.Context) int {
+	n, _ := ctx.Value(retryCountKey{}).(int)
+	return n
+}
+
+// statusCodeOf returns the HTTP status code carried by err, or 0 if err is
+// not an API error.
+func statusCodeOf(err error) int {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+// messageIDOf returns the email message ID from a decoded response, if any.
+func messageIDOf(target interface{}) string {
+	switch v := target.(type) {
+	case *SendEmailResponse:
+		return v.MessageId
+	case *Email:
+		return v.ID
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,161 @@
+package envloped
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+type ctxKey struct{}
+
+// recordingTracer records spans for assertions.
+type recordingTracer struct {
+	mu    sync.Mutex
+	spans []*recordingSpan
+}
+
+type recordingSpan struct {
+	method, path string
+	result       SpanResult
+	ended        bool
+}
+
+func (t *recordingTracer) Start(ctx context.Context, method, path string) (context.Context, Span) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	s := &recordingSpan{method: method, path: path}
+	t.spans = append(t.spans, s)
+	return context.WithValue(ctx, ctxKey{}, s), s
+}
+
+func (s *recordingSpan) End(result SpanResult) {
+	s.result = result
+	s.ended = true
+}
+
+func TestTracer_SendEmail(t *testing.T) {
+	t.Parallel()
+
+	tracer := &recordingTracer{}
+	var sawSpanContext bool
+
+	transport := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		_, sawSpanContext = r.Context().Value(ctxKey{}).(*recordingSpan)
+		return http.DefaultTransport.RoundTrip(r)
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(SendEmailResponse{Success: true, MessageId: "msg_traced"})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server).
+		WithHTTPClient(&http.Client{Transport: transport}).
+		WithTracer(tracer)
+
+	_, err := client.Emails.Send(&SendEmailRequest{
+		From:    "sender@example.com",
+		To:      []string{"recipient@example.com"},
+		Subject: "Test",
+		Html:    "<p>Hi</p>",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(tracer.spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(tracer.spans))
+	}
+	span := tracer.spans[0]
+	if !span.ended {
+		t.Error("expected span to be ended")
+	}
+	if span.method != http.MethodPost || span.path != "/v1/emails" {
+		t.Errorf("unexpected span target: %s %s", span.method, span.path)
+	}
+	if span.result.StatusCode != http.StatusOK || span.result.MessageID != "msg_traced" || span.result.Err != nil {
+		t.Errorf("unexpected span result: %+v", span.result)
+	}
+	if !sawSpanContext {
+		t.Error("expected the span context to be passed to the transport")
+	}
+}
+
+func TestTracer_ErrorResponse(t *testing.T) {
+	t.Parallel()
+
+	tracer := &recordingTracer{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(map[string]string{"error": "Invalid API key"})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server).WithTracer(tracer)
+	_, err := client.Ping()
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	result := tracer.spans[0].result
+	if result.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected status 401, got %d", result.StatusCode)
+	}
+	if !errors.Is(result.Err, ErrUnauthorized) {
+		t.Errorf("expected span error to be ErrUnauthorized, got %v", result.Err)
+	}
+}
+
+// roundTripperFunc adapts a function to http.RoundTripper.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestTracer_RetryCount(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("X-RateLimit-Limit", "10")
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"error":"rate limit exceeded"}`))
+			return
+		}
+		json.NewEncoder(w).Encode(SendEmailResponse{Success: true, MessageId: "msg_1"})
+	}))
+	defer server.Close()
+
+	tracer := &recordingTracer{}
+	client := newTestClient(t, server).WithTracer(tracer)
+	results := NewBulkSender(client, 1).Send(context.Background(), []*SendEmailRequest{{
+		From:    "sender@example.com",
+		To:      []string{"recipient@example.com"},
+		Subject: "Test",
+		Html:    "<p>Hi</p>",
+	}})
+	if results[0].Err != nil {
+		t.Fatalf("unexpected error: %v", results[0].Err)
+	}
+
+	if len(tracer.spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(tracer.spans))
+	}
+	for i, span := range tracer.spans {
+		if span.result.RetryCount != i {
+			t.Errorf("expected span %d to have retry count %d, got %d", i, i, span.result.RetryCount)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package envloped
+
+// Bool returns a pointer to v, for optional fields such as
+// SendEmailRequest.TrackOpens.
+func Bool(v bool) *bool {
+	return &v
+}
+
+// WithTrackOpens sets whether sends enable open tracking when they do not set
+// SendEmailRequest.TrackOpens themselves, overriding the account setting.
+// Disable it for privacy-sensitive transactional mail.
+// Returns the client for method chaining.
+func (c *Client) WithTrackOpens(enabled bool) *Client {
+	c.trackOpens = Bool(enabled)
+	return c
+}
+
+// WithTrackClicks sets whether sends enable click tracking when they do not
+// set SendEmailRequest.TrackClicks themselves, overriding the account setting.
+// Returns the client for method chaining.
+func (c *Client) WithTrackClicks(enabled bool) *Client {
+	c.trackClicks = Bool(enabled)
+	return c
+}
+
+// withTrackingDefaults returns params with the client's tracking defaults
+// applied to unset fields. params itself is not modified.
+func (c *Client) withTrackingDefaults(params *SendEmailRequest) *SendEmailRequest {
+	applyOpens := params.TrackOpens == nil && c.trackOpens != nil
+	applyClicks := params.TrackClicks == nil && c.trackClicks != nil
+	if !applyOpens && !applyClicks {
+		return params
+	}
+
+	cp := *params
+	if applyOpens {
+		cp.TrackOpens = Bool(*c.trackOpens)
+	}
+	if applyClicks {
+		cp.TrackClicks = Bool(*c.trackClicks)
+	}
+	return &cp
+}
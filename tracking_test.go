@@ -0,0 +1,66 @@
+package envloped
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSendEmail_Tracking(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		configure  func(*Client)
+		opens      *bool
+		clicks     *bool
+		wantOpens  any
+		wantClicks any
+	}{
+		{"unset", func(c *Client) {}, nil, nil, nil, nil},
+		{"per message", func(c *Client) {}, Bool(false), Bool(true), false, true},
+		{"client default", func(c *Client) { c.WithTrackOpens(false).WithTrackClicks(false) }, nil, nil, false, false},
+		{"message overrides default", func(c *Client) { c.WithTrackOpens(false) }, Bool(true), nil, true, nil},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var body map[string]any
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				json.NewDecoder(r.Body).Decode(&body)
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"success":true,"messageId":"msg_1"}`))
+			}))
+			defer server.Close()
+
+			client := newTestClient(t, server)
+			tt.configure(client)
+
+			params := &SendEmailRequest{
+				From:        "sender@example.com",
+				To:          []string{"recipient@example.com"},
+				Subject:     "Hi",
+				Text:        "Hello",
+				TrackOpens:  tt.opens,
+				TrackClicks: tt.clicks,
+			}
+			if _, err := client.Emails.Send(params); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got := body["trackOpens"]; got != tt.wantOpens {
+				t.Errorf("expected trackOpens %v, got %v", tt.wantOpens, got)
+			}
+			if got := body["trackClicks"]; got != tt.wantClicks {
+				t.Errorf("expected trackClicks %v, got %v", tt.wantClicks, got)
+			}
+			if params.TrackOpens != tt.opens || params.TrackClicks != tt.clicks {
+				t.Error("expected caller's params to be unmodified")
+			}
+		})
+	}
+}
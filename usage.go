@@ -0,0 +1,41 @@
+package envloped
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// Usage returns the account's current daily and monthly email counters and limits.
+func (c *Client) Usage() (*EmailUsage, error) {
+	return c.UsageWithContext(context.Background())
+}
+
+// UsageWithContext returns the account's email usage using the given context.
+func (c *Client) UsageWithContext(ctx context.Context) (*EmailUsage, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, "/v1/usage", "/v1/usage", nil)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to create usage request: %w", err)
+	}
+
+	var resp EmailUsage
+	if err := c.do(req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// DailyRemaining returns how many more emails can be sent today.
+// ok is false if the daily volume is unlimited.
+func (u *EmailUsage) DailyRemaining() (remaining int, ok bool) {
+	if u.DailyLimit == nil {
+		return 0, false
+	}
+	return max(*u.DailyLimit-u.DailyCount, 0), true
+}
+
+// MonthlyRemaining returns how many more emails can be sent this month.
+func (u *EmailUsage) MonthlyRemaining() int {
+	return max(u.MonthlyLimit-u.MonthlyCount, 0)
+}
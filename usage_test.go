@@ -0,0 +1,66 @@
+package envloped
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestUsage_Success(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/v1/usage" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"dailyCount":80,"monthlyCount":4900,"dailyLimit":100,"monthlyLimit":5000}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	usage, err := client.Usage()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if usage.DailyCount != 80 || usage.MonthlyCount != 4900 {
+		t.Errorf("unexpected usage: %+v", usage)
+	}
+	if remaining, ok := usage.DailyRemaining(); !ok || remaining != 20 {
+		t.Errorf("expected 20 remaining today, got %d (ok=%v)", remaining, ok)
+	}
+	if remaining := usage.MonthlyRemaining(); remaining != 100 {
+		t.Errorf("expected 100 remaining this month, got %d", remaining)
+	}
+}
+
+func TestEmailUsage_Remaining(t *testing.T) {
+	t.Parallel()
+
+	over := 10
+	tests := []struct {
+		name        string
+		usage       EmailUsage
+		wantDaily   int
+		wantLimited bool
+		wantMonthly int
+	}{
+		{"unlimited daily", EmailUsage{DailyCount: 5, MonthlyCount: 5, MonthlyLimit: 10}, 0, false, 5},
+		{"over limit", EmailUsage{DailyCount: 12, DailyLimit: &over, MonthlyCount: 20, MonthlyLimit: 10}, 0, true, 0},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			daily, limited := tt.usage.DailyRemaining()
+			if daily != tt.wantDaily || limited != tt.wantLimited {
+				t.Errorf("DailyRemaining() = %d, %v; want %d, %v", daily, limited, tt.wantDaily, tt.wantLimited)
+			}
+			if got := tt.usage.MonthlyRemaining(); got != tt.wantMonthly {
+				t.Errorf("MonthlyRemaining() = %d, want %d", got, tt.wantMonthly)
+			}
+		})
+	}
+}
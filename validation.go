@@ -0,0 +1,54 @@
+package envloped
+
+import (
+	"fmt"
+	"net/mail"
+)
+
+// WithStrictValidation enables syntax validation of the From, To, Cc, and Bcc
+// addresses of every send before any network call. Invalid addresses fail
+// with a *ValidationError naming the field. Returns the client for method chaining.
+func (c *Client) WithStrictValidation(enabled bool) *Client {
+	c.strictValidation = enabled
+	return c
+}
+
+// validateAddresses checks the syntax of every sender and recipient address of params.
+func validateAddresses(params *SendEmailRequest) error {
+	if err := validateAddress("from", "from", params.From); err != nil {
+		return err
+	}
+	fields := []struct {
+		name  string
+		addrs []string
+	}{
+		{"to", params.To},
+		{"cc", params.Cc},
+		{"bcc", params.Bcc},
+	}
+	for _, f := range fields {
+		for i, addr := range f.addrs {
+			if err := validateAddress(f.name, fmt.Sprintf("%s[%d]", f.name, i), addr); err != nil {
+				return err
+			}
+		}
+	}
+	for i, p := range params.Personalizations {
+		for j, addr := range p.To {
+			if err := validateAddress(fmt.Sprintf("personalizations[%d].to", i), fmt.Sprintf("personalizations[%d].to[%d]", i, j), addr); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validateAddress checks that addr is a single RFC 5322 address, optionally
+// with a display name. name describes the address in the error message and
+// field is its path in the request.
+func validateAddress(name, field, addr string) error {
+	if _, err := mail.ParseAddress(addr); err != nil {
+		return fieldError(field, "invalid", "invalid %s address %q: %v", name, addr, err)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package envloped
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestStrictValidation(t *testing.T) {
+	t.Parallel()
+
+	valid := func() SendEmailRequest {
+		return SendEmailRequest{From: "My App <a@b.com>", To: []string{"b@c.com"}, Subject: "s", Text: "x"}
+	}
+
+	tests := []struct {
+		name      string
+		mutate    func(*SendEmailRequest)
+		wantErr   string
+		wantField string
+	}{
+		{"invalid from", func(p *SendEmailRequest) { p.From = "My App" }, `invalid from address "My App"`, "from"},
+		{"invalid to", func(p *SendEmailRequest) { p.To = []string{"b@c.com", "b@"} }, `invalid to address "b@"`, "to[1]"},
+		{"list in one to entry", func(p *SendEmailRequest) { p.To = []string{"b@c.com, d@e.com"} }, "invalid to address", "to[0]"},
+		{"invalid personalization to", func(p *SendEmailRequest) {
+			p.To = nil
+			p.Personalizations = []Personalization{{To: []string{"a@b.com", "nope"}}}
+		}, `invalid personalizations[0].to address "nope"`, "personalizations[0].to[1]"},
+	}
+
+	client := NewClient("key").WithStrictValidation(true)
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			params := valid()
+			tt.mutate(&params)
+
+			_, err := client.Emails.Send(&params)
+			if !errors.Is(err, ErrValidation) {
+				t.Fatalf("expected ErrValidation, got %v", err)
+			}
+			var ve *ValidationError
+			if !errors.As(err, &ve) || ve.StatusCode != 0 {
+				t.Fatalf("expected client-side *ValidationError, got %#v", err)
+			}
+			if !contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error to contain %q, got %q", tt.wantErr, err.Error())
+			}
+			if len(ve.Fields) != 1 || ve.Fields[0].Field != tt.wantField || ve.Fields[0].Code != "invalid" {
+				t.Errorf("expected invalid field %q, got %+v", tt.wantField, ve.Fields)
+			}
+			if contains(err.Error(), "status") {
+				t.Errorf("expected no status in client-side error, got %q", err.Error())
+			}
+		})
+	}
+}
+
+func TestStrictValidation_Disabled(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Write([]byte(`{"success":true,"messageId":"msg_1"}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	_, err := client.Emails.Send(&SendEmailRequest{From: "a@b.com", To: []string{"not-an-address"}, Subject: "s", Text: "x"})
+	if err != nil {
+		t.Fatalf("expected lenient validation by default, got %v", err)
+	}
+	if atomic.LoadInt32(&calls) != 1 {
+		t.Error("expected request to reach the API")
+	}
+}
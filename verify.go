@@ -0,0 +1,114 @@
+package envloped
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// Verdict is the deliverability classification of an email address.
+type Verdict string
+
+// Address verification verdicts.
+const (
+	// VerdictValid means the mailbox exists and accepts mail.
+	VerdictValid Verdict = "valid"
+
+	// VerdictRisky means the address may accept mail but delivery is uncertain
+	// (e.g., catch-all domains, full mailboxes, role accounts).
+	VerdictRisky Verdict = "risky"
+
+	// VerdictUndeliverable means mail to the address will bounce.
+	VerdictUndeliverable Verdict = "undeliverable"
+
+	// VerdictDisposable means the address belongs to a throwaway email provider.
+	VerdictDisposable Verdict = "disposable"
+)
+
+// VerificationResult is the outcome of verifying a single email address.
+type VerificationResult struct {
+	// Email is the address that was verified.
+	Email string `json:"email"`
+
+	// Verdict is the overall deliverability classification.
+	Verdict Verdict `json:"verdict"`
+
+	// Reason is a short machine-readable explanation of the verdict
+	// (e.g., "mailbox_not_found", "catch_all").
+	Reason string `json:"reason,omitempty"`
+
+	// MXFound reports whether the domain has valid MX records.
+	MXFound bool `json:"mxFound"`
+
+	// Disposable reports whether the domain is a known disposable email provider.
+	Disposable bool `json:"disposable"`
+
+	// RoleAccount reports whether the address is a role account (e.g., info@, support@).
+	RoleAccount bool `json:"roleAccount"`
+
+	// Suggestion is a corrected address for likely typos (e.g., "gmial.com" -> "gmail.com").
+	Suggestion string `json:"suggestion,omitempty"`
+}
+
+// maxVerifyBatch is the maximum number of addresses per batch verification request.
+const maxVerifyBatch = 100
+
+// Verify checks the deliverability of an email address without sending to it.
+func (c *Client) Verify(email string) (*VerificationResult, error) {
+	return c.VerifyWithContext(context.Background(), email)
+}
+
+// VerifyWithContext checks the deliverability of an email address using the given context.
+func (c *Client) VerifyWithContext(ctx context.Context, email string) (*VerificationResult, error) {
+	if email == "" {
+		return nil, fmt.Errorf("envloped: email address is required")
+	}
+
+	body := struct {
+		Email string `json:"email"`
+	}{email}
+
+	req, err := c.newRequest(ctx, http.MethodPost, "/v1/verify", "/v1/verify", body)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to create verify request: %w", err)
+	}
+
+	var resp VerificationResult
+	if err := c.do(req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// VerifyBatch checks the deliverability of up to 100 email addresses in one request.
+// Results are returned in the same order as emails.
+func (c *Client) VerifyBatch(emails []string) ([]VerificationResult, error) {
+	return c.VerifyBatchWithContext(context.Background(), emails)
+}
+
+// VerifyBatchWithContext checks a batch of email addresses using the given context.
+func (c *Client) VerifyBatchWithContext(ctx context.Context, emails []string) ([]VerificationResult, error) {
+	if len(emails) == 0 {
+		return nil, fmt.Errorf("envloped: at least one email address is required")
+	}
+	if len(emails) > maxVerifyBatch {
+		return nil, fmt.Errorf("envloped: too many addresses (%d), batch verification allows at most %d", len(emails), maxVerifyBatch)
+	}
+
+	body := struct {
+		Emails []string `json:"emails"`
+	}{emails}
+
+	req, err := c.newRequest(ctx, http.MethodPost, "/v1/verify/batch", "/v1/verify/batch", body)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to create batch verify request: %w", err)
+	}
+
+	var resp listResponse[VerificationResult]
+	if err := c.do(req, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Data, nil
+}
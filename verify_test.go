@@ -0,0 +1,89 @@
+package envloped
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestVerify_Success(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/verify" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["email"] != "user@mailinator.com" {
+			t.Errorf("unexpected email: %q", body["email"])
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(VerificationResult{
+			Email:      body["email"],
+			Verdict:    VerdictDisposable,
+			MXFound:    true,
+			Disposable: true,
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	res, err := client.Verify("user@mailinator.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.Verdict != VerdictDisposable || !res.Disposable {
+		t.Errorf("unexpected result: %+v", res)
+	}
+}
+
+func TestVerifyBatch(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/verify/batch" {
+			t.Errorf("expected path /v1/verify/batch, got %s", r.URL.Path)
+		}
+		var body struct {
+			Emails []string `json:"emails"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+
+		results := make([]VerificationResult, len(body.Emails))
+		for i, e := range body.Emails {
+			results[i] = VerificationResult{Email: e, Verdict: VerdictValid}
+		}
+		results[1].Verdict = VerdictUndeliverable
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": results})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	results, err := client.VerifyBatch([]string{"a@example.com", "b@example.com"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 2 || results[1].Verdict != VerdictUndeliverable {
+		t.Errorf("unexpected results: %+v", results)
+	}
+}
+
+func TestVerify_Validation(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("key")
+	if _, err := client.Verify(""); err == nil {
+		t.Error("expected error for empty address")
+	}
+	if _, err := client.VerifyBatch(nil); err == nil {
+		t.Error("expected error for empty batch")
+	}
+	if _, err := client.VerifyBatch(make([]string, maxVerifyBatch+1)); err == nil {
+		t.Error("expected error for oversized batch")
+	}
+}
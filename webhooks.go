@@ -0,0 +1,238 @@
+package envloped
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Webhook is an endpoint subscribed to account events.
+type Webhook struct {
+	// ID is the unique identifier of the webhook.
+	ID string `json:"id"`
+
+	// URL is the HTTPS endpoint events are posted to.
+	URL string `json:"url"`
+
+	// EventTypes are the event types delivered to the endpoint.
+	EventTypes []EventType `json:"eventTypes"`
+
+	// Enabled reports whether events are currently delivered.
+	Enabled bool `json:"enabled"`
+
+	// SigningSecret is used to verify webhook signatures. It is only returned
+	// when the webhook is created.
+	SigningSecret string `json:"signingSecret,omitempty"`
+
+	// CreatedAt is when the webhook was created.
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// WebhookRequest is the request body for creating or updating a webhook.
+// On update, zero-valued fields are left unchanged.
+type WebhookRequest struct {
+	// URL is the HTTPS endpoint events are posted to. Required on create.
+	URL string `json:"url,omitempty"`
+
+	// EventTypes selects the event types to deliver. Required on create.
+	EventTypes []EventType `json:"eventTypes,omitempty"`
+
+	// Enabled pauses or resumes delivery. Webhooks are enabled on create when nil.
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// WebhooksSvc defines the interface for the webhooks service.
+// This interface can be mocked in consumer tests.
+type WebhooksSvc interface {
+	// Create registers a webhook endpoint.
+	Create(params *WebhookRequest) (*Webhook, error)
+
+	// CreateWithContext registers a webhook endpoint using the provided context.
+	CreateWithContext(ctx context.Context, params *WebhookRequest) (*Webhook, error)
+
+	// Get retrieves a webhook by ID.
+	Get(webhookID string) (*Webhook, error)
+
+	// GetWithContext retrieves a webhook using the provided context.
+	GetWithContext(ctx context.Context, webhookID string) (*Webhook, error)
+
+	// List returns all webhooks.
+	List() ([]Webhook, error)
+
+	// ListWithContext returns all webhooks using the provided context.
+	ListWithContext(ctx context.Context) ([]Webhook, error)
+
+	// Update changes a webhook's URL, event types, or enabled state.
+	Update(webhookID string, params *WebhookRequest) (*Webhook, error)
+
+	// UpdateWithContext updates a webhook using the provided context.
+	UpdateWithContext(ctx context.Context, webhookID string, params *WebhookRequest) (*Webhook, error)
+
+	// Delete removes a webhook.
+	Delete(webhookID string) error
+
+	// DeleteWithContext removes a webhook using the provided context.
+	DeleteWithContext(ctx context.Context, webhookID string) error
+
+	// ListDeliveries returns a page of a webhook's deliveries matching opts.
+	ListDeliveries(webhookID string, opts *ListWebhookDeliveriesOptions) (*WebhookDeliveryPage, error)
+
+	// ListDeliveriesWithContext returns a page of deliveries using the provided context.
+	ListDeliveriesWithContext(ctx context.Context, webhookID string, opts *ListWebhookDeliveriesOptions) (*WebhookDeliveryPage, error)
+
+	// Replay posts the given events to a webhook again.
+	Replay(webhookID string, eventIDs ...string) ([]WebhookDelivery, error)
+
+	// ReplayWithContext posts events to a webhook again using the provided context.
+	ReplayWithContext(ctx context.Context, webhookID string, eventIDs ...string) ([]WebhookDelivery, error)
+}
+
+// webhooksSvcImpl implements WebhooksSvc.
+type webhooksSvcImpl struct {
+	client *Client
+}
+
+// webhookPath returns the API path of a webhook, with optional suffix.
+func webhookPath(webhookID, suffix string) string {
+	return "/v1/webhooks/" + url.PathEscape(webhookID) + suffix
+}
+
+// Create registers a webhook endpoint.
+func (s *webhooksSvcImpl) Create(params *WebhookRequest) (*Webhook, error) {
+	return s.CreateWithContext(context.Background(), params)
+}
+
+// CreateWithContext registers a webhook endpoint using the provided context.
+// Store the returned SigningSecret to verify incoming webhook requests.
+func (s *webhooksSvcImpl) CreateWithContext(ctx context.Context, params *WebhookRequest) (*Webhook, error) {
+	if params == nil {
+		return nil, fmt.Errorf("envloped: webhook params must not be nil")
+	}
+	if err := validateWebhookURL(params.URL); err != nil {
+		return nil, err
+	}
+	if len(params.EventTypes) == 0 {
+		return nil, fmt.Errorf("envloped: at least one event type is required")
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodPost, "/v1/webhooks", "/v1/webhooks", params)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to create webhook request: %w", err)
+	}
+
+	var resp Webhook
+	if err := s.client.do(req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// Get retrieves a webhook by ID.
+func (s *webhooksSvcImpl) Get(webhookID string) (*Webhook, error) {
+	return s.GetWithContext(context.Background(), webhookID)
+}
+
+// GetWithContext retrieves a webhook using the provided context.
+func (s *webhooksSvcImpl) GetWithContext(ctx context.Context, webhookID string) (*Webhook, error) {
+	if webhookID == "" {
+		return nil, fmt.Errorf("envloped: webhook id is required")
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodGet, "/v1/webhooks/{id}", webhookPath(webhookID, ""), nil)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to create get webhook request: %w", err)
+	}
+
+	var resp Webhook
+	if err := s.client.do(req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// List returns all webhooks.
+func (s *webhooksSvcImpl) List() ([]Webhook, error) {
+	return s.ListWithContext(context.Background())
+}
+
+// ListWithContext returns all webhooks using the provided context.
+func (s *webhooksSvcImpl) ListWithContext(ctx context.Context) ([]Webhook, error) {
+	req, err := s.client.newRequest(ctx, http.MethodGet, "/v1/webhooks", "/v1/webhooks", nil)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to create list webhooks request: %w", err)
+	}
+
+	var resp listResponse[Webhook]
+	if err := s.client.do(req, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Data, nil
+}
+
+// Update changes a webhook's URL, event types, or enabled state.
+func (s *webhooksSvcImpl) Update(webhookID string, params *WebhookRequest) (*Webhook, error) {
+	return s.UpdateWithContext(context.Background(), webhookID, params)
+}
+
+// UpdateWithContext updates a webhook using the provided context.
+func (s *webhooksSvcImpl) UpdateWithContext(ctx context.Context, webhookID string, params *WebhookRequest) (*Webhook, error) {
+	if webhookID == "" {
+		return nil, fmt.Errorf("envloped: webhook id is required")
+	}
+	if params == nil {
+		return nil, fmt.Errorf("envloped: webhook params must not be nil")
+	}
+	if params.URL != "" {
+		if err := validateWebhookURL(params.URL); err != nil {
+			return nil, err
+		}
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodPatch, "/v1/webhooks/{id}", webhookPath(webhookID, ""), params)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to create update webhook request: %w", err)
+	}
+
+	var resp Webhook
+	if err := s.client.do(req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// Delete removes a webhook.
+func (s *webhooksSvcImpl) Delete(webhookID string) error {
+	return s.DeleteWithContext(context.Background(), webhookID)
+}
+
+// DeleteWithContext removes a webhook using the provided context.
+func (s *webhooksSvcImpl) DeleteWithContext(ctx context.Context, webhookID string) error {
+	if webhookID == "" {
+		return fmt.Errorf("envloped: webhook id is required")
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodDelete, "/v1/webhooks/{id}", webhookPath(webhookID, ""), nil)
+	if err != nil {
+		return fmt.Errorf("envloped: failed to create delete webhook request: %w", err)
+	}
+
+	return s.client.do(req, nil)
+}
+
+// validateWebhookURL checks that rawURL is an absolute HTTPS URL.
+func validateWebhookURL(rawURL string) error {
+	if rawURL == "" {
+		return fmt.Errorf("envloped: webhook url is required")
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("envloped: webhook url %q must be an absolute https url", rawURL)
+	}
+	return nil
+}
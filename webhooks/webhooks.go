@@ -0,0 +1,231 @@
+// Package webhooks receives Envloped webhook requests: it verifies their
+// signatures, rejects stale timestamps, and decodes the events.
+//
+// Usage:
+//
+//	http.Handle("/hooks/envloped", webhooks.NewHandler(secret, func(e envloped.Event) error {
+//	    switch e.Type {
+//	    case envloped.EventTypeBounced:
+//	        return suppress(e.Recipient)
+//	    }
+//	    return nil
+//	}))
+//
+// Each request carries an Envloped-Signature header of the form
+// "t=<unix timestamp>,v1=<signature>", where the signature is the hex-encoded
+// HMAC-SHA256 of the timestamp, a ".", and the request body, keyed with the
+// webhook's SigningSecret. While a secret is being rotated, the header holds
+// one v1 signature per active secret.
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/envloped/envloped-go"
+)
+
+// SignatureHeader is the request header that carries the webhook signature.
+const SignatureHeader = "Envloped-Signature"
+
+// DefaultTolerance is how far the signature timestamp may be from the
+// current time before a request is rejected as stale.
+const DefaultTolerance = 5 * time.Minute
+
+// maxBodySize is the largest request body the handler accepts.
+const maxBodySize = 1 << 20
+
+var (
+	// ErrMissingSecret is returned by Verify when the secret is empty, since
+	// anyone could sign a payload with an empty key.
+	ErrMissingSecret = errors.New("webhooks: missing signing secret")
+
+	// ErrMissingSignature is returned when a request has no signature header.
+	ErrMissingSignature = errors.New("webhooks: missing signature")
+
+	// ErrInvalidSignature is returned when no signature in the header
+	// matches the body and secret.
+	ErrInvalidSignature = errors.New("webhooks: invalid signature")
+
+	// ErrStaleTimestamp is returned when the signature timestamp is outside
+	// the tolerance, which protects against replayed requests.
+	ErrStaleTimestamp = errors.New("webhooks: timestamp outside tolerance")
+)
+
+// Sign returns the signature header value for payload signed with secret at
+// time t. It is mainly useful to build requests in tests.
+func Sign(payload []byte, secret string, t time.Time) string {
+	ts := strconv.FormatInt(t.Unix(), 10)
+	return "t=" + ts + ",v1=" + signature(payload, secret, ts)
+}
+
+// Verify checks that header, the value of the SignatureHeader, is a valid
+// signature of payload with secret, made within tolerance of now. A zero
+// tolerance disables the timestamp check. It returns ErrMissingSecret if
+// secret is empty.
+func Verify(payload []byte, header, secret string, tolerance time.Duration) error {
+	return verify(payload, header, secret, tolerance, time.Now())
+}
+
+// verify implements Verify with an explicit current time.
+func verify(payload []byte, header, secret string, tolerance time.Duration, now time.Time) error {
+	if secret == "" {
+		return ErrMissingSecret
+	}
+	if header == "" {
+		return ErrMissingSignature
+	}
+
+	var ts string
+	var sigs []string
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "t":
+			ts = value
+		case "v1":
+			sigs = append(sigs, value)
+		}
+	}
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil || len(sigs) == 0 {
+		return fmt.Errorf("%w: malformed header", ErrInvalidSignature)
+	}
+
+	expected := signature(payload, secret, ts)
+	valid := false
+	for _, sig := range sigs {
+		if hmac.Equal([]byte(sig), []byte(expected)) {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return ErrInvalidSignature
+	}
+
+	if tolerance > 0 {
+		if d := now.Sub(time.Unix(unix, 0)); d > tolerance || d < -tolerance {
+			return ErrStaleTimestamp
+		}
+	}
+	return nil
+}
+
+// signature returns the hex-encoded HMAC-SHA256 of the signed payload.
+func signature(payload []byte, secret, ts string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Option configures a handler created by NewHandler.
+type Option func(*handler)
+
+// WithTolerance sets how far the signature timestamp may be from the
+// current time. Defaults to DefaultTolerance; zero disables the check.
+func WithTolerance(d time.Duration) Option {
+	return func(h *handler) {
+		h.tolerance = d
+	}
+}
+
+// WithOnError sets a function called with every error that makes the
+// handler reject a request, including errors returned by the event handler,
+// e.g. for logging.
+func WithOnError(fn func(*http.Request, error)) Option {
+	return func(h *handler) {
+		h.onError = fn
+	}
+}
+
+// handler is the http.Handler returned by NewHandler.
+type handler struct {
+	secret    string
+	fn        func(envloped.Event) error
+	tolerance time.Duration
+	onError   func(*http.Request, error)
+	now       func() time.Time
+}
+
+// NewHandler returns an http.Handler that verifies webhook requests signed
+// with secret, decodes their events, and calls fn with each of them.
+//
+// The handler responds 200 when fn returns nil, which acknowledges the
+// event. When fn returns an error, it responds 500 so the event is delivered
+// again later; since events may therefore arrive more than once, fn should
+// be idempotent, e.g. by recording Event.ID. Requests with a missing,
+// invalid, or stale signature are rejected with 401, and malformed bodies
+// with 400, without calling fn. It panics if secret is empty, which usually
+// means the secret was not configured.
+func NewHandler(secret string, fn func(envloped.Event) error, opts ...Option) http.Handler {
+	if secret == "" {
+		panic("webhooks: NewHandler called with an empty secret")
+	}
+	h := &handler{
+		secret:    secret,
+		fn:        fn,
+		tolerance: DefaultTolerance,
+		now:       time.Now,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// ServeHTTP implements http.Handler.
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		h.fail(w, r, http.StatusMethodNotAllowed, fmt.Errorf("webhooks: method %s not allowed", r.Method))
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			h.fail(w, r, http.StatusRequestEntityTooLarge, err)
+			return
+		}
+		h.fail(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := verify(body, r.Header.Get(SignatureHeader), h.secret, h.tolerance, h.now()); err != nil {
+		h.fail(w, r, http.StatusUnauthorized, err)
+		return
+	}
+
+	e, err := envloped.ParseEvent(body)
+	if err != nil {
+		h.fail(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.fn(e); err != nil {
+		h.fail(w, r, http.StatusInternalServerError, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// fail reports err and responds with status. The error is not included in
+// the response, so internal details do not leak to the caller.
+func (h *handler) fail(w http.ResponseWriter, r *http.Request, status int, err error) {
+	if h.onError != nil {
+		h.onError(r, err)
+	}
+	http.Error(w, http.StatusText(status), status)
+}
@@ -0,0 +1,142 @@
+package webhooks
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/envloped/envloped-go"
+)
+
+const testSecret = "whsec_test"
+
+const testEvent = `{"id":"evt_1","type":"bounced","messageId":"msg_1","recipient":"ada@example.com","data":{"bounceType":"hard"}}`
+
+func TestVerify(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1700000000, 0)
+	payload := []byte(testEvent)
+	valid := Sign(payload, testSecret, now)
+
+	tests := []struct {
+		name    string
+		header  string
+		payload string
+		now     time.Time
+		want    error
+	}{
+		{"valid", valid, testEvent, now, nil},
+		{"within tolerance", valid, testEvent, now.Add(4 * time.Minute), nil},
+		{"rotated secret", valid + ",v1=" + strings.Repeat("0", 64), testEvent, now, nil},
+		{"missing", "", testEvent, now, ErrMissingSignature},
+		{"malformed", "v1=abc", testEvent, now, ErrInvalidSignature},
+		{"wrong secret", Sign(payload, "other", now), testEvent, now, ErrInvalidSignature},
+		{"tampered body", valid, strings.Replace(testEvent, "hard", "soft", 1), now, ErrInvalidSignature},
+		{"stale", valid, testEvent, now.Add(6 * time.Minute), ErrStaleTimestamp},
+		{"future", valid, testEvent, now.Add(-6 * time.Minute), ErrStaleTimestamp},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := verify([]byte(tt.payload), tt.header, testSecret, DefaultTolerance, tt.now)
+			if !errors.Is(err, tt.want) || (tt.want == nil && err != nil) {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestVerify_EmptySecret(t *testing.T) {
+	t.Parallel()
+
+	payload := []byte(testEvent)
+	forged := Sign(payload, "", time.Now())
+	if err := Verify(payload, forged, "", DefaultTolerance); !errors.Is(err, ErrMissingSecret) {
+		t.Errorf("expected ErrMissingSecret, got %v", err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected NewHandler to panic on an empty secret")
+		}
+	}()
+	NewHandler("", func(envloped.Event) error { return nil })
+}
+
+func TestNewHandler(t *testing.T) {
+	t.Parallel()
+
+	errHandler := errors.New("database unavailable")
+	tests := []struct {
+		name       string
+		method     string
+		body       string
+		sign       bool
+		signedAt   time.Time
+		handlerErr error
+		wantStatus int
+		wantCalled bool
+	}{
+		{"ack", http.MethodPost, testEvent, true, time.Now(), nil, http.StatusOK, true},
+		{"handler error retries", http.MethodPost, testEvent, true, time.Now(), errHandler, http.StatusInternalServerError, true},
+		{"unsigned", http.MethodPost, testEvent, false, time.Now(), nil, http.StatusUnauthorized, false},
+		{"stale", http.MethodPost, testEvent, true, time.Now().Add(-time.Hour), nil, http.StatusUnauthorized, false},
+		{"malformed event", http.MethodPost, `{"id":`, true, time.Now(), nil, http.StatusBadRequest, false},
+		{"wrong method", http.MethodGet, "", false, time.Now(), nil, http.StatusMethodNotAllowed, false},
+		{"too large", http.MethodPost, strings.Repeat("x", maxBodySize+1), true, time.Now(), nil, http.StatusRequestEntityTooLarge, false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var called bool
+			var reported error
+			h := NewHandler(testSecret, func(e envloped.Event) error {
+				called = true
+				if e.ID != "evt_1" || e.Bounce == nil || e.Bounce.Type != envloped.BounceTypeHard {
+					t.Errorf("unexpected event %+v", e)
+				}
+				return tt.handlerErr
+			}, WithOnError(func(_ *http.Request, err error) { reported = err }))
+
+			req := httptest.NewRequest(tt.method, "/hooks/envloped", strings.NewReader(tt.body))
+			if tt.sign {
+				req.Header.Set(SignatureHeader, Sign([]byte(tt.body), testSecret, tt.signedAt))
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+			if called != tt.wantCalled {
+				t.Errorf("expected handler called %v, got %v", tt.wantCalled, called)
+			}
+			if (tt.wantStatus != http.StatusOK) != (reported != nil) {
+				t.Errorf("unexpected reported error %v", reported)
+			}
+			if strings.Contains(rec.Body.String(), errHandler.Error()) {
+				t.Error("expected handler error not to be exposed")
+			}
+		})
+	}
+}
+
+func TestNewHandler_ToleranceDisabled(t *testing.T) {
+	t.Parallel()
+
+	h := NewHandler(testSecret, func(envloped.Event) error { return nil }, WithTolerance(0))
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(testEvent))
+	req.Header.Set(SignatureHeader, Sign([]byte(testEvent), testSecret, time.Now().Add(-24*time.Hour)))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", rec.Code)
+	}
+}
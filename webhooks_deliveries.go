@@ -0,0 +1,164 @@
+package envloped
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// WebhookDeliveryStatus is the state of a webhook delivery.
+type WebhookDeliveryStatus string
+
+// Webhook delivery statuses.
+const (
+	// WebhookDeliveryPending means the delivery has not been attempted yet,
+	// or is waiting for a retry.
+	WebhookDeliveryPending WebhookDeliveryStatus = "pending"
+
+	// WebhookDeliverySucceeded means the endpoint acknowledged the event
+	// with a 2xx response.
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded"
+
+	// WebhookDeliveryFailed means every attempt failed and no more retries
+	// are scheduled. Failed deliveries can be sent again with Replay.
+	WebhookDeliveryFailed WebhookDeliveryStatus = "failed"
+)
+
+// maxReplayEvents is the largest number of events a single replay request
+// may contain.
+const maxReplayEvents = 100
+
+// WebhookDelivery is an attempt to post an event to a webhook endpoint.
+type WebhookDelivery struct {
+	// ID is the unique identifier of the delivery.
+	ID string `json:"id"`
+
+	// WebhookID is the webhook the event was posted to.
+	WebhookID string `json:"webhookId"`
+
+	// EventID is the event that was posted.
+	EventID string `json:"eventId"`
+
+	// EventType is the type of the event.
+	EventType EventType `json:"eventType"`
+
+	// Status is the state of the delivery.
+	Status WebhookDeliveryStatus `json:"status"`
+
+	// Attempts is the number of times the event was posted.
+	Attempts int `json:"attempts"`
+
+	// StatusCode is the HTTP status of the last attempt, or zero if the
+	// endpoint could not be reached.
+	StatusCode int `json:"statusCode,omitempty"`
+
+	// Error describes why the last attempt failed, e.g. a timeout.
+	Error string `json:"error,omitempty"`
+
+	// CreatedAt is when the delivery was created.
+	CreatedAt time.Time `json:"createdAt"`
+
+	// LastAttemptAt is when the event was last posted, if it was.
+	LastAttemptAt *time.Time `json:"lastAttemptAt,omitempty"`
+
+	// NextAttemptAt is when the next retry is scheduled, if any.
+	NextAttemptAt *time.Time `json:"nextAttemptAt,omitempty"`
+}
+
+// ListWebhookDeliveriesOptions configures a page of results from
+// Webhooks.ListDeliveries.
+type ListWebhookDeliveriesOptions struct {
+	ListOptions
+
+	// Status only lists deliveries in this state.
+	Status WebhookDeliveryStatus
+}
+
+// WebhookDeliveryPage is a single page of webhook deliveries, newest first.
+type WebhookDeliveryPage = Page[WebhookDelivery]
+
+// ListDeliveries returns a page of a webhook's deliveries matching opts.
+func (s *webhooksSvcImpl) ListDeliveries(webhookID string, opts *ListWebhookDeliveriesOptions) (*WebhookDeliveryPage, error) {
+	return s.ListDeliveriesWithContext(context.Background(), webhookID, opts)
+}
+
+// ListDeliveriesWithContext returns a page of a webhook's deliveries using
+// the provided context. Pass the returned NextCursor as opts.Cursor to fetch
+// the following page.
+func (s *webhooksSvcImpl) ListDeliveriesWithContext(ctx context.Context, webhookID string, opts *ListWebhookDeliveriesOptions) (*WebhookDeliveryPage, error) {
+	if webhookID == "" {
+		return nil, fmt.Errorf("envloped: webhook id is required")
+	}
+	if opts == nil {
+		opts = &ListWebhookDeliveriesOptions{}
+	}
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
+	q := opts.values()
+	if opts.Status != "" {
+		q.Set("status", string(opts.Status))
+	}
+
+	path := webhookPath(webhookID, "/deliveries")
+	if len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodGet, "/v1/webhooks/{id}/deliveries", path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to create list webhook deliveries request: %w", err)
+	}
+
+	var page WebhookDeliveryPage
+	if err := s.client.do(req, &page); err != nil {
+		return nil, err
+	}
+
+	return &page, nil
+}
+
+// Replay posts the given events to a webhook again.
+func (s *webhooksSvcImpl) Replay(webhookID string, eventIDs ...string) ([]WebhookDelivery, error) {
+	return s.ReplayWithContext(context.Background(), webhookID, eventIDs...)
+}
+
+// ReplayWithContext posts the given events to a webhook again using the
+// provided context, e.g. after the endpoint was down. The events are queued
+// and delivered like new events, with retries; the returned deliveries are
+// pending and can be followed with ListDeliveries. At most 100 events can be
+// replayed per call.
+func (s *webhooksSvcImpl) ReplayWithContext(ctx context.Context, webhookID string, eventIDs ...string) ([]WebhookDelivery, error) {
+	if webhookID == "" {
+		return nil, fmt.Errorf("envloped: webhook id is required")
+	}
+	if len(eventIDs) == 0 {
+		return nil, fmt.Errorf("envloped: at least one event id is required")
+	}
+	if len(eventIDs) > maxReplayEvents {
+		return nil, fmt.Errorf("envloped: cannot replay more than %d events at once, got %d", maxReplayEvents, len(eventIDs))
+	}
+	for _, id := range eventIDs {
+		if id == "" {
+			return nil, fmt.Errorf("envloped: event id is required")
+		}
+	}
+
+	body := struct {
+		EventIDs []string `json:"eventIds"`
+	}{EventIDs: eventIDs}
+
+	req, err := s.client.newRequest(ctx, http.MethodPost, "/v1/webhooks/{id}/replay", webhookPath(webhookID, "/replay"), body)
+	if err != nil {
+		return nil, fmt.Errorf("envloped: failed to create replay webhook request: %w", err)
+	}
+
+	var resp listResponse[WebhookDelivery]
+	if err := s.client.do(req, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Data, nil
+}
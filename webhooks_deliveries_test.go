@@ -0,0 +1,109 @@
+package envloped
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWebhooks_ListDeliveries(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/v1/webhooks/wh_1/deliveries" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		q := r.URL.Query()
+		if q.Get("status") != "failed" || q.Get("since") != "2025-01-01T00:00:00Z" || q.Get("cursor") != "cur_1" {
+			t.Errorf("unexpected query %v", q)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":[{"id":"dlv_1","webhookId":"wh_1","eventId":"evt_1","eventType":"bounced",
+			"status":"failed","attempts":5,"statusCode":503,"lastAttemptAt":"2025-01-01T01:00:00Z"}],"nextCursor":"cur_2"}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	page, err := client.Webhooks.ListDeliveries("wh_1", &ListWebhookDeliveriesOptions{
+		ListOptions: ListOptions{Since: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), Cursor: "cur_1"},
+		Status:      WebhookDeliveryFailed,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if page.NextCursor != "cur_2" || len(page.Items) != 1 {
+		t.Fatalf("unexpected page %+v", page)
+	}
+	d := page.Items[0]
+	if d.EventID != "evt_1" || d.Status != WebhookDeliveryFailed || d.Attempts != 5 || d.StatusCode != 503 || d.LastAttemptAt == nil || d.NextAttemptAt != nil {
+		t.Errorf("unexpected delivery %+v", d)
+	}
+}
+
+func TestWebhooks_Replay(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/webhooks/wh_1/replay" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var body struct {
+			EventIDs []string `json:"eventIds"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if strings.Join(body.EventIDs, ",") != "evt_1,evt_2" {
+			t.Errorf("unexpected event ids %v", body.EventIDs)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": []WebhookDelivery{
+			{ID: "dlv_3", EventID: "evt_1", Status: WebhookDeliveryPending},
+			{ID: "dlv_4", EventID: "evt_2", Status: WebhookDeliveryPending},
+		}})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	deliveries, err := client.Webhooks.Replay("wh_1", "evt_1", "evt_2")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(deliveries) != 2 || deliveries[1].ID != "dlv_4" || deliveries[1].Status != WebhookDeliveryPending {
+		t.Errorf("unexpected deliveries %+v", deliveries)
+	}
+}
+
+func TestWebhooks_DeliveriesValidation(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("key")
+	tooMany := make([]string, maxReplayEvents+1)
+	for i := range tooMany {
+		tooMany[i] = "evt"
+	}
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{"list missing id", func() error { _, err := client.Webhooks.ListDeliveries("", nil); return err }},
+		{"list negative limit", func() error {
+			_, err := client.Webhooks.ListDeliveries("wh_1", &ListWebhookDeliveriesOptions{ListOptions: ListOptions{Limit: -1}})
+			return err
+		}},
+		{"replay missing id", func() error { _, err := client.Webhooks.Replay("", "evt_1"); return err }},
+		{"replay no events", func() error { _, err := client.Webhooks.Replay("wh_1"); return err }},
+		{"replay empty event", func() error { _, err := client.Webhooks.Replay("wh_1", "evt_1", ""); return err }},
+		{"replay too many", func() error { _, err := client.Webhooks.Replay("wh_1", tooMany...); return err }},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := tt.call(); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
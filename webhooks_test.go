@@ -0,0 +1,96 @@
+package envloped
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWebhooks_CRUD(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/webhooks":
+			var req WebhookRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			if len(req.EventTypes) != 2 {
+				t.Errorf("expected 2 event types, got %v", req.EventTypes)
+			}
+			json.NewEncoder(w).Encode(Webhook{ID: "wh_1", URL: req.URL, EventTypes: req.EventTypes, Enabled: true, SigningSecret: "whsec_abc"})
+		case r.Method == http.MethodPatch && r.URL.Path == "/v1/webhooks/wh_1":
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			if body["enabled"] != false {
+				t.Errorf("expected enabled=false in update, got %v", body)
+			}
+			if _, ok := body["url"]; ok {
+				t.Errorf("expected url to be omitted from update, got %v", body)
+			}
+			json.NewEncoder(w).Encode(Webhook{ID: "wh_1", Enabled: false})
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/webhooks":
+			json.NewEncoder(w).Encode(map[string]interface{}{"data": []Webhook{{ID: "wh_1"}}})
+		case r.Method == http.MethodDelete && r.URL.Path == "/v1/webhooks/wh_1":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+
+	wh, err := client.Webhooks.Create(&WebhookRequest{
+		URL:        "https://example.com/hooks/envloped",
+		EventTypes: []EventType{EventTypeBounced, EventTypeComplained},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if wh.SigningSecret != "whsec_abc" {
+		t.Errorf("expected signing secret, got %q", wh.SigningSecret)
+	}
+
+	disabled := false
+	wh, err = client.Webhooks.Update("wh_1", &WebhookRequest{Enabled: &disabled})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if wh.Enabled {
+		t.Error("expected webhook to be disabled")
+	}
+
+	hooks, err := client.Webhooks.List()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(hooks) != 1 {
+		t.Errorf("expected 1 webhook, got %d", len(hooks))
+	}
+
+	if err := client.Webhooks.Delete("wh_1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestWebhooks_Validation(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("key")
+	tests := []struct {
+		name   string
+		params *WebhookRequest
+	}{
+		{name: "nil params", params: nil},
+		{name: "missing url", params: &WebhookRequest{EventTypes: []EventType{EventTypeBounced}}},
+		{name: "http url", params: &WebhookRequest{URL: "http://example.com/hook", EventTypes: []EventType{EventTypeBounced}}},
+		{name: "no event types", params: &WebhookRequest{URL: "https://example.com/hook"}},
+	}
+	for _, tt := range tests {
+		if _, err := client.Webhooks.Create(tt.params); err == nil {
+			t.Errorf("%s: expected error, got nil", tt.name)
+		}
+	}
+}